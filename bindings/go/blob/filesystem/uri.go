@@ -3,7 +3,10 @@ package filesystem
 import (
 	"fmt"
 	"net/url"
+	"os"
 	"strings"
+
+	filesystemv1alpha1 "ocm.software/open-component-model/bindings/go/blob/filesystem/spec/access/v1alpha1"
 )
 
 // FilePathFromURI extracts the filesystem path from a file:// URI.
@@ -31,3 +34,17 @@ func FilePathFromURI(uri string) (string, error) {
 	}
 	return parsed.Path, nil
 }
+
+// VerifyBufferedFile checks that a file buffered by a previous transformation still exists.
+// It is used to decide whether a journaled transformation can be reused when a
+// transformation graph is resumed.
+func VerifyBufferedFile(file filesystemv1alpha1.File) error {
+	path, err := FilePathFromURI(file.URI)
+	if err != nil {
+		return fmt.Errorf("invalid buffered file URI: %w", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("buffered file is not available: %w", err)
+	}
+	return nil
+}
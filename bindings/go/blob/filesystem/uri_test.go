@@ -1,12 +1,15 @@
 package filesystem_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	filesystemv1alpha1 "ocm.software/open-component-model/bindings/go/blob/filesystem/spec/access/v1alpha1"
 )
 
 func TestFilePathFromURI(t *testing.T) {
@@ -75,3 +78,14 @@ func TestFilePathFromURI(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyBufferedFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "buffered.tar")
+	file := filesystemv1alpha1.File{URI: "file://" + filePath}
+
+	assert.ErrorContains(t, filesystem.VerifyBufferedFile(file), "buffered file is not available")
+	assert.ErrorContains(t, filesystem.VerifyBufferedFile(filesystemv1alpha1.File{URI: "https://example.com/buffered.tar"}), "invalid buffered file URI")
+
+	require.NoError(t, os.WriteFile(filePath, []byte("content"), 0o644))
+	assert.NoError(t, filesystem.VerifyBufferedFile(file))
+}
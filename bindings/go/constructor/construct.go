@@ -3,13 +3,14 @@ package constructor
 import (
 	"context"
 	"crypto"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
 	"runtime"
 	"sync"
+	"time"
 
-	"github.com/opencontainers/go-digest"
 	"golang.org/x/sync/errgroup"
 
 	"ocm.software/open-component-model/bindings/go/blob"
@@ -24,6 +25,7 @@ import (
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	"ocm.software/open-component-model/bindings/go/repository"
 	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
 )
 
 // ErrShouldSkipConstruction is an error that indicates that the construction of a component should be skipped,
@@ -199,6 +201,7 @@ func buildDiscoverer(componentConstructor *constructor.ComponentConstructor, opt
 // and adds the final component version to the target repository.
 func (c *DefaultConstructor) constructComponent(ctx context.Context, component *constructor.Component, referencedComponents map[string]*descriptor.Descriptor) (*descriptor.Descriptor, error) {
 	logger := log.Base().With("component", component.Name, "version", component.Version)
+	startedOn := time.Now()
 	desc := createBaseDescriptor(component)
 	logger.Debug("created base descriptor")
 
@@ -222,6 +225,12 @@ func (c *DefaultConstructor) constructComponent(ctx context.Context, component *
 		return nil, err
 	}
 
+	if c.opts.Provenance != nil {
+		if err := c.addProvenance(ctx, repo, component, desc, startedOn); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := repo.AddComponentVersion(ctx, desc); err != nil {
 		return nil, fmt.Errorf("error adding component version to target: %w", err)
 	}
@@ -592,7 +601,16 @@ func (c *DefaultConstructor) processReference(ctx context.Context, reference *co
 	)
 	logger.Debug("processing reference")
 
-	referencedComponentDigest, err := c.getComponentDigest(ctx, reference.ToIdentity().String(), referencedComponent)
+	// A pinned digest is verified with its own hash algorithm, all other references are digested with SHA-256.
+	hashAlgorithm := crypto.SHA256.String()
+	if reference.Digest != nil {
+		if reference.Digest.HashAlgorithm == "" || reference.Digest.NormalisationAlgorithm == "" || reference.Digest.Value == "" {
+			return nil, fmt.Errorf("reference %q has an incomplete digest: all of hashAlgorithm, normalisationAlgorithm, and value must be set", reference.ToIdentity())
+		}
+		hashAlgorithm = reference.Digest.HashAlgorithm
+	}
+
+	referencedComponentDigest, err := c.getComponentDigest(ctx, reference.ToIdentity().String(), hashAlgorithm, referencedComponent)
 	if err != nil {
 		return nil, fmt.Errorf("error getting digest for referenced component %q: %w", reference.ToIdentity(), err)
 	}
@@ -601,9 +619,6 @@ func (c *DefaultConstructor) processReference(ctx context.Context, reference *co
 
 	// If digest is specified in the constructor reference, verify it matches the calculated digest
 	if reference.Digest != nil {
-		if reference.Digest.HashAlgorithm == "" || reference.Digest.NormalisationAlgorithm == "" || reference.Digest.Value == "" {
-			return nil, fmt.Errorf("reference %q has an incomplete digest: all of hashAlgorithm, normalisationAlgorithm, and value must be set", reference.ToIdentity())
-		}
 		if reference.Digest.HashAlgorithm != referencedComponentDigest.HashAlgorithm ||
 			reference.Digest.NormalisationAlgorithm != referencedComponentDigest.NormalisationAlgorithm ||
 			reference.Digest.Value != referencedComponentDigest.Value {
@@ -628,40 +643,50 @@ func (c *DefaultConstructor) processReference(ctx context.Context, reference *co
 	return ref, nil
 }
 
-// getComponentDigest tries to get the digest for a particular component from
-// cache. If there is no cached digest for that particular component, it
-// calculates the digest and stores it in the cache.
+// getComponentDigest tries to get the digest for a particular component and
+// hash algorithm from cache. If there is no cached digest for that particular
+// component, it calculates the digest and stores it in the cache.
 // We want this operation to be atomar, to avoid concurrent calls for the
 // same component to have cache misses. That would lead to multiple
 // calculations of the same digest.
-func (c *DefaultConstructor) getComponentDigest(ctx context.Context, componentIdentity string, referencedComponent *descriptor.Descriptor) (*descriptor.Digest, error) {
+func (c *DefaultConstructor) getComponentDigest(ctx context.Context, componentIdentity, hashAlgorithm string, referencedComponent *descriptor.Descriptor) (*descriptor.Digest, error) {
 	c.componentDigestCacheMu.Lock()
 	defer c.componentDigestCacheMu.Unlock()
 
-	if componentDigest, cached := c.componentDigestCache[componentIdentity]; cached {
-		slog.DebugContext(ctx, "component digest found in cache", "component", componentIdentity)
+	key := componentIdentity + "@" + hashAlgorithm
+	if componentDigest, cached := c.componentDigestCache[key]; cached {
+		slog.DebugContext(ctx, "component digest found in cache", "component", componentIdentity, "hashAlgorithm", hashAlgorithm)
 		return componentDigest, nil
 	}
-	slog.DebugContext(ctx, "component digest not found in cache", "component", componentIdentity)
+	slog.DebugContext(ctx, "component digest not found in cache", "component", componentIdentity, "hashAlgorithm", hashAlgorithm)
 
-	componentDigest, err := calculateDigest(referencedComponent)
+	componentDigest, err := calculateDigest(referencedComponent, hashAlgorithm)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate digest: %w", err)
 	}
-	c.componentDigestCache[componentIdentity] = componentDigest
+	c.componentDigestCache[key] = componentDigest
 	return componentDigest, nil
 }
 
-func calculateDigest(component *descriptor.Descriptor) (*descriptor.Digest, error) {
+// calculateDigest digests the normalised component with one of the hash algorithms
+// supported for signing, so that pinned reference digests can be verified with them.
+func calculateDigest(component *descriptor.Descriptor, hashAlgorithm string) (*descriptor.Digest, error) {
+	hash, err := signing.SupportedHash(hashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
 	normalisedData, err := normalisation.Normalisations.Normalise(component, v4alpha1.Algorithm)
 	if err != nil {
 		return nil, fmt.Errorf("error normalising descriptor %s: %w", component.Component.ToIdentity().String(), err)
 	}
 
+	h := hash.New()
+	h.Write(normalisedData)
 	return &descriptor.Digest{
-		HashAlgorithm:          crypto.SHA256.String(),
+		HashAlgorithm:          hash.String(),
 		NormalisationAlgorithm: v4alpha1.Algorithm,
-		Value:                  digest.SHA256.FromBytes(normalisedData).Encoded(),
+		Value:                  hex.EncodeToString(h.Sum(nil)),
 	}, nil
 }
 
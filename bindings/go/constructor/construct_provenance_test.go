@@ -0,0 +1,141 @@
+package constructor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/constructor/provenance"
+	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	constructorv1 "ocm.software/open-component-model/bindings/go/constructor/spec/v1"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocirepository "ocm.software/open-component-model/bindings/go/oci/repository"
+	"ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func TestConstructWithProvenance(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+
+	yamlData := `
+components:
+  - name: ocm.software/test-provenance
+    version: v1.0.0
+    provider:
+      name: test-provider
+    resources:
+      - name: by-input
+        type: blob
+        input:
+          type: mock/v1
+      - name: by-access
+        version: v1.0.0
+        type: blob
+        access:
+          type: mock/v1
+`
+	var comp constructorv1.ComponentConstructor
+	r.NoError(yaml.Unmarshal([]byte(yamlData), &comp))
+	converted := constructorruntime.ConvertToRuntimeConstructor(&comp)
+
+	ctfRepo, err := ocirepository.NewFromCTFRepoV1(t.Context(), &ctf.Repository{
+		FilePath:   t.TempDir(),
+		AccessMode: ctf.AccessModeReadWrite,
+	})
+	r.NoError(err)
+
+	accessDigest := &descriptor.Digest{
+		HashAlgorithm:          "SHA-256",
+		NormalisationAlgorithm: "genericBlobDigest/v1",
+		Value:                  "3e6b2b3c0f8b5a4e3a1c1b0e8f5d0c9a7b6e5d4c3b2a1f0e9d8c7b6a5f4e3d2c",
+	}
+	constructorFile := &provenance.ResourceDescriptor{
+		URI:    "component-constructor.yaml",
+		Digest: map[string]string{"sha256": "0a1b2c"},
+	}
+	opts := Options{
+		TargetRepositoryProvider: &componentVersionRepoProvider{repo: ctfRepo},
+		ResourceInputMethodProvider: &mockInputMethodProvider{
+			methods: map[runtime.Type]ResourceInputMethod{
+				runtime.NewVersionedType("mock", "v1"): &mockInputMethod{
+					processedBlob: inmemory.New(bytes.NewReader([]byte("input data")), inmemory.WithMediaType("text/plain")),
+				},
+			},
+		},
+		ResourceDigestProcessorProvider: &mockDigestProcessorProvider{
+			processor: &mockDigestProcessor{processedDigest: accessDigest},
+		},
+		Provenance: &provenance.Options{
+			Builder:     provenance.Builder{ID: "https://ocm.software/test"},
+			Constructor: constructorFile,
+		},
+	}
+
+	constructorInstance := NewDefaultConstructor(converted, opts)
+	r.NoError(constructorInstance.Construct(t.Context()))
+
+	desc, err := ctfRepo.GetComponentVersion(t.Context(), "ocm.software/test-provenance", "v1.0.0")
+	r.NoError(err)
+	r.Len(desc.Component.Resources, 3)
+	provenanceResource := desc.Component.Resources[2]
+	assert.Equal(t, provenance.ResourceName, provenanceResource.Name)
+	assert.Equal(t, provenance.ResourceType, provenanceResource.Type)
+	assert.Equal(t, descriptor.LocalRelation, provenanceResource.Relation)
+	assert.NotNil(t, provenanceResource.Digest)
+
+	statement, err := provenance.Fetch(t.Context(), ctfRepo, desc)
+	r.NoError(err)
+	r.NoError(provenance.Verify(desc, statement))
+
+	assert.Len(t, statement.Subject, 2)
+	buildDefinition := statement.Predicate.BuildDefinition
+	assert.Equal(t, constructorFile, buildDefinition.ExternalParameters.Constructor)
+	r.Len(buildDefinition.ResolvedDependencies, 2)
+	assert.Equal(t, "mock/v1", buildDefinition.ResolvedDependencies[0].Annotations[provenance.AnnotationInput])
+	assert.Equal(t, desc.Component.Resources[0].Digest.Value, buildDefinition.ResolvedDependencies[0].Digest["sha256"])
+	assert.Equal(t, "mock/v1", buildDefinition.ResolvedDependencies[1].Annotations[provenance.AnnotationAccess])
+	assert.Equal(t, accessDigest.Value, buildDefinition.ResolvedDependencies[1].Digest["sha256"])
+	assert.Equal(t, "https://ocm.software/test", statement.Predicate.RunDetails.Builder.ID)
+	assert.NotNil(t, statement.Predicate.RunDetails.Metadata.StartedOn)
+}
+
+func TestConstructWithProvenance_ResourceNameConflict(t *testing.T) {
+	t.Parallel()
+
+	yamlData := `
+components:
+  - name: ocm.software/test-provenance
+    version: v1.0.0
+    provider:
+      name: test-provider
+    resources:
+      - name: slsa-provenance
+        type: blob
+        input:
+          type: mock/v1
+`
+	var comp constructorv1.ComponentConstructor
+	require.NoError(t, yaml.Unmarshal([]byte(yamlData), &comp))
+
+	mockRepo := newMockTargetRepository()
+	opts := Options{
+		TargetRepositoryProvider: &mockTargetRepositoryProvider{repo: mockRepo},
+		ResourceInputMethodProvider: &mockInputMethodProvider{
+			methods: map[runtime.Type]ResourceInputMethod{
+				runtime.NewVersionedType("mock", "v1"): &mockInputMethod{
+					processedBlob: inmemory.New(bytes.NewReader([]byte("input data"))),
+				},
+			},
+		},
+		Provenance: &provenance.Options{Builder: provenance.Builder{ID: "https://ocm.software/test"}},
+	}
+
+	err := NewDefaultConstructor(constructorruntime.ConvertToRuntimeConstructor(&comp), opts).Construct(t.Context())
+	require.ErrorContains(t, err, `resource "slsa-provenance" already exists`)
+	assert.Empty(t, mockRepo.addedVersions)
+}
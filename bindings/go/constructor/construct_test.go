@@ -825,3 +825,55 @@ func collectDescriptors(t *testing.T, graph *syncdag.SyncedDirectedAcyclicGraph[
 	})
 	return descs
 }
+
+func TestProcessReferenceWithPinnedDigest(t *testing.T) {
+	t.Parallel()
+
+	referenced := &descriptor.Descriptor{
+		Meta: descriptor.Meta{Version: "v2"},
+		Component: descriptor.Component{
+			ComponentMeta: descriptor.ComponentMeta{
+				ObjectMeta: descriptor.ObjectMeta{Name: "ocm.software/referenced", Version: "v1.0.0"},
+			},
+			Provider: descriptor.Provider{Name: "test-provider"},
+		},
+	}
+	c := &DefaultConstructor{componentDigestCache: make(map[string]*descriptor.Digest)}
+	reference := func(digest *constructorruntime.Digest) *constructorruntime.Reference {
+		return &constructorruntime.Reference{
+			ElementMeta: constructorruntime.ElementMeta{ObjectMeta: constructorruntime.ObjectMeta{Name: "ref", Version: "v1.0.0"}},
+			Component:   "ocm.software/referenced",
+			Digest:      digest,
+		}
+	}
+
+	unpinned, err := c.processReference(t.Context(), reference(nil), referenced)
+	require.NoError(t, err)
+	assert.Equal(t, "SHA-256", unpinned.Digest.HashAlgorithm)
+
+	for _, hashAlgorithm := range []string{"SHA-384", "SHA-512", "SHA3-256", "SHA3-512"} {
+		t.Run(hashAlgorithm, func(t *testing.T) {
+			expected, err := calculateDigest(referenced, hashAlgorithm)
+			require.NoError(t, err)
+
+			ref, err := c.processReference(t.Context(), reference(&constructorruntime.Digest{
+				HashAlgorithm:          expected.HashAlgorithm,
+				NormalisationAlgorithm: expected.NormalisationAlgorithm,
+				Value:                  expected.Value,
+			}), referenced)
+			require.NoError(t, err)
+			assert.Equal(t, *expected, ref.Digest)
+			assert.NotEqual(t, unpinned.Digest.Value, ref.Digest.Value)
+
+			_, err = c.processReference(t.Context(), reference(&constructorruntime.Digest{
+				HashAlgorithm:          expected.HashAlgorithm,
+				NormalisationAlgorithm: expected.NormalisationAlgorithm,
+				Value:                  unpinned.Digest.Value,
+			}), referenced)
+			require.ErrorIs(t, err, ErrDigestMismatch)
+		})
+	}
+
+	_, err = calculateDigest(referenced, "MD5")
+	require.ErrorContains(t, err, "unsupported hash algorithm")
+}
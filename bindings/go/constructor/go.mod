@@ -3,7 +3,6 @@ module ocm.software/open-component-model/bindings/go/constructor
 go 1.26.3
 
 require (
-	github.com/opencontainers/go-digest v1.0.0
 	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2
 	github.com/stretchr/testify v1.11.1
 	golang.org/x/sync v0.20.0
@@ -16,6 +15,7 @@ require (
 	ocm.software/open-component-model/bindings/go/oci v0.0.46
 	ocm.software/open-component-model/bindings/go/repository v0.0.9
 	ocm.software/open-component-model/bindings/go/runtime v0.0.8
+	ocm.software/open-component-model/bindings/go/signing v0.0.0-20260616162616-fac66c3e8710
 	sigs.k8s.io/yaml v1.6.0
 )
 
@@ -25,6 +25,7 @@ require (
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
 	github.com/go-logr/logr v1.4.3 // indirect
 	github.com/nlepage/go-tarfs v1.2.1 // indirect
+	github.com/opencontainers/go-digest v1.0.0 // indirect
 	github.com/opencontainers/image-spec v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
 	github.com/veqryn/slog-context v0.9.0 // indirect
@@ -33,5 +34,6 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	ocm.software/open-component-model/bindings/go/configuration v0.0.15 // indirect
 	ocm.software/open-component-model/bindings/go/ctf v0.4.1 // indirect
+	ocm.software/open-component-model/bindings/go/http v0.0.0-20260616162616-fac66c3e8710 // indirect
 	oras.land/oras-go/v2 v2.6.0 // indirect
 )
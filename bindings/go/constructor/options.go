@@ -3,6 +3,7 @@ package constructor
 import (
 	"context"
 
+	"ocm.software/open-component-model/bindings/go/constructor/provenance"
 	constructor "ocm.software/open-component-model/bindings/go/constructor/runtime"
 	"ocm.software/open-component-model/bindings/go/credentials"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
@@ -63,6 +64,11 @@ type Options struct {
 	// While constructing a component version, the constructor library will use the given callbacks to notify about
 	// the construction process. This can be used to implement custom logging or other actions such as progress trackers.
 	ComponentConstructionCallbacks
+
+	// While constructing a component version, the constructor library will generate a SLSA provenance of the
+	// component version with the given options and add it as local resource named provenance.ResourceName.
+	// The Provenance is OPTIONAL, if not provided, no provenance is generated.
+	Provenance *provenance.Options
 }
 
 type ComponentConstructionCallbacks struct {
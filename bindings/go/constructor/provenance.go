@@ -0,0 +1,56 @@
+package constructor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/constructor/provenance"
+	constructor "ocm.software/open-component-model/bindings/go/constructor/runtime"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+)
+
+// addProvenance generates the SLSA provenance of the processed component version desc and adds it
+// as local resource to the target repository and desc.
+// The resources of desc are expected to be aligned by index with the resources of the component specification.
+func (c *DefaultConstructor) addProvenance(ctx context.Context, repo TargetRepository, component *constructor.Component, desc *descriptor.Descriptor, startedOn time.Time) error {
+	if slices.ContainsFunc(desc.Component.Resources, func(resource descriptor.Resource) bool {
+		return resource.Name == provenance.ResourceName
+	}) {
+		return fmt.Errorf("cannot add provenance to component %q: resource %q already exists", component.ToIdentity(), provenance.ResourceName)
+	}
+
+	inputs := make([]provenance.Input, 0, len(component.Resources))
+	for i, resource := range component.Resources {
+		input := provenance.Input{Resource: desc.Component.Resources[i].ToIdentity()}
+		if resource.HasInput() {
+			input.InputType = resource.Input.GetType().String()
+		} else {
+			input.AccessType = resource.Access.GetType().String()
+		}
+		inputs = append(inputs, input)
+	}
+
+	statement, err := provenance.Generate(desc, inputs, *c.opts.Provenance, startedOn, time.Now())
+	if err != nil {
+		return fmt.Errorf("error generating provenance for component %q: %w", component.ToIdentity(), err)
+	}
+	data, err := json.Marshal(statement)
+	if err != nil {
+		return fmt.Errorf("error marshalling provenance for component %q: %w", component.ToIdentity(), err)
+	}
+
+	resource := provenance.Resource(component.Version)
+	resource.Access = &v2.LocalBlob{MediaType: provenance.MediaType}
+	uploaded, err := repo.AddLocalResource(ctx, component.Name, component.Version, resource, inmemory.New(bytes.NewReader(data), inmemory.WithMediaType(provenance.MediaType)))
+	if err != nil {
+		return fmt.Errorf("error adding provenance as local resource to component %q: %w", component.ToIdentity(), err)
+	}
+	desc.Component.Resources = append(desc.Component.Resources, *uploaded)
+	return nil
+}
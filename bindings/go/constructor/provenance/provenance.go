@@ -0,0 +1,383 @@
+// Package provenance generates and verifies SLSA provenance for constructed component versions.
+//
+// A provenance is an in-toto Statement v1 (https://github.com/in-toto/attestation/blob/main/spec/v1/statement.md)
+// carrying a SLSA Provenance v1 predicate (https://slsa.dev/spec/v1.0/provenance). Its subjects are
+// the resources of the constructed component version that have a digest, its build definition records the
+// component constructor file and the resolved inputs, accesses and component references with their digests,
+// and its run details record the builder and the build environment.
+//
+// The constructor stores the provenance as local resource named ResourceName in the component version
+// it describes. As such it is covered by the signature of the component descriptor, while the
+// component descriptor digest itself cannot be a subject of the provenance.
+package provenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	goruntime "runtime"
+	"slices"
+	"time"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// MediaType is the media type of the local blob holding a provenance statement.
+	MediaType = "application/vnd.in-toto+json"
+	// StatementType is the type of an in-toto statement v1.
+	StatementType = "https://in-toto.io/Statement/v1"
+	// PredicateType is the predicate type of a SLSA provenance v1.
+	PredicateType = "https://slsa.dev/provenance/v1"
+	// BuildType describes how the build definition of a provenance generated by the
+	// component constructor is to be interpreted.
+	BuildType = "https://ocm.software/slsa/component-constructor/v1"
+
+	// ResourceName is the name of the local resource holding the provenance of a component version.
+	ResourceName = "slsa-provenance"
+	// ResourceType is the type of the local resource holding the provenance of a component version.
+	ResourceType = "slsaProvenance"
+)
+
+var (
+	// ErrNotFound is returned by Fetch if a component version has no provenance.
+	ErrNotFound = errors.New("provenance not found")
+	// ErrMismatch is returned by Verify if a provenance does not describe the given component version.
+	ErrMismatch = errors.New("provenance does not match component version")
+)
+
+// Statement is an in-toto statement v1 with a SLSA provenance v1 predicate.
+type Statement struct {
+	Type          string               `json:"_type"`
+	Subject       []ResourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     Provenance           `json:"predicate"`
+}
+
+// ResourceDescriptor is an in-toto resource descriptor identifying an artifact by name and digest set,
+// e.g. {"sha256": "<hex>"}.
+type ResourceDescriptor struct {
+	Name        string            `json:"name,omitempty"`
+	URI         string            `json:"uri,omitempty"`
+	Digest      map[string]string `json:"digest,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Provenance is a SLSA provenance v1 predicate.
+type Provenance struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+// BuildDefinition describes the inputs of the construction of a component version.
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   ExternalParameters   `json:"externalParameters"`
+	InternalParameters   InternalParameters   `json:"internalParameters"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+// ExternalParameters are the parameters of a construction under control of the user.
+type ExternalParameters struct {
+	Component   string              `json:"component"`
+	Version     string              `json:"version"`
+	Constructor *ResourceDescriptor `json:"constructor,omitempty"`
+}
+
+// InternalParameters describe the environment the construction was run in.
+type InternalParameters struct {
+	GOOS   string `json:"goos"`
+	GOARCH string `json:"goarch"`
+}
+
+// RunDetails describe the builder running the construction.
+type RunDetails struct {
+	Builder  Builder  `json:"builder"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// Builder identifies the entity running the construction.
+type Builder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+// Metadata records when the construction was run.
+type Metadata struct {
+	StartedOn  *time.Time `json:"startedOn,omitempty"`
+	FinishedOn *time.Time `json:"finishedOn,omitempty"`
+}
+
+// Options configure the generation of a provenance.
+type Options struct {
+	// Builder identifies the entity running the construction and is MANDATORY.
+	Builder Builder
+	// Constructor describes the component constructor file the component version is constructed from.
+	// It is OPTIONAL, but should carry a digest of the file if set.
+	Constructor *ResourceDescriptor
+}
+
+// Annotation keys of the resolved dependencies of a provenance.
+const (
+	// AnnotationInput is the input type a resource was constructed from.
+	AnnotationInput = "input"
+	// AnnotationAccess is the access type a resource was referenced by.
+	AnnotationAccess = "access"
+	// AnnotationReference is the name of the component reference.
+	AnnotationReference = "reference"
+	// AnnotationNormalisationAlgorithm is the normalisation algorithm of a component reference digest.
+	AnnotationNormalisationAlgorithm = "normalisationAlgorithm"
+)
+
+// digestSetAlgorithms maps OCM hash algorithm names to in-toto digest set keys.
+var digestSetAlgorithms = map[string]string{
+	"SHA-256":  "sha256",
+	"SHA-384":  "sha384",
+	"SHA-512":  "sha512",
+	"SHA3-256": "sha3-256",
+	"SHA3-512": "sha3-512",
+}
+
+// DigestSet returns the in-toto digest set of digest.
+func DigestSet(digest *descriptor.Digest) (map[string]string, error) {
+	if digest == nil || digest.Value == "" {
+		return nil, errors.New("missing digest value")
+	}
+	algorithm, ok := digestSetAlgorithms[digest.HashAlgorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm %q", digest.HashAlgorithm)
+	}
+	return map[string]string{algorithm: digest.Value}, nil
+}
+
+// Input describes how a resource of the constructed component version was resolved.
+// Exactly one of InputType and AccessType is set.
+type Input struct {
+	Resource   runtime.Identity
+	InputType  string
+	AccessType string
+}
+
+// Generate returns the provenance of the constructed component version desc.
+// inputs describe how its resources were resolved. Resources without a digest are
+// neither subjects nor resolved dependencies of the provenance.
+func Generate(desc *descriptor.Descriptor, inputs []Input, opts Options, startedOn, finishedOn time.Time) (*Statement, error) {
+	if opts.Builder.ID == "" {
+		return nil, errors.New("missing builder id")
+	}
+
+	statement := &Statement{
+		Type:          StatementType,
+		Subject:       []ResourceDescriptor{},
+		PredicateType: PredicateType,
+		Predicate: Provenance{
+			BuildDefinition: BuildDefinition{
+				BuildType: BuildType,
+				ExternalParameters: ExternalParameters{
+					Component:   desc.Component.Name,
+					Version:     desc.Component.Version,
+					Constructor: opts.Constructor,
+				},
+				InternalParameters: InternalParameters{
+					GOOS:   goruntime.GOOS,
+					GOARCH: goruntime.GOARCH,
+				},
+			},
+			RunDetails: RunDetails{
+				Builder: opts.Builder,
+				Metadata: Metadata{
+					StartedOn:  utc(startedOn),
+					FinishedOn: utc(finishedOn),
+				},
+			},
+		},
+	}
+
+	for _, resource := range desc.Component.Resources {
+		if resource.Digest == nil || resource.Name == ResourceName {
+			continue
+		}
+		identity := resource.ToIdentity()
+		digestSet, err := DigestSet(resource.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", identity, err)
+		}
+		statement.Subject = append(statement.Subject, ResourceDescriptor{Name: identity.String(), Digest: digestSet})
+
+		index := slices.IndexFunc(inputs, func(input Input) bool { return input.Resource.Equal(identity) })
+		if index < 0 {
+			continue
+		}
+		annotations := map[string]string{}
+		if inputs[index].InputType != "" {
+			annotations[AnnotationInput] = inputs[index].InputType
+		} else {
+			annotations[AnnotationAccess] = inputs[index].AccessType
+		}
+		statement.Predicate.BuildDefinition.ResolvedDependencies = append(statement.Predicate.BuildDefinition.ResolvedDependencies, ResourceDescriptor{
+			Name:        identity.String(),
+			Digest:      digestSet,
+			Annotations: annotations,
+		})
+	}
+
+	for _, reference := range desc.Component.References {
+		dependency, err := referenceDependency(reference)
+		if err != nil {
+			return nil, err
+		}
+		statement.Predicate.BuildDefinition.ResolvedDependencies = append(statement.Predicate.BuildDefinition.ResolvedDependencies, dependency)
+	}
+
+	return statement, nil
+}
+
+// referenceDependency returns the resolved dependency describing a component reference.
+func referenceDependency(reference descriptor.Reference) (ResourceDescriptor, error) {
+	digestSet, err := DigestSet(&reference.Digest)
+	if err != nil {
+		return ResourceDescriptor{}, fmt.Errorf("component reference %q: %w", reference.Name, err)
+	}
+	return ResourceDescriptor{
+		Name:   reference.Component + ":" + reference.Version,
+		Digest: digestSet,
+		Annotations: map[string]string{
+			AnnotationReference:              reference.Name,
+			AnnotationNormalisationAlgorithm: reference.Digest.NormalisationAlgorithm,
+		},
+	}, nil
+}
+
+// Resource returns the local resource the provenance of a component version is stored as.
+func Resource(version string) *descriptor.Resource {
+	return &descriptor.Resource{
+		ElementMeta: descriptor.ElementMeta{
+			ObjectMeta: descriptor.ObjectMeta{
+				Name:    ResourceName,
+				Version: version,
+			},
+		},
+		Type:     ResourceType,
+		Relation: descriptor.LocalRelation,
+	}
+}
+
+// Parse decodes a provenance statement.
+func Parse(data []byte) (*Statement, error) {
+	var statement Statement
+	if err := json.Unmarshal(data, &statement); err != nil {
+		return nil, fmt.Errorf("failed to decode provenance: %w", err)
+	}
+	if statement.Type != StatementType {
+		return nil, fmt.Errorf("unsupported statement type %q", statement.Type)
+	}
+	if statement.PredicateType != PredicateType {
+		return nil, fmt.Errorf("unsupported predicate type %q", statement.PredicateType)
+	}
+	return &statement, nil
+}
+
+// Fetch reads the provenance stored in the component version desc from repo.
+// The integrity of the provenance against the digest of its resource is verified by the repository.
+func Fetch(ctx context.Context, repo repository.ComponentVersionRepository, desc *descriptor.Descriptor) (*Statement, error) {
+	index := slices.IndexFunc(desc.Component.Resources, func(resource descriptor.Resource) bool {
+		return resource.Name == ResourceName && resource.Type == ResourceType
+	})
+	if index < 0 {
+		return nil, fmt.Errorf("component version %s:%s has no resource %q: %w", desc.Component.Name, desc.Component.Version, ResourceName, ErrNotFound)
+	}
+	identity := desc.Component.Resources[index].ToIdentity()
+
+	data, _, err := repo.GetLocalResource(ctx, desc.Component.Name, desc.Component.Version, identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provenance resource %s: %w", identity, err)
+	}
+	reader, err := data.ReadCloser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provenance resource %s: %w", identity, err)
+	}
+	defer func() { _ = reader.Close() }()
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provenance resource %s: %w", identity, err)
+	}
+	return Parse(raw)
+}
+
+// Verify checks that statement is the provenance of the component version desc:
+// its build definition must name the component version, every resource of desc with a
+// digest must be a subject with a matching digest and vice versa, and every component
+// reference of desc must be a resolved dependency with a matching digest.
+// All mismatches are reported wrapping ErrMismatch.
+func Verify(desc *descriptor.Descriptor, statement *Statement) error {
+	var errs []error
+	parameters := statement.Predicate.BuildDefinition.ExternalParameters
+	if parameters.Component != desc.Component.Name || parameters.Version != desc.Component.Version {
+		errs = append(errs, fmt.Errorf("%w: provenance describes %s:%s", ErrMismatch, parameters.Component, parameters.Version))
+	}
+
+	subjects := make(map[string]map[string]string, len(statement.Subject))
+	for _, subject := range statement.Subject {
+		subjects[subject.Name] = subject.Digest
+	}
+	for _, resource := range desc.Component.Resources {
+		if resource.Digest == nil || resource.Name == ResourceName {
+			continue
+		}
+		name := resource.ToIdentity().String()
+		digest, ok := subjects[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: resource %s is no subject", ErrMismatch, name))
+			continue
+		}
+		delete(subjects, name)
+		if err := matches(digest, resource.Digest); err != nil {
+			errs = append(errs, fmt.Errorf("%w: resource %s: %w", ErrMismatch, name, err))
+		}
+	}
+	for name := range subjects {
+		errs = append(errs, fmt.Errorf("%w: subject %s is no resource with a digest", ErrMismatch, name))
+	}
+
+	for _, reference := range desc.Component.References {
+		index := slices.IndexFunc(statement.Predicate.BuildDefinition.ResolvedDependencies, func(dependency ResourceDescriptor) bool {
+			return dependency.Annotations[AnnotationReference] == reference.Name
+		})
+		if index < 0 {
+			errs = append(errs, fmt.Errorf("%w: component reference %q is no resolved dependency", ErrMismatch, reference.Name))
+			continue
+		}
+		dependency := statement.Predicate.BuildDefinition.ResolvedDependencies[index]
+		if err := matches(dependency.Digest, &reference.Digest); err != nil {
+			errs = append(errs, fmt.Errorf("%w: component reference %q: %w", ErrMismatch, reference.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// matches checks that the digest set contains digest.
+func matches(digestSet map[string]string, digest *descriptor.Digest) error {
+	expected, err := DigestSet(digest)
+	if err != nil {
+		return err
+	}
+	for algorithm, value := range expected {
+		if digestSet[algorithm] != value {
+			return fmt.Errorf("expected %s digest %q, got %q", algorithm, value, digestSet[algorithm])
+		}
+	}
+	return nil
+}
+
+func utc(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
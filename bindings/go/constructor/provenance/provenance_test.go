@@ -0,0 +1,147 @@
+package provenance_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/constructor/provenance"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+)
+
+func testDescriptor() *descriptor.Descriptor {
+	resource := func(name, value string) descriptor.Resource {
+		return descriptor.Resource{
+			ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: name, Version: "1.0.0"}},
+			Type:        "blob",
+			Relation:    descriptor.LocalRelation,
+			Digest: &descriptor.Digest{
+				HashAlgorithm:          "SHA-256",
+				NormalisationAlgorithm: "genericBlobDigest/v1",
+				Value:                  value,
+			},
+		}
+	}
+	desc := &descriptor.Descriptor{}
+	desc.Component.Name = "ocm.software/test"
+	desc.Component.Version = "1.0.0"
+	desc.Component.Resources = []descriptor.Resource{resource("input", "aa"), resource("access", "bb")}
+	desc.Component.Resources = append(desc.Component.Resources, descriptor.Resource{
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "undigested", Version: "1.0.0"}},
+		Type:        "blob",
+	})
+	desc.Component.References = []descriptor.Reference{{
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "ref", Version: "2.0.0"}},
+		Component:   "ocm.software/referenced",
+		Digest: descriptor.Digest{
+			HashAlgorithm:          "SHA3-256",
+			NormalisationAlgorithm: "jsonNormalisation/v4alpha1",
+			Value:                  "cc",
+		},
+	}}
+	return desc
+}
+
+func TestGenerateAndVerify(t *testing.T) {
+	r := require.New(t)
+	desc := testDescriptor()
+	started := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	statement, err := provenance.Generate(desc, []provenance.Input{
+		{Resource: desc.Component.Resources[0].ToIdentity(), InputType: "file/v1"},
+		{Resource: desc.Component.Resources[1].ToIdentity(), AccessType: "ociArtifact/v1"},
+		{Resource: desc.Component.Resources[2].ToIdentity(), InputType: "file/v1"},
+	}, provenance.Options{
+		Builder:     provenance.Builder{ID: "https://ocm.software/test", Version: map[string]string{"ocm": "1.0.0"}},
+		Constructor: &provenance.ResourceDescriptor{URI: "component-constructor.yaml", Digest: map[string]string{"sha256": "dd"}},
+	}, started, started.Add(time.Minute))
+	r.NoError(err)
+
+	assert.Equal(t, provenance.StatementType, statement.Type)
+	assert.Equal(t, provenance.PredicateType, statement.PredicateType)
+	assert.Equal(t, provenance.BuildType, statement.Predicate.BuildDefinition.BuildType)
+	assert.Equal(t, []provenance.ResourceDescriptor{
+		{Name: desc.Component.Resources[0].ToIdentity().String(), Digest: map[string]string{"sha256": "aa"}},
+		{Name: desc.Component.Resources[1].ToIdentity().String(), Digest: map[string]string{"sha256": "bb"}},
+	}, statement.Subject)
+	assert.Equal(t, []provenance.ResourceDescriptor{
+		{
+			Name:        desc.Component.Resources[0].ToIdentity().String(),
+			Digest:      map[string]string{"sha256": "aa"},
+			Annotations: map[string]string{provenance.AnnotationInput: "file/v1"},
+		},
+		{
+			Name:        desc.Component.Resources[1].ToIdentity().String(),
+			Digest:      map[string]string{"sha256": "bb"},
+			Annotations: map[string]string{provenance.AnnotationAccess: "ociArtifact/v1"},
+		},
+		{
+			Name:   "ocm.software/referenced:2.0.0",
+			Digest: map[string]string{"sha3-256": "cc"},
+			Annotations: map[string]string{
+				provenance.AnnotationReference:              "ref",
+				provenance.AnnotationNormalisationAlgorithm: "jsonNormalisation/v4alpha1",
+			},
+		},
+	}, statement.Predicate.BuildDefinition.ResolvedDependencies)
+	assert.Equal(t, started, *statement.Predicate.RunDetails.Metadata.StartedOn)
+
+	raw, err := json.Marshal(statement)
+	r.NoError(err)
+	parsed, err := provenance.Parse(raw)
+	r.NoError(err)
+	r.NoError(provenance.Verify(desc, parsed))
+
+	t.Run("changed resource digest", func(t *testing.T) {
+		changed := testDescriptor()
+		changed.Component.Resources[1].Digest.Value = "ff"
+		require.ErrorIs(t, provenance.Verify(changed, parsed), provenance.ErrMismatch)
+	})
+
+	t.Run("added resource", func(t *testing.T) {
+		changed := testDescriptor()
+		added := changed.Component.Resources[0]
+		added.Name = "added"
+		changed.Component.Resources = append(changed.Component.Resources, added)
+		require.ErrorIs(t, provenance.Verify(changed, parsed), provenance.ErrMismatch)
+	})
+
+	t.Run("removed resource", func(t *testing.T) {
+		changed := testDescriptor()
+		changed.Component.Resources = changed.Component.Resources[1:]
+		require.ErrorIs(t, provenance.Verify(changed, parsed), provenance.ErrMismatch)
+	})
+
+	t.Run("changed reference digest", func(t *testing.T) {
+		changed := testDescriptor()
+		changed.Component.References[0].Digest.Value = "ff"
+		require.ErrorIs(t, provenance.Verify(changed, parsed), provenance.ErrMismatch)
+	})
+
+	t.Run("other component version", func(t *testing.T) {
+		changed := testDescriptor()
+		changed.Component.Version = "1.0.1"
+		require.ErrorIs(t, provenance.Verify(changed, parsed), provenance.ErrMismatch)
+	})
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	desc := testDescriptor()
+	_, err := provenance.Generate(desc, nil, provenance.Options{}, time.Time{}, time.Time{})
+	require.ErrorContains(t, err, "missing builder id")
+
+	desc.Component.Resources[0].Digest.HashAlgorithm = "MD5"
+	_, err = provenance.Generate(desc, nil, provenance.Options{Builder: provenance.Builder{ID: "test"}}, time.Time{}, time.Time{})
+	require.ErrorContains(t, err, "unsupported hash algorithm")
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := provenance.Parse([]byte("{"))
+	require.ErrorContains(t, err, "failed to decode provenance")
+
+	_, err = provenance.Parse([]byte(`{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://example.com/other"}`))
+	require.ErrorContains(t, err, "unsupported predicate type")
+}
@@ -58,6 +58,7 @@ func NewGraphDiscoverer[K cmp.Ordered, V any](opts *GraphDiscovererOptions[K, V]
 	return &GraphDiscoverer[K, V]{
 		graph:   NewSyncedDirectedAcyclicGraph[K](),
 		doneMap: &sync.Map{},
+		depths:  make(map[K]int),
 		opts:    opts,
 	}
 }
@@ -65,11 +66,15 @@ func NewGraphDiscoverer[K cmp.Ordered, V any](opts *GraphDiscovererOptions[K, V]
 // GraphDiscoverer orchestrates concurrent graph discovery.
 // - graph: the shared DAG being built
 // - doneMap: ensures each vertex is processed exactly once
+// - depths: shallowest depth each vertex was reached at (only used with MaxDepth)
 // - opts: external resolver and neighbor discoverer
 type GraphDiscoverer[K cmp.Ordered, V any] struct {
 	opts    *GraphDiscovererOptions[K, V]
 	graph   *SyncedDirectedAcyclicGraph[K]
 	doneMap *sync.Map
+
+	depthsMu sync.Mutex
+	depths   map[K]int
 }
 
 func (d *GraphDiscoverer[K, V]) Graph() *SyncedDirectedAcyclicGraph[K] {
@@ -138,6 +143,7 @@ func (d *GraphDiscoverer[K, V]) CurrentState(key K) DiscoveryState {
 // Discover performs concurrent recursive discovery starting from the given roots.
 // Guarantees:
 // - Each vertex is resolved at most once.
+// - If MaxDepth is set, no vertex deeper than MaxDepth (on its shortest path from a root) is resolved.
 // - If any error occurs, discovery halts and propagates the error.
 // - States are updated consistently on success or failure.
 func (d *GraphDiscoverer[K, V]) Discover(ctx context.Context) (retErr error) {
@@ -155,7 +161,7 @@ func (d *GraphDiscoverer[K, V]) Discover(ctx context.Context) (retErr error) {
 	errGroup, errgroupCtx := errgroup.WithContext(ctx)
 	for _, root := range d.opts.Roots {
 		errGroup.Go(func() error {
-			return d.discover(errgroupCtx, root)
+			return d.discover(errgroupCtx, root, 0)
 		})
 	}
 
@@ -166,9 +172,11 @@ func (d *GraphDiscoverer[K, V]) Discover(ctx context.Context) (retErr error) {
 }
 
 // discover resolves one vertex and recursively explores its neighbors.
+// depth is the distance of the vertex from the root it was reached from.
 func (d *GraphDiscoverer[K, V]) discover(
 	ctx context.Context,
 	id K,
+	depth int,
 ) error {
 	// Early abort if context is cancelled.
 	select {
@@ -189,9 +197,15 @@ func (d *GraphDiscoverer[K, V]) discover(
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-done:
+		}
+		// The vertex may have been reached on a longer path first and
+		// therefore not been expanded deep enough.
+		if d.CurrentState(id) != DiscoveryStateCompleted || !d.shallower(id, depth) {
 			return nil
 		}
+		return d.expand(ctx, id, d.CurrentValue(id), depth)
 	}
+	d.shallower(id, depth)
 
 	// Add vertex in "discovering" state.
 	if err := d.graph.WithWriteLock(func(d *dag.DirectedAcyclicGraph[K]) error {
@@ -218,6 +232,26 @@ func (d *GraphDiscoverer[K, V]) discover(
 		return err
 	}
 
+	err = d.expand(ctx, id, value, depth)
+
+	// Finalize state.
+	return d.graph.WithWriteLock(func(d *dag.DirectedAcyclicGraph[K]) error {
+		if err != nil {
+			d.Vertices[id].Attributes[AttributeDiscoveryState] = DiscoveryStateError
+		} else {
+			d.Vertices[id].Attributes[AttributeDiscoveryState] = DiscoveryStateCompleted
+		}
+		return err
+	})
+}
+
+// expand discovers the neighbors of a resolved vertex and adds the edges to them.
+// Vertices at MaxDepth are not expanded.
+func (d *GraphDiscoverer[K, V]) expand(ctx context.Context, id K, value V, depth int) error {
+	if d.opts.MaxDepth > 0 && depth >= d.opts.MaxDepth {
+		return nil
+	}
+
 	// Discover neighbors.
 	neighbors, err := d.opts.Discoverer.Discover(ctx, value)
 
@@ -237,7 +271,7 @@ func (d *GraphDiscoverer[K, V]) discover(
 	errGroup, egctx := errgroup.WithContext(ctx)
 	for index, neighbor := range neighbors {
 		errGroup.Go(func() error {
-			if err := d.discover(egctx, neighbor); err != nil {
+			if err := d.discover(egctx, neighbor, depth+1); err != nil {
 				return fmt.Errorf("failed to discover reference %v: %w", neighbor, err)
 			}
 			// Add edge from current vertex to neighbor.
@@ -248,15 +282,21 @@ func (d *GraphDiscoverer[K, V]) discover(
 			})
 		})
 	}
-	err = errGroup.Wait()
+	return errGroup.Wait()
+}
 
-	// Finalize state.
-	return d.graph.WithWriteLock(func(d *dag.DirectedAcyclicGraph[K]) error {
-		if err != nil {
-			d.Vertices[id].Attributes[AttributeDiscoveryState] = DiscoveryStateError
-		} else {
-			d.Vertices[id].Attributes[AttributeDiscoveryState] = DiscoveryStateCompleted
-		}
-		return err
-	})
+// shallower records depth for the vertex and reports whether it is shallower
+// than any depth the vertex was reached at before. Without MaxDepth the depth
+// is irrelevant and shallower always reports false.
+func (d *GraphDiscoverer[K, V]) shallower(id K, depth int) bool {
+	if d.opts.MaxDepth <= 0 {
+		return false
+	}
+	d.depthsMu.Lock()
+	defer d.depthsMu.Unlock()
+	if previous, ok := d.depths[id]; ok && previous <= depth {
+		return false
+	}
+	d.depths[id] = depth
+	return true
 }
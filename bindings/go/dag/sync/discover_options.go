@@ -13,7 +13,6 @@ import (
 //   - K: comparable key type for vertices (e.g., string, int).
 //   - V: value type associated with each vertex (e.g., a struct or object).
 //
-// TODO(fabianburth): Add a recursion depth limit.
 // TODO(jakobmoellerdev): add queue/workerpool to introduce a discovery
 // concurrency limit (https://github.com/open-component-model/ocm-project/issues/705)
 type GraphDiscovererOptions[K cmp.Ordered, V any] struct {
@@ -30,6 +29,13 @@ type GraphDiscovererOptions[K cmp.Ordered, V any] struct {
 	// This controls how the graph is expanded once a vertex is resolved.
 	// Must be concurrency-safe.
 	Discoverer Discoverer[K, V]
+
+	// MaxDepth limits how many levels below the roots are discovered.
+	// Roots are at depth 0, their direct neighbors at depth 1, and so on.
+	// Vertices at MaxDepth are resolved, but the Discoverer is not called for them.
+	// A vertex reachable on several paths is expanded according to the shortest one.
+	// A value of 0 or below disables the limit.
+	MaxDepth int
 }
 
 // Resolver defines how to resolve a vertex key into its value.
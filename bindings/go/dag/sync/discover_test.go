@@ -3,7 +3,9 @@ package sync
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -163,4 +165,69 @@ func TestDAGDiscovery(t *testing.T) {
 
 		r.Equal(dag.CurrentState("B"), DiscoveryStateCompleted, "expected vertex B to be in completed state, but got %s", dag.CurrentState("B"))
 	})
+
+	t.Run("graph discovery stops at max depth", func(t *testing.T) {
+		ctx := t.Context()
+		r := require.New(t)
+		// D is reachable at depth 3 from root A, but at depth 1 from root R.
+		// R resolves slowly, so D is usually reached via A first and has to be
+		// expanded again once the shorter path through R is found: E (depth 2)
+		// and F (depth 3) are discovered, G (depth 4) is not.
+		//  A
+		//  |
+		//  B   R
+		//  |   |
+		//  C   |
+		//   \ /
+		//    D
+		//    |
+		//    E
+		//    |
+		//    F
+		//    |
+		//    G
+		graph := map[string][]string{
+			"A": {"B"},
+			"B": {"C"},
+			"C": {"D"},
+			"R": {"D"},
+			"D": {"E"},
+			"E": {"F"},
+			"F": {"G"},
+			"G": {},
+		}
+		var resolved sync.Map
+		dag := NewGraphDiscoverer(&GraphDiscovererOptions[string, string]{
+			Roots:    []string{"A", "R"},
+			MaxDepth: 3,
+			Resolver: ResolverFunc[string, string](func(ctx context.Context, key string) (value string, err error) {
+				if _, ok := graph[key]; !ok {
+					return "", fmt.Errorf("no node found with ID %s", key)
+				}
+				if key == "R" {
+					time.Sleep(50 * time.Millisecond)
+				}
+				resolved.Store(key, struct{}{})
+				return key, nil
+			}),
+			Discoverer: DiscovererFunc[string, string](func(ctx context.Context, parent string) (children []string, err error) {
+				return graph[parent], nil
+			}),
+		})
+
+		r.NoError(dag.Discover(ctx))
+
+		r.ElementsMatch([]string{"D"}, dag.CurrentEdges("C"))
+		r.ElementsMatch([]string{"D"}, dag.CurrentEdges("R"))
+		r.ElementsMatch([]string{"E"}, dag.CurrentEdges("D"))
+		r.ElementsMatch([]string{"F"}, dag.CurrentEdges("E"))
+		r.Empty(dag.CurrentEdges("F"), "F is at max depth and must not be expanded")
+
+		_, ok := resolved.Load("G")
+		r.False(ok, "G is beyond max depth and must not be resolved")
+		r.Equal(DiscoveryStateUnknown, dag.CurrentState("G"))
+		for _, id := range []string{"A", "B", "C", "R", "D", "E", "F"} {
+			r.Equal(DiscoveryStateCompleted, dag.CurrentState(id), "unexpected state for %s", id)
+		}
+	})
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"maps"
 	"strings"
+	"time"
 
 	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	"ocm.software/open-component-model/bindings/go/runtime"
@@ -275,9 +276,23 @@ func ConvertFromV2Signature(signature *v2.Signature) *Signature {
 		Name:      signature.Name,
 		Digest:    *ConvertFromV2Digest(&signature.Digest),
 		Signature: *ConvertFromV2SignatureInfo(&signature.Signature),
+		Timestamp: ConvertFromV2Timestamp(signature.Timestamp),
 	}
 }
 
+func ConvertFromV2Timestamp(timestamp *v2.TimestampSpec) *TimestampSpec {
+	if timestamp == nil {
+		return nil
+	}
+	n := &TimestampSpec{
+		Value: timestamp.Value,
+	}
+	if timestamp.Time != nil {
+		n.Time = timestamp.Time.Time.Time
+	}
+	return n
+}
+
 func ConvertFromV2SignatureInfo(signature *v2.SignatureInfo) *SignatureInfo {
 	if signature == nil {
 		return nil
@@ -487,9 +502,23 @@ func ConvertToV2Signature(sig *Signature) *v2.Signature {
 		Name:      sig.Name,
 		Digest:    *ConvertToV2Digest(&sig.Digest),
 		Signature: *ConvertToV2SignatureInfo(&sig.Signature),
+		Timestamp: ConvertToV2Timestamp(sig.Timestamp),
 	}
 }
 
+func ConvertToV2Timestamp(timestamp *TimestampSpec) *v2.TimestampSpec {
+	if timestamp == nil {
+		return nil
+	}
+	n := &v2.TimestampSpec{
+		Value: timestamp.Value,
+	}
+	if !timestamp.Time.IsZero() {
+		n.Time = &v2.Timestamp{Time: v2.NewTime(timestamp.Time.UTC().Round(time.Second))}
+	}
+	return n
+}
+
 func ConvertToV2SignatureInfo(sig *SignatureInfo) *v2.SignatureInfo {
 	if sig == nil {
 		return nil
@@ -331,6 +331,25 @@ type Signature struct {
 	// Signature is the metadata and cryptographic payload proving the authenticity
 	// of the digest. It includes details on the algorithm, encoding, and issuer.
 	Signature SignatureInfo `json:"-"`
+
+	// Timestamp is an optional trusted timestamp of the signature issued by a
+	// time stamping authority. It proves that the signature existed at that time,
+	// so that the signature can be verified after the signing certificate expired.
+	Timestamp *TimestampSpec `json:"-"`
+}
+
+// TimestampSpec is a trusted timestamp of a signature.
+//
+// See specification reference:
+//   - https://datatracker.ietf.org/doc/html/rfc3161
+//
+// +k8s:deepcopy-gen=true
+type TimestampSpec struct {
+	// Value is the base64 encoded RFC 3161 timestamp token.
+	Value string `json:"-"`
+
+	// Time is the time attested by the timestamp token.
+	Time time.Time `json:"-"`
 }
 
 // SignatureInfo provides the metadata and cryptographic material for a signature.
@@ -3,6 +3,7 @@ package runtime_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -506,6 +507,29 @@ func TestConvertFromV2Signatures(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with timestamp",
+			signatures: []v2.Signature{
+				{
+					Name:      "test",
+					Signature: v2.SignatureInfo{Algorithm: "test-algo", Value: "test-value"},
+					Timestamp: &v2.TimestampSpec{
+						Value: "dG9rZW4=",
+						Time:  &v2.Timestamp{Time: v2.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))},
+					},
+				},
+			},
+			want: []descriptorRuntime.Signature{
+				{
+					Name:      "test",
+					Signature: descriptorRuntime.SignatureInfo{Algorithm: "test-algo", Value: "test-value"},
+					Timestamp: &descriptorRuntime.TimestampSpec{
+						Value: "dG9rZW4=",
+						Time:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -793,6 +817,29 @@ func TestConvertToV2Signatures(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with timestamp",
+			signatures: []descriptorRuntime.Signature{
+				{
+					Name:      "test",
+					Signature: descriptorRuntime.SignatureInfo{Algorithm: "test-algo", Value: "test-value"},
+					Timestamp: &descriptorRuntime.TimestampSpec{
+						Value: "dG9rZW4=",
+						Time:  time.Date(2026, 1, 2, 3, 4, 5, 400, time.UTC),
+					},
+				},
+			},
+			want: []v2.Signature{
+				{
+					Name:      "test",
+					Signature: v2.SignatureInfo{Algorithm: "test-algo", Value: "test-value"},
+					Timestamp: &v2.TimestampSpec{
+						Value: "dG9rZW4=",
+						Time:  &v2.Timestamp{Time: v2.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
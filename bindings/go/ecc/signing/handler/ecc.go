@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+
+	"ocm.software/open-component-model/bindings/go/ecc/spec/signing/v1alpha1"
+)
+
+// signECC signs dig using the requested algorithm, which must match the type of priv.
+// The digest is signed as is: ECDSA signs it as a hash, Ed25519 as the message.
+func signECC(algorithm v1alpha1.SignatureAlgorithm, priv crypto.Signer, dig []byte) ([]byte, error) {
+	switch algorithm {
+	case v1alpha1.AlgorithmECDSA:
+		key, ok := priv.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T cannot sign %s", ErrKeyMismatch, priv, algorithm)
+		}
+		return ecdsa.SignASN1(rand.Reader, key, dig)
+	case v1alpha1.AlgorithmEd25519:
+		key, ok := priv.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T cannot sign %s", ErrKeyMismatch, priv, algorithm)
+		}
+		return ed25519.Sign(key, dig), nil
+	default:
+		return nil, ErrInvalidAlgorithm
+	}
+}
+
+// verifyECC verifies sig over dig using the requested algorithm, which must match the type of pub.
+func verifyECC(algorithm v1alpha1.SignatureAlgorithm, pub crypto.PublicKey, dig, sig []byte) error {
+	switch algorithm {
+	case v1alpha1.AlgorithmECDSA:
+		key, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: %T cannot verify %s", ErrKeyMismatch, pub, algorithm)
+		}
+		if !ecdsa.VerifyASN1(key, dig, sig) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case v1alpha1.AlgorithmEd25519:
+		key, ok := pub.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: %T cannot verify %s", ErrKeyMismatch, pub, algorithm)
+		}
+		if !ed25519.Verify(key, dig, sig) {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		return ErrInvalidAlgorithm
+	}
+}
+
+// dsseMessage returns the message signed for the DSSE pre-authentication encoding
+// pae. ECDSA signs its hash, Ed25519 the PAE itself.
+func dsseMessage(algorithm v1alpha1.SignatureAlgorithm, h crypto.Hash, pae []byte) []byte {
+	if algorithm != v1alpha1.AlgorithmECDSA {
+		return pae
+	}
+	hh := h.New()
+	hh.Write(pae)
+	return hh.Sum(nil)
+}
@@ -0,0 +1,443 @@
+// Package handler implements ECDSA and Ed25519 signing and verification for OCM.
+// It supports ECDSA on the NIST curves P-256, P-384 and P-521 as well as
+// Ed25519, and three encodings:
+//  1. Plain: hex signature bytes without certificates.
+//  2. PEM: a SIGNATURE PEM block with an embedded X.509 chain.
+//  3. DSSE: a DSSE envelope carrying an in-toto statement of the digest.
+//
+// For PEM verification, the leaf public key is taken from the chain after
+// the chain validates against system roots and/or an optional trust anchor
+// provided via credentials.
+package handler
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ecccredentials "ocm.software/open-component-model/bindings/go/ecc/signing/handler/internal/credentials"
+	eccsignature "ocm.software/open-component-model/bindings/go/ecc/signing/handler/internal/pem"
+	ecccredentialsv1alpha1 "ocm.software/open-component-model/bindings/go/ecc/spec/credentials/v1alpha1"
+	identityv1alpha1 "ocm.software/open-component-model/bindings/go/ecc/spec/identity/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/ecc/spec/signing/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/dsse"
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
+)
+
+// Common errors for callers to test.
+var (
+	ErrInvalidAlgorithm  = errors.New("invalid algorithm")
+	ErrInvalidSignature  = errors.New("invalid signature")
+	ErrKeyMismatch       = errors.New("key type does not match signature algorithm")
+	ErrMissingPrivateKey = errors.New("private key not found")
+	ErrMissingPublicKey  = errors.New("missing public key, required for plain ECC signatures")
+)
+
+// Handler holds trust anchors and time source for X.509 validation.
+type Handler struct {
+	roots *x509.CertPool
+	now   func() time.Time
+}
+
+// New returns a Handler. If useSystemRoots is true, system trust roots are loaded, otherwise an empty pool is used.
+func New(_ *runtime.Scheme, useSystemRoots bool) (*Handler, error) {
+	var (
+		roots *x509.CertPool
+		err   error
+	)
+	if useSystemRoots {
+		roots, err = x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("load system roots: %w", err)
+		}
+	}
+	return &Handler{
+		roots: roots,
+		now:   time.Now,
+	}, nil
+}
+
+func (h *Handler) GetSigningHandlerScheme() *runtime.Scheme {
+	return v1alpha1.Scheme
+}
+
+// ---- SPI ----
+
+// Sign produces a signature for the given digest, using the configured
+// algorithm and encoding policy. The algorithm must match the type of the
+// private key. For PEM encoding, the certificate chain is read from
+// credentials and embedded into the SIGNATURE block. For DSSE encoding, the PAE
+// of an in-toto statement of the digest is signed instead of the digest.
+func (h *Handler) Sign(
+	ctx context.Context,
+	unsigned descruntime.Digest,
+	rawCfg runtime.Typed,
+	creds runtime.Typed,
+) (descruntime.SignatureInfo, error) {
+	var supported v1alpha1.Config
+	if err := h.GetSigningHandlerScheme().Convert(rawCfg, &supported); err != nil {
+		return descruntime.SignatureInfo{}, fmt.Errorf("convert config: %w", err)
+	}
+	algorithm := supported.GetSignatureAlgorithm()
+
+	var eccCreds *ecccredentialsv1alpha1.ECCCredentials
+	if creds != nil {
+		c, err := ecccredentialsv1alpha1.ConvertToECCCredentials(creds)
+		if err != nil {
+			return descruntime.SignatureInfo{}, fmt.Errorf("parse ecc credentials: %w", err)
+		}
+		eccCreds = c
+	}
+
+	priv, err := ecccredentials.PrivateKeyFromCredentials(eccCreds)
+	if err != nil {
+		return descruntime.SignatureInfo{}, fmt.Errorf("cannot load private key from credentials for signing: %w", err)
+	}
+	if priv == nil {
+		return descruntime.SignatureInfo{}, ErrMissingPrivateKey
+	}
+
+	hash, dig, err := parseDigest(unsigned)
+	if err != nil {
+		return descruntime.SignatureInfo{}, err
+	}
+
+	if supported.GetSignatureEncodingPolicy() == v1alpha1.SignatureEncodingPolicyDSSE {
+		slog.WarnContext(ctx, "signing with DSSE encoding is experimental")
+		envelope, err := dsse.Seal(unsigned, func(pae []byte) ([]byte, error) {
+			return signECC(algorithm, priv, dsseMessage(algorithm, hash, pae))
+		})
+		if err != nil {
+			return descruntime.SignatureInfo{}, fmt.Errorf("ecc sign: %w", err)
+		}
+		return descruntime.SignatureInfo{
+			Algorithm: string(algorithm),
+			MediaType: v1alpha1.MediaTypeDSSE,
+			Value:     envelope,
+		}, nil
+	}
+
+	rawSig, err := signECC(algorithm, priv, dig)
+	if err != nil {
+		return descruntime.SignatureInfo{}, fmt.Errorf("ecc sign: %w", err)
+	}
+
+	switch supported.GetSignatureEncodingPolicy() {
+	case v1alpha1.SignatureEncodingPolicyPEM:
+		slog.WarnContext(ctx, "signing with PEM encoding is experimental")
+		chain, err := ecccredentials.CertificateChainFromCredentials(eccCreds)
+		if err != nil {
+			return descruntime.SignatureInfo{}, fmt.Errorf("read certificate chain: %w", err)
+		}
+		pem := eccsignature.SignatureBytesToPem(string(algorithm), rawSig, chain...)
+		return descruntime.SignatureInfo{
+			Algorithm: string(algorithm),
+			MediaType: v1alpha1.MediaTypePEM,
+			Value:     string(pem),
+		}, nil
+	case v1alpha1.SignatureEncodingPolicyPlain:
+		fallthrough
+	default:
+		return descruntime.SignatureInfo{
+			Algorithm: string(algorithm),
+			MediaType: supported.GetDefaultMediaType(),
+			Value:     hex.EncodeToString(rawSig),
+		}, nil
+	}
+}
+
+// Verify validates an OCM signature. For plain and DSSE signatures, a public key
+// must be present in credentials. For PEM signatures, the embedded chain must be valid
+// against system roots and/or the optional trust anchor in credentials, at the
+// time of a verified timestamp if the context carries one.
+func (h *Handler) Verify(
+	ctx context.Context,
+	signed descruntime.Signature,
+	// we use hints from the signature to determine the correct settings, so no additional config is needed
+	_ runtime.Typed,
+	creds runtime.Typed,
+) error {
+	var eccCreds *ecccredentialsv1alpha1.ECCCredentials
+	if creds != nil {
+		c, err := ecccredentialsv1alpha1.ConvertToECCCredentials(creds)
+		if err != nil {
+			return fmt.Errorf("parse ecc credentials: %w", err)
+		}
+		eccCreds = c
+	}
+
+	hash, dig, err := parseDigest(signed.Digest)
+	if err != nil {
+		return err
+	}
+
+	switch signed.Signature.MediaType {
+	case v1alpha1.MediaTypePlainECDSA, v1alpha1.MediaTypePlainEd25519:
+		pubFromCreds, err := ecccredentials.PublicKeyFromCredentials(eccCreds)
+		if err != nil {
+			return fmt.Errorf("cannot load public key from credentials for verification: %w", err)
+		}
+		if pubFromCreds == nil {
+			return ErrMissingPublicKey
+		}
+		sig, err := hex.DecodeString(signed.Signature.Value)
+		if err != nil {
+			return fmt.Errorf("decode hex signature: %w", err)
+		}
+		alg, err := algorithmFromPlainMedia(signed.Signature.MediaType)
+		if err != nil {
+			return err
+		}
+		return verifyECC(alg, pubFromCreds.PublicKey, dig, sig)
+
+	case v1alpha1.MediaTypePEM:
+		slog.WarnContext(ctx, "verifying signatures with PEM encoding is experimental")
+		return h.verifyPEMSignature(ctx, signed, dig, eccCreds)
+
+	case v1alpha1.MediaTypeDSSE:
+		slog.WarnContext(ctx, "verifying signatures with DSSE encoding is experimental")
+		pubFromCreds, err := ecccredentials.PublicKeyFromCredentials(eccCreds)
+		if err != nil {
+			return fmt.Errorf("cannot load public key from credentials for verification: %w", err)
+		}
+		if pubFromCreds == nil {
+			return ErrMissingPublicKey
+		}
+		alg := v1alpha1.SignatureAlgorithm(signed.Signature.Algorithm)
+		return dsse.Open(signed.Signature.Value, signed.Digest, func(pae, sig []byte) error {
+			return verifyECC(alg, pubFromCreds.PublicKey, dsseMessage(alg, hash, pae), sig)
+		})
+
+	default:
+		return fmt.Errorf("unsupported media type %q", signed.Signature.MediaType)
+	}
+}
+
+// verifyPEMSignature handles the MediaTypePEM case for Verify. It parses the
+// embedded chain, classifies the credential chain into intermediates and an
+// optional root anchor, validates the X.509 path and issuer constraint, and
+// finally verifies the signature bytes with the public key of the leaf.
+func (h *Handler) verifyPEMSignature(
+	ctx context.Context,
+	signed descruntime.Signature,
+	dig []byte,
+	creds *ecccredentialsv1alpha1.ECCCredentials,
+) error {
+	sig, algFromPEM, chain, err := eccsignature.GetSignatureFromPem([]byte(signed.Signature.Value))
+	if err != nil {
+		return fmt.Errorf("parse pem signature: %w", err)
+	}
+	if len(chain) == 0 {
+		return errors.New("pem signature missing certificate chain")
+	}
+	leaf := chain[0]
+	if !eccsignature.IsSupportedPublicKey(leaf.PublicKey) {
+		return errors.New("leaf cert public key is neither ECDSA nor Ed25519")
+	}
+
+	credIntermediates, credAnchor, err := classifyCredentialChain(creds)
+	if err != nil {
+		return err
+	}
+
+	// Merge embedded chain intermediates with credential intermediates.
+	allIntermediates := make([]*x509.Certificate, 0, len(chain)-1+len(credIntermediates))
+	allIntermediates = append(allIntermediates, chain[1:]...)
+	allIntermediates = append(allIntermediates, credIntermediates...)
+
+	if err := verifyChainWithOptionalAnchor(leaf, allIntermediates, credAnchor, h.roots, h.verificationTime(ctx)); err != nil {
+		return fmt.Errorf("certificate verification failed: %w", err)
+	}
+
+	if err := verifyIssuerForLeafCert(signed, leaf); err != nil {
+		return fmt.Errorf("issuer verification based on leaf certificate failed: %w", err)
+	}
+
+	return verifyECC(v1alpha1.SignatureAlgorithm(algFromPEM), leaf.PublicKey, dig, sig)
+}
+
+// verificationTime returns the time at which certificate chains are validated.
+// It is the time of a verified timestamp of the signature, if the caller verified
+// one (see [tsa.WithVerifiedTime]), and the current time otherwise.
+func (h *Handler) verificationTime(ctx context.Context) func() time.Time {
+	if t, ok := tsa.VerifiedTime(ctx); ok {
+		return func() time.Time { return t }
+	}
+	return h.now
+}
+
+// GetSigningCredentialConsumerIdentity requests credentials for signing.
+// It encodes the algorithm and the logical signature name.
+func (*Handler) GetSigningCredentialConsumerIdentity(
+	_ context.Context,
+	name string,
+	_ descruntime.Digest,
+	rawCfg runtime.Typed,
+) (runtime.Identity, error) {
+	var supported v1alpha1.Config
+	if err := v1alpha1.Scheme.Convert(rawCfg, &supported); err != nil {
+		return nil, fmt.Errorf("convert config: %w", err)
+	}
+	id := baseIdentity(supported.GetSignatureAlgorithm())
+	id.Signature = name
+	return eccIdentityToMap(id), nil
+}
+
+// GetVerifyingCredentialConsumerIdentity requests credentials for verification.
+// For plain signatures, infer algorithm from media type if empty.
+// For PEM signatures, parse the PEM and ensure its algorithm matches the declared one.
+// If declared is empty, use the algorithm parsed from the PEM.
+func (*Handler) GetVerifyingCredentialConsumerIdentity(
+	_ context.Context,
+	signature descruntime.Signature,
+	_ runtime.Typed,
+) (runtime.Identity, error) {
+	alg := signature.Signature.Algorithm
+
+	if signature.Signature.MediaType == v1alpha1.MediaTypePEM {
+		_, pemAlg, _, err := eccsignature.GetSignatureFromPem([]byte(signature.Signature.Value))
+		if err != nil {
+			return nil, fmt.Errorf("parse pem signature: %w", err)
+		}
+		if alg != "" && alg != pemAlg {
+			return nil, fmt.Errorf("algorithm mismatch: declared %q, pem %q", alg, pemAlg)
+		}
+		if alg == "" {
+			alg = pemAlg
+		}
+	} else if alg == "" {
+		if inferred, err := algorithmFromPlainMedia(signature.Signature.MediaType); err == nil {
+			alg = string(inferred)
+		}
+	}
+
+	id := baseIdentity(v1alpha1.SignatureAlgorithm(alg))
+	id.Signature = signature.Name
+	return eccIdentityToMap(id), nil
+}
+
+// ---- internal helpers ----
+
+// algorithmFromPlainMedia infers the algorithm from a plain media type.
+func algorithmFromPlainMedia(mt string) (v1alpha1.SignatureAlgorithm, error) {
+	switch mt {
+	case v1alpha1.MediaTypePlainECDSA:
+		return v1alpha1.AlgorithmECDSA, nil
+	case v1alpha1.MediaTypePlainEd25519:
+		return v1alpha1.AlgorithmEd25519, nil
+	default:
+		return "", fmt.Errorf("unsupported media type %q", mt)
+	}
+}
+
+// isSelfSigned reports whether cert is self-signed, i.e. its Issuer equals its
+// Subject and its signature can be verified with its own public key.
+func isSelfSigned(cert *x509.Certificate) bool {
+	return cert.CheckSignatureFrom(cert) == nil
+}
+
+// classifyCredentialChain parses the verifier-controlled credential chain and
+// splits it into intermediates (non-self-signed) and an optional root anchor
+// (the single self-signed cert, which must appear last if present).
+// A self-signed cert at any position other than last is rejected.
+func classifyCredentialChain(creds *ecccredentialsv1alpha1.ECCCredentials) (intermediates []*x509.Certificate, anchor *x509.Certificate, err error) {
+	chain, err := ecccredentials.CertificateChainFromCredentials(creds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot load certificate chain from credentials: %w", err)
+	}
+	for i, c := range chain {
+		if isSelfSigned(c) {
+			if i != len(chain)-1 {
+				return nil, nil, fmt.Errorf("self-signed certificate %q at position %d must be the last certificate in the credential chain", c.Subject.String(), i)
+			}
+			anchor = c
+		} else {
+			intermediates = append(intermediates, c)
+		}
+	}
+	return intermediates, anchor, nil
+}
+
+// verifyChainWithOptionalAnchor validates leaf against a root pool.
+//
+// intermediates are path-building certificates merged from the embedded
+// signature chain and any credential-supplied intermediates. Self-signed
+// certificates in intermediates are rejected — the signer must not embed
+// root CAs, and a self-signed cert is invalid as an intermediate.
+//
+// anchor is the self-signed root CA from credentials, or nil:
+//   - nil: system roots are the only trust anchors.
+//   - non-nil: system roots are ignored; the chain must terminate at exactly
+//     this anchor.
+func verifyChainWithOptionalAnchor(
+	leaf *x509.Certificate,
+	intermediates []*x509.Certificate,
+	anchor *x509.Certificate,
+	roots *x509.CertPool,
+	now func() time.Time,
+) error {
+	if anchor != nil {
+		// Credential root supplied: use an isolated pool so system roots cannot
+		// satisfy the chain in place of the verifier's chosen anchor.
+		roots = x509.NewCertPool()
+		roots.AddCert(anchor)
+	} else if roots == nil {
+		roots = x509.NewCertPool()
+	}
+
+	ip := x509.NewCertPool()
+	for _, c := range intermediates {
+		if isSelfSigned(c) {
+			return fmt.Errorf("invalid certificate chain: self-signed certificate %q must not be embedded in the signature; supply root CAs via credentials instead", c.Subject.String())
+		}
+		ip.AddCert(c)
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Intermediates: ip,
+		Roots:         roots,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		CurrentTime:   now(),
+	})
+	return err
+}
+
+// verifyIssuerForLeafCert checks that the Issuer field declared in the signature
+// matches the RFC 2253 string of the X.509 Issuer of the leaf certificate, i.e. the
+// DN of the CA that directly signed the leaf. The check is skipped when the Issuer
+// field is empty.
+func verifyIssuerForLeafCert(signed descruntime.Signature, leaf *x509.Certificate) error {
+	iss := strings.TrimSpace(signed.Signature.Issuer)
+	if iss == "" {
+		return nil
+	}
+	if leafIssuer := leaf.Issuer.String(); iss != leafIssuer {
+		return fmt.Errorf("issuer mismatch between %q and %q", iss, leafIssuer)
+	}
+	return nil
+}
+
+// baseIdentity builds a typed ECC credential consumer identity.
+func baseIdentity(algorithm v1alpha1.SignatureAlgorithm) *identityv1alpha1.ECCIdentity {
+	return &identityv1alpha1.ECCIdentity{
+		Type:      identityv1alpha1.V1Alpha1Type,
+		Algorithm: string(algorithm),
+	}
+}
+
+// eccIdentityToMap converts a typed ECCIdentity to a runtime.Identity map.
+func eccIdentityToMap(id *identityv1alpha1.ECCIdentity) runtime.Identity {
+	m := runtime.Identity{
+		identityv1alpha1.IdentityAttributeAlgorithm: id.Algorithm,
+		identityv1alpha1.IdentityAttributeSignature: id.Signature,
+	}
+	m.SetType(id.Type)
+	return m
+}
@@ -0,0 +1,476 @@
+package handler
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ecccredentialsv1alpha1 "ocm.software/open-component-model/bindings/go/ecc/spec/credentials/v1alpha1"
+	identityv1alpha1 "ocm.software/open-component-model/bindings/go/ecc/spec/identity/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/ecc/spec/signing/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/dsse"
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
+	"ocm.software/open-component-model/bindings/go/signing/tsa/tsatest"
+)
+
+type keyCase struct {
+	name      string
+	algorithm v1alpha1.SignatureAlgorithm
+	newKey    func(t *testing.T) crypto.Signer
+}
+
+var keyCases = []keyCase{
+	{name: "ECDSA P-256", algorithm: v1alpha1.AlgorithmECDSA, newKey: ecdsaKey(elliptic.P256())},
+	{name: "ECDSA P-384", algorithm: v1alpha1.AlgorithmECDSA, newKey: ecdsaKey(elliptic.P384())},
+	{name: "ECDSA P-521", algorithm: v1alpha1.AlgorithmECDSA, newKey: ecdsaKey(elliptic.P521())},
+	{name: "Ed25519", algorithm: v1alpha1.AlgorithmEd25519, newKey: ed25519Key},
+}
+
+func Test_ECC_Handler(t *testing.T) {
+	h, err := New(v1alpha1.Scheme, false)
+	require.NoError(t, err)
+
+	for _, kc := range keyCases {
+		t.Run(kc.name, func(t *testing.T) {
+			for _, d := range []descruntime.Digest{
+				digestHex(crypto.SHA256, []byte("hello world")),
+				digestHex(crypto.SHA512, []byte("hello world")),
+				digestHex(crypto.SHA3_256, []byte("hello world")),
+			} {
+				t.Run(d.HashAlgorithm, func(t *testing.T) {
+					key := kc.newKey(t)
+					cert := mustSelfSigned(t, "signer", key)
+					priv, chainPath := writeKeyAndChain(t, t.TempDir(), key, cert)
+					other := kc.newKey(t)
+					_, otherChainPath := writeKeyAndChain(t, t.TempDir(), other, mustSelfSigned(t, "other", other))
+
+					sign := func(t *testing.T, policy v1alpha1.SignatureEncodingPolicy) descruntime.Signature {
+						t.Helper()
+						si, err := h.Sign(t.Context(), d, &v1alpha1.Config{
+							Type:                    runtime.NewVersionedType(v1alpha1.ConfigType, v1alpha1.Version),
+							SignatureAlgorithm:      kc.algorithm,
+							SignatureEncodingPolicy: policy,
+						}, &ecccredentialsv1alpha1.ECCCredentials{
+							Type:              ecccredentialsv1alpha1.VersionedType,
+							PrivateKeyPEMFile: priv,
+							PublicKeyPEMFile:  chainPath,
+						})
+						require.NoError(t, err)
+						assert.Equal(t, string(kc.algorithm), si.Algorithm)
+						return descruntime.Signature{Name: "test", Digest: d, Signature: si}
+					}
+
+					tests := []struct {
+						name    string
+						policy  v1alpha1.SignatureEncodingPolicy
+						creds   *ecccredentialsv1alpha1.ECCCredentials
+						tamper  func(s *descruntime.Signature)
+						wantErr string
+					}{
+						{
+							name:   "plain signature with matching certificate",
+							policy: v1alpha1.SignatureEncodingPolicyPlain,
+							creds:  &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEMFile: chainPath},
+						},
+						{
+							name:   "plain signature with matching public key",
+							policy: v1alpha1.SignatureEncodingPolicyPlain,
+							creds:  &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEM: pkixPublicKeyPEM(t, key.Public())},
+						},
+						{
+							name:   "plain signature with only the private key",
+							policy: v1alpha1.SignatureEncodingPolicyPlain,
+							creds:  &ecccredentialsv1alpha1.ECCCredentials{PrivateKeyPEMFile: priv},
+						},
+						{
+							name:    "plain signature with another public key",
+							policy:  v1alpha1.SignatureEncodingPolicyPlain,
+							creds:   &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEMFile: otherChainPath},
+							wantErr: ErrInvalidSignature.Error(),
+						},
+						{
+							name:    "plain signature without credentials",
+							policy:  v1alpha1.SignatureEncodingPolicyPlain,
+							wantErr: ErrMissingPublicKey.Error(),
+						},
+						{
+							name:    "plain signature over another digest",
+							policy:  v1alpha1.SignatureEncodingPolicyPlain,
+							creds:   &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEMFile: chainPath},
+							tamper:  func(s *descruntime.Signature) { s.Digest = digestHex(crypto.SHA256, []byte("tampered")) },
+							wantErr: ErrInvalidSignature.Error(),
+						},
+						{
+							name:   "pem signature with the certificate as trust anchor",
+							policy: v1alpha1.SignatureEncodingPolicyPEM,
+							creds:  &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEMFile: chainPath},
+						},
+						{
+							name:    "pem signature without trust anchor",
+							policy:  v1alpha1.SignatureEncodingPolicyPEM,
+							wantErr: "certificate signed by unknown authority",
+						},
+						{
+							name:    "pem signature with another trust anchor",
+							policy:  v1alpha1.SignatureEncodingPolicyPEM,
+							creds:   &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEMFile: otherChainPath},
+							wantErr: "certificate signed by unknown authority",
+						},
+						{
+							name:    "pem signature with issuer mismatch",
+							policy:  v1alpha1.SignatureEncodingPolicyPEM,
+							creds:   &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEMFile: chainPath},
+							tamper:  func(s *descruntime.Signature) { s.Signature.Issuer = "CN=mismatch" },
+							wantErr: `issuer mismatch between "CN=mismatch" and "CN=signer"`,
+						},
+						{
+							name:   "pem signature with matching issuer",
+							policy: v1alpha1.SignatureEncodingPolicyPEM,
+							creds:  &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEMFile: chainPath},
+							tamper: func(s *descruntime.Signature) { s.Signature.Issuer = "CN=signer" },
+						},
+						{
+							name:   "dsse signature with matching public key",
+							policy: v1alpha1.SignatureEncodingPolicyDSSE,
+							creds:  &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEM: pkixPublicKeyPEM(t, key.Public())},
+						},
+						{
+							name:    "dsse signature with another public key",
+							policy:  v1alpha1.SignatureEncodingPolicyDSSE,
+							creds:   &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEMFile: otherChainPath},
+							wantErr: ErrInvalidSignature.Error(),
+						},
+						{
+							name:    "dsse signature without credentials",
+							policy:  v1alpha1.SignatureEncodingPolicyDSSE,
+							wantErr: ErrMissingPublicKey.Error(),
+						},
+						{
+							name:    "dsse signature over another digest",
+							policy:  v1alpha1.SignatureEncodingPolicyDSSE,
+							creds:   &ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEMFile: chainPath},
+							tamper:  func(s *descruntime.Signature) { s.Digest.Value = strings.Repeat("0", len(s.Digest.Value)) },
+							wantErr: dsse.ErrInvalidEnvelope.Error(),
+						},
+					}
+					for _, tc := range tests {
+						t.Run(tc.name, func(t *testing.T) {
+							s := sign(t, tc.policy)
+							if tc.tamper != nil {
+								tc.tamper(&s)
+							}
+							var creds runtime.Typed
+							if tc.creds != nil {
+								tc.creds.Type = ecccredentialsv1alpha1.VersionedType
+								creds = tc.creds
+							}
+							err := h.Verify(t.Context(), s, nil, creds)
+							if tc.wantErr != "" {
+								require.ErrorContains(t, err, tc.wantErr)
+								return
+							}
+							require.NoError(t, err)
+						})
+					}
+				})
+			}
+		})
+	}
+}
+
+func Test_ECC_Handler_CertificateChain(t *testing.T) {
+	h, err := New(v1alpha1.Scheme, false)
+	require.NoError(t, err)
+	d := digestHex(crypto.SHA256, []byte("hello world"))
+
+	for _, kc := range keyCases {
+		t.Run(kc.name, func(t *testing.T) {
+			c := buildChain(t, kc.newKey)
+			dir := t.TempDir()
+
+			signWith := func(t *testing.T, embedded ...*x509.Certificate) descruntime.Signature {
+				t.Helper()
+				priv, chainPath := writeKeyAndChain(t, t.TempDir(), c.leafKey, embedded...)
+				si, err := h.Sign(t.Context(), d, &v1alpha1.Config{
+					SignatureAlgorithm:      kc.algorithm,
+					SignatureEncodingPolicy: v1alpha1.SignatureEncodingPolicyPEM,
+				}, &ecccredentialsv1alpha1.ECCCredentials{
+					Type:              ecccredentialsv1alpha1.VersionedType,
+					PrivateKeyPEMFile: priv,
+					PublicKeyPEMFile:  chainPath,
+				})
+				require.NoError(t, err)
+				assert.Equal(t, v1alpha1.MediaTypePEM, si.MediaType)
+				si.Issuer = c.interm.Subject.String()
+				return descruntime.Signature{Digest: d, Signature: si}
+			}
+			rootCreds := &ecccredentialsv1alpha1.ECCCredentials{
+				Type:             ecccredentialsv1alpha1.VersionedType,
+				PublicKeyPEMFile: writeCertsPEM(t, dir, "root.pem", c.root),
+			}
+
+			t.Run("intermediate embedded, root from credentials", func(t *testing.T) {
+				require.NoError(t, h.Verify(t.Context(), signWith(t, c.leaf, c.interm), nil, rootCreds))
+			})
+
+			t.Run("intermediate and root from credentials", func(t *testing.T) {
+				require.NoError(t, h.Verify(t.Context(), signWith(t, c.leaf), nil, &ecccredentialsv1alpha1.ECCCredentials{
+					Type:             ecccredentialsv1alpha1.VersionedType,
+					PublicKeyPEMFile: writeCertsPEM(t, dir, "interm-root.pem", c.interm, c.root),
+				}))
+			})
+
+			t.Run("embedded root is rejected", func(t *testing.T) {
+				err := h.Verify(t.Context(), signWith(t, c.leaf, c.interm, c.root), nil, rootCreds)
+				require.ErrorContains(t, err, "must not be embedded in the signature")
+			})
+
+			t.Run("root must be last in the credential chain", func(t *testing.T) {
+				err := h.Verify(t.Context(), signWith(t, c.leaf), nil, &ecccredentialsv1alpha1.ECCCredentials{
+					Type:             ecccredentialsv1alpha1.VersionedType,
+					PublicKeyPEMFile: writeCertsPEM(t, dir, "root-interm.pem", c.root, c.interm),
+				})
+				require.ErrorContains(t, err, "must be the last certificate in the credential chain")
+			})
+
+			t.Run("expired certificate with verified timestamp", func(t *testing.T) {
+				server := tsatest.NewServer(t)
+				sig := signWith(t, c.leaf, c.interm)
+				sig.Timestamp, err = (&tsa.Client{URL: server.URL}).Timestamp(t.Context(), sig.Signature)
+				require.NoError(t, err)
+
+				expired := &Handler{now: func() time.Time { return c.leaf.NotAfter.Add(time.Hour) }}
+				require.ErrorContains(t, expired.Verify(t.Context(), sig, nil, rootCreds), "expired")
+
+				verified, err := tsa.Verify(sig, server.Roots())
+				require.NoError(t, err)
+				require.NoError(t, expired.Verify(tsa.WithVerifiedTime(t.Context(), verified), sig, nil, rootCreds))
+			})
+		})
+	}
+}
+
+func Test_ECC_Sign_Errors(t *testing.T) {
+	h, err := New(v1alpha1.Scheme, false)
+	require.NoError(t, err)
+	d := digestHex(crypto.SHA256, []byte("hello world"))
+
+	ecPriv, _ := writeKeyAndChain(t, t.TempDir(), ecdsaKey(elliptic.P256())(t))
+	edPriv, _ := writeKeyAndChain(t, t.TempDir(), ed25519Key(t))
+
+	tests := []struct {
+		name    string
+		cfg     *v1alpha1.Config
+		creds   runtime.Typed
+		digest  descruntime.Digest
+		wantErr string
+	}{
+		{
+			name:    "missing private key",
+			cfg:     &v1alpha1.Config{},
+			creds:   &ecccredentialsv1alpha1.ECCCredentials{Type: ecccredentialsv1alpha1.VersionedType},
+			digest:  d,
+			wantErr: ErrMissingPrivateKey.Error(),
+		},
+		{
+			name:    "Ed25519 key with the default ECDSA algorithm",
+			cfg:     &v1alpha1.Config{},
+			creds:   &ecccredentialsv1alpha1.ECCCredentials{Type: ecccredentialsv1alpha1.VersionedType, PrivateKeyPEMFile: edPriv},
+			digest:  d,
+			wantErr: ErrKeyMismatch.Error(),
+		},
+		{
+			name:    "ECDSA key with the Ed25519 algorithm",
+			cfg:     &v1alpha1.Config{SignatureAlgorithm: v1alpha1.AlgorithmEd25519},
+			creds:   &ecccredentialsv1alpha1.ECCCredentials{Type: ecccredentialsv1alpha1.VersionedType, PrivateKeyPEMFile: ecPriv},
+			digest:  d,
+			wantErr: ErrKeyMismatch.Error(),
+		},
+		{
+			name:    "unknown algorithm",
+			cfg:     &v1alpha1.Config{SignatureAlgorithm: "RSASSA-PSS"},
+			creds:   &ecccredentialsv1alpha1.ECCCredentials{Type: ecccredentialsv1alpha1.VersionedType, PrivateKeyPEMFile: ecPriv},
+			digest:  d,
+			wantErr: ErrInvalidAlgorithm.Error(),
+		},
+		{
+			name:    "unsupported hash algorithm",
+			cfg:     &v1alpha1.Config{},
+			creds:   &ecccredentialsv1alpha1.ECCCredentials{Type: ecccredentialsv1alpha1.VersionedType, PrivateKeyPEMFile: ecPriv},
+			digest:  descruntime.Digest{HashAlgorithm: "MD5", Value: d.Value},
+			wantErr: `unsupported hash algorithm "MD5"`,
+		},
+		{
+			name: "direct credentials",
+			cfg:  &v1alpha1.Config{},
+			creds: &runtime.Raw{
+				Type: runtime.NewVersionedType("Credentials", "v1"),
+				Data: []byte(`{"type":"Credentials/v1","properties":{"privateKeyPEMFile":"` + ecPriv + `"}}`),
+			},
+			digest: d,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.Type = runtime.NewVersionedType(v1alpha1.ConfigType, v1alpha1.Version)
+			_, err := h.Sign(t.Context(), tc.digest, tc.cfg, tc.creds)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_ECC_Identity(t *testing.T) {
+	h, err := New(v1alpha1.Scheme, false)
+	require.NoError(t, err)
+
+	id, err := h.GetSigningCredentialConsumerIdentity(t.Context(), "sig", descruntime.Digest{}, &v1alpha1.Config{
+		Type:               runtime.NewUnversionedType(v1alpha1.ConfigType),
+		SignatureAlgorithm: v1alpha1.AlgorithmEd25519,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, identityv1alpha1.V1Alpha1Type, id.GetType())
+	assert.Equal(t, "Ed25519", id[identityv1alpha1.IdentityAttributeAlgorithm])
+	assert.Equal(t, "sig", id[identityv1alpha1.IdentityAttributeSignature])
+
+	id, err = h.GetSigningCredentialConsumerIdentity(t.Context(), "sig", descruntime.Digest{}, &v1alpha1.Config{
+		Type: runtime.NewUnversionedType(v1alpha1.ConfigType),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ECDSA", id[identityv1alpha1.IdentityAttributeAlgorithm], "defaults to ECDSA")
+
+	id, err = h.GetVerifyingCredentialConsumerIdentity(t.Context(), descruntime.Signature{
+		Name:      "sig",
+		Signature: descruntime.SignatureInfo{MediaType: v1alpha1.MediaTypePlainEd25519},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Ed25519", id[identityv1alpha1.IdentityAttributeAlgorithm], "inferred from the media type")
+	assert.Equal(t, "sig", id[identityv1alpha1.IdentityAttributeSignature])
+}
+
+func digestHex(algorithm crypto.Hash, b []byte) descruntime.Digest {
+	h := algorithm.New()
+	h.Write(b)
+	return descruntime.Digest{HashAlgorithm: algorithm.String(), Value: hex.EncodeToString(h.Sum(nil))}
+}
+
+func ecdsaKey(curve elliptic.Curve) func(t *testing.T) crypto.Signer {
+	return func(t *testing.T) crypto.Signer {
+		t.Helper()
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+		return key
+	}
+}
+
+func ed25519Key(t *testing.T) crypto.Signer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func mustSelfSigned(t *testing.T, cn string, key crypto.Signer) *x509.Certificate {
+	t.Helper()
+	return issueCert(t, nil, key, cn, true, key.Public())
+}
+
+func mustRand128(t *testing.T) *big.Int {
+	t.Helper()
+	n, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	require.NoError(t, err)
+	return n
+}
+
+func writeKeyAndChain(t *testing.T, dir string, priv crypto.Signer, chain ...*x509.Certificate) (privPath, chainPath string) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	privPath = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	chainPath = writeCertsPEM(t, dir, "chain.pem", chain...)
+	return
+}
+
+func pkixPublicKeyPEM(t *testing.T, pub crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// issueCert issues a certificate for pub signed by parentKey. If parent is nil,
+// the certificate is self-signed.
+func issueCert(t *testing.T, parent *x509.Certificate, parentKey crypto.Signer, subjectcn string, isCA bool, pub crypto.PublicKey) *x509.Certificate {
+	t.Helper()
+	tmpl := &x509.Certificate{
+		SerialNumber:          mustRand128(t),
+		Subject:               pkix.Name{CommonName: subjectcn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(7 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: isCA,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		tmpl.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+	if parent == nil {
+		parent = tmpl
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+type chain struct {
+	root, interm, leaf *x509.Certificate
+	leafKey            crypto.Signer
+}
+
+func buildChain(t *testing.T, newKey func(t *testing.T) crypto.Signer) chain {
+	t.Helper()
+	rootKey := newKey(t)
+	root := issueCert(t, nil, rootKey, "root", true, rootKey.Public())
+
+	intermKey := newKey(t)
+	interm := issueCert(t, root, rootKey, "intermediate", true, intermKey.Public())
+
+	leafKey := newKey(t)
+	leaf := issueCert(t, interm, intermKey, "leaf", false, leafKey.Public())
+
+	return chain{root: root, interm: interm, leaf: leaf, leafKey: leafKey}
+}
+
+func writeCertsPEM(t *testing.T, dir, name string, certs ...*x509.Certificate) string {
+	t.Helper()
+	var blob []byte
+	for _, c := range certs {
+		blob = append(blob, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})...)
+	}
+	p := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(p, blob, 0o600))
+	return p
+}
@@ -0,0 +1,77 @@
+package credentials
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	eccpem "ocm.software/open-component-model/bindings/go/ecc/signing/handler/internal/pem"
+	ecccredentialsv1alpha1 "ocm.software/open-component-model/bindings/go/ecc/spec/credentials/v1alpha1"
+)
+
+// PrivateKeyFromCredentials returns the ECDSA or Ed25519 private key of the credentials,
+// or nil if the credentials contain none.
+func PrivateKeyFromCredentials(creds *ecccredentialsv1alpha1.ECCCredentials) (crypto.Signer, error) {
+	if creds == nil {
+		return nil, nil
+	}
+	b, err := loadBytes(creds.PrivateKeyPEM, creds.PrivateKeyPEMFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading private key PEM: %w", err)
+	}
+	if len(b) == 0 {
+		return nil, nil
+	}
+	return eccpem.ParsePrivateKeyPEM(b), nil
+}
+
+// PublicKeyFromCredentials returns the public key of the credentials. If the credentials
+// contain no public key, it is derived from the private key.
+func PublicKeyFromCredentials(creds *ecccredentialsv1alpha1.ECCCredentials) (*eccpem.PublicKeyPEM, error) {
+	if creds == nil {
+		return nil, nil
+	}
+	b, err := loadBytes(creds.PublicKeyPEM, creds.PublicKeyPEMFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading public key PEM: %w", err)
+	}
+	if len(b) == 0 {
+		// fallback: derive from private
+		pk, err := PrivateKeyFromCredentials(creds)
+		if err != nil {
+			return nil, err
+		}
+		if pk == nil {
+			return nil, nil
+		}
+		return &eccpem.PublicKeyPEM{
+			PublicKey: pk.Public(),
+		}, nil
+	}
+	return eccpem.ParsePublicKeyPEM(b), nil
+}
+
+// CertificateChainFromCredentials returns the certificates in the public key PEM of the
+// credentials, or nil if it contains no certificates.
+func CertificateChainFromCredentials(creds *ecccredentialsv1alpha1.ECCCredentials) ([]*x509.Certificate, error) {
+	if creds == nil {
+		return nil, nil
+	}
+	b, err := loadBytes(creds.PublicKeyPEM, creds.PublicKeyPEMFile)
+	if err != nil || len(b) == 0 {
+		return nil, nil
+	}
+	return eccpem.ParseCertificateChain(b)
+}
+
+func loadBytes(inline, file string) ([]byte, error) {
+	if inline != "" {
+		// treat as literal bytes
+		return []byte(inline), nil
+	}
+	if file != "" {
+		return os.ReadFile(file)
+	}
+	return nil, nil
+}
@@ -0,0 +1,155 @@
+package credentials
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ecccredentialsv1alpha1 "ocm.software/open-component-model/bindings/go/ecc/spec/credentials/v1alpha1"
+)
+
+func sec1PrivatePEM(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+}
+
+func pkcs8PrivatePEM(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func pkixPublicPEM(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func selfSignedCertPEM(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestPrivateKeyFromCredentials(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, ed, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	privFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(privFile, []byte(pkcs8PrivatePEM(t, ed)), 0o600))
+
+	tests := []struct {
+		name  string
+		creds *ecccredentialsv1alpha1.ECCCredentials
+		want  crypto.PublicKey
+	}{
+		{name: "nil credentials", creds: nil},
+		{name: "no private key", creds: &ecccredentialsv1alpha1.ECCCredentials{}},
+		{name: "SEC 1 ECDSA P-256", creds: &ecccredentialsv1alpha1.ECCCredentials{PrivateKeyPEM: sec1PrivatePEM(t, p256)}, want: p256.Public()},
+		{name: "PKCS#8 ECDSA P-384", creds: &ecccredentialsv1alpha1.ECCCredentials{PrivateKeyPEM: pkcs8PrivatePEM(t, p384)}, want: p384.Public()},
+		{name: "PKCS#8 Ed25519 from file", creds: &ecccredentialsv1alpha1.ECCCredentials{PrivateKeyPEMFile: privFile}, want: ed.Public()},
+		{name: "inline takes precedence", creds: &ecccredentialsv1alpha1.ECCCredentials{PrivateKeyPEM: sec1PrivatePEM(t, p256), PrivateKeyPEMFile: privFile}, want: p256.Public()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := PrivateKeyFromCredentials(tt.creds)
+			require.NoError(t, err)
+			if tt.want == nil {
+				assert.Nil(t, key)
+				return
+			}
+			require.NotNil(t, key)
+			assert.Equal(t, tt.want, key.Public())
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := PrivateKeyFromCredentials(&ecccredentialsv1alpha1.ECCCredentials{PrivateKeyPEMFile: filepath.Join(t.TempDir(), "missing.pem")})
+		require.ErrorContains(t, err, "failed loading private key PEM")
+	})
+}
+
+func TestPublicKeyFromCredentials(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("PKIX public key", func(t *testing.T) {
+		pub, err := PublicKeyFromCredentials(&ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEM: pkixPublicPEM(t, edPub)})
+		require.NoError(t, err)
+		require.NotNil(t, pub)
+		assert.Equal(t, edPub, pub.PublicKey)
+		assert.Nil(t, pub.GetOptionalUnderlyingCert())
+	})
+
+	t.Run("certificate", func(t *testing.T) {
+		pub, err := PublicKeyFromCredentials(&ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEM: selfSignedCertPEM(t, key)})
+		require.NoError(t, err)
+		require.NotNil(t, pub)
+		assert.Equal(t, key.Public(), pub.PublicKey)
+		assert.NotNil(t, pub.GetOptionalUnderlyingCert())
+	})
+
+	t.Run("derived from private key", func(t *testing.T) {
+		pub, err := PublicKeyFromCredentials(&ecccredentialsv1alpha1.ECCCredentials{PrivateKeyPEM: pkcs8PrivatePEM(t, edPriv)})
+		require.NoError(t, err)
+		require.NotNil(t, pub)
+		assert.Equal(t, edPub, pub.PublicKey)
+	})
+
+	t.Run("no key material", func(t *testing.T) {
+		pub, err := PublicKeyFromCredentials(&ecccredentialsv1alpha1.ECCCredentials{})
+		require.NoError(t, err)
+		assert.Nil(t, pub)
+	})
+}
+
+func TestCertificateChainFromCredentials(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	chain, err := CertificateChainFromCredentials(&ecccredentialsv1alpha1.ECCCredentials{
+		PublicKeyPEM: selfSignedCertPEM(t, key) + selfSignedCertPEM(t, edPriv),
+	})
+	require.NoError(t, err)
+	assert.Len(t, chain, 2)
+
+	chain, err = CertificateChainFromCredentials(&ecccredentialsv1alpha1.ECCCredentials{})
+	require.NoError(t, err)
+	assert.Nil(t, chain)
+
+	_, err = CertificateChainFromCredentials(&ecccredentialsv1alpha1.ECCCredentials{PublicKeyPEM: pkixPublicPEM(t, key.Public())})
+	require.ErrorContains(t, err, "unexpected pem block type")
+}
@@ -0,0 +1,140 @@
+// Package pem contains low-level PEM and X.509 helpers used by the ECC
+// handler. Functions here are intentionally small and dependency-free.
+package pem
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// PEM block types used across helpers.
+const (
+	CertificatePEMBlockType = "CERTIFICATE"
+	pemSEC1PrivateKey       = "EC PRIVATE KEY"
+	pemPKCS8PrivateKey      = "PRIVATE KEY"
+	pemPKIXPublicKey        = "PUBLIC KEY"
+)
+
+// ParsePrivateKeyPEM scans concatenated PEM data and returns the first ECDSA
+// or Ed25519 private key found. It supports SEC 1 ("EC PRIVATE KEY") and
+// PKCS#8 ("PRIVATE KEY") containers. It returns nil if no key can be parsed.
+// The returned signer is either an *ecdsa.PrivateKey or an ed25519.PrivateKey.
+func ParsePrivateKeyPEM(pemBytes []byte) crypto.Signer {
+	for len(pemBytes) > 0 {
+		block, rest := pem.Decode(pemBytes)
+		if block == nil {
+			break
+		}
+		switch block.Type {
+		case pemSEC1PrivateKey:
+			if k, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+				return k
+			}
+		case pemPKCS8PrivateKey:
+			if anyKey, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+				switch k := anyKey.(type) {
+				case *ecdsa.PrivateKey:
+					return k
+				case ed25519.PrivateKey:
+					return k
+				}
+			}
+		}
+		pemBytes = rest
+	}
+	return nil
+}
+
+// PublicKeyPEM holds a parsed ECDSA or Ed25519 public key and optionally the
+// original X.509 certificate it came from.
+type PublicKeyPEM struct {
+	// PublicKey is either an *ecdsa.PublicKey or an ed25519.PublicKey.
+	PublicKey      crypto.PublicKey
+	UnderlyingCert *x509.Certificate
+}
+
+func (pem *PublicKeyPEM) GetOptionalUnderlyingCert() *x509.Certificate {
+	if pem == nil {
+		return nil
+	}
+	return pem.UnderlyingCert
+}
+
+// ParsePublicKeyPEM scans concatenated PEM data and returns PublicKeyPEM
+// if one can be parsed. It supports PKIX ("PUBLIC KEY") containers as well as
+// X.509 certificates.
+//
+// If none can be parsed it returns (nil).
+func ParsePublicKeyPEM(pemBytes []byte) *PublicKeyPEM {
+	for len(pemBytes) > 0 {
+		block, rest := pem.Decode(pemBytes)
+		if block == nil {
+			// No more PEM blocks.
+			return nil
+		}
+		switch block.Type {
+		case pemPKIXPublicKey:
+			if k, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil && IsSupportedPublicKey(k) {
+				return &PublicKeyPEM{
+					PublicKey: k,
+				}
+			}
+		case CertificatePEMBlockType:
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil && IsSupportedPublicKey(cert.PublicKey) {
+				return &PublicKeyPEM{
+					PublicKey:      cert.PublicKey,
+					UnderlyingCert: cert,
+				}
+			}
+		}
+		pemBytes = rest
+	}
+	return nil
+}
+
+// IsSupportedPublicKey reports whether key is an ECDSA or Ed25519 public key.
+func IsSupportedPublicKey(key crypto.PublicKey) bool {
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseCertificateChain parses one or more consecutive CERTIFICATE PEM blocks
+// and returns them in order. If a non-CERTIFICATE block is encountered before
+// any certificate is parsed, or if no certificates are found, an error is
+// returned.
+func ParseCertificateChain(data []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+
+	for len(data) > 0 {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != CertificatePEMBlockType {
+			if len(chain) == 0 {
+				return nil, fmt.Errorf("unexpected pem block type for certificate: %q", block.Type)
+			}
+			// Stop at first non-certificate after having parsed at least one.
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, cert)
+		data = rest
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("invalid certificate format (expected %q PEM block)", CertificatePEMBlockType)
+	}
+	return chain, nil
+}
@@ -0,0 +1,61 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	v1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+//nolint:gosec // G101: These are key names, not credentials.
+const (
+	credentialKeyPublicKeyPEM      = "publicKeyPEM"
+	credentialKeyPublicKeyPEMFile  = "publicKeyPEMFile"
+	credentialKeyPrivateKeyPEM     = "privateKeyPEM"
+	credentialKeyPrivateKeyPEMFile = "privateKeyPEMFile"
+)
+
+var convertScheme = runtime.NewScheme()
+
+func init() {
+	MustRegisterCredentialType(convertScheme)
+	v1.MustRegister(convertScheme)
+}
+
+// ConvertToECCCredentials converts [runtime.Typed] into [ECCCredentials].
+// Direct conversion as well as converting from [v1.DirectCredentials] is supported.
+// Other supported [runtime.Typed] implementations are [runtime.Raw].
+// For unsupported [runtime.Typed] implementations, an error will be returned.
+func ConvertToECCCredentials(creds runtime.Typed) (*ECCCredentials, error) {
+	if t, ok := creds.(*ECCCredentials); ok {
+		return t, nil
+	}
+
+	typed, err := convertScheme.NewObject(creds.GetType())
+	if err != nil {
+		return nil, fmt.Errorf("error converting credential type: %w", err)
+	}
+
+	if err = convertScheme.Convert(creds, typed); err != nil {
+		return nil, fmt.Errorf("error converting credential type: %w", err)
+	}
+
+	switch t := typed.(type) {
+	case *v1.DirectCredentials:
+		return fromDirectCredentials(t.Properties), nil
+	case *ECCCredentials:
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("unsupported credential type %v", typed.GetType())
+}
+
+func fromDirectCredentials(properties map[string]string) *ECCCredentials {
+	return &ECCCredentials{
+		Type:              VersionedType,
+		PublicKeyPEM:      properties[credentialKeyPublicKeyPEM],
+		PublicKeyPEMFile:  properties[credentialKeyPublicKeyPEMFile],
+		PrivateKeyPEM:     properties[credentialKeyPrivateKeyPEM],
+		PrivateKeyPEMFile: properties[credentialKeyPrivateKeyPEMFile],
+	}
+}
@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func TestConvertToECCCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		creds    runtime.Typed
+		expected *ECCCredentials
+		wantErr  string
+	}{
+		{
+			name: "direct credentials",
+			creds: &v1.DirectCredentials{
+				Type: runtime.NewVersionedType(v1.CredentialsType, v1.Version),
+				Properties: map[string]string{
+					"privateKeyPEM":     "test-private-key",
+					"privateKeyPEMFile": "/path/to/private.pem",
+					"publicKeyPEM":      "test-public-key",
+					"publicKeyPEMFile":  "/path/to/public.pem",
+					"unknownField":      "ignored",
+				},
+			},
+			expected: &ECCCredentials{
+				Type:              VersionedType,
+				PrivateKeyPEM:     "test-private-key",
+				PrivateKeyPEMFile: "/path/to/private.pem",
+				PublicKeyPEM:      "test-public-key",
+				PublicKeyPEMFile:  "/path/to/public.pem",
+			},
+		},
+		{
+			name: "typed credentials",
+			creds: &ECCCredentials{
+				Type:          VersionedType,
+				PrivateKeyPEM: "test-private-key",
+			},
+			expected: &ECCCredentials{Type: VersionedType, PrivateKeyPEM: "test-private-key"},
+		},
+		{
+			name: "raw credentials",
+			creds: &runtime.Raw{
+				Type: runtime.NewUnversionedType(ECCCredentialsType),
+				Data: []byte(`{"type":"ECCCredentials","publicKeyPEMFile":"/path/pub.pem"}`),
+			},
+			expected: &ECCCredentials{Type: runtime.NewUnversionedType(ECCCredentialsType), PublicKeyPEMFile: "/path/pub.pem"},
+		},
+		{
+			name:    "unknown type",
+			creds:   &runtime.Raw{Type: runtime.NewVersionedType("RSACredentials", "v1"), Data: []byte(`{"type":"RSACredentials/v1"}`)},
+			wantErr: "error converting credential type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds, err := ConvertToECCCredentials(tt.creds)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, creds)
+		})
+	}
+}
+
+func TestMustRegisterCredentialType(t *testing.T) {
+	scheme := runtime.NewScheme()
+	MustRegisterCredentialType(scheme)
+
+	obj, err := scheme.NewObject(VersionedType)
+	require.NoError(t, err)
+	assert.IsType(t, &ECCCredentials{}, obj)
+
+	obj, err = scheme.NewObject(runtime.NewUnversionedType(ECCCredentialsType))
+	require.NoError(t, err)
+	assert.IsType(t, &ECCCredentials{}, obj)
+}
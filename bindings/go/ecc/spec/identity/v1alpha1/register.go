@@ -0,0 +1,11 @@
+package v1alpha1
+
+import "ocm.software/open-component-model/bindings/go/runtime"
+
+// MustRegisterIdentityType registers ECC/v1alpha1 (with unversioned alias) in the given scheme.
+func MustRegisterIdentityType(scheme *runtime.Scheme) {
+	scheme.MustRegisterWithAlias(&ECCIdentity{},
+		V1Alpha1Type,
+		Type, // unversioned alias
+	)
+}
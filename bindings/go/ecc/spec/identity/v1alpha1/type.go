@@ -0,0 +1,36 @@
+package v1alpha1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	ECCIdentityType = "ECC"
+	Version         = "v1alpha1"
+)
+
+// Type is the unversioned consumer identity type for ECC signing.
+var Type = runtime.NewUnversionedType(ECCIdentityType)
+
+// V1Alpha1Type is the versioned consumer identity type.
+var V1Alpha1Type = runtime.NewVersionedType(ECCIdentityType, Version)
+
+// Identity attribute keys for ECC signing credentials.
+const (
+	IdentityAttributeAlgorithm = "algorithm"
+	IdentityAttributeSignature = "signature"
+)
+
+// ECCIdentity is the typed consumer identity for ECDSA and Ed25519 signing handlers.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type ECCIdentity struct {
+	// +ocm:jsonschema-gen:enum=ECC/v1alpha1
+	// +ocm:jsonschema-gen:enum:deprecated=ECC
+	Type      runtime.Type `json:"type"`
+	Algorithm string       `json:"algorithm,omitempty"`
+	Signature string       `json:"signature,omitempty"`
+}
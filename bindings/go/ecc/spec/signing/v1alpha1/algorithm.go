@@ -0,0 +1,8 @@
+package v1alpha1
+
+// SignatureAlgorithm is the signature algorithm to use when creating new signatures.
+// This field is optional and defaults to AlgorithmECDSA. It must match the type of the
+// private key used for signing. For verification, this field is ignored and the signature
+// algorithm is inferred from the signature specification.
+// +ocm:jsonschema-gen:enum=ECDSA,Ed25519
+type SignatureAlgorithm string
@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const ConfigType = "ECCSigningConfiguration"
+
+var Scheme = runtime.NewScheme()
+
+func init() {
+	Scheme.MustRegisterWithAlias(&Config{},
+		runtime.NewUnversionedType(ConfigType),
+		runtime.NewVersionedType(ConfigType, Version),
+	)
+}
+
+// Config defines configuration for signing based on AlgorithmECDSA or AlgorithmEd25519.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Config struct {
+	// Type identifies this configuration object's runtime type.
+	// +ocm:jsonschema-gen:enum=ECCSigningConfiguration/v1alpha1
+	// +ocm:jsonschema-gen:enum:deprecated=ECCSigningConfiguration
+	Type runtime.Type `json:"type"`
+
+	SignatureEncodingPolicy SignatureEncodingPolicy `json:"signatureEncodingPolicy,omitempty"`
+
+	SignatureAlgorithm SignatureAlgorithm `json:"signatureAlgorithm,omitempty"`
+}
+
+func (cfg *Config) GetSignatureEncodingPolicy() SignatureEncodingPolicy {
+	if cfg == nil || cfg.SignatureEncodingPolicy == "" {
+		return SignatureEncodingPolicyDefault
+	}
+	return cfg.SignatureEncodingPolicy
+}
+
+func (cfg *Config) GetSignatureAlgorithm() SignatureAlgorithm {
+	if cfg == nil || cfg.SignatureAlgorithm == "" {
+		return AlgorithmECDSA
+	}
+	return cfg.SignatureAlgorithm
+}
+
+func (cfg *Config) GetDefaultMediaType() string {
+	switch cfg.GetSignatureAlgorithm() {
+	case AlgorithmECDSA:
+		return MediaTypePlainECDSA
+	case AlgorithmEd25519:
+		return MediaTypePlainEd25519
+	default:
+		return ""
+	}
+}
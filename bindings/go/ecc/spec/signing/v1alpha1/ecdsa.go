@@ -0,0 +1,23 @@
+package v1alpha1
+
+const (
+	// MediaTypePlainECDSA is the media type for a plain signature based on AlgorithmECDSA encoded as a hex string.
+	MediaTypePlainECDSA = "application/vnd.ocm.signature.ecdsa"
+
+	// AlgorithmECDSA is the identifier for the Elliptic Curve Digital Signature Algorithm (ECDSA).
+	//
+	// ECDSA is defined in:
+	//   - NIST FIPS 186-5: https://csrc.nist.gov/pubs/fips/186-5/final
+	//   - SEC 1 v2.0: https://www.secg.org/sec1-v2.pdf
+	//
+	// Key properties:
+	//   - Based on the NIST curves P-256, P-384 and P-521, determined by the key.
+	//   - Non-deterministic: the same message produces different signatures when signed multiple times.
+	//   - Much smaller keys and signatures than RSA at a comparable security level.
+	//
+	// Parameters used in OCM:
+	//   - Hash function: SHA-256, SHA-384, SHA-512, SHA3-256, or SHA3-512 based on digest specification for the signing handler.
+	//     The digest is signed as is, it is not hashed again.
+	//   - Signature format: ASN.1 DER encoded (r, s) pair.
+	AlgorithmECDSA SignatureAlgorithm = "ECDSA"
+)
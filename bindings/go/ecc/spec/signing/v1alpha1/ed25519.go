@@ -0,0 +1,24 @@
+package v1alpha1
+
+const (
+	// MediaTypePlainEd25519 is the media type for a plain signature based on AlgorithmEd25519 encoded as a hex string.
+	MediaTypePlainEd25519 = "application/vnd.ocm.signature.ed25519"
+
+	// AlgorithmEd25519 is the identifier for the Edwards-curve Digital Signature Algorithm (EdDSA)
+	// over Curve25519.
+	//
+	// Ed25519 is defined in:
+	//   - RFC 8032: https://datatracker.ietf.org/doc/html/rfc8032
+	//   - NIST FIPS 186-5: https://csrc.nist.gov/pubs/fips/186-5/final
+	//
+	// Key properties:
+	//   - Fixed curve and key size, no parameters to choose.
+	//   - Deterministic: the same message always produces the same signature with the same key.
+	//   - Fast signing and verification with 64 byte signatures.
+	//
+	// Parameters used in OCM:
+	//   - Variant: pure Ed25519, the raw digest bytes are the signed message.
+	//   - Hash function: the digest may be SHA-256, SHA-384, SHA-512, SHA3-256, or SHA3-512 based on digest specification
+	//     for the signing handler. Ed25519 applies SHA-512 internally.
+	AlgorithmEd25519 SignatureAlgorithm = "Ed25519"
+)
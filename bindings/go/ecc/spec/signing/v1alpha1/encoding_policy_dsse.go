@@ -0,0 +1,28 @@
+package v1alpha1
+
+const (
+	// MediaTypeDSSE is the media type for an ECDSA or Ed25519 signature encoded as
+	// DSSE envelope. It represents a signature encoded via SignatureEncodingPolicyDSSE.
+	MediaTypeDSSE = "application/vnd.dsse.envelope.v1+json"
+
+	// SignatureEncodingPolicyDSSE encodes the signature as a DSSE envelope carrying
+	// an in-toto statement, which is understood by supply-chain tooling that
+	// processes in-toto attestations.
+	//
+	// Encoding procedure:
+	//   1. Create an in-toto statement v1 whose only subject is the digest of the
+	//      normalised component descriptor, e.g. {"sha256": "<hex>"}.
+	//   2. Sign the DSSE pre-authentication encoding (PAE) of the statement. ECDSA
+	//      signs the hash of the PAE, using the hash function of the digest.
+	//      Ed25519 signs the PAE itself.
+	//   3. Store the JSON encoded DSSE envelope as signature value.
+	//
+	// Verification rules:
+	//   1. The public key must be supplied from an external source, like for
+	//      SignatureEncodingPolicyPlain. The signing algorithm is taken from the
+	//      signature.
+	//   2. The statement must attest the digest of the signature.
+	//
+	// Experimental: This encoding policy is experimental and may change or be deprecated in the future.
+	SignatureEncodingPolicyDSSE SignatureEncodingPolicy = "DSSE"
+)
@@ -0,0 +1,28 @@
+package v1alpha1
+
+const (
+	// MediaTypePEM is the media type for a PEM-encoded ECDSA or Ed25519 signature.
+	// It represents a signature encoded via SignatureEncodingPolicyPEM.
+	MediaTypePEM = "application/x-pem-file"
+
+	// SignatureEncodingPolicyPEM encodes the signature in a PEM block, optionally
+	// followed by the signer's certificate chain.
+	//
+	// Encoding procedure:
+	//   1. Create a PEM block with type "SIGNATURE".
+	//   2. Insert the raw signature bytes into the block.
+	//   3. Add the signing algorithm (e.g. "ECDSA") as the "Signature Algorithm" header.
+	//   4. Encode the block into PEM format.
+	//   5. Optionally append the signer's certificate chain in PEM format
+	//      (only possible if the signature was created with a certificate).
+	//
+	// Verification rules:
+	//   1. The public key is extracted from the appended certificate chain after the chain
+	//      was validated against the host system's trust store or a root certificate
+	//      supplied with the credentials.
+	//   2. The certificate chain must not contain self-signed certificates; trust anchors
+	//      are only taken from the verifier's side.
+	//
+	// Experimental: This encoding policy is experimental and may change or be deprecated in the future.
+	SignatureEncodingPolicyPEM SignatureEncodingPolicy = "PEM"
+)
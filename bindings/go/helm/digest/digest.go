@@ -20,10 +20,9 @@ import (
 	ocicredsv1 "ocm.software/open-component-model/bindings/go/oci/spec/credentials/v1"
 	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/digestprocessor"
 	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
 )
 
-const hashAlgorithmSHA256 = "SHA-256"
-
 var _ digestprocessor.BuiltinDigestProcessorPlugin = (*DigestProcessor)(nil)
 
 // DigestProcessor resolves digests for Helm chart access types.
@@ -297,11 +296,21 @@ func verifyDigest(target *runtime.Digest, d godigest.Digest) error {
 	return nil
 }
 
-func algorithmName(algo godigest.Algorithm) string {
-	switch algo {
-	case godigest.SHA256:
-		return hashAlgorithmSHA256
-	default:
-		return ""
+// hashAlgorithmNames maps the OCI digest algorithms to the OCM hash algorithm names
+// supported for signing. Hash algorithms without a registered OCI digest algorithm,
+// such as SHA-3, are left out, as chart digests cannot carry them.
+var hashAlgorithmNames = func() map[godigest.Algorithm]string {
+	names := make(map[godigest.Algorithm]string)
+	for _, name := range signing.SupportedHashAlgorithms() {
+		// OCI names the algorithms in lower case without separator, e.g. "sha256" for "SHA-256".
+		algorithm := godigest.Algorithm(strings.ToLower(strings.ReplaceAll(name, "-", "")))
+		if algorithm.Available() {
+			names[algorithm] = name
+		}
 	}
+	return names
+}()
+
+func algorithmName(algo godigest.Algorithm) string {
+	return hashAlgorithmNames[algo]
 }
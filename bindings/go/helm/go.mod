@@ -18,6 +18,7 @@ require (
 	ocm.software/open-component-model/bindings/go/plugin v0.0.17
 	ocm.software/open-component-model/bindings/go/repository v0.0.9
 	ocm.software/open-component-model/bindings/go/runtime v0.0.8
+	ocm.software/open-component-model/bindings/go/signing v0.0.0-20260616162616-fac66c3e8710
 	oras.land/oras-go/v2 v2.6.0
 )
 
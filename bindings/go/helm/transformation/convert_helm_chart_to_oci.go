@@ -168,3 +168,17 @@ func imageReferenceFromHelmAccess(helmAccess v1.Helm) (string, error) {
 
 	return ref, nil
 }
+
+// VerifyOutput checks that the OCI layout buffered by a previous execution of
+// the transformation is still available, so that the execution can be skipped
+// when the transformation graph is resumed.
+func (t *ConvertHelmChartToOCI) VerifyOutput(_ context.Context, step runtime.Typed) error {
+	var transformation v1alpha1.ConvertHelmToOCI
+	if err := t.Scheme.Convert(step, &transformation); err != nil {
+		return fmt.Errorf("failed converting generic transformation to convert helm transformation: %w", err)
+	}
+	if transformation.Output == nil {
+		return fmt.Errorf("output is missing")
+	}
+	return filesystem.VerifyBufferedFile(transformation.Output.File)
+}
@@ -179,3 +179,23 @@ func readChartMetadata(ctx context.Context, chartArchive blob.ReadOnlyBlob) (nam
 	}
 	return loadedChart.Name(), loadedChart.Metadata.Version, nil
 }
+
+// VerifyOutput checks that the chart and provenance file buffered by a previous
+// execution of the transformation are still available, so that the execution
+// can be skipped when the transformation graph is resumed.
+func (t *GetHelmChart) VerifyOutput(_ context.Context, step runtime.Typed) error {
+	var transformation v1alpha1.GetHelmChart
+	if err := t.Scheme.Convert(step, &transformation); err != nil {
+		return fmt.Errorf("failed converting generic transformation to get helm transformation: %w", err)
+	}
+	if transformation.Output == nil {
+		return fmt.Errorf("output is missing")
+	}
+	if err := filesystem.VerifyBufferedFile(transformation.Output.ChartFile); err != nil {
+		return err
+	}
+	if transformation.Output.ProvFile != nil {
+		return filesystem.VerifyBufferedFile(*transformation.Output.ProvFile)
+	}
+	return nil
+}
@@ -2,19 +2,22 @@ package digest
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/opencontainers/go-digest"
 
 	"ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
 )
 
 const (
 	HashAlgorithmSHA256 = "SHA-256"
 )
 
-var SHAMapping = map[string]digest.Algorithm{
-	HashAlgorithmSHA256: digest.SHA256,
-}
+// SHAMapping maps the OCM hash algorithm names supported for signing to the OCI
+// digest algorithms. Hash algorithms without a registered OCI digest algorithm,
+// such as SHA-3, are left out, as OCI content digests cannot carry them.
+var SHAMapping = ociDigestAlgorithms()
 
 var ReverseSHAMapping = reverseMap(SHAMapping)
 
@@ -62,3 +65,15 @@ func reverseMap[K, V comparable](m map[K]V) map[V]K {
 	}
 	return reversed
 }
+
+func ociDigestAlgorithms() map[string]digest.Algorithm {
+	mapping := make(map[string]digest.Algorithm)
+	for _, name := range signing.SupportedHashAlgorithms() {
+		// OCI names the algorithms in lower case without separator, e.g. "sha256" for "SHA-256".
+		algorithm := digest.Algorithm(strings.ToLower(strings.ReplaceAll(name, "-", "")))
+		if algorithm.Available() {
+			mapping[name] = algorithm
+		}
+	}
+	return mapping
+}
@@ -0,0 +1,17 @@
+package digest
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSHAMapping(t *testing.T) {
+	assert.Equal(t, map[string]digest.Algorithm{
+		"SHA-256": digest.SHA256,
+		"SHA-384": digest.SHA384,
+		"SHA-512": digest.SHA512,
+	}, SHAMapping, "SHA-3 has no OCI digest algorithm")
+	assert.Equal(t, "SHA-512", ReverseSHAMapping[digest.SHA512])
+}
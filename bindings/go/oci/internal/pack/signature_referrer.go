@@ -0,0 +1,90 @@
+package pack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/oci/internal/introspection"
+	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
+)
+
+// SignatureReferrer builds a resource signature referrer - an OCI manifest with a
+// subject pointing at the OCI resource and a single layer holding the given
+// signature of the resource digest.
+// Returns (zero, nil, zero, nil, nil) if the subject is not an OCI manifest. The
+// caller must push the empty config blob and the layer before the manifest.
+//
+// Unlike ownership referrers, signature referrers record their creation time, so
+// that re-signing the resource under the same name yields a new referrer that can
+// be told apart from the previous one.
+func SignatureReferrer(ctx context.Context, subject ociImageSpecV1.Descriptor, artifact descriptor.Artifact, component, version string, signature descriptor.Signature, created time.Time) (manifestDesc ociImageSpecV1.Descriptor, manifest []byte, layerDesc ociImageSpecV1.Descriptor, layer []byte, err error) {
+	if !introspection.IsOCICompliantManifest(subject) {
+		slog.DebugContext(ctx, "skipping signature referrer: subject is not an OCI manifest", "mediaType", subject.MediaType, "digest", subject.Digest.String())
+		return ociImageSpecV1.Descriptor{}, nil, ociImageSpecV1.Descriptor{}, nil, nil
+	}
+
+	kind, err := artifactKind(artifact)
+	if err != nil {
+		return ociImageSpecV1.Descriptor{}, nil, ociImageSpecV1.Descriptor{}, nil, err
+	}
+	meta := artifact.GetElementMeta()
+	artifactValue, err := marshalArtifactAnnotation(meta.ToIdentity(), kind)
+	if err != nil {
+		return ociImageSpecV1.Descriptor{}, nil, ociImageSpecV1.Descriptor{}, nil, fmt.Errorf("failed to build signature artifact annotation: %w", err)
+	}
+
+	if layer, err = json.Marshal(descriptor.ConvertToV2Signature(&signature)); err != nil {
+		return ociImageSpecV1.Descriptor{}, nil, ociImageSpecV1.Descriptor{}, nil, fmt.Errorf("failed to marshal signature %q: %w", signature.Name, err)
+	}
+	layerDesc = ociImageSpecV1.Descriptor{
+		MediaType: annotations.ResourceSignatureMediaType,
+		Digest:    digest.FromBytes(layer),
+		Size:      int64(len(layer)),
+	}
+
+	body, err := json.Marshal(ociImageSpecV1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ociImageSpecV1.MediaTypeImageManifest,
+		ArtifactType: annotations.ResourceSignatureArtifactType,
+		Config:       ociImageSpecV1.DescriptorEmptyJSON,
+		Layers:       []ociImageSpecV1.Descriptor{layerDesc},
+		Subject:      &subject,
+		Annotations: map[string]string{
+			annotations.OwnershipComponentName:    component,
+			annotations.OwnershipComponentVersion: version,
+			annotations.ArtifactAnnotationKey:     artifactValue,
+			annotations.ResourceSignatureName:     signature.Name,
+			ociImageSpecV1.AnnotationCreated:      created.UTC().Format(time.RFC3339Nano),
+		},
+	})
+	if err != nil {
+		return ociImageSpecV1.Descriptor{}, nil, ociImageSpecV1.Descriptor{}, nil, fmt.Errorf("failed to marshal signature referrer manifest: %w", err)
+	}
+
+	manifestDesc = ociImageSpecV1.Descriptor{
+		MediaType:    ociImageSpecV1.MediaTypeImageManifest,
+		ArtifactType: annotations.ResourceSignatureArtifactType,
+		Digest:       digest.FromBytes(body),
+		Size:         int64(len(body)),
+	}
+	return manifestDesc, body, layerDesc, layer, nil
+}
+
+// ParseSignatureLayer decodes the signature stored in the layer of a resource
+// signature referrer.
+func ParseSignatureLayer(layer []byte) (descriptor.Signature, error) {
+	var signature v2.Signature
+	if err := json.Unmarshal(layer, &signature); err != nil {
+		return descriptor.Signature{}, fmt.Errorf("failed to decode signature: %w", err)
+	}
+	return *descriptor.ConvertFromV2Signature(&signature), nil
+}
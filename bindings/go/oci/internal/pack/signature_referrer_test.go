@@ -0,0 +1,65 @@
+package pack
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
+)
+
+func TestSignatureReferrer(t *testing.T) {
+	resource := &descriptor.Resource{
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "my-resource", Version: "1.0.0"}},
+	}
+	signature := descriptor.Signature{
+		Name:      "default",
+		Digest:    descriptor.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "genericBlobDigest/v1", Value: digest.FromString("payload").Encoded()},
+		Signature: descriptor.SignatureInfo{Algorithm: "RSASSA-PSS", Value: "01", MediaType: "application/vnd.ocm.signature.rsa"},
+	}
+	created := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	t.Run("manifest subject", func(t *testing.T) {
+		r := require.New(t)
+		subject := ociImageSpecV1.Descriptor{
+			MediaType: ociImageSpecV1.MediaTypeImageManifest,
+			Digest:    digest.FromBytes([]byte("subject")),
+			Size:      7,
+		}
+
+		desc, body, layerDesc, layer, err := SignatureReferrer(t.Context(), subject, resource, "ocm.software/my-component", "1.0.0", signature, created)
+		r.NoError(err)
+		r.Equal(digest.FromBytes(body), desc.Digest)
+		r.Equal(annotations.ResourceSignatureArtifactType, desc.ArtifactType)
+
+		var m ociImageSpecV1.Manifest
+		r.NoError(json.Unmarshal(body, &m))
+		r.Equal(&subject, m.Subject)
+		r.Equal([]ociImageSpecV1.Descriptor{layerDesc}, m.Layers)
+		r.Equal("default", m.Annotations[annotations.ResourceSignatureName])
+		r.Equal("ocm.software/my-component", m.Annotations[annotations.OwnershipComponentName])
+		r.Equal("2026-01-02T03:04:05.000000006Z", m.Annotations[ociImageSpecV1.AnnotationCreated])
+
+		parsed, err := ParseSignatureLayer(layer)
+		r.NoError(err)
+		r.Equal(signature, parsed)
+	})
+
+	t.Run("raw blob subject yields no referrer", func(t *testing.T) {
+		r := require.New(t)
+		subject := ociImageSpecV1.Descriptor{
+			MediaType: "application/octet-stream",
+			Digest:    digest.FromBytes([]byte("blob")),
+			Size:      4,
+		}
+		_, body, _, layer, err := SignatureReferrer(t.Context(), subject, resource, "ocm.software/my-component", "1.0.0", signature, created)
+		r.NoError(err)
+		r.Nil(body)
+		r.Nil(layer)
+	})
+}
@@ -727,20 +727,20 @@ func (repo *Repository) AddOwnership(ctx context.Context, component, version str
 		done(err)
 	}()
 
-	store, subject, err := repo.resolveOwnershipSubject(ctx, component, version, resource)
+	store, subject, err := repo.resolveReferrerSubject(ctx, component, version, resource)
 	if err != nil {
 		return err
 	}
 	return repo.buildAndPushOwnershipReferrer(ctx, store, subject, resource, component, version)
 }
 
-func (repo *Repository) resolveOwnershipSubject(ctx context.Context, component, version string, resource *descriptor.Resource) (spec.Store, ociImageSpecV1.Descriptor, error) {
+func (repo *Repository) resolveReferrerSubject(ctx context.Context, component, version string, resource *descriptor.Resource) (spec.Store, ociImageSpecV1.Descriptor, error) {
 	typed, err := repo.scheme.NewObject(resource.Access.GetType())
 	if err != nil {
-		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("error creating resource access for ownership referrer: %w", err)
+		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("error creating resource access for referrer: %w", err)
 	}
 	if err := repo.scheme.Convert(resource.Access, typed); err != nil {
-		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("error converting resource access for ownership referrer: %w", err)
+		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("error converting resource access for referrer: %w", err)
 	}
 
 	switch typed := typed.(type) {
@@ -751,7 +751,7 @@ func (repo *Repository) resolveOwnershipSubject(ctx context.Context, component,
 		}
 		subject, err := store.Resolve(ctx, typed.LocalReference)
 		if err != nil {
-			return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("failed to resolve uploaded artifact %q for ownership referrer: %w", typed.LocalReference, err)
+			return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("failed to resolve uploaded artifact %q for referrer: %w", typed.LocalReference, err)
 		}
 		return store, subject, nil
 	case *accessv1.OCIImage:
@@ -765,11 +765,11 @@ func (repo *Repository) resolveOwnershipSubject(ctx context.Context, component,
 		}
 		subject, err := store.Resolve(ctx, ref.ReferenceOrTag())
 		if err != nil {
-			return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("failed to resolve subject %q for ownership referrer: %w", typed.ImageReference, err)
+			return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("failed to resolve subject %q for referrer: %w", typed.ImageReference, err)
 		}
 		return store, subject, nil
 	default:
-		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("unsupported resource access type for ownership referrer: %T", typed)
+		return nil, ociImageSpecV1.Descriptor{}, fmt.Errorf("unsupported resource access type for referrer: %T", typed)
 	}
 }
 
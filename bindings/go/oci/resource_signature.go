@@ -0,0 +1,158 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	slogcontext "github.com/veqryn/slog-context"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/internal/introspection"
+	"ocm.software/open-component-model/bindings/go/oci/internal/log"
+	"ocm.software/open-component-model/bindings/go/oci/internal/pack"
+	"ocm.software/open-component-model/bindings/go/oci/spec/annotations"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+var _ repository.ResourceSignatureRepository = (*Repository)(nil)
+
+// AddResourceSignature attaches a detached signature of the digest of a resource
+// to the resource. The signature is attached as a referrer manifest pointing at
+// the resource, next to its ownership referrer. Resources that are not stored as
+// OCI manifests cannot carry referrers and fail with
+// [repository.ErrResourceSignatureNotSupported].
+// Caution: EXPERIMENTAL
+func (repo *Repository) AddResourceSignature(ctx context.Context, component, version string, resource *descriptor.Resource, signature descriptor.Signature, _ runtime.Typed) (err error) {
+	ctx = slogcontext.NewCtx(ctx, repo.logger)
+	done := log.Operation(ctx, "add resource signature referrer",
+		slog.String("component", component),
+		slog.String("version", version),
+		slog.String("signature", signature.Name),
+		log.IdentityLogAttr("resource", resource.ToIdentity()))
+	defer func() {
+		done(err)
+	}()
+
+	if resource.Digest == nil {
+		return fmt.Errorf("resource %s has no digest to sign", resource.ToIdentity())
+	}
+	if signature.Digest.Value != resource.Digest.Value || signature.Digest.HashAlgorithm != resource.Digest.HashAlgorithm {
+		return fmt.Errorf("signature %q does not sign the digest of resource %s", signature.Name, resource.ToIdentity())
+	}
+
+	store, subject, err := repo.resolveReferrerSubject(ctx, component, version, resource)
+	if err != nil {
+		return err
+	}
+	desc, body, layerDesc, layer, err := pack.SignatureReferrer(ctx, subject, resource, component, version, signature, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to build signature referrer: %w", err)
+	}
+	if body == nil {
+		return fmt.Errorf("resource %s is stored as %s: %w", resource.ToIdentity(), subject.MediaType, repository.ErrResourceSignatureNotSupported)
+	}
+
+	// push the blobs before the manifest referencing them, see buildAndPushOwnershipReferrer.
+	empty := ociImageSpecV1.DescriptorEmptyJSON
+	if err := store.Push(ctx, empty, bytes.NewReader(empty.Data)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push signature referrer empty blob %s: %w", empty.Digest, err)
+	}
+	if err := store.Push(ctx, layerDesc, bytes.NewReader(layer)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push signature %s: %w", layerDesc.Digest, err)
+	}
+	if err := store.Push(ctx, desc, bytes.NewReader(body)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return fmt.Errorf("failed to push signature referrer %s: %w", desc.Digest, err)
+	}
+	return nil
+}
+
+// GetResourceSignatures returns the detached signatures attached to a resource by
+// [Repository.AddResourceSignature] for the given component. If a signature name
+// was attached more than once, only the most recent signature is returned.
+// Resources that are not stored as OCI manifests fail with
+// [repository.ErrResourceSignatureNotSupported].
+// Caution: EXPERIMENTAL
+func (repo *Repository) GetResourceSignatures(ctx context.Context, component, version string, resource *descriptor.Resource, _ runtime.Typed) (_ []descriptor.Signature, err error) {
+	ctx = slogcontext.NewCtx(ctx, repo.logger)
+	done := log.Operation(ctx, "get resource signature referrers",
+		slog.String("component", component),
+		slog.String("version", version),
+		log.IdentityLogAttr("resource", resource.ToIdentity()))
+	defer func() {
+		done(err)
+	}()
+
+	store, subject, err := repo.resolveReferrerSubject(ctx, component, version, resource)
+	if err != nil {
+		return nil, err
+	}
+	if !introspection.IsOCICompliantManifest(subject) {
+		return nil, fmt.Errorf("resource %s is stored as %s: %w", resource.ToIdentity(), subject.MediaType, repository.ErrResourceSignatureNotSupported)
+	}
+	refs, err := registry.Referrers(ctx, store, subject, annotations.ResourceSignatureArtifactType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signature referrers of %s: %w", subject.Digest, err)
+	}
+
+	latest := make(map[string]ociImageSpecV1.Descriptor, len(refs))
+	var names []string
+	for _, ref := range refs {
+		if ref.Annotations[annotations.OwnershipComponentName] != component {
+			continue
+		}
+		name := ref.Annotations[annotations.ResourceSignatureName]
+		existing, ok := latest[name]
+		if !ok {
+			names = append(names, name)
+		} else if !created(ref).After(created(existing)) {
+			continue
+		}
+		latest[name] = ref
+	}
+
+	signatures := make([]descriptor.Signature, 0, len(names))
+	for _, name := range names {
+		signature, err := fetchResourceSignature(ctx, store, latest[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch signature %q: %w", name, err)
+		}
+		signatures = append(signatures, signature)
+	}
+	return signatures, nil
+}
+
+func fetchResourceSignature(ctx context.Context, store content.Fetcher, ref ociImageSpecV1.Descriptor) (descriptor.Signature, error) {
+	var manifest ociImageSpecV1.Manifest
+	raw, err := content.FetchAll(ctx, store, ref)
+	if err != nil {
+		return descriptor.Signature{}, err
+	}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return descriptor.Signature{}, fmt.Errorf("failed to decode signature referrer %s: %w", ref.Digest, err)
+	}
+	if len(manifest.Layers) != 1 || manifest.Layers[0].MediaType != annotations.ResourceSignatureMediaType {
+		return descriptor.Signature{}, fmt.Errorf("signature referrer %s must have exactly one layer of media type %s", ref.Digest, annotations.ResourceSignatureMediaType)
+	}
+	layer, err := content.FetchAll(ctx, store, manifest.Layers[0])
+	if err != nil {
+		return descriptor.Signature{}, err
+	}
+	return pack.ParseSignatureLayer(layer)
+}
+
+// created returns the creation time of a signature referrer, or the zero time if
+// it has none.
+func created(ref ociImageSpecV1.Descriptor) time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, ref.Annotations[ociImageSpecV1.AnnotationCreated])
+	return t
+}
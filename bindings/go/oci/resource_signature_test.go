@@ -0,0 +1,138 @@
+package oci_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+
+	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	"ocm.software/open-component-model/bindings/go/ctf"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	ocictf "ocm.software/open-component-model/bindings/go/oci/ctf"
+	"ocm.software/open-component-model/bindings/go/oci/spec/layout"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func resourceSignature(name, value string, digest descriptor.Digest) descriptor.Signature {
+	return descriptor.Signature{
+		Name:   name,
+		Digest: digest,
+		Signature: descriptor.SignatureInfo{
+			Algorithm: "RSASSA-PSS",
+			Value:     value,
+			MediaType: "application/vnd.ocm.signature.rsa",
+		},
+	}
+}
+
+func TestRepository_ResourceSignatures(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	const (
+		component = "ocm.software/test-component"
+		version   = "1.0.0"
+	)
+
+	layoutBytes, _ := createSingleLayerOCIImage(t, []byte("signed payload"), "irrelevant:latest")
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	repo := Repository(t, ocictf.WithCTF(ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))))
+
+	resource := &descriptor.Resource{
+		Relation:    descriptor.LocalRelation,
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "backend", Version: version}},
+		Type:        "ociArtifact",
+		Access: &v2.LocalBlob{
+			LocalReference: digest.FromBytes(layoutBytes).String(),
+			MediaType:      layout.MediaTypeOCIImageLayoutTarV1,
+		},
+	}
+	uploaded, err := repo.AddLocalResource(ctx, component, version, resource, inmemory.New(bytes.NewReader(layoutBytes)))
+	r.NoError(err)
+	uploaded.Digest = &descriptor.Digest{
+		HashAlgorithm:          "SHA-256",
+		NormalisationAlgorithm: "genericBlobDigest/v1",
+		Value:                  digest.FromString("signed payload").Encoded(),
+	}
+
+	signatures, err := repo.GetResourceSignatures(ctx, component, version, uploaded, nil)
+	r.NoError(err)
+	r.Empty(signatures, "a resource must have no signatures before one is added")
+
+	r.NoError(repo.AddResourceSignature(ctx, component, version, uploaded, resourceSignature("default", "01", *uploaded.Digest), nil))
+	r.NoError(repo.AddResourceSignature(ctx, component, version, uploaded, resourceSignature("release", "02", *uploaded.Digest), nil))
+
+	signatures, err = repo.GetResourceSignatures(ctx, component, version, uploaded, nil)
+	r.NoError(err)
+	r.ElementsMatch([]descriptor.Signature{
+		resourceSignature("default", "01", *uploaded.Digest),
+		resourceSignature("release", "02", *uploaded.Digest),
+	}, signatures)
+
+	t.Run("re-signing replaces the signature of the same name", func(t *testing.T) {
+		r := require.New(t)
+		r.NoError(repo.AddResourceSignature(ctx, component, version, uploaded, resourceSignature("default", "03", *uploaded.Digest), nil))
+
+		signatures, err := repo.GetResourceSignatures(ctx, component, version, uploaded, nil)
+		r.NoError(err)
+		r.ElementsMatch([]descriptor.Signature{
+			resourceSignature("default", "03", *uploaded.Digest),
+			resourceSignature("release", "02", *uploaded.Digest),
+		}, signatures)
+	})
+
+	t.Run("signature of another digest is rejected", func(t *testing.T) {
+		r := require.New(t)
+		other := *uploaded.Digest
+		other.Value = digest.FromString("other payload").Encoded()
+		err := repo.AddResourceSignature(ctx, component, version, uploaded, resourceSignature("default", "04", other), nil)
+		r.ErrorContains(err, "does not sign the digest")
+	})
+}
+
+func TestRepository_ResourceSignatures_RawBlobSubjectNotSupported(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	const (
+		component = "ocm.software/test-component"
+		version   = "1.0.0"
+	)
+
+	fs, err := filesystem.NewFS(t.TempDir(), os.O_RDWR)
+	r.NoError(err)
+	store := ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))
+	repo := Repository(t, ocictf.WithCTF(store))
+
+	componentStore, err := store.StoreForReference(ctx, store.ComponentVersionReference(ctx, component, version))
+	r.NoError(err)
+	raw := []byte("not a manifest")
+	rawDesc := content.NewDescriptorFromBytes("application/octet-stream", raw)
+	r.NoError(componentStore.Push(ctx, rawDesc, bytes.NewReader(raw)))
+
+	resource := &descriptor.Resource{
+		Relation:    descriptor.LocalRelation,
+		ElementMeta: descriptor.ElementMeta{ObjectMeta: descriptor.ObjectMeta{Name: "backend", Version: version}},
+		Type:        "blob",
+		Access: &v2.LocalBlob{
+			Type:           runtime.NewVersionedType(descriptor.LocalBlobAccessType, descriptor.LocalBlobAccessTypeVersion),
+			LocalReference: rawDesc.Digest.String(),
+			MediaType:      "application/octet-stream",
+		},
+		Digest: &descriptor.Digest{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "genericBlobDigest/v1", Value: rawDesc.Digest.Encoded()},
+	}
+
+	err = repo.AddResourceSignature(ctx, component, version, resource, resourceSignature("default", "01", *resource.Digest), nil)
+	r.ErrorIs(err, repository.ErrResourceSignatureNotSupported)
+
+	_, err = repo.GetResourceSignatures(ctx, component, version, resource, nil)
+	r.ErrorIs(err, repository.ErrResourceSignatureNotSupported)
+}
@@ -0,0 +1,22 @@
+package annotations
+
+// Resource signature referrer annotation keys.
+//
+// A resource signature referrer is an OCI manifest whose subject points to a
+// resource manifest and whose only layer holds a detached signature of the
+// resource digest. It carries the same component and artifact annotations as an
+// ownership referrer, plus the name of the signature.
+const (
+	// ResourceSignatureName is an annotation that records the name of the
+	// signature on a resource signature referrer manifest.
+	ResourceSignatureName = "software.ocm.signature.name"
+)
+
+// ResourceSignatureArtifactType is the OCI artifactType set on resource signature
+// referrer manifests. It enables filtering via the Referrers API.
+const ResourceSignatureArtifactType = "application/vnd.ocm.software.resource-signature.v1+json"
+
+// ResourceSignatureMediaType is the media type of the layer of a resource signature
+// referrer. The layer holds a single signature in the format of the signatures
+// of a v2 component descriptor.
+const ResourceSignatureMediaType = "application/vnd.ocm.software.signature.v1+json"
@@ -127,3 +127,31 @@ func (t *GetLocalResource) Transform(ctx context.Context, step runtime.Typed) (r
 
 	return transformation, nil
 }
+
+// VerifyOutput checks that the resource buffered by a previous execution of the
+// transformation is still available, so that the execution can be skipped when
+// the transformation graph is resumed.
+func (t *GetLocalResource) VerifyOutput(_ context.Context, step runtime.Typed) error {
+	transformation, err := t.Scheme.NewObject(step.GetType())
+	if err != nil {
+		return fmt.Errorf("failed creating get local resource transformation object: %w", err)
+	}
+	if err := t.Scheme.Convert(step, transformation); err != nil {
+		return fmt.Errorf("failed converting generic transformation to get local resource transformation: %w", err)
+	}
+
+	switch tr := transformation.(type) {
+	case *v1alpha1.OCIGetLocalResource:
+		if tr.Output == nil {
+			return fmt.Errorf("output is missing")
+		}
+		return filesystem.VerifyBufferedFile(tr.Output.File)
+	case *v1alpha1.CTFGetLocalResource:
+		if tr.Output == nil {
+			return fmt.Errorf("output is missing")
+		}
+		return filesystem.VerifyBufferedFile(tr.Output.File)
+	default:
+		return fmt.Errorf("unexpected transformation type: %T", transformation)
+	}
+}
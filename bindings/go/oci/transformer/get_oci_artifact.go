@@ -87,3 +87,17 @@ func (t *GetOCIArtifact) Transform(ctx context.Context, step runtime.Typed) (run
 
 	return &transformation, nil
 }
+
+// VerifyOutput checks that the artifact buffered by a previous execution of the
+// transformation is still available, so that the execution can be skipped when
+// the transformation graph is resumed.
+func (t *GetOCIArtifact) VerifyOutput(_ context.Context, step runtime.Typed) error {
+	var transformation v1alpha1.GetOCIArtifact
+	if err := t.Scheme.Convert(step, &transformation); err != nil {
+		return fmt.Errorf("failed converting generic transformation to get oci artifact transformation: %w", err)
+	}
+	if transformation.Output == nil {
+		return fmt.Errorf("output is missing")
+	}
+	return filesystem.VerifyBufferedFile(transformation.Output.File)
+}
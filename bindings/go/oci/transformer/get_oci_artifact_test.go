@@ -102,6 +102,11 @@ func TestGetOCIArtifact_Transform_OCI(t *testing.T) {
 	// Verify resource in output
 	assert.Equal(t, "test-image", transformed.Output.Resource.Name)
 	assert.Equal(t, "1.21.0", transformed.Output.Resource.Version)
+
+	// Verify the buffered output can be reused until the file is removed
+	require.NoError(t, transformer.VerifyOutput(ctx, transformed))
+	require.NoError(t, os.Remove(osPath))
+	assert.ErrorContains(t, transformer.VerifyOutput(ctx, transformed), "buffered file is not available")
 }
 
 func TestGetOCIArtifact_Transform_OCI_WithOutputPath(t *testing.T) {
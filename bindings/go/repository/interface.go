@@ -124,6 +124,23 @@ type OwnershipAwareRepository interface {
 	AddOwnership(ctx context.Context, component, version string, res *descriptor.Resource, credentials runtime.Typed) error
 }
 
+// ErrResourceSignatureNotSupported is returned by a ResourceSignatureRepository for
+// resources that cannot carry detached signatures, e.g. resources stored as plain
+// blobs instead of OCI manifests.
+var ErrResourceSignatureNotSupported = errors.New("resource does not support detached signatures")
+
+// ResourceSignatureRepository is an optional capability of a ComponentVersionRepository.
+// It attaches detached signatures of the digest of a resource to the resource itself,
+// so that consumers of a single resource can verify it without the component descriptor.
+type ResourceSignatureRepository interface {
+	// AddResourceSignature attaches signature, which must sign the digest of res,
+	// to the resource. Adding a signature with the name of an existing one replaces it.
+	AddResourceSignature(ctx context.Context, component, version string, res *descriptor.Resource, signature descriptor.Signature, credentials runtime.Typed) error
+	// GetResourceSignatures returns the detached signatures attached to the resource,
+	// at most one per signature name.
+	GetResourceSignatures(ctx context.Context, component, version string, res *descriptor.Resource, credentials runtime.Typed) ([]descriptor.Signature, error)
+}
+
 // SourceRepository defines the interface for storing and retrieving OCM sources
 // independently of component versions from a store implementation.
 // TODO https://github.com/open-component-model/ocm-project/issues/857 also provide credentials in UploadSource/DownloadSource
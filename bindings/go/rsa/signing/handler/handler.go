@@ -1,7 +1,8 @@
 // Package handler implements RSA signing and verification for OCM.
-// It supports both RSASSA-PSS and RSASSA-PKCS1-v1_5, and two encodings:
+// It supports both RSASSA-PSS and RSASSA-PKCS1-v1_5, and three encodings:
 //  1. Plain: hex signature bytes without certificates.
 //  2. PEM: a SIGNATURE PEM block with an embedded X.509 chain.
+//  3. DSSE: a DSSE envelope carrying an in-toto statement of the digest.
 //
 // For PEM verification, the leaf public key is taken from the chain after
 // the chain validates against system roots and/or an optional trust anchor
@@ -28,6 +29,8 @@ import (
 	rsacredentialsv1 "ocm.software/open-component-model/bindings/go/rsa/spec/credentials/v1"
 	identityv1 "ocm.software/open-component-model/bindings/go/rsa/spec/identity/v1"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/dsse"
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
 )
 
 // Common errors for callers to test.
@@ -69,7 +72,8 @@ func (h *Handler) GetSigningHandlerScheme() *runtime.Scheme {
 
 // Sign produces a signature for the given digest, using RSA and the configured
 // algorithm and encoding policy. For PEM encoding, the certificate chain is
-// read from credentials and embedded into the SIGNATURE block.
+// read from credentials and embedded into the SIGNATURE block. For DSSE encoding,
+// the PAE of an in-toto statement of the digest is signed instead of the digest.
 func (h *Handler) Sign(
 	ctx context.Context,
 	unsigned descruntime.Digest,
@@ -104,6 +108,21 @@ func (h *Handler) Sign(
 		return descruntime.SignatureInfo{}, err
 	}
 
+	if supported.GetSignatureEncodingPolicy() == v1alpha1.SignatureEncodingPolicyDSSE {
+		slog.WarnContext(ctx, "signing with DSSE encoding is experimental")
+		envelope, err := dsse.Seal(unsigned, func(pae []byte) ([]byte, error) {
+			return signRSA(algorithm, priv, hash, hashPAE(hash, pae))
+		})
+		if err != nil {
+			return descruntime.SignatureInfo{}, fmt.Errorf("rsa sign: %w", err)
+		}
+		return descruntime.SignatureInfo{
+			Algorithm: string(algorithm),
+			MediaType: v1alpha1.MediaTypeDSSE,
+			Value:     envelope,
+		}, nil
+	}
+
 	rawSig, err := signRSA(algorithm, priv, hash, dig)
 	if err != nil {
 		return descruntime.SignatureInfo{}, fmt.Errorf("rsa sign: %w", err)
@@ -133,9 +152,10 @@ func (h *Handler) Sign(
 	}
 }
 
-// Verify validates an OCM signature. For plain signatures, a public key must be
-// present in credentials. For PEM signatures, the embedded chain must be valid
-// against system roots and/or the optional trust anchor in credentials.
+// Verify validates an OCM signature. For plain and DSSE signatures, a public key
+// must be present in credentials. For PEM signatures, the embedded chain must be valid
+// against system roots and/or the optional trust anchor in credentials, at the
+// time of a verified timestamp if the context carries one.
 func (h *Handler) Verify(
 	ctx context.Context,
 	signed descruntime.Signature,
@@ -179,7 +199,17 @@ func (h *Handler) Verify(
 
 	case v1alpha1.MediaTypePEM:
 		slog.WarnContext(ctx, "verifying signatures with PEM encoding is experimental")
-		return h.verifyPEMSignature(signed, hash, dig, rsaCreds)
+		return h.verifyPEMSignature(ctx, signed, hash, dig, rsaCreds)
+
+	case v1alpha1.MediaTypeDSSE:
+		slog.WarnContext(ctx, "verifying signatures with DSSE encoding is experimental")
+		if pubFromCreds == nil {
+			return ErrMissingPublicKey
+		}
+		alg := v1alpha1.SignatureAlgorithm(signed.Signature.Algorithm)
+		return dsse.Open(signed.Signature.Value, signed.Digest, func(pae, sig []byte) error {
+			return verifyRSA(alg, pubFromCreds.PublicKey, hash, hashPAE(hash, pae), sig)
+		})
 
 	default:
 		return fmt.Errorf("unsupported media type %q", signed.Signature.MediaType)
@@ -191,6 +221,7 @@ func (h *Handler) Verify(
 // optional root anchor, merges the two intermediate pools, validates the X.509
 // path and issuer constraint, and finally verifies the RSA signature bytes.
 func (h *Handler) verifyPEMSignature(
+	ctx context.Context,
 	signed descruntime.Signature,
 	hash crypto.Hash,
 	dig []byte,
@@ -219,7 +250,7 @@ func (h *Handler) verifyPEMSignature(
 	allIntermediates = append(allIntermediates, chain[1:]...)
 	allIntermediates = append(allIntermediates, credIntermediates...)
 
-	if err := verifyChainWithOptionalAnchor(leaf, allIntermediates, credAnchor, h.roots, h.now); err != nil {
+	if err := verifyChainWithOptionalAnchor(leaf, allIntermediates, credAnchor, h.roots, h.verificationTime(ctx)); err != nil {
 		return fmt.Errorf("certificate verification failed: %w", err)
 	}
 
@@ -233,6 +264,16 @@ func (h *Handler) verifyPEMSignature(
 	return verifyRSA(v1alpha1.SignatureAlgorithm(algFromPEM), rsaPub, hash, dig, sig)
 }
 
+// verificationTime returns the time at which certificate chains are validated.
+// It is the time of a verified timestamp of the signature, if the caller verified
+// one (see [tsa.WithVerifiedTime]), and the current time otherwise.
+func (h *Handler) verificationTime(ctx context.Context) func() time.Time {
+	if t, ok := tsa.VerifiedTime(ctx); ok {
+		return func() time.Time { return t }
+	}
+	return h.now
+}
+
 // GetSigningCredentialConsumerIdentity requests credentials for signing.
 // It encodes the algorithm and the logical signature name.
 func (*Handler) GetSigningCredentialConsumerIdentity(
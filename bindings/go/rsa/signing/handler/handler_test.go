@@ -24,6 +24,9 @@ import (
 	rsacredentialsv1 "ocm.software/open-component-model/bindings/go/rsa/spec/credentials/v1"
 	identityv1 "ocm.software/open-component-model/bindings/go/rsa/spec/identity/v1"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/dsse"
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
+	"ocm.software/open-component-model/bindings/go/signing/tsa/tsatest"
 )
 
 func Test_RSA_Handler(t *testing.T) {
@@ -42,10 +45,11 @@ func Test_RSA_Handler(t *testing.T) {
 
 	testData := []byte("hello world")
 
-	// test both signature schemes and both hashes
+	// test both signature schemes and the SHA-2 and SHA-3 hashes
 	for _, hashCfg := range []crypto.Hash{
 		crypto.SHA256,
 		crypto.SHA512,
+		crypto.SHA3_256,
 	} {
 		t.Run(hashCfg.String(), func(t *testing.T) {
 			for _, alg := range []v1alpha1.SignatureAlgorithm{
@@ -661,6 +665,51 @@ func Test_RSA_Credentials_Override_SystemRoots(t *testing.T) {
 	})
 }
 
+func Test_RSA_Verify_At_Timestamp(t *testing.T) {
+	h, err := New(v1alpha1.Scheme, false)
+	require.NoError(t, err)
+
+	key := mustKey(t)
+	cert := mustSelfSigned(t, "signer", key)
+	privPath, chainPath := writeKeyAndChain(t, t.TempDir(), key, cert)
+	d := digestHex(crypto.SHA256, []byte("payload"))
+
+	si, err := h.Sign(t.Context(), d, &v1alpha1.Config{
+		SignatureAlgorithm:      v1alpha1.AlgorithmRSASSAPSS,
+		SignatureEncodingPolicy: v1alpha1.SignatureEncodingPolicyPEM,
+	}, &rsacredentialsv1.RSACredentials{
+		Type:              rsacredentialsv1.VersionedType,
+		PrivateKeyPEMFile: privPath,
+		PublicKeyPEMFile:  chainPath,
+	})
+	require.NoError(t, err)
+
+	server := tsatest.NewServer(t)
+	timestamp, err := (&tsa.Client{URL: server.URL}).Timestamp(t.Context(), si)
+	require.NoError(t, err)
+	sig := descruntime.Signature{Digest: d, Signature: si, Timestamp: timestamp}
+	creds := &rsacredentialsv1.RSACredentials{Type: rsacredentialsv1.VersionedType, PublicKeyPEMFile: chainPath}
+
+	// The signing certificate expired after the signature was timestamped.
+	h.now = func() time.Time { return cert.NotAfter.Add(time.Hour) }
+
+	t.Run("expired certificate fails without timestamp", func(t *testing.T) {
+		err := h.Verify(t.Context(), sig, nil, creds)
+		require.ErrorContains(t, err, "certificate has expired")
+	})
+
+	t.Run("expired certificate succeeds at the verified timestamp", func(t *testing.T) {
+		verified, err := tsa.Verify(sig, server.Roots())
+		require.NoError(t, err)
+		require.NoError(t, h.Verify(tsa.WithVerifiedTime(t.Context(), verified), sig, nil, creds))
+	})
+
+	t.Run("timestamp after expiry fails", func(t *testing.T) {
+		err := h.Verify(tsa.WithVerifiedTime(t.Context(), cert.NotAfter.Add(time.Minute)), sig, nil, creds)
+		require.ErrorContains(t, err, "certificate has expired")
+	})
+}
+
 func Test_RSA_Verify_ErrorPaths_BothAlgs(t *testing.T) {
 	for _, alg := range []v1alpha1.SignatureAlgorithm{v1alpha1.AlgorithmRSASSAPSS, v1alpha1.AlgorithmRSASSAPKCS1V15} {
 		t.Run(string(alg), func(t *testing.T) {
@@ -809,6 +858,47 @@ func Test_RSA_Verify_ErrorPaths_BothAlgs(t *testing.T) {
 	}
 }
 
+func Test_RSA_DSSE(t *testing.T) {
+	h, err := New(v1alpha1.Scheme, false)
+	require.NoError(t, err)
+
+	key := mustKey(t)
+	pubPath := writePKIXPublicKeyPEM(t, t.TempDir(), &key.PublicKey)
+	privPath := writePKCS8PrivateKeyPEM(t, t.TempDir(), key)
+	verifyCreds := &rsacredentialsv1.RSACredentials{Type: rsacredentialsv1.VersionedType, PublicKeyPEMFile: pubPath}
+
+	for _, alg := range []v1alpha1.SignatureAlgorithm{v1alpha1.AlgorithmRSASSAPSS, v1alpha1.AlgorithmRSASSAPKCS1V15} {
+		for _, hash := range []crypto.Hash{crypto.SHA256, crypto.SHA3_256} {
+			t.Run(string(alg)+"/"+hash.String(), func(t *testing.T) {
+				d := digestHex(hash, []byte("payload"))
+				d.NormalisationAlgorithm = "jsonNormalisation/v4alpha1"
+
+				si, err := h.Sign(t.Context(), d, &v1alpha1.Config{
+					SignatureAlgorithm:      alg,
+					SignatureEncodingPolicy: v1alpha1.SignatureEncodingPolicyDSSE,
+				}, &rsacredentialsv1.RSACredentials{Type: rsacredentialsv1.VersionedType, PrivateKeyPEMFile: privPath})
+				require.NoError(t, err)
+				require.Equal(t, v1alpha1.MediaTypeDSSE, si.MediaType)
+				require.Equal(t, string(alg), si.Algorithm)
+
+				signed := descruntime.Signature{Name: "sig", Digest: d, Signature: si}
+				require.NoError(t, h.Verify(t.Context(), signed, nil, verifyCreds))
+
+				require.ErrorIs(t, h.Verify(t.Context(), signed, nil, nil), ErrMissingPublicKey)
+
+				other := signed
+				other.Digest = digestHex(hash, []byte("different"))
+				other.Digest.NormalisationAlgorithm = d.NormalisationAlgorithm
+				require.ErrorIs(t, h.Verify(t.Context(), other, nil, verifyCreds), dsse.ErrInvalidEnvelope)
+
+				otherKey := mustKey(t)
+				otherCreds := &rsacredentialsv1.RSACredentials{Type: rsacredentialsv1.VersionedType, PublicKeyPEMFile: writePKIXPublicKeyPEM(t, t.TempDir(), &otherKey.PublicKey)}
+				require.Error(t, h.Verify(t.Context(), signed, nil, otherCreds))
+			})
+		}
+	}
+}
+
 func Test_RSA_Identity(t *testing.T) {
 	h, err := New(v1alpha1.Scheme, false)
 	require.NoError(t, err)
@@ -2,6 +2,9 @@ package handler
 
 import (
 	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha3"
+	_ "crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -43,6 +46,10 @@ func hashFromString(hashAlgorithm string) (crypto.Hash, error) {
 		return crypto.SHA384, nil
 	case crypto.SHA512.String():
 		return crypto.SHA512, nil
+	case crypto.SHA3_256.String():
+		return crypto.SHA3_256, nil
+	case crypto.SHA3_512.String():
+		return crypto.SHA3_512, nil
 	}
 	return 0, fmt.Errorf("unsupported hash algorithm %q", hashAlgorithm)
 }
@@ -31,3 +31,11 @@ func verifyRSA(algorithm v1alpha1.SignatureAlgorithm, pub *rsa.PublicKey, h cryp
 		return ErrInvalidAlgorithm
 	}
 }
+
+// hashPAE hashes the DSSE pre-authentication encoding, which is signed instead
+// of the digest for DSSE signatures.
+func hashPAE(h crypto.Hash, pae []byte) []byte {
+	hh := h.New()
+	hh.Write(pae)
+	return hh.Sum(nil)
+}
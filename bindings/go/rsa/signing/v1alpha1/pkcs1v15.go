@@ -23,7 +23,7 @@ const (
 	//   2. Compare the result against the expected ASN.1 DigestInfo structure of the message digest.
 	//
 	// Parameters used in OCM:
-	//   - Hash function: SHA-256, SHA-384, or SHA-512 based on digest specification for the signing handler.
+	//   - Hash function: SHA-256, SHA-384, SHA-512, SHA3-256, or SHA3-512 based on digest specification for the signing handler.
 	//   - Padding: fixed PKCS #1 v1.5 encoding (non-probabilistic, non-configurable).
 	//
 	// Notes:
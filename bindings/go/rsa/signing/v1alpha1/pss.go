@@ -22,7 +22,7 @@ const (
 	//   3. Compare the result against the expected padded hash value.
 	//
 	// Parameters used in OCM:
-	//   - Hash function: SHA-256, SHA-384, or SHA-512 based on digest specification for the signing handler.
+	//   - Hash function: SHA-256, SHA-384, SHA-512, SHA3-256, or SHA3-512 based on digest specification for the signing handler.
 	//   - Salt length: equal to the length of the hash output.
 	//   - MGF: MGF1 with the same underlying hash function, non modifiable.
 	//
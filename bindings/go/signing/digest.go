@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha3"
+	_ "crypto/sha512"
 	"encoding/hex"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"ocm.software/open-component-model/bindings/go/descriptor/normalisation"
@@ -31,7 +35,7 @@ const (
 // Steps:
 //  1. Resolve the normalisation algorithm (legacy → v4alpha1 if required).
 //  2. Normalise the descriptor with that algorithm.
-//  3. Select the hash algorithm from supported list (SHA256, SHA512).
+//  3. Select the hash algorithm from the supported list (see SupportedHashAlgorithms).
 //  4. Hash the normalised descriptor.
 //  5. Decode the digest value from the signature.
 //  6. Compare the freshly computed digest against the signature digest.
@@ -48,7 +52,7 @@ func VerifyDigestMatchesDescriptor(
 		return fmt.Errorf("normalising component version failed: %w", err)
 	}
 
-	hash, err := getSupportedHash(signature.Digest.HashAlgorithm)
+	hash, err := SupportedHash(signature.Digest.HashAlgorithm)
 	if err != nil {
 		return err
 	}
@@ -100,7 +104,7 @@ func GenerateDigest(
 		return nil, fmt.Errorf("normalising component version failed: %w", err)
 	}
 
-	hash, err := getSupportedHash(hashAlgorithm)
+	hash, err := SupportedHash(hashAlgorithm)
 	if err != nil {
 		return nil, err
 	}
@@ -176,17 +180,31 @@ func ensureNormalisationAlgo(ctx context.Context, algo string, logger *slog.Logg
 
 // supportedHashes lists supported hashing algorithms keyed by their identifier
 var supportedHashes = map[string]crypto.Hash{
-	crypto.SHA256.String(): crypto.SHA256,
-	crypto.SHA512.String(): crypto.SHA512,
+	crypto.SHA256.String():   crypto.SHA256,
+	crypto.SHA384.String():   crypto.SHA384,
+	crypto.SHA512.String():   crypto.SHA512,
+	crypto.SHA3_256.String(): crypto.SHA3_256,
+	crypto.SHA3_512.String(): crypto.SHA3_512,
 }
 
-// getSupportedHash looks up a crypto.Hash from its string identifier.
-// Returns an error if the identifier is not in supportedHashes.
-func getSupportedHash(name string) (crypto.Hash, error) {
+// SupportedHashAlgorithms returns the sorted identifiers of the hash algorithms
+// accepted by GenerateDigest and VerifyDigestMatchesDescriptor, e.g. "SHA-256".
+func SupportedHashAlgorithms() []string {
+	names := make([]string, 0, len(supportedHashes))
+	for name := range supportedHashes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// SupportedHash looks up a crypto.Hash from its string identifier, e.g. "SHA-256".
+// Returns an error if the identifier is not one of SupportedHashAlgorithms.
+func SupportedHash(name string) (crypto.Hash, error) {
 	n := strings.ToUpper(name)
 	h, ok := supportedHashes[n]
 	if !ok {
-		return 0, fmt.Errorf("unsupported hash algorithm %q (use: %q)", n, supportedHashes)
+		return 0, fmt.Errorf("unsupported hash algorithm %q (use one of %s)", n, strings.Join(SupportedHashAlgorithms(), ", "))
 	}
 
 	return h, nil
@@ -15,12 +15,12 @@ import (
 	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 )
 
-func TestGetSupportedHash(t *testing.T) {
-	h, err := getSupportedHash(crypto.SHA256.String())
+func TestSupportedHash(t *testing.T) {
+	h, err := SupportedHash(crypto.SHA256.String())
 	require.NoError(t, err)
 	assert.Equal(t, crypto.SHA256, h)
 
-	_, err = getSupportedHash("unknown-hash")
+	_, err = SupportedHash("unknown-hash")
 	assert.Error(t, err)
 }
 
@@ -147,6 +147,30 @@ func TestVerifyDigestMatchesDescriptor_Success(t *testing.T) {
 	require.NoError(t, VerifyDigestMatchesDescriptor(ctx, &descruntime.Descriptor{Component: d.Component}, sig, logger))
 }
 
+func TestVerifyDigestMatchesDescriptor_HashAlgorithms(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	d := &descruntime.Descriptor{
+		Component: descruntime.Component{
+			ComponentMeta: descruntime.ComponentMeta{ObjectMeta: descruntime.ObjectMeta{Name: "cmp", Version: "v1"}},
+			Provider:      descruntime.Provider{Name: "provider"},
+		},
+	}
+
+	for _, hash := range []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512, crypto.SHA3_256, crypto.SHA3_512} {
+		t.Run(hash.String(), func(t *testing.T) {
+			dg, err := GenerateDigest(ctx, d, logger, v4alpha1.Algorithm, hash.String())
+			require.NoError(t, err)
+			assert.Equal(t, hash.String(), dg.HashAlgorithm)
+			assert.Len(t, dg.Value, hex.EncodedLen(hash.Size()))
+
+			require.NoError(t, VerifyDigestMatchesDescriptor(ctx, d, descruntime.Signature{Name: "s1", Digest: *dg}, logger))
+		})
+	}
+	assert.Len(t, SupportedHashAlgorithms(), 5)
+}
+
 func TestVerifyDigestMatchesDescriptor_Mismatch(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	ctx := context.Background()
@@ -0,0 +1,205 @@
+// Package dsse encodes OCM signatures as DSSE envelopes carrying an in-toto statement.
+//
+// The envelope follows the Dead Simple Signing Envelope specification
+// (https://github.com/secure-systems-lab/dsse) and its payload is an in-toto
+// Statement v1 (https://github.com/in-toto/attestation/blob/main/spec/v1/statement.md)
+// whose only subject is the digest of the normalised component descriptor.
+// Supply-chain tooling that understands in-toto attestations can thus process
+// OCM signatures without knowing about OCM.
+//
+// The package does not implement a signature algorithm. Signing handlers seal a
+// digest with their own keys, signing the pre-authentication encoding (PAE) of
+// the payload:
+//
+//	value, err := dsse.Seal(digest, func(pae []byte) ([]byte, error) {
+//		return sign(pae)
+//	})
+//
+// and open the envelope again during verification, which checks the signature
+// over the PAE as well as that the statement attests the expected digest:
+//
+//	err := dsse.Open(signature.Signature.Value, signature.Digest, func(pae, sig []byte) error {
+//		return verify(pae, sig)
+//	})
+package dsse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+)
+
+const (
+	// MediaType is the media type of a signature value holding a DSSE envelope.
+	MediaType = "application/vnd.dsse.envelope.v1+json"
+	// PayloadType is the DSSE payload type of an in-toto statement.
+	PayloadType = "application/vnd.in-toto+json"
+	// StatementType is the type of an in-toto statement v1.
+	StatementType = "https://in-toto.io/Statement/v1"
+	// PredicateType identifies the predicate of statements attesting the digest of
+	// a component descriptor.
+	PredicateType = "https://ocm.software/attestations/component-descriptor-digest/v1"
+)
+
+// ErrInvalidEnvelope is returned by Open for values that are no valid envelope
+// for the expected digest.
+var ErrInvalidEnvelope = errors.New("invalid DSSE envelope")
+
+// Envelope is a DSSE envelope. Payload and signatures are base64 encoded in JSON.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     []byte      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is a signature over the PAE of the payload of an Envelope.
+type Signature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   []byte `json:"sig"`
+}
+
+// Statement is an in-toto statement v1 attesting a component descriptor digest.
+type Statement struct {
+	Type          string     `json:"_type"`
+	Subject       []Subject  `json:"subject"`
+	PredicateType string     `json:"predicateType"`
+	Predicate     *Predicate `json:"predicate,omitempty"`
+}
+
+// Subject is an in-toto resource descriptor identifying the attested artifact by
+// its digest set, e.g. {"sha256": "<hex>"}.
+type Subject struct {
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Predicate records how the attested component descriptor digest was calculated.
+type Predicate struct {
+	NormalisationAlgorithm string `json:"normalisationAlgorithm"`
+}
+
+// digestSetAlgorithms maps OCM hash algorithm names to in-toto digest set keys.
+var digestSetAlgorithms = map[string]string{
+	"SHA-256":  "sha256",
+	"SHA-384":  "sha384",
+	"SHA-512":  "sha512",
+	"SHA3-256": "sha3-256",
+	"SHA3-512": "sha3-512",
+}
+
+// NewStatement returns the in-toto statement attesting digest.
+func NewStatement(digest descruntime.Digest) (*Statement, error) {
+	algorithm, ok := digestSetAlgorithms[digest.HashAlgorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm %q", digest.HashAlgorithm)
+	}
+	if digest.Value == "" {
+		return nil, errors.New("missing digest value")
+	}
+	return &Statement{
+		Type:          StatementType,
+		Subject:       []Subject{{Digest: map[string]string{algorithm: digest.Value}}},
+		PredicateType: PredicateType,
+		Predicate:     &Predicate{NormalisationAlgorithm: digest.NormalisationAlgorithm},
+	}, nil
+}
+
+// PAE returns the DSSE pre-authentication encoding of payload, which is the
+// message that is actually signed.
+func PAE(payloadType string, payload []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("DSSEv1 ")
+	b.WriteString(strconv.Itoa(len(payloadType)))
+	b.WriteByte(' ')
+	b.WriteString(payloadType)
+	b.WriteByte(' ')
+	b.WriteString(strconv.Itoa(len(payload)))
+	b.WriteByte(' ')
+	b.Write(payload)
+	return b.Bytes()
+}
+
+// Seal builds the in-toto statement attesting digest, signs its PAE with sign and
+// returns the JSON encoded envelope to be stored as signature value.
+func Seal(digest descruntime.Digest, sign func(pae []byte) ([]byte, error)) (string, error) {
+	statement, err := NewStatement(digest)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return "", fmt.Errorf("marshal in-toto statement: %w", err)
+	}
+	sig, err := sign(PAE(PayloadType, payload))
+	if err != nil {
+		return "", err
+	}
+	envelope, err := json.Marshal(Envelope{
+		PayloadType: PayloadType,
+		Payload:     payload,
+		Signatures:  []Signature{{Sig: sig}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal DSSE envelope: %w", err)
+	}
+	return string(envelope), nil
+}
+
+// Open decodes the envelope in value and verifies it: at least one of its
+// signatures must be valid according to verify, and the statement must attest
+// digest. The error of the last failed verification is returned if no signature
+// is valid.
+func Open(value string, digest descruntime.Digest, verify func(pae, sig []byte) error) error {
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(value), &envelope); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+	if envelope.PayloadType != PayloadType {
+		return fmt.Errorf("%w: unsupported payload type %q", ErrInvalidEnvelope, envelope.PayloadType)
+	}
+	if len(envelope.Signatures) == 0 {
+		return fmt.Errorf("%w: no signatures", ErrInvalidEnvelope)
+	}
+
+	pae := PAE(envelope.PayloadType, envelope.Payload)
+	var err error
+	for _, signature := range envelope.Signatures {
+		if err = verify(pae, signature.Sig); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	var statement Statement
+	if err := json.Unmarshal(envelope.Payload, &statement); err != nil {
+		return fmt.Errorf("%w: decode in-toto statement: %w", ErrInvalidEnvelope, err)
+	}
+	return verifyStatement(&statement, digest)
+}
+
+// verifyStatement checks that statement attests digest.
+func verifyStatement(statement *Statement, digest descruntime.Digest) error {
+	if statement.Type != StatementType {
+		return fmt.Errorf("%w: unsupported statement type %q", ErrInvalidEnvelope, statement.Type)
+	}
+	if statement.PredicateType != PredicateType {
+		return fmt.Errorf("%w: unsupported predicate type %q", ErrInvalidEnvelope, statement.PredicateType)
+	}
+	if statement.Predicate == nil || statement.Predicate.NormalisationAlgorithm != digest.NormalisationAlgorithm {
+		return fmt.Errorf("%w: statement does not attest normalisation algorithm %q", ErrInvalidEnvelope, digest.NormalisationAlgorithm)
+	}
+	algorithm, ok := digestSetAlgorithms[digest.HashAlgorithm]
+	if !ok {
+		return fmt.Errorf("unsupported hash algorithm %q", digest.HashAlgorithm)
+	}
+	if len(statement.Subject) != 1 || statement.Subject[0].Digest[algorithm] != digest.Value {
+		return fmt.Errorf("%w: statement subject does not match digest %s:%s", ErrInvalidEnvelope, algorithm, digest.Value)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package dsse_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/dsse"
+)
+
+var errInvalidSignature = errors.New("invalid signature")
+
+func TestPAE(t *testing.T) {
+	// test vector of the DSSE specification
+	assert.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world",
+		string(dsse.PAE("http://example.com/HelloWorld", []byte("hello world"))))
+}
+
+func TestSealAndOpen(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sign := func(pae []byte) ([]byte, error) { return ed25519.Sign(priv, pae), nil }
+	verifyWith := func(pub ed25519.PublicKey) func(pae, sig []byte) error {
+		return func(pae, sig []byte) error {
+			if !ed25519.Verify(pub, pae, sig) {
+				return errInvalidSignature
+			}
+			return nil
+		}
+	}
+
+	digest := descruntime.Digest{
+		HashAlgorithm:          "SHA3-256",
+		NormalisationAlgorithm: "jsonNormalisation/v4alpha1",
+		Value:                  "0a1b2c",
+	}
+	value, err := dsse.Seal(digest, sign)
+	require.NoError(t, err)
+
+	var envelope dsse.Envelope
+	require.NoError(t, json.Unmarshal([]byte(value), &envelope))
+	assert.Equal(t, dsse.PayloadType, envelope.PayloadType)
+	require.Len(t, envelope.Signatures, 1)
+	var statement dsse.Statement
+	require.NoError(t, json.Unmarshal(envelope.Payload, &statement))
+	assert.Equal(t, dsse.StatementType, statement.Type)
+	assert.Equal(t, []dsse.Subject{{Digest: map[string]string{"sha3-256": "0a1b2c"}}}, statement.Subject)
+
+	require.NoError(t, dsse.Open(value, digest, verifyWith(pub)))
+
+	t.Run("other key", func(t *testing.T) {
+		other, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		require.ErrorIs(t, dsse.Open(value, digest, verifyWith(other)), errInvalidSignature)
+	})
+
+	t.Run("other digest", func(t *testing.T) {
+		other := digest
+		other.Value = "ffff"
+		require.ErrorIs(t, dsse.Open(value, other, verifyWith(pub)), dsse.ErrInvalidEnvelope)
+	})
+
+	t.Run("other normalisation", func(t *testing.T) {
+		other := digest
+		other.NormalisationAlgorithm = "jsonNormalisation/v3"
+		require.ErrorIs(t, dsse.Open(value, other, verifyWith(pub)), dsse.ErrInvalidEnvelope)
+	})
+
+	t.Run("payload replaced", func(t *testing.T) {
+		other := digest
+		other.Value = "ffff"
+		forged, err := dsse.Seal(other, sign)
+		require.NoError(t, err)
+		var forgedEnvelope dsse.Envelope
+		require.NoError(t, json.Unmarshal([]byte(forged), &forgedEnvelope))
+
+		tampered := envelope
+		tampered.Payload = forgedEnvelope.Payload
+		raw, err := json.Marshal(tampered)
+		require.NoError(t, err)
+		require.ErrorIs(t, dsse.Open(string(raw), other, verifyWith(pub)), errInvalidSignature)
+	})
+
+	t.Run("not an envelope", func(t *testing.T) {
+		require.ErrorIs(t, dsse.Open("0a1b2c", digest, verifyWith(pub)), dsse.ErrInvalidEnvelope)
+	})
+}
+
+func TestNewStatement_UnsupportedHash(t *testing.T) {
+	_, err := dsse.NewStatement(descruntime.Digest{HashAlgorithm: "MD5", Value: "00"})
+	require.ErrorContains(t, err, "unsupported hash algorithm")
+}
@@ -0,0 +1,238 @@
+// Package policy evaluates signature verification policies against the signatures
+// of a component descriptor.
+//
+// A policy (see [v1alpha1.Config]) replaces the rule that every signature of a
+// component version has to be valid: it names trusted signatures of which a
+// threshold must be valid, optional signatures that are verified but may fail,
+// and ignored signatures. [Evaluate] verifies the selected signatures and reports
+// the outcome of every clause of the policy, so callers can show which parts of
+// the policy were met.
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/policy/v1alpha1"
+)
+
+// ClauseKind names the part of a policy a [Clause] was evaluated for.
+type ClauseKind string
+
+const (
+	// ClauseTrusted is the evaluation of a trusted signature.
+	ClauseTrusted ClauseKind = "trusted"
+	// ClauseOptional is the evaluation of a signature matching an optional entry.
+	ClauseOptional ClauseKind = "optional"
+	// ClauseIgnored is a signature matching an ignored entry.
+	ClauseIgnored ClauseKind = "ignored"
+	// ClauseUntrusted is a signature that matches no entry of the policy.
+	ClauseUntrusted ClauseKind = "untrusted"
+	// ClauseThreshold is the check of the number of valid trusted signatures.
+	ClauseThreshold ClauseKind = "threshold"
+)
+
+// Outcome is the result of a [Clause].
+type Outcome string
+
+const (
+	// OutcomePassed means the signature is valid or the threshold was reached.
+	OutcomePassed Outcome = "passed"
+	// OutcomeFailed means the signature is invalid, untrusted or the threshold was not reached.
+	OutcomeFailed Outcome = "failed"
+	// OutcomeMissing means a trusted signature is not present on the component version.
+	OutcomeMissing Outcome = "missing"
+	// OutcomeSkipped means the signature was not verified because it is ignored.
+	OutcomeSkipped Outcome = "skipped"
+)
+
+// Clause is the evaluated outcome of one part of a policy.
+type Clause struct {
+	Kind ClauseKind `json:"kind"`
+	// Signature is the name of the evaluated signature. Empty for the threshold clause.
+	Signature string `json:"signature,omitempty"`
+	// Rule is the policy entry the signature matched, e.g. the pattern of an
+	// optional entry, or "2 of 3" for the threshold clause.
+	Rule    string  `json:"rule,omitempty"`
+	Outcome Outcome `json:"outcome"`
+	// Error is the reason of a failed clause.
+	Error string `json:"error,omitempty"`
+}
+
+// Result is the evaluation of a policy against the signatures of a component version.
+type Result struct {
+	// Clauses holds the trusted clauses in policy order, followed by the clauses of
+	// the remaining signatures in descriptor order and the threshold clause.
+	Clauses []Clause `json:"clauses"`
+	// Valid is the number of valid trusted signatures.
+	Valid int `json:"valid"`
+	// Required is the number of valid trusted signatures required by the policy.
+	Required int `json:"required"`
+}
+
+// Satisfied reports whether the threshold was reached and no untrusted signature was found.
+func (r *Result) Satisfied() bool {
+	return r.Err() == nil
+}
+
+// Err returns an error describing why the policy is not satisfied, or nil.
+func (r *Result) Err() error {
+	var errs []error
+	for _, clause := range r.Clauses {
+		switch {
+		case clause.Kind == ClauseUntrusted:
+			errs = append(errs, fmt.Errorf("signature %q is not trusted by the policy", clause.Signature))
+		case clause.Kind == ClauseThreshold && clause.Outcome != OutcomePassed:
+			errs = append(errs, fmt.Errorf("%d of %d required trusted signatures are valid", r.Valid, r.Required))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// VerifyFunc verifies a single signature with the given verifier configuration.
+// The verifier is nil if the matching policy entry has none, in which case the
+// caller's default verifier is expected to be used.
+type VerifyFunc func(ctx context.Context, signature descruntime.Signature, verifier *runtime.Raw) error
+
+// Options configure [Evaluate].
+type Options struct {
+	// Concurrency limits the number of signatures verified in parallel.
+	// Values below 1 verify one signature at a time.
+	Concurrency int
+}
+
+// Evaluate verifies the signatures selected by the policy with verify and returns
+// the outcome of every clause. All selected signatures are verified, even after
+// the first failure, so that the result is complete. Use [Result.Err] to decide
+// whether the policy is satisfied.
+func Evaluate(ctx context.Context, policy *v1alpha1.Config, signatures []descruntime.Signature, verify VerifyFunc, opts Options) *Result {
+	type task struct {
+		clause    int
+		signature descruntime.Signature
+		verifier  *runtime.Raw
+	}
+
+	byName := make(map[string]descruntime.Signature, len(signatures))
+	for _, signature := range signatures {
+		byName[signature.Name] = signature
+	}
+
+	result := &Result{Required: policy.RequiredSignatures()}
+	var tasks []task
+
+	trusted := make(map[string]struct{}, len(policy.Trusted))
+	for _, rule := range policy.Trusted {
+		trusted[rule.Signature] = struct{}{}
+		clause := Clause{Kind: ClauseTrusted, Signature: rule.Signature, Rule: rule.Signature}
+		signature, ok := byName[rule.Signature]
+		if !ok {
+			clause.Outcome = OutcomeMissing
+			clause.Error = "signature not found"
+		} else {
+			tasks = append(tasks, task{clause: len(result.Clauses), signature: signature, verifier: rule.Verifier})
+		}
+		result.Clauses = append(result.Clauses, clause)
+	}
+
+	for _, signature := range signatures {
+		if _, ok := trusted[signature.Name]; ok {
+			continue
+		}
+		if rule, ok := matchOptional(policy.Optional, signature.Name); ok {
+			tasks = append(tasks, task{clause: len(result.Clauses), signature: signature, verifier: rule.Verifier})
+			result.Clauses = append(result.Clauses, Clause{Kind: ClauseOptional, Signature: signature.Name, Rule: rule.Signature})
+			continue
+		}
+		if pattern, ok := matchIgnored(policy.Ignored, signature.Name); ok {
+			result.Clauses = append(result.Clauses, Clause{Kind: ClauseIgnored, Signature: signature.Name, Rule: pattern, Outcome: OutcomeSkipped})
+			continue
+		}
+		result.Clauses = append(result.Clauses, Clause{Kind: ClauseUntrusted, Signature: signature.Name, Outcome: OutcomeFailed, Error: "signature matches no policy entry"})
+	}
+
+	concurrency := max(opts.Concurrency, 1)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, t := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			clause := &result.Clauses[t.clause]
+			if err := verify(ctx, t.signature, t.verifier); err != nil {
+				clause.Outcome = OutcomeFailed
+				clause.Error = err.Error()
+				return
+			}
+			clause.Outcome = OutcomePassed
+		}()
+	}
+	wg.Wait()
+
+	for _, clause := range result.Clauses {
+		if clause.Kind == ClauseTrusted && clause.Outcome == OutcomePassed {
+			result.Valid++
+		}
+	}
+	threshold := Clause{
+		Kind:    ClauseThreshold,
+		Rule:    fmt.Sprintf("%d of %d", result.Required, len(policy.Trusted)),
+		Outcome: OutcomePassed,
+	}
+	if result.Valid < result.Required {
+		threshold.Outcome = OutcomeFailed
+		threshold.Error = fmt.Sprintf("only %d valid", result.Valid)
+	}
+	result.Clauses = append(result.Clauses, threshold)
+
+	return result
+}
+
+// String renders the result as one line per clause.
+func (r *Result) String() string {
+	var sb strings.Builder
+	for i, clause := range r.Clauses {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s", clause.Kind)
+		if clause.Signature != "" {
+			fmt.Fprintf(&sb, " %q", clause.Signature)
+		}
+		if clause.Rule != "" && clause.Rule != clause.Signature {
+			fmt.Fprintf(&sb, " (%s)", clause.Rule)
+		}
+		fmt.Fprintf(&sb, ": %s", clause.Outcome)
+		if clause.Error != "" {
+			fmt.Fprintf(&sb, ": %s", clause.Error)
+		}
+	}
+	return sb.String()
+}
+
+func matchOptional(rules []v1alpha1.SignatureRule, name string) (v1alpha1.SignatureRule, bool) {
+	for _, rule := range rules {
+		if ok, _ := path.Match(rule.Signature, name); ok {
+			return rule, true
+		}
+	}
+	return v1alpha1.SignatureRule{}, false
+}
+
+func matchIgnored(patterns []string, name string) (string, bool) {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,162 @@
+package policy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/policy"
+	"ocm.software/open-component-model/bindings/go/signing/policy/v1alpha1"
+)
+
+// verifyValid returns a VerifyFunc that only accepts the given signature names.
+func verifyValid(valid ...string) policy.VerifyFunc {
+	return func(_ context.Context, signature descruntime.Signature, _ *runtime.Raw) error {
+		for _, name := range valid {
+			if signature.Name == name {
+				return nil
+			}
+		}
+		return errors.New("invalid signature")
+	}
+}
+
+func signatures(names ...string) []descruntime.Signature {
+	sigs := make([]descruntime.Signature, 0, len(names))
+	for _, name := range names {
+		sigs = append(sigs, descruntime.Signature{Name: name})
+	}
+	return sigs
+}
+
+func releasePolicy() *v1alpha1.Config {
+	return &v1alpha1.Config{
+		Threshold: 2,
+		Trusted: []v1alpha1.SignatureRule{
+			{Signature: "release-bot"},
+			{Signature: "security-team"},
+			{Signature: "product-owner"},
+		},
+		Optional: []v1alpha1.SignatureRule{{Signature: "legacy-*"}},
+		Ignored:  []string{"experimental-*"},
+	}
+}
+
+func outcomes(result *policy.Result) map[string]policy.Outcome {
+	out := make(map[string]policy.Outcome, len(result.Clauses))
+	for _, clause := range result.Clauses {
+		key := string(clause.Kind)
+		if clause.Signature != "" {
+			key += "/" + clause.Signature
+		}
+		out[key] = clause.Outcome
+	}
+	return out
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name          string
+		signatures    []descruntime.Signature
+		valid         []string
+		wantSatisfied bool
+		wantValid     int
+		wantOutcomes  map[string]policy.Outcome
+	}{
+		{
+			name:          "threshold reached with a missing trusted signature",
+			signatures:    signatures("release-bot", "security-team"),
+			valid:         []string{"release-bot", "security-team"},
+			wantSatisfied: true,
+			wantValid:     2,
+			wantOutcomes: map[string]policy.Outcome{
+				"trusted/release-bot":   policy.OutcomePassed,
+				"trusted/security-team": policy.OutcomePassed,
+				"trusted/product-owner": policy.OutcomeMissing,
+				"threshold":             policy.OutcomePassed,
+			},
+		},
+		{
+			name:          "threshold not reached with an invalid trusted signature",
+			signatures:    signatures("release-bot", "security-team", "product-owner"),
+			valid:         []string{"release-bot"},
+			wantSatisfied: false,
+			wantValid:     1,
+			wantOutcomes: map[string]policy.Outcome{
+				"trusted/release-bot":   policy.OutcomePassed,
+				"trusted/security-team": policy.OutcomeFailed,
+				"trusted/product-owner": policy.OutcomeFailed,
+				"threshold":             policy.OutcomeFailed,
+			},
+		},
+		{
+			name:          "invalid optional and ignored signatures do not fail the policy",
+			signatures:    signatures("release-bot", "product-owner", "legacy-rsa", "experimental-pq"),
+			valid:         []string{"release-bot", "product-owner"},
+			wantSatisfied: true,
+			wantValid:     2,
+			wantOutcomes: map[string]policy.Outcome{
+				"trusted/release-bot":     policy.OutcomePassed,
+				"trusted/security-team":   policy.OutcomeMissing,
+				"trusted/product-owner":   policy.OutcomePassed,
+				"optional/legacy-rsa":     policy.OutcomeFailed,
+				"ignored/experimental-pq": policy.OutcomeSkipped,
+				"threshold":               policy.OutcomePassed,
+			},
+		},
+		{
+			name:          "untrusted signature fails the policy",
+			signatures:    signatures("release-bot", "security-team", "someone-else"),
+			valid:         []string{"release-bot", "security-team", "someone-else"},
+			wantSatisfied: false,
+			wantValid:     2,
+			wantOutcomes: map[string]policy.Outcome{
+				"trusted/release-bot":    policy.OutcomePassed,
+				"trusted/security-team":  policy.OutcomePassed,
+				"trusted/product-owner":  policy.OutcomeMissing,
+				"untrusted/someone-else": policy.OutcomeFailed,
+				"threshold":              policy.OutcomePassed,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := policy.Evaluate(t.Context(), releasePolicy(), tt.signatures, verifyValid(tt.valid...), policy.Options{Concurrency: 2})
+			assert.Equal(t, tt.wantSatisfied, result.Satisfied(), result.String())
+			assert.Equal(t, tt.wantValid, result.Valid)
+			assert.Equal(t, 2, result.Required)
+			assert.Equal(t, tt.wantOutcomes, outcomes(result))
+		})
+	}
+}
+
+func TestEvaluate_ThresholdDefaultsToAllTrusted(t *testing.T) {
+	cfg := releasePolicy()
+	cfg.Threshold = 0
+
+	result := policy.Evaluate(t.Context(), cfg, signatures("release-bot", "security-team"), verifyValid("release-bot", "security-team"), policy.Options{})
+	require.Error(t, result.Err())
+	assert.Contains(t, result.Err().Error(), "2 of 3 required trusted signatures are valid")
+
+	result = policy.Evaluate(t.Context(), cfg, signatures("release-bot", "security-team", "product-owner"), verifyValid("release-bot", "security-team", "product-owner"), policy.Options{})
+	assert.NoError(t, result.Err())
+}
+
+func TestEvaluate_PassesRuleVerifier(t *testing.T) {
+	verifier := &runtime.Raw{Type: runtime.NewVersionedType("ECCSigningConfiguration", "v1alpha1")}
+	cfg := &v1alpha1.Config{Trusted: []v1alpha1.SignatureRule{{Signature: "release-bot", Verifier: verifier}}}
+
+	var got *runtime.Raw
+	result := policy.Evaluate(t.Context(), cfg, signatures("release-bot"), func(_ context.Context, _ descruntime.Signature, v *runtime.Raw) error {
+		got = v
+		return nil
+	}, policy.Options{})
+	require.NoError(t, result.Err())
+	assert.Same(t, verifier, got)
+}
@@ -0,0 +1,192 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+const (
+	// ConfigType defines the type identifier for signature verification policies.
+	ConfigType = "verification.config.ocm.software"
+)
+
+var Scheme = runtime.NewScheme()
+
+func init() {
+	Scheme.MustRegisterWithAlias(&Config{},
+		runtime.NewVersionedType(ConfigType, Version),
+		runtime.NewUnversionedType(ConfigType),
+	)
+}
+
+// Config is a signature verification policy. It decides which signatures of a
+// component version have to be valid for the component version to be considered
+// verified, instead of requiring every signature to be valid.
+//
+// The policy below requires valid signatures of at least two of the three trusted
+// signers, verifies signatures named legacy-* if they are present without requiring
+// them to be valid, and ignores signatures named experimental-*:
+//
+//	type: verification.config.ocm.software/v1alpha1
+//	threshold: 2
+//	trusted:
+//	  - signature: release-bot
+//	    verifier:
+//	      type: SigstoreVerificationConfiguration/v1alpha1
+//	      certificateOIDCIssuer: https://token.actions.githubusercontent.com
+//	      certificateIdentity: https://github.com/acme/release/.github/workflows/release.yaml@refs/heads/main
+//	  - signature: security-team
+//	  - signature: product-owner
+//	optional:
+//	  - signature: legacy-*
+//	ignored:
+//	  - experimental-*
+//
+// A signature is matched against the trusted, optional and ignored entries in this
+// order, the first match decides how it is treated. Signatures that match no entry
+// are not trusted and fail the policy.
+//
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +k8s:deepcopy-gen=true
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type Config struct {
+	// +ocm:jsonschema-gen:enum=verification.config.ocm.software/v1alpha1
+	// +ocm:jsonschema-gen:enum:deprecated=verification.config.ocm.software
+	Type runtime.Type `json:"type"`
+
+	// Trusted lists the signatures by exact name whose validity counts towards the
+	// Threshold. A trusted signature that is missing on the component version or
+	// fails verification does not count.
+	Trusted []SignatureRule `json:"trusted"`
+
+	// Threshold is the minimum number of Trusted signatures that must be valid.
+	// 0 requires all of them.
+	//
+	// +ocm:jsonschema-gen:minimum=0
+	Threshold int `json:"threshold,omitempty"`
+
+	// Optional lists signatures by name or path.Match pattern that are verified if
+	// they are present on the component version. Their verification result is
+	// reported but never fails the policy.
+	Optional []SignatureRule `json:"optional,omitempty"`
+
+	// Ignored lists signature names or path.Match patterns of signatures that are
+	// neither verified nor fail the policy.
+	Ignored []string `json:"ignored,omitempty"`
+}
+
+// SignatureRule selects signatures by name and configures how they are verified.
+//
+// +k8s:deepcopy-gen=true
+type SignatureRule struct {
+	// Signature is the name of the signature. In Optional entries it may be a
+	// path.Match pattern such as "legacy-*".
+	Signature string `json:"signature"`
+	// Verifier is the configuration of the signing handler that verifies the
+	// signature, e.g. a SigstoreVerificationConfiguration/v1alpha1. It must not
+	// contain keys, they are resolved as credentials of the signing handler.
+	// If not set, the default verifier of the caller is used.
+	Verifier *runtime.Raw `json:"verifier,omitempty"`
+}
+
+// RequiredSignatures returns the number of trusted signatures that must be valid.
+func (cfg *Config) RequiredSignatures() int {
+	if cfg.Threshold == 0 {
+		return len(cfg.Trusted)
+	}
+	return cfg.Threshold
+}
+
+// Validate rejects a non-matching [Config.Type], a policy without trusted
+// signatures, a threshold that can never be reached and malformed names or patterns.
+// An empty Type is allowed so callers constructing a Config programmatically
+// do not need to set it explicitly.
+func (cfg *Config) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if !cfg.Type.IsEmpty() {
+		if cfg.Type.Name != ConfigType || (cfg.Type.Version != "" && cfg.Type.Version != Version) {
+			return fmt.Errorf("invalid type %q (must be %q or %q)",
+				cfg.Type, ConfigType, runtime.NewVersionedType(ConfigType, Version))
+		}
+	}
+
+	var errs []error
+	if len(cfg.Trusted) == 0 {
+		errs = append(errs, errors.New("at least one trusted signature is required"))
+	}
+	if cfg.Threshold < 0 || cfg.Threshold > len(cfg.Trusted) {
+		errs = append(errs, fmt.Errorf("invalid threshold %d (must be between 0 and the number of trusted signatures %d)", cfg.Threshold, len(cfg.Trusted)))
+	}
+
+	seen := make(map[string]struct{}, len(cfg.Trusted))
+	for i, rule := range cfg.Trusted {
+		if rule.Signature == "" {
+			errs = append(errs, fmt.Errorf("trusted[%d] has no signature name", i))
+			continue
+		}
+		if _, ok := seen[rule.Signature]; ok {
+			errs = append(errs, fmt.Errorf("trusted[%d] duplicates signature %q", i, rule.Signature))
+		}
+		seen[rule.Signature] = struct{}{}
+	}
+	for i, rule := range cfg.Optional {
+		if err := validatePattern(rule.Signature); err != nil {
+			errs = append(errs, fmt.Errorf("optional[%d]: %w", i, err))
+		}
+	}
+	for i, pattern := range cfg.Ignored {
+		if err := validatePattern(pattern); err != nil {
+			errs = append(errs, fmt.Errorf("ignored[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validatePattern(pattern string) error {
+	if pattern == "" {
+		return errors.New("empty signature name")
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid signature pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// LookupConfig extracts the verification policy from a central generic config.
+// All entries of type [ConfigType] are decoded and validated. A policy is applied
+// as a whole, so the last entry wins. Returns nil if cfg is nil or contains no
+// verification policy.
+func LookupConfig(cfg *genericv1.Config) (*Config, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	filtered, err := genericv1.Filter(cfg, &genericv1.FilterOptions{
+		ConfigTypes: []runtime.Type{
+			runtime.NewVersionedType(ConfigType, Version),
+			runtime.NewUnversionedType(ConfigType),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter config: %w", err)
+	}
+	var policy *Config
+	for _, entry := range filtered.Configurations {
+		var config Config
+		if err := Scheme.Convert(entry, &config); err != nil {
+			return nil, fmt.Errorf("failed to decode verification policy: %w", err)
+		}
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid verification policy: %w", err)
+		}
+		policy = &config
+	}
+	return policy, nil
+}
@@ -0,0 +1,136 @@
+package v1alpha1_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/policy/v1alpha1"
+)
+
+func TestLookupConfig(t *testing.T) {
+	r := require.New(t)
+
+	var generic genericv1.Config
+	r.NoError(genericv1.Scheme.Decode(strings.NewReader(`
+type: generic.config.ocm.software/v1
+configurations:
+  - type: verification.config.ocm.software/v1alpha1
+    trusted:
+      - signature: previous
+  - type: verification.config.ocm.software/v1alpha1
+    threshold: 1
+    trusted:
+      - signature: release-bot
+        verifier:
+          type: SigstoreVerificationConfiguration/v1alpha1
+          certificateOIDCIssuer: https://accounts.google.com
+          certificateIdentity: jane.doe@example.com
+      - signature: security-team
+    optional:
+      - signature: legacy-*
+    ignored:
+      - experimental-*
+`), &generic))
+
+	cfg, err := v1alpha1.LookupConfig(&generic)
+	r.NoError(err)
+	r.NotNil(cfg)
+
+	assert.Equal(t, 1, cfg.RequiredSignatures())
+	r.Len(cfg.Trusted, 2)
+	assert.Equal(t, "release-bot", cfg.Trusted[0].Signature)
+	r.NotNil(cfg.Trusted[0].Verifier)
+	assert.Equal(t, runtime.NewVersionedType("SigstoreVerificationConfiguration", "v1alpha1"), cfg.Trusted[0].Verifier.Type)
+	assert.Nil(t, cfg.Trusted[1].Verifier)
+	assert.Equal(t, []v1alpha1.SignatureRule{{Signature: "legacy-*"}}, cfg.Optional)
+	assert.Equal(t, []string{"experimental-*"}, cfg.Ignored)
+}
+
+func TestLookupConfig_None(t *testing.T) {
+	cfg, err := v1alpha1.LookupConfig(nil)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	cfg, err = v1alpha1.LookupConfig(&genericv1.Config{})
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     v1alpha1.Config
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfg: v1alpha1.Config{
+				Threshold: 1,
+				Trusted:   []v1alpha1.SignatureRule{{Signature: "a"}, {Signature: "b"}},
+				Optional:  []v1alpha1.SignatureRule{{Signature: "legacy-*"}},
+				Ignored:   []string{"x-?"},
+			},
+		},
+		{
+			name:    "no trusted signatures",
+			cfg:     v1alpha1.Config{},
+			wantErr: "at least one trusted signature is required",
+		},
+		{
+			name:    "threshold above trusted signatures",
+			cfg:     v1alpha1.Config{Threshold: 2, Trusted: []v1alpha1.SignatureRule{{Signature: "a"}}},
+			wantErr: "invalid threshold 2",
+		},
+		{
+			name:    "negative threshold",
+			cfg:     v1alpha1.Config{Threshold: -1, Trusted: []v1alpha1.SignatureRule{{Signature: "a"}}},
+			wantErr: "invalid threshold -1",
+		},
+		{
+			name:    "duplicate trusted signature",
+			cfg:     v1alpha1.Config{Trusted: []v1alpha1.SignatureRule{{Signature: "a"}, {Signature: "a"}}},
+			wantErr: `trusted[1] duplicates signature "a"`,
+		},
+		{
+			name: "malformed optional pattern",
+			cfg: v1alpha1.Config{
+				Trusted:  []v1alpha1.SignatureRule{{Signature: "a"}},
+				Optional: []v1alpha1.SignatureRule{{Signature: "legacy-["}},
+			},
+			wantErr: "optional[0]: invalid signature pattern",
+		},
+		{
+			name: "empty ignored pattern",
+			cfg: v1alpha1.Config{
+				Trusted: []v1alpha1.SignatureRule{{Signature: "a"}},
+				Ignored: []string{""},
+			},
+			wantErr: "ignored[0]: empty signature name",
+		},
+		{
+			name: "wrong type",
+			cfg: v1alpha1.Config{
+				Type:    runtime.NewVersionedType("transfer.config.ocm.software", v1alpha1.Version),
+				Trusted: []v1alpha1.SignatureRule{{Signature: "a"}},
+			},
+			wantErr: "invalid type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
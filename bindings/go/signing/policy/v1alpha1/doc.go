@@ -0,0 +1,6 @@
+// Package v1alpha1 defines the signature verification policy configuration type
+// verification.config.ocm.software/v1alpha1.
+//
+// See the parent package ocm.software/open-component-model/bindings/go/signing/policy
+// for the evaluation of a policy against the signatures of a component descriptor.
+package v1alpha1
@@ -0,0 +1,202 @@
+package tsa
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"ocm.software/open-component-model/bindings/go/signing/tsa/internal/rfc3161"
+)
+
+// tokenSigner is the certificate that signed a timestamp token, together with the
+// other certificates embedded into the token.
+type tokenSigner struct {
+	leaf  *x509.Certificate
+	certs []*x509.Certificate
+}
+
+// parseToken decodes a timestamp token and verifies its CMS signature with the
+// signer certificate embedded into the token. The certificate itself is not
+// verified.
+func parseToken(token []byte) (*rfc3161.TSTInfo, *tokenSigner, error) {
+	var content rfc3161.ContentInfo
+	if rest, err := asn1.Unmarshal(token, &content); err != nil {
+		return nil, nil, fmt.Errorf("decoding token: %w", err)
+	} else if len(rest) > 0 {
+		return nil, nil, errors.New("decoding token: trailing data")
+	}
+	if !content.ContentType.Equal(rfc3161.OIDSignedData) {
+		return nil, nil, fmt.Errorf("unexpected token content type %s", content.ContentType)
+	}
+	var signed rfc3161.SignedData
+	if _, err := asn1.Unmarshal(content.Content.Bytes, &signed); err != nil {
+		return nil, nil, fmt.Errorf("decoding signed data: %w", err)
+	}
+	if !signed.EncapContentInfo.EContentType.Equal(rfc3161.OIDTSTInfo) {
+		return nil, nil, fmt.Errorf("unexpected signed content type %s", signed.EncapContentInfo.EContentType)
+	}
+	var info rfc3161.TSTInfo
+	if _, err := asn1.Unmarshal(signed.EncapContentInfo.EContent, &info); err != nil {
+		return nil, nil, fmt.Errorf("decoding TSTInfo: %w", err)
+	}
+	if len(signed.SignerInfos) != 1 {
+		return nil, nil, fmt.Errorf("expected exactly one signer, got %d", len(signed.SignerInfos))
+	}
+
+	certs, err := x509.ParseCertificates(signed.Certificates.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing certificates: %w", err)
+	}
+	signerInfo := signed.SignerInfos[0]
+	leaf, err := findSigner(signerInfo.SID, certs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := verifySignerInfo(signerInfo, leaf, signed.EncapContentInfo.EContent); err != nil {
+		return nil, nil, err
+	}
+
+	signer := &tokenSigner{leaf: leaf}
+	for _, cert := range certs {
+		if cert != leaf {
+			signer.certs = append(signer.certs, cert)
+		}
+	}
+	return &info, signer, nil
+}
+
+// findSigner returns the certificate identified by the signer identifier, either by
+// issuer and serial number or by the [0] tagged subject key identifier.
+func findSigner(sid asn1.RawValue, certs []*x509.Certificate) (*x509.Certificate, error) {
+	if sid.Class == asn1.ClassContextSpecific && sid.Tag == 0 {
+		for _, cert := range certs {
+			if bytes.Equal(cert.SubjectKeyId, sid.Bytes) {
+				return cert, nil
+			}
+		}
+		return nil, errors.New("signer certificate not found in token")
+	}
+	var id rfc3161.IssuerAndSerialNumber
+	if _, err := asn1.Unmarshal(sid.FullBytes, &id); err != nil {
+		return nil, fmt.Errorf("decoding signer identifier: %w", err)
+	}
+	for _, cert := range certs {
+		if bytes.Equal(cert.RawIssuer, id.Issuer.FullBytes) && cert.SerialNumber.Cmp(id.SerialNumber) == 0 {
+			return cert, nil
+		}
+	}
+	return nil, errors.New("signer certificate not found in token")
+}
+
+// verifySignerInfo verifies the signature of the signed attributes and that they
+// attest the content type and the digest of the TSTInfo, see RFC 5652, section 5.4.
+func verifySignerInfo(signerInfo rfc3161.SignerInfo, cert *x509.Certificate, content []byte) error {
+	if len(signerInfo.SignedAttrs.FullBytes) == 0 {
+		return errors.New("token has no signed attributes")
+	}
+	hash, err := rfc3161.HashFromOID(signerInfo.DigestAlgorithm.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	// The signature is calculated over the DER encoding of the attributes with
+	// an explicit SET OF tag instead of the implicit [0] tag.
+	signedAttrs := bytes.Clone(signerInfo.SignedAttrs.FullBytes)
+	signedAttrs[0] = asn1.TagSet | 0x20
+
+	var attrs []rfc3161.Attribute
+	if _, err := asn1.UnmarshalWithParams(signedAttrs, &attrs, "set"); err != nil {
+		return fmt.Errorf("decoding signed attributes: %w", err)
+	}
+	var contentType asn1.ObjectIdentifier
+	var messageDigest []byte
+	for _, attr := range attrs {
+		switch {
+		case attr.Type.Equal(rfc3161.OIDAttributeType):
+			if _, err := asn1.Unmarshal(attr.Values.Bytes, &contentType); err != nil {
+				return fmt.Errorf("decoding content type attribute: %w", err)
+			}
+		case attr.Type.Equal(rfc3161.OIDAttributeDigest):
+			if _, err := asn1.Unmarshal(attr.Values.Bytes, &messageDigest); err != nil {
+				return fmt.Errorf("decoding message digest attribute: %w", err)
+			}
+		}
+	}
+	if !contentType.Equal(rfc3161.OIDTSTInfo) {
+		return errors.New("signed attributes do not attest a TSTInfo")
+	}
+	h := hash.New()
+	h.Write(content)
+	if !bytes.Equal(messageDigest, h.Sum(nil)) {
+		return errors.New("message digest does not match the TSTInfo")
+	}
+
+	algorithm, err := signatureAlgorithm(signerInfo, cert)
+	if err != nil {
+		return err
+	}
+	if err := cert.CheckSignature(algorithm, signedAttrs, signerInfo.Signature); err != nil {
+		return fmt.Errorf("verifying token signature: %w", err)
+	}
+	return nil
+}
+
+var (
+	oidRSAEncryption = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECPublicKey   = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidEd25519       = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+	oidSHA256WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}
+	oidSHA384WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 12}
+	oidSHA512WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 13}
+	oidECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidECDSAWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3}
+	oidECDSAWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 4}
+)
+
+// signatureAlgorithm maps the signature and digest algorithm of a signer to the
+// x509 signature algorithm. CMS allows the bare key algorithm as signature
+// algorithm, in which case the digest algorithm selects the hash.
+func signatureAlgorithm(signerInfo rfc3161.SignerInfo, cert *x509.Certificate) (x509.SignatureAlgorithm, error) {
+	digestAlgorithm := signerInfo.DigestAlgorithm.Algorithm
+	byDigest := func(sha256, sha384, sha512 x509.SignatureAlgorithm) x509.SignatureAlgorithm {
+		switch {
+		case digestAlgorithm.Equal(rfc3161.OIDSHA256):
+			return sha256
+		case digestAlgorithm.Equal(rfc3161.OIDSHA384):
+			return sha384
+		case digestAlgorithm.Equal(rfc3161.OIDSHA512):
+			return sha512
+		}
+		return x509.UnknownSignatureAlgorithm
+	}
+
+	var algorithm x509.SignatureAlgorithm
+	switch oid := signerInfo.SignatureAlgorithm.Algorithm; {
+	case oid.Equal(oidRSAEncryption):
+		algorithm = byDigest(x509.SHA256WithRSA, x509.SHA384WithRSA, x509.SHA512WithRSA)
+	case oid.Equal(oidECPublicKey):
+		algorithm = byDigest(x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512)
+	case oid.Equal(oidSHA256WithRSA):
+		algorithm = x509.SHA256WithRSA
+	case oid.Equal(oidSHA384WithRSA):
+		algorithm = x509.SHA384WithRSA
+	case oid.Equal(oidSHA512WithRSA):
+		algorithm = x509.SHA512WithRSA
+	case oid.Equal(oidECDSAWithSHA256):
+		algorithm = x509.ECDSAWithSHA256
+	case oid.Equal(oidECDSAWithSHA384):
+		algorithm = x509.ECDSAWithSHA384
+	case oid.Equal(oidECDSAWithSHA512):
+		algorithm = x509.ECDSAWithSHA512
+	case oid.Equal(oidEd25519):
+		algorithm = x509.PureEd25519
+	}
+	if algorithm == x509.UnknownSignatureAlgorithm {
+		return algorithm, fmt.Errorf("unsupported token signature algorithm %s with digest %s for %s key",
+			signerInfo.SignatureAlgorithm.Algorithm, digestAlgorithm, cert.PublicKeyAlgorithm)
+	}
+	return algorithm, nil
+}
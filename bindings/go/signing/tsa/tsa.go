@@ -0,0 +1,241 @@
+// Package tsa adds RFC 3161 trusted timestamps to OCM signatures.
+//
+// A timestamp token issued by a time stamping authority (TSA) attests that a
+// signature existed at a given time. The token is stored next to the signature
+// in the component descriptor, see [descruntime.TimestampSpec]. A verifier that
+// trusts the TSA can then validate the certificate chain of the signature at the
+// attested time instead of the current time, so that signatures remain verifiable
+// after their signing certificate expired.
+//
+// The token attests the hash of the signature value:
+//
+//	client := &tsa.Client{URL: "https://timestamp.example.com", HTTPClient: ocmhttp.New()}
+//	signature.Timestamp, err = client.Timestamp(ctx, signature.Signature)
+//
+// Before verifying the signature, the verifier checks the token against the roots of
+// the trusted TSAs and hands the attested time to the signing handler via the context:
+//
+//	t, err := tsa.Verify(signature, roots)
+//	err = handler.Verify(tsa.WithVerifiedTime(ctx, t), signature, config, creds)
+package tsa
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
+	"ocm.software/open-component-model/bindings/go/signing/tsa/internal/rfc3161"
+)
+
+// maxResponseSize limits the size of a time-stamp response read from a TSA.
+const maxResponseSize = 1 << 20
+
+var (
+	// ErrNoTimestamp is returned by Verify for signatures without a timestamp.
+	ErrNoTimestamp = errors.New("signature has no timestamp")
+	// ErrInvalidTimestamp is returned by Verify if the timestamp token is invalid.
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
+)
+
+// Client requests timestamp tokens from a time stamping authority.
+type Client struct {
+	// URL is the endpoint of the time stamping authority.
+	URL string
+	// HTTPClient sends the time-stamp requests. If nil, a client built with
+	// [ocmhttp.New] without configuration is used.
+	HTTPClient *http.Client
+	// Hash is the hash algorithm of the message imprint. Defaults to SHA-256.
+	Hash crypto.Hash
+}
+
+// Timestamp requests a timestamp token for the value of the signature. The
+// token is checked to attest the requested imprint, but its certificate chain is
+// not verified, this is up to the verifier, see [Verify].
+func (c *Client) Timestamp(ctx context.Context, signature descruntime.SignatureInfo) (*descruntime.TimestampSpec, error) {
+	hash := c.Hash
+	if hash == 0 {
+		hash = crypto.SHA256
+	}
+	oid, err := rfc3161.HashOID(hash)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	request, err := asn1.Marshal(rfc3161.TimeStampReq{
+		Version: 1,
+		MessageImprint: rfc3161.MessageImprint{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oid},
+			HashedMessage: digest(hash, []byte(signature.Value)),
+		},
+		Nonce:   nonce,
+		CertReq: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding time-stamp request: %w", err)
+	}
+
+	token, err := c.send(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	info, _, err := parseToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTimestamp, err)
+	}
+	if info.Nonce == nil || info.Nonce.Cmp(nonce) != 0 {
+		return nil, fmt.Errorf("%w: nonce does not match the request", ErrInvalidTimestamp)
+	}
+	if err := checkImprint(info.MessageImprint, []byte(signature.Value)); err != nil {
+		return nil, err
+	}
+
+	return &descruntime.TimestampSpec{
+		Value: base64.StdEncoding.EncodeToString(token),
+		Time:  info.GenTime.UTC(),
+	}, nil
+}
+
+func (c *Client) send(ctx context.Context, request []byte) ([]byte, error) {
+	client := c.HTTPClient
+	if client == nil {
+		client = ocmhttp.New()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(request))
+	if err != nil {
+		return nil, fmt.Errorf("creating time-stamp request: %w", err)
+	}
+	req.Header.Set("Content-Type", rfc3161.MediaTypeQuery)
+	req.Header.Set("Accept", rfc3161.MediaTypeReply)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting timestamp from %s: %w", c.URL, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("requesting timestamp from %s: unexpected status %s", c.URL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading time-stamp response: %w", err)
+	}
+
+	var response rfc3161.TimeStampResp
+	if rest, err := asn1.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("decoding time-stamp response: %w", err)
+	} else if len(rest) > 0 {
+		return nil, fmt.Errorf("decoding time-stamp response: trailing data")
+	}
+	if status := response.Status.Status; status != rfc3161.StatusGranted && status != rfc3161.StatusGrantedWithMods {
+		return nil, fmt.Errorf("time-stamp request rejected with status %d: %v", status, response.Status.StatusString)
+	}
+	if len(response.TimeStampToken.FullBytes) == 0 {
+		return nil, fmt.Errorf("time-stamp response has no token")
+	}
+	return response.TimeStampToken.FullBytes, nil
+}
+
+// Verify verifies the timestamp token of the signature and returns the attested
+// time. The token must attest the hash of the signature value and be signed by a
+// time stamping certificate that chains up to roots at the attested time.
+func Verify(signature descruntime.Signature, roots *x509.CertPool) (time.Time, error) {
+	if signature.Timestamp == nil || signature.Timestamp.Value == "" {
+		return time.Time{}, ErrNoTimestamp
+	}
+	token, err := base64.StdEncoding.DecodeString(signature.Timestamp.Value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: decoding token: %w", ErrInvalidTimestamp, err)
+	}
+	genTime, err := VerifyToken(token, []byte(signature.Signature.Value), x509.VerifyOptions{Roots: roots})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t := signature.Timestamp.Time; !t.IsZero() && !t.Round(time.Second).Equal(genTime.Round(time.Second)) {
+		return time.Time{}, fmt.Errorf("%w: time %s does not match the token time %s", ErrInvalidTimestamp, t, genTime)
+	}
+	return genTime, nil
+}
+
+// VerifyToken verifies a DER encoded timestamp token over message and returns the
+// attested time. The token signer is verified with the roots and intermediates of
+// opts at the attested time, certificates embedded into the token are added to the
+// intermediates. The key usage and time of opts are ignored.
+func VerifyToken(token, message []byte, opts x509.VerifyOptions) (time.Time, error) {
+	info, signer, err := parseToken(token)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidTimestamp, err)
+	}
+	if err := checkImprint(info.MessageImprint, message); err != nil {
+		return time.Time{}, err
+	}
+
+	if opts.Roots == nil {
+		opts.Roots = x509.NewCertPool()
+	}
+	if opts.Intermediates != nil {
+		opts.Intermediates = opts.Intermediates.Clone()
+	} else {
+		opts.Intermediates = x509.NewCertPool()
+	}
+	for _, cert := range signer.certs {
+		opts.Intermediates.AddCert(cert)
+	}
+	opts.KeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping}
+	opts.CurrentTime = info.GenTime
+	if _, err := signer.leaf.Verify(opts); err != nil {
+		return time.Time{}, fmt.Errorf("%w: verifying TSA certificate: %w", ErrInvalidTimestamp, err)
+	}
+	return info.GenTime.UTC(), nil
+}
+
+type verifiedTimeKey struct{}
+
+// WithVerifiedTime returns a context that carries the time attested by a verified
+// timestamp. Signing handlers validate certificate chains at this time instead of
+// the current time, see [VerifiedTime]. The time must only be set after the
+// timestamp was verified with [Verify].
+func WithVerifiedTime(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, verifiedTimeKey{}, t)
+}
+
+// VerifiedTime returns the time set with [WithVerifiedTime], if any.
+func VerifiedTime(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(verifiedTimeKey{}).(time.Time)
+	return t, ok
+}
+
+func digest(hash crypto.Hash, value []byte) []byte {
+	h := hash.New()
+	_, _ = h.Write(value)
+	return h.Sum(nil)
+}
+
+// checkImprint checks that the message imprint is the hash of the signature value.
+func checkImprint(imprint rfc3161.MessageImprint, value []byte) error {
+	hash, err := rfc3161.HashFromOID(imprint.HashAlgorithm.Algorithm)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTimestamp, err)
+	}
+	if !bytes.Equal(imprint.HashedMessage, digest(hash, value)) {
+		return fmt.Errorf("%w: token does not attest the signature value", ErrInvalidTimestamp)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package tsa_test
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
+	"ocm.software/open-component-model/bindings/go/signing/tsa/tsatest"
+)
+
+func timestampedSignature(t *testing.T, server *tsatest.Server) descruntime.Signature {
+	t.Helper()
+	signature := descruntime.Signature{
+		Name:      "default",
+		Signature: descruntime.SignatureInfo{Algorithm: "RSASSA-PSS", MediaType: "application/vnd.ocm.signature.rsa.pss", Value: "abcdef"},
+	}
+	client := &tsa.Client{URL: server.URL}
+	timestamp, err := client.Timestamp(t.Context(), signature.Signature)
+	require.NoError(t, err)
+	signature.Timestamp = timestamp
+	return signature
+}
+
+func TestTimestamp(t *testing.T) {
+	server := tsatest.NewServer(t)
+	attested := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+	server.Now = func() time.Time { return attested }
+
+	signature := timestampedSignature(t, server)
+	require.NotNil(t, signature.Timestamp)
+	assert.Equal(t, attested, signature.Timestamp.Time)
+
+	verified, err := tsa.Verify(signature, server.Roots())
+	require.NoError(t, err)
+	assert.Equal(t, attested, verified)
+
+	t.Run("survives the v2 conversion", func(t *testing.T) {
+		converted := descruntime.ConvertFromV2Signature(descruntime.ConvertToV2Signature(&signature))
+		verified, err := tsa.Verify(*converted, server.Roots())
+		require.NoError(t, err)
+		assert.Equal(t, attested, verified)
+	})
+
+	t.Run("token verifies over the signature value", func(t *testing.T) {
+		token, err := base64.StdEncoding.DecodeString(signature.Timestamp.Value)
+		require.NoError(t, err)
+		verified, err := tsa.VerifyToken(token, []byte(signature.Signature.Value), x509.VerifyOptions{Roots: server.Roots()})
+		require.NoError(t, err)
+		assert.Equal(t, attested, verified)
+
+		_, err = tsa.VerifyToken(token, []byte("other message"), x509.VerifyOptions{Roots: server.Roots()})
+		require.ErrorIs(t, err, tsa.ErrInvalidTimestamp)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	server := tsatest.NewServer(t)
+	signature := timestampedSignature(t, server)
+
+	tests := []struct {
+		name    string
+		mutate  func(*descruntime.Signature)
+		roots   *x509.CertPool
+		wantErr error
+		errMsg  string
+	}{
+		{
+			name:    "no timestamp",
+			mutate:  func(s *descruntime.Signature) { s.Timestamp = nil },
+			roots:   server.Roots(),
+			wantErr: tsa.ErrNoTimestamp,
+		},
+		{
+			name:    "untrusted TSA",
+			roots:   tsatest.NewServer(t).Roots(),
+			wantErr: tsa.ErrInvalidTimestamp,
+			errMsg:  "certificate signed by unknown authority",
+		},
+		{
+			name:    "no roots",
+			wantErr: tsa.ErrInvalidTimestamp,
+			errMsg:  "certificate signed by unknown authority",
+		},
+		{
+			name:    "signature value replaced",
+			mutate:  func(s *descruntime.Signature) { s.Signature.Value = "fedcba" },
+			roots:   server.Roots(),
+			wantErr: tsa.ErrInvalidTimestamp,
+			errMsg:  "token does not attest the signature value",
+		},
+		{
+			name:    "time modified",
+			mutate:  func(s *descruntime.Signature) { s.Timestamp.Time = s.Timestamp.Time.Add(-time.Hour) },
+			roots:   server.Roots(),
+			wantErr: tsa.ErrInvalidTimestamp,
+			errMsg:  "does not match the token time",
+		},
+		{
+			name: "token tampered",
+			mutate: func(s *descruntime.Signature) {
+				token, err := base64.StdEncoding.DecodeString(s.Timestamp.Value)
+				require.NoError(t, err)
+				token[len(token)-1] ^= 0xff
+				s.Timestamp.Value = base64.StdEncoding.EncodeToString(token)
+			},
+			roots:   server.Roots(),
+			wantErr: tsa.ErrInvalidTimestamp,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			signature := *signature.DeepCopy()
+			if tc.mutate != nil {
+				tc.mutate(&signature)
+			}
+			_, err := tsa.Verify(signature, tc.roots)
+			require.ErrorIs(t, err, tc.wantErr)
+			if tc.errMsg != "" {
+				require.ErrorContains(t, err, tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestTimestamp_Errors(t *testing.T) {
+	client := &tsa.Client{URL: tsatest.NewServer(t).URL, Hash: crypto.MD5}
+	_, err := client.Timestamp(t.Context(), descruntime.SignatureInfo{Value: "abcdef"})
+	require.ErrorContains(t, err, "unsupported hash algorithm")
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(notFound.Close)
+	client = &tsa.Client{URL: notFound.URL}
+	_, err = client.Timestamp(t.Context(), descruntime.SignatureInfo{Value: "abcdef"})
+	require.ErrorContains(t, err, "unexpected status 404")
+}
+
+func TestVerifiedTime(t *testing.T) {
+	_, ok := tsa.VerifiedTime(t.Context())
+	assert.False(t, ok)
+
+	now := time.Now()
+	verified, ok := tsa.VerifiedTime(tsa.WithVerifiedTime(t.Context(), now))
+	assert.True(t, ok)
+	assert.Equal(t, now, verified)
+}
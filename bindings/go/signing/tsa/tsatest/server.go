@@ -0,0 +1,229 @@
+// Package tsatest provides a local RFC 3161 time stamping authority for tests.
+package tsatest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ocm.software/open-component-model/bindings/go/signing/tsa/internal/rfc3161"
+)
+
+var (
+	oidPolicy          = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}
+	oidECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+)
+
+// Server is a time stamping authority that issues tokens signed with an ECDSA
+// certificate for time stamping, issued by a self-signed root.
+type Server struct {
+	*httptest.Server
+
+	// Root is the root certificate of the TSA certificate.
+	Root *x509.Certificate
+	// Now returns the time attested by issued tokens. Defaults to [time.Now].
+	Now func() time.Time
+
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	serial atomic.Int64
+}
+
+// NewServer starts a time stamping authority that is closed with the test.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating root key: %v", err)
+	}
+	root := createCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test TSA Root"},
+		NotBefore:             time.Now().Add(-24 * time.Hour * 365),
+		NotAfter:              time.Now().Add(24 * time.Hour * 365),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, &rootKey.PublicKey, rootKey)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating TSA key: %v", err)
+	}
+	cert := createCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test TSA"},
+		NotBefore:    root.NotBefore,
+		NotAfter:     root.NotAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}, root, &key.PublicKey, rootKey)
+
+	s := &Server{Root: root, Now: time.Now, cert: cert, key: key}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Roots returns a pool with the root certificate of the TSA.
+func (s *Server) Roots() *x509.CertPool {
+	roots := x509.NewCertPool()
+	roots.AddCert(s.Root)
+	return roots
+}
+
+// RootPEM returns the PEM encoded root certificate of the TSA.
+func (s *Server) RootPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Root.Raw})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != rfc3161.MediaTypeQuery {
+		http.Error(w, "expected a time-stamp query", http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req rfc3161.TimeStampReq
+	if _, err := asn1.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	token, err := s.issue(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp, err := asn1.Marshal(rfc3161.TimeStampResp{
+		Status:         rfc3161.PKIStatusInfo{Status: rfc3161.StatusGranted},
+		TimeStampToken: asn1.RawValue{FullBytes: token},
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", rfc3161.MediaTypeReply)
+	_, _ = w.Write(resp)
+}
+
+// issue creates a timestamp token for the request, see RFC 3161, section 2.4.2.
+func (s *Server) issue(req rfc3161.TimeStampReq) ([]byte, error) {
+	info, err := asn1.Marshal(rfc3161.TSTInfo{
+		Version:        1,
+		Policy:         oidPolicy,
+		MessageImprint: req.MessageImprint,
+		SerialNumber:   big.NewInt(s.serial.Add(1)),
+		GenTime:        s.Now().UTC().Truncate(time.Second),
+		Nonce:          req.Nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contentDigest := sha256.Sum256(info)
+	contentType, err := attribute(rfc3161.OIDAttributeType, rfc3161.OIDTSTInfo)
+	if err != nil {
+		return nil, err
+	}
+	messageDigest, err := attribute(rfc3161.OIDAttributeDigest, contentDigest[:])
+	if err != nil {
+		return nil, err
+	}
+	// The signature is calculated over the attributes encoded as SET OF, while
+	// the SignerInfo carries them with the implicit [0] tag.
+	attrs, err := asn1.MarshalWithParams([]rfc3161.Attribute{contentType, messageDigest}, "set")
+	if err != nil {
+		return nil, err
+	}
+	attrsDigest := sha256.Sum256(attrs)
+	signature, err := s.key.Sign(rand.Reader, attrsDigest[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	var rawIssuer asn1.RawValue
+	if _, err := asn1.Unmarshal(s.cert.RawIssuer, &rawIssuer); err != nil {
+		return nil, err
+	}
+	sid, err := asn1.Marshal(rfc3161.IssuerAndSerialNumber{Issuer: rawIssuer, SerialNumber: s.cert.SerialNumber})
+	if err != nil {
+		return nil, err
+	}
+
+	signedAttrs := append([]byte{}, attrs...)
+	signedAttrs[0] = 0xa0
+	sha256Algorithm := pkix.AlgorithmIdentifier{Algorithm: rfc3161.OIDSHA256}
+	signedData := rfc3161.SignedData{
+		Version:          3,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{sha256Algorithm},
+		EncapContentInfo: rfc3161.EncapsulatedContentInfo{EContentType: rfc3161.OIDTSTInfo, EContent: info},
+		SignerInfos: []rfc3161.SignerInfo{{
+			Version:            1,
+			SID:                asn1.RawValue{FullBytes: sid},
+			DigestAlgorithm:    sha256Algorithm,
+			SignedAttrs:        asn1.RawValue{FullBytes: signedAttrs},
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256},
+			Signature:          signature,
+		}},
+	}
+	if req.CertReq {
+		signedData.Certificates = asn1.RawValue{
+			Class:      asn1.ClassContextSpecific,
+			Tag:        0,
+			IsCompound: true,
+			Bytes:      s.cert.Raw,
+		}
+	}
+	content, err := asn1.Marshal(signedData)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(rfc3161.ContentInfo{
+		ContentType: rfc3161.OIDSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: content},
+	})
+}
+
+func attribute(oid asn1.ObjectIdentifier, value any) (rfc3161.Attribute, error) {
+	encoded, err := asn1.Marshal(value)
+	if err != nil {
+		return rfc3161.Attribute{}, err
+	}
+	return rfc3161.Attribute{
+		Type:   oid,
+		Values: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: encoded},
+	}, nil
+}
+
+func createCertificate(t testing.TB, template, parent *x509.Certificate, pub *ecdsa.PublicKey, signer *ecdsa.PrivateKey) *x509.Certificate {
+	t.Helper()
+	if parent == nil {
+		parent = template
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	return cert
+}
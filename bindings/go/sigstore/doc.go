@@ -4,7 +4,8 @@
 //
 // This handler invokes cosign as an external process, keeping the transitive
 // dependency footprint minimal while producing standard Sigstore protobuf
-// bundles (v0.3).
+// bundles (v0.3). Verification against a trusted-root credential does not need
+// cosign, see Native Verification.
 //
 // # Prerequisites
 //
@@ -70,10 +71,22 @@
 //
 // Trusted root resolution applies to verification only; the signing path does
 // not pass --trusted-root to cosign. Resolution order on verify (first wins):
-//  1. TrustedRoot.TrustedRootJSON — inline JSON written to a temp file
-//  2. TrustedRoot.TrustedRootJSONFile — path passed as --trusted-root
+//  1. TrustedRoot.TrustedRootJSON — inline JSON document
+//  2. TrustedRoot.TrustedRootJSONFile — path to a JSON document
 //  3. "" — cosign falls back to public-good TUF default
 //
+// # Native Verification
+//
+// When a trusted root is resolved from credentials, the handler verifies the
+// bundle in-process (signing/handler/internal/bundle) and cosign is not required
+// on the verifying machine. The verification covers the transparency log entry
+// (inclusion proof, checkpoint and inclusion promise), RFC 3161 timestamps, the
+// Fulcio certificate chain and its embedded SCTs, the identity policy and the
+// signature itself. Only message signatures with hashedrekord v0.0.1 entries are
+// supported. Verification against the public-good TUF default, or with
+// WithCosignVerification, still delegates to cosign verify-blob, passing the
+// trusted root as --trusted-root.
+//
 // Note: TUF_ROOT and SIGSTORE_ROOT_FILE env vars control cosign's TUF cache
 // and initialization, not the --trusted-root flag. They coexist with
 // credential-provided trusted roots without conflict.
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
-	"encoding/asn1"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -20,6 +19,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/signing"
 	"ocm.software/open-component-model/bindings/go/sigstore/signing/handler/internal"
+	"ocm.software/open-component-model/bindings/go/sigstore/signing/handler/internal/bundle"
 	"ocm.software/open-component-model/bindings/go/sigstore/signing/v1alpha1"
 	oidcv1 "ocm.software/open-component-model/bindings/go/sigstore/spec/credentials/oidcidentitytoken/v1alpha1"
 	trustedrootv1 "ocm.software/open-component-model/bindings/go/sigstore/spec/credentials/trustedroot/v1alpha1"
@@ -29,11 +29,14 @@ import (
 
 var _ signing.Handler = (*Handler)(nil)
 
-// Handler implements signing.Handler by delegating to the cosign CLI.
+// Handler implements signing.Handler by delegating signing to the cosign CLI.
+// Verification runs in-process if a trusted root is configured, and is delegated
+// to the cosign CLI otherwise, which resolves the public-good trusted root via TUF.
 // Safe for concurrent use. Binary resolution happens lazily on first Sign or Verify call.
 type Handler struct {
-	runner  *internal.CosignBinary
-	tempDir string
+	runner             *internal.CosignBinary
+	tempDir            string
+	cosignVerification bool
 }
 
 // New creates a Handler. Binary resolution happens lazily on first Sign or Verify call.
@@ -158,9 +161,11 @@ func (h *Handler) Sign(
 	}, nil
 }
 
-// Verify checks a Sigstore bundle via cosign verify-blob: decodes the bundle and digest,
-// validates the Fulcio certificate chain and Rekor inclusion proof, and confirms the
-// signed content matches the digest using the configured identity/issuer constraints.
+// Verify checks a Sigstore bundle: decodes the bundle and digest, validates the Fulcio
+// certificate chain and Rekor inclusion proof, and confirms the signed content matches
+// the digest using the configured identity/issuer constraints.
+// With a TrustedRoot credential the bundle is verified in-process, without one it is
+// verified via cosign verify-blob against the public-good trusted root.
 func (h *Handler) Verify(
 	ctx context.Context,
 	signed descruntime.Signature,
@@ -193,9 +198,7 @@ func (h *Handler) Verify(
 		trustedRootCreds = &trustedrootv1.TrustedRoot{}
 	}
 
-	if cfg.PrivateInfrastructure &&
-		strings.TrimSpace(trustedRootCreds.TrustedRootJSON) == "" &&
-		strings.TrimSpace(trustedRootCreds.TrustedRootJSONFile) == "" {
+	if cfg.PrivateInfrastructure && !hasTrustedRoot(trustedRootCreds) {
 		return fmt.Errorf("privateInfrastructure requires a trusted root: " +
 			"provide a TrustedRoot credential (trustedRootJSON or trustedRootJSONFile)")
 	}
@@ -217,6 +220,10 @@ func (h *Handler) Verify(
 		return fmt.Errorf("digest value must not be empty")
 	}
 
+	if !h.cosignVerification && hasTrustedRoot(trustedRootCreds) {
+		return verifyNative(ctx, cfg, trustedRootCreds, bundleJSON, digestBytes)
+	}
+
 	tmpDir, err := os.MkdirTemp(h.tempDir, "cosign-verify-*")
 	if err != nil {
 		return fmt.Errorf("create temp dir for verify: %w", err)
@@ -278,6 +285,44 @@ func (h *Handler) Verify(
 	return nil
 }
 
+// verifyNative verifies the bundle in-process against the trusted root, with the same
+// semantics as cosign verify-blob with --trusted-root.
+func verifyNative(ctx context.Context, cfg v1alpha1.VerifyConfig, creds *trustedrootv1.TrustedRoot, bundleJSON, digestBytes []byte) error {
+	trustedRootJSON, err := readTrustedRoot(creds)
+	if err != nil {
+		return fmt.Errorf("resolve trusted root: %w", err)
+	}
+	trustedRoot, err := bundle.ParseTrustedRoot(trustedRootJSON)
+	if err != nil {
+		return err
+	}
+	b, err := bundle.Parse(bundleJSON)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "sigstore verify: verifying bundle in-process, enforcing identity constraints",
+		"certificate_identity", cfg.CertificateIdentity,
+		"certificate_identity_regexp", cfg.CertificateIdentityRegexp,
+		"certificate_oidc_issuer", cfg.CertificateOIDCIssuer,
+		"certificate_oidc_issuer_regexp", cfg.CertificateOIDCIssuerRegexp,
+		"private_infrastructure", cfg.PrivateInfrastructure,
+	)
+
+	if err := bundle.Verify(b, digestBytes, trustedRoot, bundle.Options{
+		Policy: bundle.Policy{
+			Identity:       cfg.CertificateIdentity,
+			IdentityRegexp: cfg.CertificateIdentityRegexp,
+			Issuer:         cfg.CertificateOIDCIssuer,
+			IssuerRegexp:   cfg.CertificateOIDCIssuerRegexp,
+		},
+		IgnoreTlog: cfg.PrivateInfrastructure,
+	}); err != nil {
+		return fmt.Errorf("sigstore bundle verification failed: %w", err)
+	}
+	return nil
+}
+
 func (*Handler) GetSigningCredentialConsumerIdentity(
 	_ context.Context,
 	name string,
@@ -348,6 +393,27 @@ func resolveTrustedRootPath(creds *trustedrootv1.TrustedRoot, tmpDir string) (st
 	return "", nil
 }
 
+func hasTrustedRoot(creds *trustedrootv1.TrustedRoot) bool {
+	return strings.TrimSpace(creds.TrustedRootJSON) != "" || strings.TrimSpace(creds.TrustedRootJSONFile) != ""
+}
+
+// readTrustedRoot returns the trusted root JSON, with the same resolution order
+// as resolveTrustedRootPath.
+func readTrustedRoot(creds *trustedrootv1.TrustedRoot) ([]byte, error) {
+	if jsonVal := strings.TrimSpace(creds.TrustedRootJSON); jsonVal != "" {
+		return []byte(jsonVal), nil
+	}
+	filePath := strings.TrimSpace(creds.TrustedRootJSONFile)
+	if err := validateTrustedRootPath(filePath); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read trusted root file: %w", err)
+	}
+	return data, nil
+}
+
 func validateTrustedRootPath(p string) error {
 	if !filepath.IsAbs(p) {
 		return fmt.Errorf("trusted root file path must be absolute, got %q", p)
@@ -358,16 +424,10 @@ func validateTrustedRootPath(p string) error {
 	return nil
 }
 
-// Fulcio embeds the OIDC issuer URL in Sigstore bundle certificates as proprietary X.509
-// extensions under the Sigstore PEN (Private Enterprise Number 57264).
-// See https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md for the full OID registry.
-//
-// V1 (OID .1): issuer stored as raw UTF-8 bytes directly in the extension value.
-// V2 (OID .8): issuer stored as an ASN.1 DER-encoded UTF8String (requires asn1.Unmarshal).
-// We prefer V2 and fall back to V1 for compatibility with older Fulcio deployments.
+// The OIDC issuer extensions of Fulcio certificates, see [bundle.OIDCIssuer].
 var (
-	sigstoreIssuerV1OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
-	sigstoreIssuerV2OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+	sigstoreIssuerV1OID = bundle.OIDIssuerV1
+	sigstoreIssuerV2OID = bundle.OIDIssuerV2
 )
 
 type bundleCertInfo struct {
@@ -389,12 +449,12 @@ type sigstoreBundle struct {
 // to a specific identity without re-parsing the bundle.
 // Tries the V2 issuer OID first, falls back to V1 for older Fulcio deployments.
 func extractCertInfoFromBundleJSON(bundleJSON []byte) (bundleCertInfo, error) {
-	var bundle sigstoreBundle
-	if err := json.Unmarshal(bundleJSON, &bundle); err != nil {
+	var b sigstoreBundle
+	if err := json.Unmarshal(bundleJSON, &b); err != nil {
 		return bundleCertInfo{}, fmt.Errorf("unmarshal bundle JSON: %w", err)
 	}
 
-	certDER, err := base64.StdEncoding.DecodeString(bundle.VerificationMaterial.Certificate.RawBytes)
+	certDER, err := base64.StdEncoding.DecodeString(b.VerificationMaterial.Certificate.RawBytes)
 	if err != nil {
 		return bundleCertInfo{}, fmt.Errorf("decode certificate base64: %w", err)
 	}
@@ -414,30 +474,28 @@ func extractCertInfoFromBundleJSON(bundleJSON []byte) (bundleCertInfo, error) {
 		identity = cert.URIs[0].String()
 	}
 
-	var v1Issuer string
-	var v2Err error
-	for _, ext := range cert.Extensions {
-		if ext.Id.Equal(sigstoreIssuerV2OID) {
-			var issuer string
-			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
-				return bundleCertInfo{Issuer: issuer, Identity: identity}, nil
-			} else {
-				v2Err = err
-			}
-		}
-		if v1Issuer == "" && ext.Id.Equal(sigstoreIssuerV1OID) {
-			v1Issuer = string(ext.Value)
-		}
+	issuer, err := bundle.OIDCIssuer(cert)
+	if err != nil {
+		return bundleCertInfo{}, err
 	}
+	return bundleCertInfo{Issuer: issuer, Identity: identity}, nil
+}
 
-	if v1Issuer != "" {
-		return bundleCertInfo{Issuer: v1Issuer, Identity: identity}, nil
+// SigningCertificate returns the Fulcio certificate in the verification material of the
+// Sigstore bundle of a signature created by the handler. It does not verify the bundle.
+func SigningCertificate(signature descruntime.SignatureInfo) (*x509.Certificate, error) {
+	if signature.MediaType != v1alpha1.MediaTypeSigstoreBundle {
+		return nil, fmt.Errorf("unsupported media type %q, expected %q", signature.MediaType, v1alpha1.MediaTypeSigstoreBundle)
 	}
-
-	if v2Err != nil {
-		return bundleCertInfo{}, fmt.Errorf("fulcio certificate: V2 issuer extension (OID %s) present but malformed: %w", sigstoreIssuerV2OID, v2Err)
+	bundleJSON, err := base64.StdEncoding.DecodeString(signature.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decode bundle base64: %w", err)
+	}
+	b, err := bundle.Parse(bundleJSON)
+	if err != nil {
+		return nil, err
 	}
-	return bundleCertInfo{}, fmt.Errorf("fulcio certificate contains no issuer extension (OID %s or %s)", sigstoreIssuerV1OID, sigstoreIssuerV2OID)
+	return b.LeafCertificate()
 }
 
 func writeTemp(dir, pattern string, r io.Reader) (path string, err error) {
@@ -47,3 +47,11 @@ func WithLookPath(fn func(string) (string, error)) HandlerOption {
 		h.runner.LookPath = fn
 	}
 }
+
+// WithCosignVerification delegates verification to cosign verify-blob even if a
+// trusted root is configured, instead of verifying bundles in-process.
+func WithCosignVerification() HandlerOption {
+	return func(h *Handler) {
+		h.cosignVerification = true
+	}
+}
@@ -9,10 +9,12 @@ import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 	"slices"
 	"testing"
 	"time"
@@ -22,6 +24,7 @@ import (
 	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/sigstore/signing/handler/internal"
+	"ocm.software/open-component-model/bindings/go/sigstore/signing/handler/internal/bundle/bundletest"
 	"ocm.software/open-component-model/bindings/go/sigstore/signing/v1alpha1"
 	oidcv1 "ocm.software/open-component-model/bindings/go/sigstore/spec/credentials/oidcidentitytoken/v1alpha1"
 	trustedrootv1 "ocm.software/open-component-model/bindings/go/sigstore/spec/credentials/trustedroot/v1alpha1"
@@ -75,6 +78,7 @@ func newWithRunner(runner *execRecorder, opts ...HandlerOption) *Handler {
 	allOpts := []HandlerOption{
 		WithLookPath(func(string) (string, error) { return "/fake/cosign", nil }),
 		WithExecCosign(runner.exec),
+		WithCosignVerification(),
 	}
 	allOpts = append(allOpts, opts...)
 	return New(allOpts...)
@@ -784,6 +788,97 @@ func TestVerify_UnsupportedMediaType(t *testing.T) {
 	r.Contains(err.Error(), "unsupported media type")
 }
 
+func TestVerify_Native(t *testing.T) {
+	t.Parallel()
+
+	stack := bundletest.NewStack(t)
+	stack.Identity = "user@example.com"
+	stack.Issuer = "https://accounts.google.com"
+	trustedRootJSON := stack.TrustedRoot(t)
+	trustedRootFile := filepath.Join(t.TempDir(), "trusted_root.json")
+	require.NoError(t, os.WriteFile(trustedRootFile, trustedRootJSON, 0o600))
+
+	digestBytes, err := hex.DecodeString(testDigest().Value)
+	require.NoError(t, err)
+	signed := descruntime.Signature{
+		Name:   "test-sig",
+		Digest: testDigest(),
+		Signature: descruntime.SignatureInfo{
+			Algorithm: v1alpha1.AlgorithmSigstore,
+			MediaType: v1alpha1.MediaTypeSigstoreBundle,
+			Value:     base64.StdEncoding.EncodeToString(stack.SignJSON(t, digestBytes)),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		cfgSetup func(cfg *v1alpha1.VerifyConfig)
+		creds    *trustedrootv1.TrustedRoot
+		digest   string
+		wantErr  string
+	}{
+		{
+			name:  "trusted root from inline JSON credential",
+			creds: &trustedrootv1.TrustedRoot{TrustedRootJSON: string(trustedRootJSON)},
+		},
+		{
+			name:  "trusted root from file credential",
+			creds: &trustedrootv1.TrustedRoot{TrustedRootJSONFile: trustedRootFile},
+		},
+		{
+			name: "other identity",
+			cfgSetup: func(cfg *v1alpha1.VerifyConfig) {
+				cfg.CertificateIdentity = "other@example.com"
+			},
+			creds:   &trustedrootv1.TrustedRoot{TrustedRootJSON: string(trustedRootJSON)},
+			wantErr: "none of the certificate identities [user@example.com] matches the expected identity",
+		},
+		{
+			name:    "other digest",
+			creds:   &trustedrootv1.TrustedRoot{TrustedRootJSON: string(trustedRootJSON)},
+			digest:  "0000000000000000000000000000000000000000000000000000000000000000",
+			wantErr: "bundle message digest does not match the artifact",
+		},
+		{
+			name:    "untrusted stack",
+			creds:   &trustedrootv1.TrustedRoot{TrustedRootJSON: string(bundletest.NewStack(t).TrustedRoot(t))},
+			wantErr: "sigstore bundle verification failed",
+		},
+		{
+			name:    "missing trusted root file",
+			creds:   &trustedrootv1.TrustedRoot{TrustedRootJSONFile: filepath.Join(t.TempDir(), "missing.json")},
+			wantErr: "read trusted root file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Native verification never resolves the cosign binary.
+			h := New(WithLookPath(func(string) (string, error) {
+				return "", fmt.Errorf("cosign must not be used")
+			}))
+
+			cfg := testVerifyConfig()
+			if tc.cfgSetup != nil {
+				tc.cfgSetup(cfg)
+			}
+			signed := signed
+			if tc.digest != "" {
+				signed.Digest.Value = tc.digest
+			}
+
+			err := h.Verify(t.Context(), signed, cfg, tc.creds)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 // --- ResolveTrustedRootPath Tests ---
 
 func TestResolveTrustedRootPath(t *testing.T) {
@@ -1100,6 +1195,29 @@ func TestExtractCertInfoFromBundleJSON(t *testing.T) {
 	}
 }
 
+func TestSigningCertificate(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+
+	stack := bundletest.NewStack(t)
+	stack.Identity = "user@example.com"
+	digestBytes, err := hex.DecodeString(testDigest().Value)
+	r.NoError(err)
+	info := descruntime.SignatureInfo{
+		Algorithm: v1alpha1.AlgorithmSigstore,
+		MediaType: v1alpha1.MediaTypeSigstoreBundle,
+		Value:     base64.StdEncoding.EncodeToString(stack.SignJSON(t, digestBytes)),
+	}
+
+	cert, err := SigningCertificate(info)
+	r.NoError(err)
+	r.Equal([]string{"user@example.com"}, cert.EmailAddresses)
+
+	info.MediaType = "application/x-pem-file"
+	_, err = SigningCertificate(info)
+	r.ErrorContains(err, "unsupported media type")
+}
+
 func TestWithOperationTimeout_DeadlineExceeded(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,190 @@
+// Package bundle verifies Sigstore bundles in-process against a Sigstore trusted root,
+// without invoking the cosign CLI.
+//
+// The verification follows the steps of cosign verify-blob for keyless signatures:
+//
+//  1. The bundle is parsed, only message signatures (as produced by cosign sign-blob)
+//     are supported.
+//  2. Transparency log entries are verified against the Rekor keys of the trusted root:
+//     the canonicalized entry must describe the signature, its inclusion proof must
+//     lead to a signed checkpoint and its inclusion promise must be signed by the log.
+//  3. RFC 3161 timestamps are verified against the timestamp authorities of the trusted root.
+//  4. The Fulcio certificate is verified against the certificate authorities of the
+//     trusted root at every verified time (log integration or timestamp), and its
+//     embedded SCTs are verified against the CT logs of the trusted root.
+//  5. The certificate identity and OIDC issuer are matched against the policy.
+//  6. The signature is verified over the artifact with the certificate key.
+//
+// Only hashedrekord v0.0.1 entries of Rekor v1 are supported.
+package bundle
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	mediaTypeBundleV03 = "application/vnd.dev.sigstore.bundle.v0.3+json"
+	mediaTypeBundle    = "application/vnd.dev.sigstore.bundle+json;version="
+)
+
+// Bundle is the JSON encoding of a Sigstore bundle, see
+// https://github.com/sigstore/protobuf-specs/blob/main/protos/sigstore_bundle.proto.
+type Bundle struct {
+	MediaType            string               `json:"mediaType"`
+	VerificationMaterial VerificationMaterial `json:"verificationMaterial"`
+	MessageSignature     *MessageSignature    `json:"messageSignature,omitempty"`
+	DSSEEnvelope         json.RawMessage      `json:"dsseEnvelope,omitempty"`
+}
+
+// VerificationMaterial holds the certificate, transparency log entries and timestamps
+// needed to verify the signature of a bundle.
+type VerificationMaterial struct {
+	Certificate               *RawCertificate            `json:"certificate,omitempty"`
+	X509CertificateChain      *CertificateChain          `json:"x509CertificateChain,omitempty"`
+	TlogEntries               []TransparencyLogEntry     `json:"tlogEntries,omitempty"`
+	TimestampVerificationData *TimestampVerificationData `json:"timestampVerificationData,omitempty"`
+}
+
+// RawCertificate is a DER encoded X.509 certificate.
+type RawCertificate struct {
+	RawBytes []byte `json:"rawBytes"`
+}
+
+// CertificateChain is a chain of certificates, starting with the leaf.
+type CertificateChain struct {
+	Certificates []RawCertificate `json:"certificates"`
+}
+
+// TransparencyLogEntry is an entry of the Rekor transparency log.
+type TransparencyLogEntry struct {
+	LogIndex          Int64             `json:"logIndex"`
+	LogID             LogID             `json:"logId"`
+	KindVersion       KindVersion       `json:"kindVersion"`
+	IntegratedTime    Int64             `json:"integratedTime"`
+	InclusionPromise  *InclusionPromise `json:"inclusionPromise,omitempty"`
+	InclusionProof    *InclusionProof   `json:"inclusionProof,omitempty"`
+	CanonicalizedBody []byte            `json:"canonicalizedBody"`
+}
+
+// LogID identifies a transparency log by the SHA-256 hash of its public key.
+type LogID struct {
+	KeyID []byte `json:"keyId"`
+}
+
+// KindVersion is the Rekor entry type of a transparency log entry.
+type KindVersion struct {
+	Kind    string `json:"kind"`
+	Version string `json:"version"`
+}
+
+// InclusionPromise is the signed entry timestamp returned by Rekor on upload.
+type InclusionPromise struct {
+	SignedEntryTimestamp []byte `json:"signedEntryTimestamp"`
+}
+
+// InclusionProof proves the inclusion of an entry into the log tree at the checkpoint.
+type InclusionProof struct {
+	LogIndex   Int64      `json:"logIndex"`
+	RootHash   []byte     `json:"rootHash"`
+	TreeSize   Int64      `json:"treeSize"`
+	Hashes     [][]byte   `json:"hashes"`
+	Checkpoint Checkpoint `json:"checkpoint"`
+}
+
+// Checkpoint is a signed note committing to the root hash of the log tree.
+type Checkpoint struct {
+	Envelope string `json:"envelope"`
+}
+
+// TimestampVerificationData holds RFC 3161 timestamps over the signature.
+type TimestampVerificationData struct {
+	RFC3161Timestamps []RFC3161Timestamp `json:"rfc3161Timestamps,omitempty"`
+}
+
+// RFC3161Timestamp is a DER encoded RFC 3161 timestamp token.
+type RFC3161Timestamp struct {
+	SignedTimestamp []byte `json:"signedTimestamp"`
+}
+
+// MessageSignature is a signature over an artifact together with the artifact digest.
+type MessageSignature struct {
+	MessageDigest *HashOutput `json:"messageDigest,omitempty"`
+	Signature     []byte      `json:"signature"`
+}
+
+// HashOutput is a digest together with its algorithm, e.g. SHA2_256.
+type HashOutput struct {
+	Algorithm string `json:"algorithm"`
+	Digest    []byte `json:"digest"`
+}
+
+// Int64 is an int64 that is encoded as a JSON string in protobuf JSON, but also
+// accepted as a JSON number.
+type Int64 int64
+
+// MarshalJSON implements json.Marshaler.
+func (i Int64) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.FormatInt(int64(i), 10))), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Int64) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid int64 %s: %w", data, err)
+	}
+	*i = Int64(v)
+	return nil
+}
+
+// Parse decodes a Sigstore bundle and checks that it carries a message signature.
+func Parse(data []byte) (*Bundle, error) {
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("decode bundle: %w", err)
+	}
+	if b.MediaType != mediaTypeBundleV03 && !strings.HasPrefix(b.MediaType, mediaTypeBundle) {
+		return nil, fmt.Errorf("unsupported bundle media type %q", b.MediaType)
+	}
+	if b.MessageSignature == nil {
+		if len(b.DSSEEnvelope) > 0 {
+			return nil, errors.New("bundles with DSSE envelopes are not supported")
+		}
+		return nil, errors.New("bundle has no message signature")
+	}
+	if len(b.MessageSignature.Signature) == 0 {
+		return nil, errors.New("bundle has an empty signature")
+	}
+	return &b, nil
+}
+
+// LeafCertificate returns the signing certificate of the bundle.
+func (b *Bundle) LeafCertificate() (*x509.Certificate, error) {
+	var der []byte
+	switch {
+	case b.VerificationMaterial.Certificate != nil:
+		der = b.VerificationMaterial.Certificate.RawBytes
+	case b.VerificationMaterial.X509CertificateChain != nil && len(b.VerificationMaterial.X509CertificateChain.Certificates) > 0:
+		der = b.VerificationMaterial.X509CertificateChain.Certificates[0].RawBytes
+	}
+	if len(der) == 0 {
+		return nil, errors.New("bundle contains no certificate, only keyless signatures are supported")
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse signing certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// String returns the base64 encoded log ID, as used in error messages.
+func (id LogID) String() string {
+	return base64.StdEncoding.EncodeToString(id.KeyID)
+}
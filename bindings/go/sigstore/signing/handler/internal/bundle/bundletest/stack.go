@@ -0,0 +1,384 @@
+// Package bundletest provides an in-memory Sigstore stack for tests, which issues
+// bundles in the format of cosign sign-blob and the matching trusted root.
+package bundletest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
+	"ocm.software/open-component-model/bindings/go/signing/tsa/tsatest"
+	"ocm.software/open-component-model/bindings/go/sigstore/signing/handler/internal/bundle"
+)
+
+var (
+	oidSCTList  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+	rekorOrigin = "rekor.test"
+)
+
+// Stack is a Fulcio certificate authority with a CT log, a Rekor transparency log
+// and optionally a timestamp authority.
+type Stack struct {
+	// Identity is the email subject alternative name of issued certificates.
+	Identity string
+	// Issuer is the OIDC issuer embedded into issued certificates.
+	Issuer string
+	// Now returns the signing time. Defaults to [time.Now].
+	Now func() time.Time
+	// TSA timestamps signatures if set.
+	TSA *tsatest.Server
+
+	rootCert, intermediateCert *x509.Certificate
+	intermediateKey            *ecdsa.PrivateKey
+	ctlogKey, rekorKey         *ecdsa.PrivateKey
+	leaves                     [][]byte
+	serial                     int64
+}
+
+// NewStack creates a stack whose transparency log already contains some entries.
+func NewStack(t testing.TB) *Stack {
+	t.Helper()
+	s := &Stack{
+		Identity: "signer@example.com",
+		Issuer:   "https://issuer.example.com",
+		Now:      time.Now,
+		leaves:   [][]byte{[]byte("entry-0"), []byte("entry-1"), []byte("entry-2")},
+		serial:   2,
+	}
+	rootKey := generateKey(t)
+	s.rootCert = createCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Fulcio Root"},
+		NotBefore:             time.Now().Add(-365 * 24 * time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, &rootKey.PublicKey, rootKey)
+	s.intermediateKey = generateKey(t)
+	s.intermediateCert = createCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "Test Fulcio Intermediate"},
+		NotBefore:             s.rootCert.NotBefore,
+		NotAfter:              s.rootCert.NotAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}, s.rootCert, &s.intermediateKey.PublicKey, rootKey)
+	s.ctlogKey = generateKey(t)
+	s.rekorKey = generateKey(t)
+	return s
+}
+
+// TrustedRoot returns the trusted root JSON of the stack.
+func (s *Stack) TrustedRoot(t testing.TB) []byte {
+	t.Helper()
+	validFor := &bundle.TimeRange{Start: s.rootCert.NotBefore}
+	root := bundle.TrustedRoot{
+		MediaType: "application/vnd.dev.sigstore.trustedroot+json;version=0.1",
+		Tlogs: []bundle.TransparencyLogInstance{{
+			BaseURL:       "https://" + rekorOrigin,
+			HashAlgorithm: "SHA2_256",
+			PublicKey:     publicKey(t, s.rekorKey, validFor),
+			LogID:         bundle.LogID{KeyID: keyID(t, s.rekorKey)},
+		}},
+		CertificateAuthorities: []bundle.CertificateAuthority{{
+			URI: "https://fulcio.test",
+			CertChain: bundle.CertificateChain{Certificates: []bundle.RawCertificate{
+				{RawBytes: s.intermediateCert.Raw},
+				{RawBytes: s.rootCert.Raw},
+			}},
+			ValidFor: validFor,
+		}},
+		Ctlogs: []bundle.TransparencyLogInstance{{
+			BaseURL:       "https://ctlog.test",
+			HashAlgorithm: "SHA2_256",
+			PublicKey:     publicKey(t, s.ctlogKey, validFor),
+			LogID:         bundle.LogID{KeyID: keyID(t, s.ctlogKey)},
+		}},
+	}
+	if s.TSA != nil {
+		root.TimestampAuthorities = []bundle.CertificateAuthority{{
+			URI:       s.TSA.URL,
+			CertChain: bundle.CertificateChain{Certificates: []bundle.RawCertificate{{RawBytes: s.TSA.Root.Raw}}},
+		}}
+	}
+	data, err := json.Marshal(root)
+	if err != nil {
+		t.Fatalf("encoding trusted root: %v", err)
+	}
+	return data
+}
+
+// Sign signs the artifact with a short-lived certificate, records the signature in
+// the transparency log and returns the bundle.
+func (s *Stack) Sign(t testing.TB, artifact []byte) *bundle.Bundle {
+	t.Helper()
+	now := s.Now().UTC().Truncate(time.Second)
+	key := generateKey(t)
+	cert := s.issueCertificate(t, key, now)
+
+	digest := sha256.Sum256(artifact)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("signing artifact: %v", err)
+	}
+
+	b := &bundle.Bundle{
+		MediaType: "application/vnd.dev.sigstore.bundle.v0.3+json",
+		VerificationMaterial: bundle.VerificationMaterial{
+			Certificate: &bundle.RawCertificate{RawBytes: cert.Raw},
+			TlogEntries: []bundle.TransparencyLogEntry{s.record(t, cert, digest[:], signature, now)},
+		},
+		MessageSignature: &bundle.MessageSignature{
+			MessageDigest: &bundle.HashOutput{Algorithm: "SHA2_256", Digest: digest[:]},
+			Signature:     signature,
+		},
+	}
+	if s.TSA != nil {
+		// The token attests the hash of the signature bytes.
+		timestamp, err := (&tsa.Client{URL: s.TSA.URL}).Timestamp(t.Context(), descruntime.SignatureInfo{Value: string(signature)})
+		if err != nil {
+			t.Fatalf("timestamping signature: %v", err)
+		}
+		token, err := base64.StdEncoding.DecodeString(timestamp.Value)
+		if err != nil {
+			t.Fatalf("decoding timestamp: %v", err)
+		}
+		b.VerificationMaterial.TimestampVerificationData = &bundle.TimestampVerificationData{
+			RFC3161Timestamps: []bundle.RFC3161Timestamp{{SignedTimestamp: token}},
+		}
+	}
+	return b
+}
+
+// SignJSON is like Sign, but returns the JSON encoded bundle.
+func (s *Stack) SignJSON(t testing.TB, artifact []byte) []byte {
+	t.Helper()
+	data, err := json.Marshal(s.Sign(t, artifact))
+	if err != nil {
+		t.Fatalf("encoding bundle: %v", err)
+	}
+	return data
+}
+
+// issueCertificate issues a Fulcio certificate with an SCT of the CT log embedded.
+func (s *Stack) issueCertificate(t testing.TB, key *ecdsa.PrivateKey, now time.Time) *x509.Certificate {
+	t.Helper()
+	issuer, err := asn1.MarshalWithParams(s.Issuer, "utf8")
+	if err != nil {
+		t.Fatalf("encoding issuer: %v", err)
+	}
+	s.serial++
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(s.serial),
+		NotBefore:       now.Add(-time.Minute),
+		NotAfter:        now.Add(10 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		EmailAddresses:  []string{s.Identity},
+		ExtraExtensions: []pkix.Extension{{Id: bundle.OIDIssuerV2, Value: issuer}},
+	}
+	precert := createCertificate(t, template, s.intermediateCert, &key.PublicKey, s.intermediateKey)
+
+	// The SCT signs the precertificate, the certificate without the SCT extension.
+	timestamp := uint64(now.UnixMilli())
+	issuerKeyHash := sha256.Sum256(s.intermediateCert.RawSubjectPublicKeyInfo)
+	tbs := precert.RawTBSCertificate
+	signed := []byte{0, 0}
+	signed = binary.BigEndian.AppendUint64(signed, timestamp)
+	signed = binary.BigEndian.AppendUint16(signed, 1)
+	signed = append(signed, issuerKeyHash[:]...)
+	signed = append(signed, byte(len(tbs)>>16), byte(len(tbs)>>8), byte(len(tbs)))
+	signed = append(signed, tbs...)
+	signed = binary.BigEndian.AppendUint16(signed, 0)
+	sctSignature := sign(t, s.ctlogKey, signed)
+
+	sct := []byte{0}
+	sct = append(sct, keyID(t, s.ctlogKey)...)
+	sct = binary.BigEndian.AppendUint64(sct, timestamp)
+	sct = binary.BigEndian.AppendUint16(sct, 0)
+	sct = append(sct, 4, 3)
+	sct = binary.BigEndian.AppendUint16(sct, uint16(len(sctSignature)))
+	sct = append(sct, sctSignature...)
+	list := binary.BigEndian.AppendUint16(nil, uint16(len(sct)))
+	list = append(list, sct...)
+	list = append(binary.BigEndian.AppendUint16(nil, uint16(len(list))), list...)
+	value, err := asn1.Marshal(list)
+	if err != nil {
+		t.Fatalf("encoding SCT list: %v", err)
+	}
+
+	template.ExtraExtensions = append(template.ExtraExtensions, pkix.Extension{Id: oidSCTList, Value: value})
+	return createCertificate(t, template, s.intermediateCert, &key.PublicKey, s.intermediateKey)
+}
+
+// record appends a hashedrekord entry to the transparency log and returns it with
+// its inclusion proof and promise.
+func (s *Stack) record(t testing.TB, cert *x509.Certificate, digest, signature []byte, now time.Time) bundle.TransparencyLogEntry {
+	t.Helper()
+	var rekord struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+		Spec       struct {
+			Data struct {
+				Hash struct {
+					Algorithm string `json:"algorithm"`
+					Value     string `json:"value"`
+				} `json:"hash"`
+			} `json:"data"`
+			Signature struct {
+				Content   []byte `json:"content"`
+				PublicKey struct {
+					Content []byte `json:"content"`
+				} `json:"publicKey"`
+			} `json:"signature"`
+		} `json:"spec"`
+	}
+	rekord.APIVersion, rekord.Kind = "0.0.1", "hashedrekord"
+	rekord.Spec.Data.Hash.Algorithm = "sha256"
+	rekord.Spec.Data.Hash.Value = hex.EncodeToString(digest)
+	rekord.Spec.Signature.Content = signature
+	rekord.Spec.Signature.PublicKey.Content = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	body, err := json.Marshal(rekord)
+	if err != nil {
+		t.Fatalf("encoding entry: %v", err)
+	}
+
+	s.leaves = append(s.leaves, body)
+	index, size := len(s.leaves)-1, len(s.leaves)
+	rootHash := treeHash(s.leaves)
+	logID := keyID(t, s.rekorKey)
+
+	checkpoint := rekorOrigin + "\n" + strconv.Itoa(size) + "\n" + base64.StdEncoding.EncodeToString(rootHash) + "\n"
+	noteSignature := append(append([]byte{}, logID[:4]...), sign(t, s.rekorKey, []byte(checkpoint))...)
+	checkpoint += "\n— " + rekorOrigin + " " + base64.StdEncoding.EncodeToString(noteSignature) + "\n"
+
+	promise, err := json.Marshal(struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{base64.StdEncoding.EncodeToString(body), now.Unix(), hex.EncodeToString(logID), int64(index)})
+	if err != nil {
+		t.Fatalf("encoding inclusion promise: %v", err)
+	}
+
+	return bundle.TransparencyLogEntry{
+		LogIndex:          bundle.Int64(index),
+		LogID:             bundle.LogID{KeyID: logID},
+		KindVersion:       bundle.KindVersion{Kind: "hashedrekord", Version: "0.0.1"},
+		IntegratedTime:    bundle.Int64(now.Unix()),
+		InclusionPromise:  &bundle.InclusionPromise{SignedEntryTimestamp: sign(t, s.rekorKey, promise)},
+		CanonicalizedBody: body,
+		InclusionProof: &bundle.InclusionProof{
+			LogIndex:   bundle.Int64(index),
+			RootHash:   rootHash,
+			TreeSize:   bundle.Int64(size),
+			Hashes:     inclusionPath(index, s.leaves),
+			Checkpoint: bundle.Checkpoint{Envelope: checkpoint},
+		},
+	}
+}
+
+// treeHash is the Merkle tree hash of RFC 6962, section 2.1.
+func treeHash(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		h := sha256.Sum256(nil)
+		return h[:]
+	case 1:
+		h := sha256.Sum256(append([]byte{0}, leaves[0]...))
+		return h[:]
+	}
+	k := splitPoint(len(leaves))
+	h := sha256.Sum256(append(append([]byte{1}, treeHash(leaves[:k])...), treeHash(leaves[k:])...))
+	return h[:]
+}
+
+// inclusionPath is the Merkle audit path of RFC 6962, section 2.1.1.
+func inclusionPath(m int, leaves [][]byte) [][]byte {
+	if len(leaves) <= 1 {
+		return nil
+	}
+	k := splitPoint(len(leaves))
+	if m < k {
+		return append(inclusionPath(m, leaves[:k]), treeHash(leaves[k:]))
+	}
+	return append(inclusionPath(m-k, leaves[k:]), treeHash(leaves[:k]))
+}
+
+// splitPoint returns the largest power of two smaller than n.
+func splitPoint(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+func generateKey(t testing.TB) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	return key
+}
+
+func sign(t testing.TB, key *ecdsa.PrivateKey, message []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(message)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("signing: %v", err)
+	}
+	return signature
+}
+
+func publicKey(t testing.TB, key *ecdsa.PrivateKey, validFor *bundle.TimeRange) bundle.PublicKey {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("encoding public key: %v", err)
+	}
+	return bundle.PublicKey{RawBytes: der, KeyDetails: "PKIX_ECDSA_P256_SHA_256", ValidFor: validFor}
+}
+
+func keyID(t testing.TB, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	id := sha256.Sum256(publicKey(t, key, nil).RawBytes)
+	return id[:]
+}
+
+func createCertificate(t testing.TB, template, parent *x509.Certificate, pub *ecdsa.PublicKey, signer *ecdsa.PrivateKey) *x509.Certificate {
+	t.Helper()
+	if parent == nil {
+		parent = template
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	return cert
+}
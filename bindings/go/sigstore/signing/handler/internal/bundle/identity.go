@@ -0,0 +1,112 @@
+package bundle
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"regexp"
+)
+
+// Fulcio embeds the OIDC issuer URL in Sigstore bundle certificates as proprietary X.509
+// extensions under the Sigstore PEN (Private Enterprise Number 57264).
+// See https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md for the full OID registry.
+//
+// V1 (OID .1): issuer stored as raw UTF-8 bytes directly in the extension value.
+// V2 (OID .8): issuer stored as an ASN.1 DER-encoded UTF8String (requires asn1.Unmarshal).
+// We prefer V2 and fall back to V1 for compatibility with older Fulcio deployments.
+var (
+	OIDIssuerV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	OIDIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// Policy constrains the identity of the signing certificate. Exact values and
+// regular expressions are alternatives, regular expressions are not anchored,
+// like in cosign.
+type Policy struct {
+	Identity       string
+	IdentityRegexp string
+	Issuer         string
+	IssuerRegexp   string
+}
+
+// OIDCIssuer returns the OIDC issuer embedded into a Fulcio certificate.
+// Tries the V2 issuer OID first, falls back to V1 for older Fulcio deployments.
+func OIDCIssuer(cert *x509.Certificate) (string, error) {
+	var v1Issuer string
+	var v2Err error
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(OIDIssuerV2) {
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer, nil
+			} else {
+				v2Err = err
+			}
+		}
+		if v1Issuer == "" && ext.Id.Equal(OIDIssuerV1) {
+			v1Issuer = string(ext.Value)
+		}
+	}
+
+	if v1Issuer != "" {
+		return v1Issuer, nil
+	}
+
+	if v2Err != nil {
+		return "", fmt.Errorf("fulcio certificate: V2 issuer extension (OID %s) present but malformed: %w", OIDIssuerV2, v2Err)
+	}
+	return "", fmt.Errorf("fulcio certificate contains no issuer extension (OID %s or %s)", OIDIssuerV1, OIDIssuerV2)
+}
+
+// SubjectAlternativeNames returns the email, URI, DNS and IP subject alternative
+// names of the certificate.
+func SubjectAlternativeNames(cert *x509.Certificate) []string {
+	var names []string
+	names = append(names, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	names = append(names, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return names
+}
+
+// verifyIdentity checks the issuer and the subject alternative names of the
+// certificate against the policy. One of the names must match.
+func (p Policy) verifyIdentity(cert *x509.Certificate) error {
+	issuer, err := OIDCIssuer(cert)
+	if err != nil {
+		return err
+	}
+	if ok, err := matches(issuer, p.Issuer, p.IssuerRegexp); err != nil {
+		return fmt.Errorf("match issuer: %w", err)
+	} else if !ok {
+		return fmt.Errorf("certificate issuer %q does not match the expected issuer", issuer)
+	}
+
+	names := SubjectAlternativeNames(cert)
+	for _, name := range names {
+		if ok, err := matches(name, p.Identity, p.IdentityRegexp); err != nil {
+			return fmt.Errorf("match identity: %w", err)
+		} else if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("none of the certificate identities %v matches the expected identity", names)
+}
+
+func matches(value, exact, expr string) (bool, error) {
+	if exact != "" {
+		return value == exact, nil
+	}
+	if expr == "" {
+		return false, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(value), nil
+}
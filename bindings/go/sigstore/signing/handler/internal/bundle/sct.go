@@ -0,0 +1,212 @@
+package bundle
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// oidSCTList is the X.509 extension carrying the signed certificate timestamps
+// embedded into a certificate, see RFC 6962, section 3.3.
+var oidSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+const (
+	sctVersionV1           = 0
+	sctSignatureTypeCert   = 0
+	sctEntryTypePrecert    = 1
+	tlsHashAlgorithmSHA256 = 4
+	tlsHashAlgorithmSHA384 = 5
+	tlsHashAlgorithmSHA512 = 6
+	sctLogIDLength         = sha256.Size
+)
+
+// signedCertificateTimestamp is a promise of a CT log to include a precertificate.
+type signedCertificateTimestamp struct {
+	logID         []byte
+	timestamp     uint64
+	extensions    []byte
+	hashAlgorithm byte
+	signature     []byte
+}
+
+// verifySCTs verifies that at least one of the SCTs embedded into cert is signed by
+// a CT log of the trusted root. The SCTs sign the precertificate, which is the
+// certificate without the SCT extension, issued by issuer.
+func verifySCTs(cert, issuer *x509.Certificate, logs []TransparencyLogInstance) error {
+	var value []byte
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidSCTList) {
+			value = ext.Value
+		}
+	}
+	if value == nil {
+		return errors.New("certificate has no embedded SCTs")
+	}
+	var list []byte
+	if _, err := asn1.Unmarshal(value, &list); err != nil {
+		return fmt.Errorf("decode SCT list: %w", err)
+	}
+	scts, err := parseSCTList(list)
+	if err != nil {
+		return err
+	}
+	tbs, err := removeExtension(cert.RawTBSCertificate, oidSCTList)
+	if err != nil {
+		return fmt.Errorf("reconstruct precertificate: %w", err)
+	}
+	issuerKeyHash := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+
+	var errs []error
+	for _, sct := range scts {
+		timestamp := time.UnixMilli(int64(sct.timestamp))
+		log, err := findLog(logs, sct.logID, timestamp)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		hash, err := tlsHash(sct.hashAlgorithm)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := verifySignature(log.key, hash, sct.signedData(issuerKeyHash[:], tbs), sct.signature); err != nil {
+			errs = append(errs, fmt.Errorf("SCT of log %s: %w", log.LogID, err))
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("no valid SCT: %w", errors.Join(errs...))
+}
+
+// signedData returns the data signed by the log for a precertificate entry,
+// see RFC 6962, section 3.2.
+func (s signedCertificateTimestamp) signedData(issuerKeyHash, tbs []byte) []byte {
+	data := []byte{sctVersionV1, sctSignatureTypeCert}
+	data = binary.BigEndian.AppendUint64(data, s.timestamp)
+	data = binary.BigEndian.AppendUint16(data, sctEntryTypePrecert)
+	data = append(data, issuerKeyHash...)
+	data = append(data, byte(len(tbs)>>16), byte(len(tbs)>>8), byte(len(tbs)))
+	data = append(data, tbs...)
+	data = binary.BigEndian.AppendUint16(data, uint16(len(s.extensions)))
+	return append(data, s.extensions...)
+}
+
+// parseSCTList decodes the TLS encoded SignedCertificateTimestampList.
+func parseSCTList(data []byte) ([]signedCertificateTimestamp, error) {
+	r := &tlsReader{data: data}
+	list := &tlsReader{data: r.vector(2)}
+	var scts []signedCertificateTimestamp
+	for r.err == nil && list.err == nil && len(list.data) > 0 {
+		entry := &tlsReader{data: list.vector(2)}
+		if version := entry.bytes(1); entry.err == nil && version[0] != sctVersionV1 {
+			return nil, fmt.Errorf("unsupported SCT version %d", version[0])
+		}
+		sct := signedCertificateTimestamp{
+			logID:      entry.bytes(sctLogIDLength),
+			timestamp:  binary.BigEndian.Uint64(entry.bytes(8)),
+			extensions: entry.vector(2),
+		}
+		sct.hashAlgorithm = entry.bytes(1)[0]
+		entry.bytes(1) // signature algorithm, implied by the log key
+		sct.signature = entry.vector(2)
+		if entry.err != nil {
+			return nil, fmt.Errorf("decode SCT: %w", entry.err)
+		}
+		scts = append(scts, sct)
+	}
+	if err := errors.Join(r.err, list.err); err != nil {
+		return nil, fmt.Errorf("decode SCT list: %w", err)
+	}
+	return scts, nil
+}
+
+// removeExtension re-encodes a TBSCertificate without the extension with the given OID.
+func removeExtension(rawTBS []byte, oid asn1.ObjectIdentifier) ([]byte, error) {
+	var tbs asn1.RawValue
+	if _, err := asn1.Unmarshal(rawTBS, &tbs); err != nil {
+		return nil, err
+	}
+	var fields []byte
+	for rest := tbs.Bytes; len(rest) > 0; {
+		var field asn1.RawValue
+		var err error
+		if rest, err = asn1.Unmarshal(rest, &field); err != nil {
+			return nil, err
+		}
+		// The extensions are the explicitly [3] tagged last field.
+		if field.Class == asn1.ClassContextSpecific && field.Tag == 3 {
+			var extensions asn1.RawValue
+			if _, err := asn1.Unmarshal(field.Bytes, &extensions); err != nil {
+				return nil, err
+			}
+			var kept []byte
+			for extRest := extensions.Bytes; len(extRest) > 0; {
+				var ext pkix.Extension
+				remaining, err := asn1.Unmarshal(extRest, &ext)
+				if err != nil {
+					return nil, err
+				}
+				if !ext.Id.Equal(oid) {
+					kept = append(kept, extRest[:len(extRest)-len(remaining)]...)
+				}
+				extRest = remaining
+			}
+			sequence, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence, IsCompound: true, Bytes: kept})
+			if err != nil {
+				return nil, err
+			}
+			if field.FullBytes, err = asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 3, IsCompound: true, Bytes: sequence}); err != nil {
+				return nil, err
+			}
+		}
+		fields = append(fields, field.FullBytes...)
+	}
+	return asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence, IsCompound: true, Bytes: fields})
+}
+
+func tlsHash(algorithm byte) (crypto.Hash, error) {
+	switch algorithm {
+	case tlsHashAlgorithmSHA256:
+		return crypto.SHA256, nil
+	case tlsHashAlgorithmSHA384:
+		return crypto.SHA384, nil
+	case tlsHashAlgorithmSHA512:
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported SCT hash algorithm %d", algorithm)
+	}
+}
+
+// tlsReader reads the TLS presentation language encoding of RFC 5246, section 4.
+// Reading past the end sets err and returns zeroed data.
+type tlsReader struct {
+	data []byte
+	err  error
+}
+
+func (r *tlsReader) bytes(n int) []byte {
+	if r.err != nil || len(r.data) < n {
+		if r.err == nil {
+			r.err = errors.New("unexpected end of data")
+		}
+		return make([]byte, n)
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+// vector reads a variable length vector with a length prefix of lengthBytes.
+func (r *tlsReader) vector(lengthBytes int) []byte {
+	var n int
+	for _, b := range r.bytes(lengthBytes) {
+		n = n<<8 | int(b)
+	}
+	return r.bytes(n)
+}
@@ -0,0 +1,240 @@
+package bundle
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	kindHashedRekord               = "hashedrekord"
+	versionHashedRekord            = "0.0.1"
+	checkpointSignaturePrefix      = "— "
+	checkpointKeyHintLength        = 4
+	merkleLeafHashPrefix      byte = 0x00
+	merkleNodeHashPrefix      byte = 0x01
+)
+
+// hashedRekord is the canonicalized body of a hashedrekord v0.0.1 entry.
+type hashedRekord struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Spec       struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content   []byte `json:"content"`
+			PublicKey struct {
+				Content []byte `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// verifyTlogEntry verifies that the entry records the signature over digest made with
+// cert, that it is included in the log and that the log promised its inclusion.
+// It returns the integration time if it is attested by a verified inclusion promise,
+// and the zero time otherwise.
+func verifyTlogEntry(entry TransparencyLogEntry, root *TrustedRoot, hash crypto.Hash, digest, signature []byte, cert *x509.Certificate) (time.Time, error) {
+	if entry.KindVersion.Kind != kindHashedRekord || entry.KindVersion.Version != versionHashedRekord {
+		return time.Time{}, fmt.Errorf("unsupported entry kind %s/%s, only %s/%s is supported",
+			entry.KindVersion.Kind, entry.KindVersion.Version, kindHashedRekord, versionHashedRekord)
+	}
+	integratedTime := time.Unix(int64(entry.IntegratedTime), 0).UTC()
+	log, err := findLog(root.Tlogs, entry.LogID.KeyID, integratedTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := verifyHashedRekord(entry.CanonicalizedBody, hash, digest, signature, cert); err != nil {
+		return time.Time{}, err
+	}
+	if entry.InclusionProof == nil && entry.InclusionPromise == nil {
+		return time.Time{}, errors.New("entry has neither an inclusion proof nor an inclusion promise")
+	}
+	if entry.InclusionProof != nil {
+		if err := verifyInclusionProof(entry.InclusionProof, entry.CanonicalizedBody, log); err != nil {
+			return time.Time{}, fmt.Errorf("inclusion proof: %w", err)
+		}
+	}
+	if entry.InclusionPromise == nil {
+		return time.Time{}, nil
+	}
+	if err := verifyInclusionPromise(entry, log); err != nil {
+		return time.Time{}, fmt.Errorf("inclusion promise: %w", err)
+	}
+	return integratedTime, nil
+}
+
+// verifyHashedRekord checks that the canonicalized body describes the signature.
+func verifyHashedRekord(body []byte, hash crypto.Hash, digest, signature []byte, cert *x509.Certificate) error {
+	var rekord hashedRekord
+	if err := json.Unmarshal(body, &rekord); err != nil {
+		return fmt.Errorf("decode canonicalized body: %w", err)
+	}
+	if rekord.Kind != kindHashedRekord || rekord.APIVersion != versionHashedRekord {
+		return fmt.Errorf("canonicalized body is of kind %s/%s", rekord.Kind, rekord.APIVersion)
+	}
+	if algorithm := rekordHashAlgorithm(hash); rekord.Spec.Data.Hash.Algorithm != algorithm {
+		return fmt.Errorf("entry hash algorithm %q does not match %q", rekord.Spec.Data.Hash.Algorithm, algorithm)
+	}
+	if !strings.EqualFold(rekord.Spec.Data.Hash.Value, hex.EncodeToString(digest)) {
+		return errors.New("entry does not record the artifact digest")
+	}
+	if !bytes.Equal(rekord.Spec.Signature.Content, signature) {
+		return errors.New("entry does not record the bundle signature")
+	}
+	block, _ := pem.Decode(rekord.Spec.Signature.PublicKey.Content)
+	if block == nil || !bytes.Equal(block.Bytes, cert.Raw) {
+		return errors.New("entry does not record the signing certificate")
+	}
+	return nil
+}
+
+// verifyInclusionProof verifies the Merkle inclusion proof of the entry and the
+// checkpoint that signs the root hash, see RFC 9162, section 2.1.3.2.
+func verifyInclusionProof(proof *InclusionProof, body []byte, log *TransparencyLogInstance) error {
+	index, size := uint64(proof.LogIndex), uint64(proof.TreeSize)
+	if index >= size {
+		return fmt.Errorf("index %d is outside of the tree of size %d", index, size)
+	}
+
+	root := leafHash(body)
+	fn, sn := index, size-1
+	for _, p := range proof.Hashes {
+		if sn == 0 {
+			return errors.New("proof is too long")
+		}
+		if fn&1 == 1 || fn == sn {
+			root = nodeHash(p, root)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			root = nodeHash(root, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return errors.New("proof is too short")
+	}
+	if !bytes.Equal(root, proof.RootHash) {
+		return errors.New("calculated root hash does not match the proof")
+	}
+
+	checkpointSize, checkpointRoot, err := verifyCheckpoint(proof.Checkpoint.Envelope, log)
+	if err != nil {
+		return fmt.Errorf("checkpoint: %w", err)
+	}
+	if checkpointSize != size || !bytes.Equal(checkpointRoot, proof.RootHash) {
+		return errors.New("checkpoint does not commit to the proof root hash")
+	}
+	return nil
+}
+
+// verifyCheckpoint verifies a checkpoint in the signed note format and returns the
+// tree size and root hash it commits to. The note is signed with the log key, whose
+// hint is the prefix of the log ID.
+func verifyCheckpoint(envelope string, log *TransparencyLogInstance) (uint64, []byte, error) {
+	text, signatures, ok := strings.Cut(envelope, "\n\n")
+	if !ok {
+		return 0, nil, errors.New("missing signatures")
+	}
+	text += "\n"
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if len(lines) < 3 {
+		return 0, nil, errors.New("expected origin, tree size and root hash")
+	}
+	size, err := strconv.ParseUint(lines[1], 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid tree size: %w", err)
+	}
+	rootHash, err := base64.StdEncoding.DecodeString(lines[2])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid root hash: %w", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(signatures, "\n"), "\n") {
+		line, ok := strings.CutPrefix(line, checkpointSignaturePrefix)
+		if !ok {
+			return 0, nil, fmt.Errorf("malformed signature line %q", line)
+		}
+		_, encoded, ok := strings.Cut(line, " ")
+		if !ok {
+			return 0, nil, fmt.Errorf("malformed signature line %q", line)
+		}
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(signature) <= checkpointKeyHintLength {
+			return 0, nil, fmt.Errorf("malformed signature line %q", line)
+		}
+		if !bytes.HasPrefix(log.LogID.KeyID, signature[:checkpointKeyHintLength]) {
+			continue
+		}
+		if err := verifySignature(log.key, crypto.SHA256, []byte(text), signature[checkpointKeyHintLength:]); err != nil {
+			return 0, nil, err
+		}
+		return size, rootHash, nil
+	}
+	return 0, nil, fmt.Errorf("not signed by log %s", log.LogID)
+}
+
+// verifyInclusionPromise verifies the signed entry timestamp, a signature of the log
+// over the canonical JSON of the entry body, integration time, log ID and index.
+func verifyInclusionPromise(entry TransparencyLogEntry, log *TransparencyLogInstance) error {
+	payload, err := json.Marshal(struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{
+		Body:           base64.StdEncoding.EncodeToString(entry.CanonicalizedBody),
+		IntegratedTime: int64(entry.IntegratedTime),
+		LogID:          hex.EncodeToString(entry.LogID.KeyID),
+		LogIndex:       int64(entry.LogIndex),
+	})
+	if err != nil {
+		return err
+	}
+	return verifySignature(log.key, crypto.SHA256, payload, entry.InclusionPromise.SignedEntryTimestamp)
+}
+
+func leafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{merkleLeafHashPrefix})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{merkleNodeHashPrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+func rekordHashAlgorithm(hash crypto.Hash) string {
+	switch hash {
+	case crypto.SHA384:
+		return "sha384"
+	case crypto.SHA512:
+		return "sha512"
+	default:
+		return "sha256"
+	}
+}
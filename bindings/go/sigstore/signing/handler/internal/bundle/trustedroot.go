@@ -0,0 +1,181 @@
+package bundle
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TrustedRoot is the JSON encoding of a Sigstore trusted root, see
+// https://github.com/sigstore/protobuf-specs/blob/main/protos/sigstore_trustroot.proto.
+type TrustedRoot struct {
+	MediaType              string                    `json:"mediaType"`
+	Tlogs                  []TransparencyLogInstance `json:"tlogs,omitempty"`
+	CertificateAuthorities []CertificateAuthority    `json:"certificateAuthorities,omitempty"`
+	Ctlogs                 []TransparencyLogInstance `json:"ctlogs,omitempty"`
+	TimestampAuthorities   []CertificateAuthority    `json:"timestampAuthorities,omitempty"`
+}
+
+// TransparencyLogInstance is a Rekor or CT log trusted to sign entries with its key.
+type TransparencyLogInstance struct {
+	BaseURL       string    `json:"baseUrl,omitempty"`
+	HashAlgorithm string    `json:"hashAlgorithm,omitempty"`
+	PublicKey     PublicKey `json:"publicKey"`
+	LogID         LogID     `json:"logId"`
+
+	key crypto.PublicKey
+}
+
+// PublicKey is a DER encoded PKIX public key.
+type PublicKey struct {
+	RawBytes   []byte     `json:"rawBytes"`
+	KeyDetails string     `json:"keyDetails,omitempty"`
+	ValidFor   *TimeRange `json:"validFor,omitempty"`
+}
+
+// CertificateAuthority is a Fulcio or timestamp authority trusted to issue certificates.
+// The chain starts with the issuing certificate and ends with the root.
+type CertificateAuthority struct {
+	URI       string           `json:"uri,omitempty"`
+	CertChain CertificateChain `json:"certChain"`
+	ValidFor  *TimeRange       `json:"validFor,omitempty"`
+
+	roots         *x509.CertPool
+	intermediates *x509.CertPool
+}
+
+// TimeRange is the period in which trust material is valid. An unset end means
+// that the material is still valid.
+type TimeRange struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// ParseTrustedRoot decodes a trusted root and its keys and certificates.
+func ParseTrustedRoot(data []byte) (*TrustedRoot, error) {
+	var root TrustedRoot
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("decode trusted root: %w", err)
+	}
+	for i := range root.Tlogs {
+		if err := root.Tlogs[i].parse(); err != nil {
+			return nil, fmt.Errorf("transparency log %q: %w", root.Tlogs[i].BaseURL, err)
+		}
+	}
+	for i := range root.Ctlogs {
+		if err := root.Ctlogs[i].parse(); err != nil {
+			return nil, fmt.Errorf("CT log %q: %w", root.Ctlogs[i].BaseURL, err)
+		}
+	}
+	for i := range root.CertificateAuthorities {
+		if err := root.CertificateAuthorities[i].parse(); err != nil {
+			return nil, fmt.Errorf("certificate authority %q: %w", root.CertificateAuthorities[i].URI, err)
+		}
+	}
+	for i := range root.TimestampAuthorities {
+		if err := root.TimestampAuthorities[i].parse(); err != nil {
+			return nil, fmt.Errorf("timestamp authority %q: %w", root.TimestampAuthorities[i].URI, err)
+		}
+	}
+	return &root, nil
+}
+
+func (l *TransparencyLogInstance) parse() error {
+	key, err := x509.ParsePKIXPublicKey(l.PublicKey.RawBytes)
+	if err != nil {
+		return fmt.Errorf("parse public key: %w", err)
+	}
+	l.key = key
+	// The log ID is the SHA-256 hash of the public key and is derived if unset.
+	if len(l.LogID.KeyID) == 0 {
+		id := sha256.Sum256(l.PublicKey.RawBytes)
+		l.LogID.KeyID = id[:]
+	}
+	return nil
+}
+
+func (ca *CertificateAuthority) parse() error {
+	certs := ca.CertChain.Certificates
+	if len(certs) == 0 {
+		return errors.New("empty certificate chain")
+	}
+	ca.roots = x509.NewCertPool()
+	ca.intermediates = x509.NewCertPool()
+	for i, raw := range certs {
+		cert, err := x509.ParseCertificate(raw.RawBytes)
+		if err != nil {
+			return fmt.Errorf("parse certificate %d: %w", i, err)
+		}
+		if i == len(certs)-1 {
+			ca.roots.AddCert(cert)
+		} else {
+			ca.intermediates.AddCert(cert)
+		}
+	}
+	return nil
+}
+
+// findLog returns the log with the given ID whose key is valid at t.
+func findLog(logs []TransparencyLogInstance, id []byte, t time.Time) (*TransparencyLogInstance, error) {
+	for i := range logs {
+		if !bytes.Equal(logs[i].LogID.KeyID, id) {
+			continue
+		}
+		if !logs[i].PublicKey.ValidFor.contains(t) {
+			return nil, fmt.Errorf("key of log %s is not valid at %s", LogID{KeyID: id}, t)
+		}
+		return &logs[i], nil
+	}
+	return nil, fmt.Errorf("log %s is not in the trusted root", LogID{KeyID: id})
+}
+
+func (r *TimeRange) contains(t time.Time) bool {
+	if r == nil {
+		return true
+	}
+	if t.Before(r.Start) {
+		return false
+	}
+	return r.End == nil || !t.After(*r.End)
+}
+
+// verifySignature verifies a signature over message with key. ECDSA and RSA
+// signatures are calculated over the hash of the message, Ed25519 signatures
+// over the message itself.
+func verifySignature(key crypto.PublicKey, hash crypto.Hash, message, signature []byte) error {
+	if k, ok := key.(ed25519.PublicKey); ok {
+		if !ed25519.Verify(k, message, signature) {
+			return errors.New("invalid Ed25519 signature")
+		}
+		return nil
+	}
+	h := hash.New()
+	h.Write(message)
+	return verifyDigestSignature(key, hash, h.Sum(nil), signature)
+}
+
+// verifyDigestSignature verifies an ECDSA or RSA PKCS #1 v1.5 signature over a digest.
+func verifyDigestSignature(key crypto.PublicKey, hash crypto.Hash, digest, signature []byte) error {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest, signature) {
+			return errors.New("invalid ECDSA signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, hash, digest, signature); err != nil {
+			return fmt.Errorf("invalid RSA signature: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
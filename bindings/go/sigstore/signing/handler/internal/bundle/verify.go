@@ -0,0 +1,172 @@
+package bundle
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
+)
+
+// Options configure the verification of a bundle.
+type Options struct {
+	Policy
+	// IgnoreTlog skips the transparency log verification for signatures made
+	// against private infrastructure, like cosign --private-infrastructure.
+	// The certificate is then verified at the time of a trusted timestamp, or
+	// at the current time if the bundle has none.
+	IgnoreTlog bool
+	// Now returns the current time. Defaults to [time.Now].
+	Now func() time.Time
+}
+
+// Verify verifies the message signature of the bundle over artifact against the
+// trust material of root, see the package documentation for the individual steps.
+func Verify(b *Bundle, artifact []byte, root *TrustedRoot, opts Options) error {
+	cert, err := b.LeafCertificate()
+	if err != nil {
+		return err
+	}
+	hash, err := messageDigestHash(b.MessageSignature.MessageDigest)
+	if err != nil {
+		return err
+	}
+	h := hash.New()
+	h.Write(artifact)
+	digest := h.Sum(nil)
+	if md := b.MessageSignature.MessageDigest; md != nil && !bytes.Equal(md.Digest, digest) {
+		return errors.New("bundle message digest does not match the artifact")
+	}
+	signature := b.MessageSignature.Signature
+
+	var verifiedTimes []time.Time
+	if !opts.IgnoreTlog {
+		if len(b.VerificationMaterial.TlogEntries) == 0 {
+			return errors.New("bundle has no transparency log entry")
+		}
+		for i, entry := range b.VerificationMaterial.TlogEntries {
+			integratedTime, err := verifyTlogEntry(entry, root, hash, digest, signature, cert)
+			if err != nil {
+				return fmt.Errorf("transparency log entry %d: %w", i, err)
+			}
+			if !integratedTime.IsZero() {
+				verifiedTimes = append(verifiedTimes, integratedTime)
+			}
+		}
+	}
+
+	timestamps, timestampErrs := verifyTimestamps(b.VerificationMaterial.TimestampVerificationData, signature, root)
+	verifiedTimes = append(verifiedTimes, timestamps...)
+	if len(verifiedTimes) == 0 {
+		if !opts.IgnoreTlog {
+			return fmt.Errorf("bundle has neither an inclusion promise nor a trusted timestamp: %w", errors.Join(timestampErrs...))
+		}
+		now := opts.Now
+		if now == nil {
+			now = time.Now
+		}
+		verifiedTimes = append(verifiedTimes, now())
+	}
+
+	var issuer *x509.Certificate
+	for _, t := range verifiedTimes {
+		if issuer, err = verifyCertificate(cert, root, t); err != nil {
+			return err
+		}
+	}
+	if len(root.Ctlogs) > 0 {
+		if err := verifySCTs(cert, issuer, root.Ctlogs); err != nil {
+			return fmt.Errorf("certificate transparency: %w", err)
+		}
+	}
+	if err := opts.verifyIdentity(cert); err != nil {
+		return err
+	}
+	if err := verifySignature(cert.PublicKey, hash, artifact, signature); err != nil {
+		return fmt.Errorf("verify signature: %w", err)
+	}
+	return nil
+}
+
+// verifyCertificate verifies the signing certificate against the certificate
+// authorities of the trusted root at t and returns its issuer.
+func verifyCertificate(cert *x509.Certificate, root *TrustedRoot, t time.Time) (*x509.Certificate, error) {
+	var errs []error
+	for _, ca := range root.CertificateAuthorities {
+		if !ca.ValidFor.contains(t) {
+			continue
+		}
+		chains, err := cert.Verify(x509.VerifyOptions{
+			Roots:         ca.roots,
+			Intermediates: ca.intermediates,
+			CurrentTime:   t,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if chain := chains[0]; len(chain) > 1 {
+			return chain[1], nil
+		}
+		return cert, nil
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no certificate authority of the trusted root is valid at %s", t)
+	}
+	return nil, fmt.Errorf("verify signing certificate at %s: %w", t, errors.Join(errs...))
+}
+
+// verifyTimestamps returns the times attested by the RFC 3161 timestamps over the
+// signature that are issued by a timestamp authority of the trusted root. Timestamps
+// of unknown authorities are not an error by themselves, their errors are returned
+// for the case that no other time can be verified.
+func verifyTimestamps(data *TimestampVerificationData, signature []byte, root *TrustedRoot) ([]time.Time, []error) {
+	if data == nil {
+		return nil, nil
+	}
+	var times []time.Time
+	var errs []error
+	for i, timestamp := range data.RFC3161Timestamps {
+		var tsaErrs []error
+		for _, authority := range root.TimestampAuthorities {
+			t, err := tsa.VerifyToken(timestamp.SignedTimestamp, signature, x509.VerifyOptions{
+				Roots:         authority.roots,
+				Intermediates: authority.intermediates,
+			})
+			if err == nil && !authority.ValidFor.contains(t) {
+				err = fmt.Errorf("timestamp authority %q is not valid at %s", authority.URI, t)
+			}
+			if err != nil {
+				tsaErrs = append(tsaErrs, err)
+				continue
+			}
+			times = append(times, t)
+			tsaErrs = nil
+			break
+		}
+		if len(tsaErrs) > 0 {
+			errs = append(errs, fmt.Errorf("timestamp %d: %w", i, errors.Join(tsaErrs...)))
+		}
+	}
+	return times, errs
+}
+
+func messageDigestHash(digest *HashOutput) (crypto.Hash, error) {
+	if digest == nil {
+		return crypto.SHA256, nil
+	}
+	switch digest.Algorithm {
+	case "SHA2_256":
+		return crypto.SHA256, nil
+	case "SHA2_384":
+		return crypto.SHA384, nil
+	case "SHA2_512":
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported message digest algorithm %q", digest.Algorithm)
+	}
+}
@@ -0,0 +1,201 @@
+package bundle_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/signing/tsa/tsatest"
+	"ocm.software/open-component-model/bindings/go/sigstore/signing/handler/internal/bundle"
+	"ocm.software/open-component-model/bindings/go/sigstore/signing/handler/internal/bundle/bundletest"
+)
+
+var artifact = []byte("digest of the component descriptor")
+
+func trustedRoot(t *testing.T, stack *bundletest.Stack) *bundle.TrustedRoot {
+	t.Helper()
+	root, err := bundle.ParseTrustedRoot(stack.TrustedRoot(t))
+	require.NoError(t, err)
+	return root
+}
+
+func policy(stack *bundletest.Stack) bundle.Options {
+	return bundle.Options{Policy: bundle.Policy{Identity: stack.Identity, Issuer: stack.Issuer}}
+}
+
+func TestVerify(t *testing.T) {
+	stack := bundletest.NewStack(t)
+	root := trustedRoot(t, stack)
+
+	t.Run("valid bundle", func(t *testing.T) {
+		b, err := bundle.Parse(stack.SignJSON(t, artifact))
+		require.NoError(t, err)
+		require.NoError(t, bundle.Verify(b, artifact, root, policy(stack)))
+	})
+
+	t.Run("identity and issuer regular expressions", func(t *testing.T) {
+		opts := bundle.Options{Policy: bundle.Policy{IdentityRegexp: "@example\\.com$", IssuerRegexp: "issuer\\.example"}}
+		require.NoError(t, bundle.Verify(stack.Sign(t, artifact), artifact, root, opts))
+	})
+
+	t.Run("certificate expired after signing", func(t *testing.T) {
+		past := bundletest.NewStack(t)
+		past.Now = func() time.Time { return time.Now().Add(-48 * time.Hour) }
+		require.NoError(t, bundle.Verify(past.Sign(t, artifact), artifact, trustedRoot(t, past), policy(past)))
+	})
+
+	t.Run("timestamp instead of inclusion promise", func(t *testing.T) {
+		timestamped := bundletest.NewStack(t)
+		timestamped.TSA = tsatest.NewServer(t)
+		b := timestamped.Sign(t, artifact)
+		b.VerificationMaterial.TlogEntries[0].InclusionPromise = nil
+		require.NoError(t, bundle.Verify(b, artifact, trustedRoot(t, timestamped), policy(timestamped)))
+	})
+
+	t.Run("private infrastructure without transparency log", func(t *testing.T) {
+		b := stack.Sign(t, artifact)
+		b.VerificationMaterial.TlogEntries = nil
+		opts := policy(stack)
+		require.ErrorContains(t, bundle.Verify(b, artifact, root, opts), "bundle has no transparency log entry")
+		opts.IgnoreTlog = true
+		require.NoError(t, bundle.Verify(b, artifact, root, opts))
+	})
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	stack := bundletest.NewStack(t)
+	root := trustedRoot(t, stack)
+
+	tests := []struct {
+		name     string
+		mutate   func(*bundle.Bundle)
+		artifact []byte
+		opts     func(*bundle.Options)
+		root     *bundle.TrustedRoot
+		wantErr  string
+	}{
+		{
+			name:     "other artifact",
+			artifact: []byte("other artifact"),
+			wantErr:  "bundle message digest does not match the artifact",
+		},
+		{
+			name:    "other identity",
+			opts:    func(o *bundle.Options) { o.Identity = "attacker@example.com" },
+			wantErr: "none of the certificate identities [signer@example.com] matches the expected identity",
+		},
+		{
+			name:    "other issuer",
+			opts:    func(o *bundle.Options) { o.Issuer = "https://accounts.google.com" },
+			wantErr: `certificate issuer "https://issuer.example.com" does not match the expected issuer`,
+		},
+		{
+			name:    "signature replaced",
+			mutate:  func(b *bundle.Bundle) { b.MessageSignature.Signature[len(b.MessageSignature.Signature)-1] ^= 0xff },
+			wantErr: "entry does not record the bundle signature",
+		},
+		{
+			name: "inclusion proof tampered",
+			mutate: func(b *bundle.Bundle) {
+				b.VerificationMaterial.TlogEntries[0].InclusionProof.Hashes[0][0] ^= 0xff
+			},
+			wantErr: "calculated root hash does not match the proof",
+		},
+		{
+			name: "checkpoint signature tampered",
+			mutate: func(b *bundle.Bundle) {
+				proof := b.VerificationMaterial.TlogEntries[0].InclusionProof
+				proof.Checkpoint.Envelope = "other.origin" + proof.Checkpoint.Envelope[len("rekor.test"):]
+			},
+			wantErr: "checkpoint: invalid ECDSA signature",
+		},
+		{
+			name:    "integration time modified",
+			mutate:  func(b *bundle.Bundle) { b.VerificationMaterial.TlogEntries[0].IntegratedTime -= 60 },
+			wantErr: "inclusion promise: invalid ECDSA signature",
+		},
+		{
+			name: "neither inclusion promise nor timestamp",
+			mutate: func(b *bundle.Bundle) {
+				b.VerificationMaterial.TlogEntries[0].InclusionPromise = nil
+			},
+			wantErr: "bundle has neither an inclusion promise nor a trusted timestamp",
+		},
+		{
+			name: "unsupported entry kind",
+			mutate: func(b *bundle.Bundle) {
+				b.VerificationMaterial.TlogEntries[0].KindVersion = bundle.KindVersion{Kind: "hashedrekord", Version: "0.0.2"}
+			},
+			wantErr: "unsupported entry kind hashedrekord/0.0.2",
+		},
+		{
+			name:    "untrusted stack",
+			root:    trustedRoot(t, bundletest.NewStack(t)),
+			wantErr: "is not in the trusted root",
+		},
+		{
+			name: "untrusted CT log",
+			root: func() *bundle.TrustedRoot {
+				root := trustedRoot(t, stack)
+				root.Ctlogs = root.Tlogs
+				return root
+			}(),
+			wantErr: "certificate transparency: no valid SCT",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := stack.Sign(t, artifact)
+			if tc.mutate != nil {
+				tc.mutate(b)
+			}
+			a := artifact
+			if tc.artifact != nil {
+				a = tc.artifact
+			}
+			opts := policy(stack)
+			if tc.opts != nil {
+				tc.opts(&opts)
+			}
+			r := root
+			if tc.root != nil {
+				r = tc.root
+			}
+			require.ErrorContains(t, bundle.Verify(b, a, r, opts), tc.wantErr)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	stack := bundletest.NewStack(t)
+	data := stack.SignJSON(t, artifact)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	entry := raw["verificationMaterial"].(map[string]any)["tlogEntries"].([]any)[0].(map[string]any)
+	require.IsType(t, "", entry["logIndex"], "int64 values are encoded as strings like in protobuf JSON")
+
+	// Int64 values are also accepted as JSON numbers.
+	entry["integratedTime"] = json.Number(entry["integratedTime"].(string))
+	data, err := json.Marshal(raw)
+	require.NoError(t, err)
+	b, err := bundle.Parse(data)
+	require.NoError(t, err)
+	require.NoError(t, bundle.Verify(b, artifact, trustedRoot(t, stack), policy(stack)))
+
+	for name, tc := range map[string]struct {
+		bundle  string
+		wantErr string
+	}{
+		"media type":      {`{"mediaType":"application/json"}`, `unsupported bundle media type "application/json"`},
+		"DSSE envelope":   {`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json","dsseEnvelope":{}}`, "bundles with DSSE envelopes are not supported"},
+		"empty signature": {`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json","messageSignature":{}}`, "bundle has an empty signature"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := bundle.Parse([]byte(tc.bundle))
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
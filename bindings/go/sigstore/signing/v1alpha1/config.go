@@ -71,7 +71,8 @@ type SignConfig struct {
 	ClientID string `json:"clientID,omitempty"`
 }
 
-// VerifyConfig defines configuration for Sigstore-based keyless verification via the cosign CLI.
+// VerifyConfig defines configuration for Sigstore-based keyless verification. Bundles are
+// verified in-process when a trusted-root credential is available, and via the cosign CLI otherwise.
 //
 // For keyless (Sigstore) verification, identity constraints are REQUIRED: you must set either
 // CertificateOIDCIssuer (or CertificateOIDCIssuerRegexp) AND CertificateIdentity
@@ -37,12 +37,11 @@ type TrustedRoot struct {
 	// TrustedRootJSON is an inline JSON document conforming to the Sigstore TrustedRoot schema.
 	// Overrides the default public-good TUF root, enabling verification against private Sigstore
 	// infrastructure (required when VerifyConfig.PrivateInfrastructure is true).
-	// Written to a temp file and passed to cosign as --trusted-root.
+	// Bundles are verified in-process against it, without invoking cosign.
 	// Takes precedence over TrustedRootJSONFile when both are set.
 	TrustedRootJSON string `json:"trustedRootJSON,omitempty"`
 	// TrustedRootJSONFile is a path to a JSON file conforming to the Sigstore TrustedRoot schema.
-	// Same semantics as TrustedRootJSON, but loaded from disk; passed directly to cosign as
-	// --trusted-root without an intermediate temp file. Ignored when TrustedRootJSON is also set.
+	// Same semantics as TrustedRootJSON, but loaded from disk. Ignored when TrustedRootJSON is also set.
 	// Must be an absolute, canonical path (no .. segments).
 	TrustedRootJSONFile string `json:"trustedRootJSONFile,omitempty"`
 }
@@ -14,6 +14,32 @@ func NewDefaultBuilder(
 	repoProvider repository.ComponentVersionRepositoryProvider,
 	resourceRepo repository.ResourceRepository,
 	credentialProvider credentials.Resolver,
+	opts ...BuilderOption,
 ) *builder.Builder {
-	return internal.NewDefaultBuilder(repoProvider, resourceRepo, credentialProvider)
+	return internal.NewDefaultBuilder(repoProvider, resourceRepo, credentialProvider, opts...)
+}
+
+type (
+	// BuilderOption configures optional dependencies of the transformers created by [NewDefaultBuilder].
+	BuilderOption = internal.BuilderOption
+	// SigningHandlerProvider returns the signing handler for a signing or verification
+	// configuration, e.g. the signing registry of the plugin manager.
+	SigningHandlerProvider = internal.SigningHandlerProvider
+	// ResourceDigestProcessorProvider returns the digest processor for the access of a
+	// resource, e.g. the digest processor registry of the plugin manager.
+	ResourceDigestProcessorProvider = internal.ResourceDigestProcessorProvider
+)
+
+// WithSigningHandlers sets the signing handlers that verify and sign component versions
+// in transfers whose configuration sets signing.
+// Without them, such transfers fail when the graph is processed.
+func WithSigningHandlers(handlers SigningHandlerProvider) BuilderOption {
+	return internal.WithSigningHandlers(handlers)
+}
+
+// WithResourceDigestProcessors sets the digest processors that verify the digests of
+// resources other than local blobs in transfers that verify component versions.
+// Without them, such transfers fail when the graph is processed.
+func WithResourceDigestProcessors(processors ResourceDigestProcessorProvider) BuilderOption {
+	return internal.WithResourceDigestProcessors(processors)
 }
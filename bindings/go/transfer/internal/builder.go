@@ -14,6 +14,30 @@ import (
 	"ocm.software/open-component-model/bindings/go/transform/graph/builder"
 )
 
+// BuilderOption configures optional dependencies of the transformers created by [NewDefaultBuilder].
+type BuilderOption func(*builderOptions)
+
+type builderOptions struct {
+	signingHandlers  SigningHandlerProvider
+	digestProcessors ResourceDigestProcessorProvider
+}
+
+// WithSigningHandlers sets the signing handlers used to verify and sign component versions.
+// Without them, transfers that verify or sign component versions fail.
+func WithSigningHandlers(handlers SigningHandlerProvider) BuilderOption {
+	return func(o *builderOptions) {
+		o.signingHandlers = handlers
+	}
+}
+
+// WithResourceDigestProcessors sets the digest processors used to verify the digests of
+// resources that are not local blobs. Without them, transfers that verify such resources fail.
+func WithResourceDigestProcessors(processors ResourceDigestProcessorProvider) BuilderOption {
+	return func(o *builderOptions) {
+		o.digestProcessors = processors
+	}
+}
+
 // NewDefaultBuilder creates a builder.Builder pre-configured with all standard OCI, CTF, and Helm transformers.
 // It accepts the repository provider, resource repository, and credential resolver interfaces
 // that are needed by the transformers to interact with repositories.
@@ -21,7 +45,13 @@ func NewDefaultBuilder(
 	repoProvider repository.ComponentVersionRepositoryProvider,
 	resourceRepo repository.ResourceRepository,
 	credentialProvider credentials.Resolver,
+	opts ...BuilderOption,
 ) *builder.Builder {
+	var options builderOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	transformerScheme := runtime.NewScheme()
 	transformerScheme.MustRegisterScheme(ociv1alpha1.Scheme)
 	transformerScheme.MustRegisterScheme(ociaccess.Scheme)
@@ -92,6 +122,31 @@ func NewDefaultBuilder(
 		Scheme: transformerScheme,
 	}
 
+	// Component version conflict check transformer
+	transformerScheme.MustRegisterWithAlias(&CheckComponentVersionConflictTransformation{}, CheckComponentVersionConflictVersionedType)
+	conflictCheck := &CheckComponentVersionConflict{
+		Scheme:             transformerScheme,
+		RepoProvider:       repoProvider,
+		CredentialProvider: credentialProvider,
+	}
+
+	// Signing transformers
+	transformerScheme.MustRegisterWithAlias(&VerifyComponentVersionTransformation{}, VerifyComponentVersionVersionedType)
+	verify := &VerifyComponentVersion{
+		Scheme:             transformerScheme,
+		RepoProvider:       repoProvider,
+		Handlers:           options.signingHandlers,
+		DigestProcessors:   options.digestProcessors,
+		CredentialProvider: credentialProvider,
+	}
+	transformerScheme.MustRegisterWithAlias(&SignComponentVersionTransformation{}, SignComponentVersionVersionedType)
+	sign := &SignComponentVersion{
+		Scheme:             transformerScheme,
+		RepoProvider:       repoProvider,
+		Handlers:           options.signingHandlers,
+		CredentialProvider: credentialProvider,
+	}
+
 	// File cleanup transformer
 	transformerScheme.MustRegisterWithAlias(&FileCleanupTransformation{}, FileCleanupVersionedType)
 	fileCleanup := &FileCleanup{
@@ -112,5 +167,8 @@ func NewDefaultBuilder(
 		WithTransformer(&ociv1alpha1.TransferOCIArtifact{}, ociTransferOCIArtifact).
 		WithTransformer(&helmv1alpha1.GetHelmChart{}, getHelmChart).
 		WithTransformer(&helmv1alpha1.ConvertHelmToOCI{}, convertHelmToOCI).
+		WithTransformer(&CheckComponentVersionConflictTransformation{}, conflictCheck).
+		WithTransformer(&VerifyComponentVersionTransformation{}, verify).
+		WithTransformer(&SignComponentVersionTransformation{}, sign).
 		WithTransformer(&FileCleanupTransformation{}, fileCleanup)
 }
@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1/meta"
+)
+
+const (
+	CheckComponentVersionConflictType    = "CheckComponentVersionConflict"
+	checkComponentVersionConflictVersion = "v1alpha1"
+)
+
+// CheckComponentVersionConflictVersionedType is the versioned type identifier for
+// CheckComponentVersionConflict transformations.
+var CheckComponentVersionConflictVersionedType = runtime.NewVersionedType(CheckComponentVersionConflictType, checkComponentVersionConflictVersion)
+
+// CheckComponentVersionConflictSpec is the input specification for a
+// CheckComponentVersionConflict transformation.
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type CheckComponentVersionConflictSpec struct {
+	// Repository is the target repository the component version is transferred to.
+	Repository *runtime.Raw `json:"repository"`
+	// Descriptor is the component version that is transferred.
+	Descriptor *descriptorv2.Descriptor `json:"descriptor"`
+	// Policy determines how a component version that already exists in the
+	// target repository is handled.
+	Policy transferv1alpha1.ComponentVersionConflictPolicy `json:"policy"`
+}
+
+// CheckComponentVersionConflictOutput is the output of a CheckComponentVersionConflict transformation.
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type CheckComponentVersionConflictOutput struct {
+	// Transfer is false if the component version and its resources must not be
+	// transferred because the target already holds them.
+	Transfer bool `json:"transfer"`
+}
+
+// CheckComponentVersionConflictTransformation is a transformation specification that
+// applies a [transferv1alpha1.ComponentVersionConflictPolicy] before a component version
+// is transferred. All other transformations of the component version for the same target
+// only run if its output allows the transfer.
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type CheckComponentVersionConflictTransformation struct {
+	// +ocm:jsonschema-gen:enum=CheckComponentVersionConflict/v1alpha1
+	Type   runtime.Type                         `json:"type"`
+	ID     string                               `json:"id"`
+	Spec   *CheckComponentVersionConflictSpec   `json:"spec"`
+	Output *CheckComponentVersionConflictOutput `json:"output,omitempty"`
+}
+
+// CheckComponentVersionConflict is a transformer that looks up a component version in
+// the target repository and decides based on the conflict policy whether it is
+// transferred, skipped or whether the transfer fails.
+// Existing component versions are compared by their normalised digest, not by their version.
+type CheckComponentVersionConflict struct {
+	Scheme             *runtime.Scheme
+	RepoProvider       repository.ComponentVersionRepositoryProvider
+	CredentialProvider credentials.Resolver
+}
+
+func (t *CheckComponentVersionConflict) Transform(ctx context.Context, step runtime.Typed) (runtime.Typed, error) {
+	var transformation CheckComponentVersionConflictTransformation
+	if err := t.Scheme.Convert(step, &transformation); err != nil {
+		return nil, fmt.Errorf("failed converting generic transformation to component version conflict check: %w", err)
+	}
+	if transformation.Spec == nil || transformation.Spec.Repository == nil || transformation.Spec.Descriptor == nil {
+		return nil, fmt.Errorf("repository and descriptor are required for component version conflict check")
+	}
+
+	desc, err := descruntime.ConvertFromV2(transformation.Spec.Descriptor)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting component version from v2: %w", err)
+	}
+	component, version := desc.Component.Name, desc.Component.Version
+
+	transformation.Output = &CheckComponentVersionConflictOutput{Transfer: true}
+	if transformation.Spec.Policy == "" || transformation.Spec.Policy == transferv1alpha1.ComponentVersionConflictReplace {
+		return &transformation, nil
+	}
+
+	repoSpec, err := convertToConcreteRepo(transformation.Spec.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("converting repository spec: %w", err)
+	}
+
+	var creds runtime.Typed
+	if t.CredentialProvider != nil {
+		if consumerID, err := t.RepoProvider.GetComponentVersionRepositoryCredentialConsumerIdentity(ctx, repoSpec); err == nil {
+			if creds, err = t.CredentialProvider.Resolve(ctx, consumerID); err != nil && !errors.Is(err, credentials.ErrNotFound) {
+				return nil, fmt.Errorf("failed resolving credentials: %w", err)
+			}
+		}
+	}
+
+	repo, err := t.RepoProvider.GetComponentVersionRepository(ctx, repoSpec, creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting component version repository: %w", err)
+	}
+
+	existing, err := repo.GetComponentVersion(ctx, component, version)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return &transformation, nil
+	case err != nil:
+		return nil, fmt.Errorf("error checking for existing component version in target repository: %w", err)
+	}
+
+	switch transformation.Spec.Policy {
+	case transferv1alpha1.ComponentVersionConflictAbortAndFail:
+		return nil, fmt.Errorf("component version %s:%s already exists in target repository", component, version)
+	case transferv1alpha1.ComponentVersionConflictSkip:
+		digest, err := normalisedDigest(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		existingDigest, err := normalisedDigest(ctx, existing)
+		if err != nil {
+			return nil, err
+		}
+		if digest != existingDigest {
+			return nil, fmt.Errorf("component version %s:%s already exists in target repository with digest %s instead of %s",
+				component, version, existingDigest, digest)
+		}
+		slog.InfoContext(ctx, "component version already exists in target repository with the same digest, skipping transfer",
+			"component", component, "version", version, "digest", digest)
+		transformation.Output.Transfer = false
+		return &transformation, nil
+	default:
+		return nil, fmt.Errorf("unsupported component version conflict policy %q", transformation.Spec.Policy)
+	}
+}
+
+// addConflictCheckTransformation appends a CheckComponentVersionConflict transformation
+// for the component version stored in the environment under envID and returns the
+// when expression that gates the remaining transformations of the component version.
+func addConflictCheckTransformation(id, envID string, toSpec runtime.Typed, policy transferv1alpha1.ComponentVersionConflictPolicy, tgd *transformv1alpha1.TransformationGraphDefinition) (string, error) {
+	toRepo, err := asUnstructured(toSpec)
+	if err != nil {
+		return "", fmt.Errorf("cannot convert target spec to unstructured: %w", err)
+	}
+
+	checkID := id + "Check"
+	tgd.Transformations = append(tgd.Transformations, transformv1alpha1.GenericTransformation{
+		TransformationMeta: meta.TransformationMeta{
+			Type: CheckComponentVersionConflictVersionedType,
+			ID:   checkID,
+		},
+		Spec: &runtime.Unstructured{Data: map[string]any{
+			"repository": toRepo.Data,
+			"descriptor": fmt.Sprintf("${environment.%s}", envID),
+			"policy":     string(policy),
+		}},
+	})
+	return fmt.Sprintf("${%s.output.transfer}", checkID), nil
+}
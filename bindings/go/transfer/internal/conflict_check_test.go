@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+)
+
+// mockRepoProvider opens every repository specification as the same repository.
+type mockRepoProvider struct {
+	repository.ComponentVersionRepositoryProvider
+	repo repository.ComponentVersionRepository
+}
+
+func (m *mockRepoProvider) GetComponentVersionRepositoryCredentialConsumerIdentity(context.Context, runtime.Typed) (runtime.Identity, error) {
+	return runtime.Identity{}, nil
+}
+
+func (m *mockRepoProvider) GetComponentVersionRepository(context.Context, runtime.Typed, runtime.Typed) (repository.ComponentVersionRepository, error) {
+	return m.repo, nil
+}
+
+func newConflictCheck(t *testing.T, policy transferv1alpha1.ComponentVersionConflictPolicy, desc *descriptor.Descriptor) *CheckComponentVersionConflictTransformation {
+	t.Helper()
+	var repo runtime.Raw
+	require.NoError(t, runtime.NewScheme(runtime.WithAllowUnknown()).Convert(testOCIRepo("ghcr.io/target"), &repo))
+	v2desc, err := descriptor.ConvertToV2(runtime.NewScheme(runtime.WithAllowUnknown()), desc)
+	require.NoError(t, err)
+	return &CheckComponentVersionConflictTransformation{
+		Type: CheckComponentVersionConflictVersionedType,
+		ID:   "check",
+		Spec: &CheckComponentVersionConflictSpec{
+			Repository: &repo,
+			Descriptor: v2desc,
+			Policy:     policy,
+		},
+	}
+}
+
+func TestCheckComponentVersionConflict_Transform(t *testing.T) {
+	source := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{
+		withDigest(localBlobResource("blob", "1.0.0"), "aaa"),
+	}, nil)
+	changed := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{
+		withDigest(localBlobResource("blob", "1.0.0"), "bbb"),
+	}, nil)
+
+	tests := []struct {
+		name         string
+		policy       transferv1alpha1.ComponentVersionConflictPolicy
+		existing     *descriptor.Descriptor
+		wantTransfer bool
+		wantErr      string
+	}{
+		{name: "replace transfers existing", policy: transferv1alpha1.ComponentVersionConflictReplace, existing: changed, wantTransfer: true},
+		{name: "skip transfers missing", policy: transferv1alpha1.ComponentVersionConflictSkip, wantTransfer: true},
+		{name: "skip skips identical", policy: transferv1alpha1.ComponentVersionConflictSkip, existing: source, wantTransfer: false},
+		{name: "skip fails on different digest", policy: transferv1alpha1.ComponentVersionConflictSkip, existing: changed, wantErr: "already exists in target repository with digest"},
+		{name: "abort-and-fail transfers missing", policy: transferv1alpha1.ComponentVersionConflictAbortAndFail, wantTransfer: true},
+		{name: "abort-and-fail fails on identical", policy: transferv1alpha1.ComponentVersionConflictAbortAndFail, existing: source, wantErr: "already exists in target repository"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			target := &planRepo{descriptors: map[string]*descriptor.Descriptor{}}
+			if tc.existing != nil {
+				target.descriptors["ocm.software/test:1.0.0"] = tc.existing
+			}
+			s := runtime.NewScheme()
+			s.MustRegisterWithAlias(&CheckComponentVersionConflictTransformation{}, CheckComponentVersionConflictVersionedType)
+			transformer := &CheckComponentVersionConflict{
+				Scheme:       s,
+				RepoProvider: &mockRepoProvider{repo: target},
+			}
+
+			result, err := transformer.Transform(t.Context(), newConflictCheck(t, tc.policy, source))
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			check, ok := result.(*CheckComponentVersionConflictTransformation)
+			require.True(t, ok)
+			require.NotNil(t, check.Output)
+			assert.Equal(t, tc.wantTransfer, check.Output.Transfer)
+		})
+	}
+}
+
+func TestBuildGraphDefinition_ConflictPolicy(t *testing.T) {
+	sourceRepo := testOCIRepo("ghcr.io/source")
+	targetRepo := testOCIRepo("ghcr.io/target")
+	desc := testDescriptor("ocm.software/test", "1.0.0",
+		[]descriptor.Resource{localBlobResource("my-resource", "1.0.0")}, nil)
+	resolver := testResolverFor("ocm.software/test", "1.0.0", sourceRepo, desc)
+	roots := testTransferRoots("ocm.software/test", "1.0.0", targetRepo, resolver)
+
+	t.Run("replace adds no check", func(t *testing.T) {
+		tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+			CopyMode:                       transferv1alpha1.CopyModeLocalBlobResources,
+			ComponentVersionConflictPolicy: transferv1alpha1.ComponentVersionConflictReplace,
+		})
+		require.NoError(t, err)
+		for _, tr := range tgd.Transformations {
+			assert.NotEqual(t, CheckComponentVersionConflictVersionedType, tr.Type)
+			assert.Empty(t, tr.When)
+		}
+		assert.NotNil(t, findCleanupTransformation(tgd))
+	})
+
+	t.Run("skip gates all transformations of the component version", func(t *testing.T) {
+		tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+			CopyMode:                       transferv1alpha1.CopyModeLocalBlobResources,
+			ComponentVersionConflictPolicy: transferv1alpha1.ComponentVersionConflictSkip,
+		})
+		require.NoError(t, err)
+
+		var check *transformv1alpha1.GenericTransformation
+		var cleanups []string
+		for i, tr := range tgd.Transformations {
+			switch {
+			case tr.Type == CheckComponentVersionConflictVersionedType:
+				check = &tgd.Transformations[i]
+			case tr.Type == FileCleanupVersionedType:
+				cleanups = append(cleanups, tr.ID)
+				assert.Empty(t, tr.When, "cleanup is skipped with the component version through its dependencies")
+			default:
+				assert.Equal(t, "${"+check.ID+".output.transfer}", tr.When, tr.ID)
+			}
+		}
+		require.NotNil(t, check)
+		assert.Equal(t, string(transferv1alpha1.ComponentVersionConflictSkip), check.Spec.Data["policy"])
+		assert.True(t, strings.HasPrefix(check.Spec.Data["descriptor"].(string), "${environment."))
+		require.Len(t, cleanups, 1)
+		assert.NotEqual(t, fileCleanupID, cleanups[0], "a skipped component version must not skip the shared cleanup")
+	})
+}
@@ -23,6 +23,8 @@ import (
 type discoveryValue struct {
 	Descriptor       *descriptor.Descriptor
 	SourceRepository runtime.Typed
+	// Repository is the opened source repository the descriptor was fetched from.
+	Repository repository.ComponentVersionRepository
 }
 
 // multiResolver dispatches component version resolution to per-component resolvers.
@@ -112,6 +114,7 @@ func (r *multiResolver) Resolve(ctx context.Context, key string) (*discoveryValu
 	return &discoveryValue{
 		Descriptor:       desc,
 		SourceRepository: repoSpec,
+		Repository:       repo,
 	}, nil
 }
 
@@ -151,7 +154,8 @@ type discoverer struct {
 }
 
 // Discover extracts component references from a resolved parent and returns their keys
-// for recursive resolution. Returns nil if recursive mode is disabled.
+// for recursive resolution. Returns nil if recursive mode is disabled. A depth limit is
+// not enforced here but by the DAG discoverer, which does not expand parents at that depth.
 //
 // For each child reference, it:
 //  1. Records the expected digest (if pinned) for later verification.
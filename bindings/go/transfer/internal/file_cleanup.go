@@ -16,6 +16,9 @@ import (
 const (
 	FileCleanupType    = "FileCleanup"
 	fileCleanupVersion = "v1alpha1"
+
+	// fileCleanupID is the ID of the cleanup shared by all transformations of a graph.
+	fileCleanupID = "fileBufferCleanup"
 )
 
 // FileCleanupVersionedType is the versioned type identifier for FileCleanup transformations.
@@ -114,11 +117,11 @@ func (t *FileCleanup) Transform(ctx context.Context, step runtime.Typed) (runtim
 	return &transformation, nil
 }
 
-// addFileCleanupTransformation appends a FileCleanup transformation to the graph.
+// addFileCleanupTransformation appends a FileCleanup transformation with the given id to the graph.
 // fileExpressions is a list of CEL spec-field expressions referencing each consumer's
 // spec field (not the producer's output), so the dependency discovery system creates
 // edges from those consumers to the cleanup node — guaranteeing cleanup runs last.
-func addFileCleanupTransformation(tgd *transformv1alpha1.TransformationGraphDefinition, fileExpressions []string) {
+func addFileCleanupTransformation(tgd *transformv1alpha1.TransformationGraphDefinition, id string, fileExpressions []string) {
 	if len(fileExpressions) == 0 {
 		return
 	}
@@ -131,7 +134,7 @@ func addFileCleanupTransformation(tgd *transformv1alpha1.TransformationGraphDefi
 	cleanup := transformv1alpha1.GenericTransformation{
 		TransformationMeta: meta.TransformationMeta{
 			Type: FileCleanupVersionedType,
-			ID:   "fileBufferCleanup",
+			ID:   id,
 		},
 		Spec: &runtime.Unstructured{Data: map[string]any{
 			"files": files,
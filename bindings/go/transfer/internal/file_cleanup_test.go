@@ -186,7 +186,7 @@ func TestAddFileCleanupTransformation(t *testing.T) {
 		"${addRes2.spec.file}",
 	}
 
-	addFileCleanupTransformation(tgd, exprs)
+	addFileCleanupTransformation(tgd, fileCleanupID, exprs)
 
 	require.Len(t, tgd.Transformations, 1)
 
@@ -204,10 +204,10 @@ func TestAddFileCleanupTransformation(t *testing.T) {
 func TestAddFileCleanupTransformation_NoExpressionsNoNode(t *testing.T) {
 	tgd := &transformv1alpha1.TransformationGraphDefinition{}
 
-	addFileCleanupTransformation(tgd, nil)
+	addFileCleanupTransformation(tgd, fileCleanupID, nil)
 	assert.Empty(t, tgd.Transformations, "should not add cleanup when there are no expressions")
 
-	addFileCleanupTransformation(tgd, []string{})
+	addFileCleanupTransformation(tgd, fileCleanupID, []string{})
 	assert.Empty(t, tgd.Transformations, "should not add cleanup for empty slice")
 }
 
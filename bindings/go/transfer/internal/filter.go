@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+
+	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+)
+
+// resourceFilterVariable is the name under which CEL expressions of a
+// [transferv1alpha1.ResourceSelector] access the resource.
+const resourceFilterVariable = "resource"
+
+// resourceFilter evaluates a [transferv1alpha1.ResourceFilter] against resources.
+// A nil resourceFilter selects every resource.
+type resourceFilter struct {
+	include []resourceSelector
+	exclude []resourceSelector
+}
+
+type resourceSelector struct {
+	transferv1alpha1.ResourceSelector
+	program cel.Program
+}
+
+// newResourceFilter compiles the CEL expressions of the filter once, so that
+// invalid expressions fail before any transformation is built.
+func newResourceFilter(spec *transferv1alpha1.ResourceFilter) (*resourceFilter, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	env, err := cel.NewEnv(cel.Variable(resourceFilterVariable, cel.DynType))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create CEL environment for resource filter: %w", err)
+	}
+	compile := func(kind string, selectors []transferv1alpha1.ResourceSelector) ([]resourceSelector, error) {
+		compiled := make([]resourceSelector, 0, len(selectors))
+		for i, selector := range selectors {
+			s := resourceSelector{ResourceSelector: selector}
+			if selector.Expression != "" {
+				ast, issues := env.Compile(selector.Expression)
+				if issues.Err() != nil {
+					return nil, fmt.Errorf("invalid expression in %s[%d]: %w", kind, i, issues.Err())
+				}
+				if !ast.OutputType().IsAssignableType(cel.BoolType) {
+					return nil, fmt.Errorf("expression in %s[%d] evaluates to %s, expected a bool", kind, i, ast.OutputType())
+				}
+				if s.program, err = env.Program(ast); err != nil {
+					return nil, fmt.Errorf("cannot create program for expression in %s[%d]: %w", kind, i, err)
+				}
+			}
+			compiled = append(compiled, s)
+		}
+		return compiled, nil
+	}
+
+	filter := &resourceFilter{}
+	if filter.include, err = compile("include", spec.Include); err != nil {
+		return nil, err
+	}
+	if filter.exclude, err = compile("exclude", spec.Exclude); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// resourceSelection is the outcome of evaluating a [resourceFilter] against a resource.
+type resourceSelection int
+
+const (
+	// resourceSelected means the resource matches an include selector (or there
+	// are none) and no exclude selector.
+	resourceSelected resourceSelection = iota
+	// resourceNotIncluded means the resource matches no include selector and no
+	// exclude selector.
+	resourceNotIncluded
+	// resourceExcluded means the resource matches an exclude selector.
+	resourceExcluded
+)
+
+// Select evaluates the filter against the resource. Exclude selectors are
+// evaluated first, so that an explicit exclusion is reported even for resources
+// that are not included.
+func (f *resourceFilter) Select(resource *descriptorv2.Resource) (resourceSelection, error) {
+	if f == nil {
+		return resourceSelected, nil
+	}
+	var object map[string]any
+	if f.needsObject() {
+		raw, err := json.Marshal(resource)
+		if err != nil {
+			return 0, fmt.Errorf("cannot marshal resource for filter expression: %w", err)
+		}
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return 0, fmt.Errorf("cannot unmarshal resource for filter expression: %w", err)
+		}
+	}
+
+	for _, selector := range f.exclude {
+		ok, err := selector.matches(resource, object)
+		if err != nil {
+			return 0, fmt.Errorf("evaluating exclude selector: %w", err)
+		}
+		if ok {
+			return resourceExcluded, nil
+		}
+	}
+	if len(f.include) == 0 {
+		return resourceSelected, nil
+	}
+	for _, selector := range f.include {
+		ok, err := selector.matches(resource, object)
+		if err != nil {
+			return 0, fmt.Errorf("evaluating include selector: %w", err)
+		}
+		if ok {
+			return resourceSelected, nil
+		}
+	}
+	return resourceNotIncluded, nil
+}
+
+func (f *resourceFilter) needsObject() bool {
+	for _, selectors := range [][]resourceSelector{f.include, f.exclude} {
+		for _, selector := range selectors {
+			if selector.program != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s resourceSelector) matches(resource *descriptorv2.Resource, object map[string]any) (bool, error) {
+	if s.Type != "" && s.Type != resource.Type {
+		return false, nil
+	}
+	if len(s.Identity) > 0 && !runtime.IdentitySubset(s.Identity, resource.ToIdentity()) {
+		return false, nil
+	}
+	for _, label := range s.Labels {
+		if !hasLabel(resource.Labels, label) {
+			return false, nil
+		}
+	}
+	if s.program == nil {
+		return true, nil
+	}
+	result, _, err := s.program.Eval(map[string]any{resourceFilterVariable: object})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression %q: %w", s.Expression, err)
+	}
+	ok, isBool := result.Value().(bool)
+	if !isBool {
+		return false, fmt.Errorf("expression %q evaluated to %T, expected a bool", s.Expression, result.Value())
+	}
+	return ok, nil
+}
+
+// hasLabel reports whether labels contain a label with the name of the selector
+// and, if the selector has a value, the same value.
+func hasLabel(labels []descriptorv2.Label, selector transferv1alpha1.LabelSelector) bool {
+	for _, label := range labels {
+		if label.Name != selector.Name {
+			continue
+		}
+		if selector.Value == "" {
+			return true
+		}
+		var value string
+		if err := json.Unmarshal(label.Value, &value); err == nil {
+			return value == selector.Value
+		}
+		return string(label.Value) == selector.Value
+	}
+	return false
+}
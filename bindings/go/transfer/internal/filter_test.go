@@ -0,0 +1,187 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ociv1alpha1 "ocm.software/open-component-model/bindings/go/oci/spec/transformation/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+)
+
+func withLabel(resource descriptor.Resource, name string, value any) descriptor.Resource {
+	raw, _ := json.Marshal(value)
+	resource.Labels = append(resource.Labels, descriptor.Label{Name: name, Value: raw})
+	return resource
+}
+
+func TestResourceFilter_Select(t *testing.T) {
+	image := withLabel(ociImageResource("image", "1.0.0", "ghcr.io/org/image:1.0.0"), "internal-only", true)
+	testData := withLabel(ociImageResource("test-data", "0.1.0", "ghcr.io/org/test-data:0.1.0"), "team", "qa")
+	testData.Type = "blob"
+
+	tests := []struct {
+		name   string
+		filter *transferv1alpha1.ResourceFilter
+		want   map[string]resourceSelection
+	}{
+		{
+			name: "no filter selects everything",
+			want: map[string]resourceSelection{"image": resourceSelected, "test-data": resourceSelected},
+		},
+		{
+			name:   "include by type",
+			filter: &transferv1alpha1.ResourceFilter{Include: []transferv1alpha1.ResourceSelector{{Type: "ociImage"}}},
+			want:   map[string]resourceSelection{"image": resourceSelected, "test-data": resourceNotIncluded},
+		},
+		{
+			name:   "exclude by identity",
+			filter: &transferv1alpha1.ResourceFilter{Exclude: []transferv1alpha1.ResourceSelector{{Identity: map[string]string{"name": "test-data"}}}},
+			want:   map[string]resourceSelection{"image": resourceSelected, "test-data": resourceExcluded},
+		},
+		{
+			name:   "exclude by label without value",
+			filter: &transferv1alpha1.ResourceFilter{Exclude: []transferv1alpha1.ResourceSelector{{Labels: []transferv1alpha1.LabelSelector{{Name: "internal-only"}}}}},
+			want:   map[string]resourceSelection{"image": resourceExcluded, "test-data": resourceSelected},
+		},
+		{
+			name: "label values compare strings unquoted and others as JSON",
+			filter: &transferv1alpha1.ResourceFilter{Include: []transferv1alpha1.ResourceSelector{
+				{Labels: []transferv1alpha1.LabelSelector{{Name: "internal-only", Value: "true"}}},
+				{Labels: []transferv1alpha1.LabelSelector{{Name: "team", Value: "qa"}}},
+			}},
+			want: map[string]resourceSelection{"image": resourceSelected, "test-data": resourceSelected},
+		},
+		{
+			name: "criteria of a selector are combined",
+			filter: &transferv1alpha1.ResourceFilter{Include: []transferv1alpha1.ResourceSelector{
+				{Type: "ociImage", Identity: map[string]string{"name": "test-data"}},
+			}},
+			want: map[string]resourceSelection{"image": resourceNotIncluded, "test-data": resourceNotIncluded},
+		},
+		{
+			name: "exclude wins over include",
+			filter: &transferv1alpha1.ResourceFilter{
+				Include: []transferv1alpha1.ResourceSelector{{Type: "ociImage"}, {Type: "blob"}},
+				Exclude: []transferv1alpha1.ResourceSelector{{Type: "blob"}},
+			},
+			want: map[string]resourceSelection{"image": resourceSelected, "test-data": resourceExcluded},
+		},
+		{
+			name: "exclude is reported for resources that are not included",
+			filter: &transferv1alpha1.ResourceFilter{
+				Include: []transferv1alpha1.ResourceSelector{{Type: "ociImage"}},
+				Exclude: []transferv1alpha1.ResourceSelector{{Identity: map[string]string{"name": "test-data"}}},
+			},
+			want: map[string]resourceSelection{"image": resourceSelected, "test-data": resourceExcluded},
+		},
+		{
+			name: "expression",
+			filter: &transferv1alpha1.ResourceFilter{Exclude: []transferv1alpha1.ResourceSelector{
+				{Expression: "resource.version.startsWith('0.') && resource.labels.exists(l, l.name == 'team')"},
+			}},
+			want: map[string]resourceSelection{"image": resourceSelected, "test-data": resourceExcluded},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := newResourceFilter(tc.filter)
+			require.NoError(t, err)
+
+			desc := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{image, testData}, nil)
+			v2desc, err := descriptor.ConvertToV2(runtime.NewScheme(runtime.WithAllowUnknown()), desc)
+			require.NoError(t, err)
+
+			got := make(map[string]resourceSelection)
+			for i := range v2desc.Component.Resources {
+				resource := &v2desc.Component.Resources[i]
+				got[resource.Name], err = filter.Select(resource)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNewResourceFilter_InvalidExpression(t *testing.T) {
+	_, err := newResourceFilter(&transferv1alpha1.ResourceFilter{
+		Include: []transferv1alpha1.ResourceSelector{{Expression: "resource.name =="}},
+	})
+	require.ErrorContains(t, err, "invalid expression in include[0]")
+
+	_, err = newResourceFilter(&transferv1alpha1.ResourceFilter{
+		Exclude: []transferv1alpha1.ResourceSelector{{Expression: "'not a bool'"}},
+	})
+	require.ErrorContains(t, err, "expected a bool")
+}
+
+func TestBuildGraphDefinition_ResourceFilter(t *testing.T) {
+	sourceRepo := testOCIRepo("ghcr.io/source")
+	targetRepo := testOCIRepo("ghcr.io/target")
+	testData := ociImageResource("test-data", "1.0.0", "ghcr.io/org/test-data:1.0.0")
+	testData.Type = "blob"
+	desc := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{
+		localBlobResource("my-resource", "1.0.0"),
+		ociImageResource("my-image", "1.0.0", "ghcr.io/org/image:1.0.0"),
+		testData,
+	}, nil)
+	resolver := testResolverFor("ocm.software/test", "1.0.0", sourceRepo, desc)
+	roots := testTransferRoots("ocm.software/test", "1.0.0", targetRepo, resolver)
+
+	t.Run("excluded resources keep their access", func(t *testing.T) {
+		tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+			CopyMode:  transferv1alpha1.CopyModeAllResources,
+			Resources: &transferv1alpha1.ResourceFilter{Exclude: []transferv1alpha1.ResourceSelector{{Type: "blob"}}},
+		})
+		require.NoError(t, err)
+
+		var getOCI []string
+		for _, tr := range tgd.Transformations {
+			if tr.Type == ociv1alpha1.GetOCIArtifactV1alpha1 {
+				getOCI = append(getOCI, tr.ID)
+			}
+		}
+		require.Len(t, getOCI, 1)
+		assert.NotContains(t, getOCI[0], "TestData")
+	})
+
+	t.Run("local blobs not included are copied", func(t *testing.T) {
+		tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+			CopyMode:  transferv1alpha1.CopyModeAllResources,
+			Resources: &transferv1alpha1.ResourceFilter{Include: []transferv1alpha1.ResourceSelector{{Type: "ociImage"}}},
+		})
+		require.NoError(t, err)
+
+		var getLocal, getOCI []string
+		for _, tr := range tgd.Transformations {
+			switch tr.Type {
+			case ociv1alpha1.GetOCIArtifactV1alpha1:
+				getOCI = append(getOCI, tr.ID)
+			case ociv1alpha1.OCIGetLocalResourceV1alpha1:
+				getLocal = append(getLocal, tr.ID)
+			}
+		}
+		require.Len(t, getLocal, 1)
+		require.Len(t, getOCI, 1)
+		assert.NotContains(t, getOCI[0], "TestData")
+	})
+
+	t.Run("excluding a local blob fails", func(t *testing.T) {
+		_, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+			CopyMode:  transferv1alpha1.CopyModeAllResources,
+			Resources: &transferv1alpha1.ResourceFilter{Exclude: []transferv1alpha1.ResourceSelector{{Identity: map[string]string{"name": "my-resource"}}}},
+		})
+		require.ErrorContains(t, err, "is a local blob and cannot be excluded")
+	})
+
+	t.Run("invalid expression fails before discovery", func(t *testing.T) {
+		_, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+			Resources: &transferv1alpha1.ResourceFilter{Include: []transferv1alpha1.ResourceSelector{{Expression: "resource."}}},
+		})
+		require.ErrorContains(t, err, "invalid resource filter")
+	})
+}
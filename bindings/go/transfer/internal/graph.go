@@ -54,7 +54,9 @@ type TransferRoot struct {
 // The process has two phases:
 //
 //  1. Discovery: A concurrent DAG discoverer resolves each root component and, if recursion is
-//     enabled, follows component references to build a complete dependency graph. During discovery, each
+//     enabled, follows component references to build a complete dependency graph. A positive
+//     [transferv1alpha1.Recursive] depth stops the discovery that many levels below the roots;
+//     components at that depth are transferred, their references are not. During discovery, each
 //     component's target repositories and resolver are tracked in shared maps (targetMap, resolverMap)
 //     that the discoverer propagates from parent to child.
 //
@@ -70,6 +72,45 @@ func BuildGraphDefinition(
 	roots map[string]TransferRoot,
 	cfg transferv1alpha1.Config,
 ) (*transformv1alpha1.TransformationGraphDefinition, error) {
+	filter, err := newResourceFilter(cfg.Resources)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource filter: %w", err)
+	}
+	policies, err := newVerificationPolicies(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verification: %w", err)
+	}
+
+	g, targetMap, err := discover(ctx, roots, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	tgd := &transformv1alpha1.TransformationGraphDefinition{
+		Environment: &runtime.Unstructured{
+			Data: map[string]any{},
+		},
+	}
+
+	// Phase 2: walk the discovered DAG and generate transformation nodes per (component, target) pair.
+	err = g.WithReadLock(func(d *dag.DirectedAcyclicGraph[string]) error {
+		return fillGraphDefinitionWithPrefetchedComponents(ctx, d, targetMap, tgd, cfg, filter, policies)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tgd, nil
+}
+
+// discover resolves the roots and, if recursion is enabled, their references.
+// It returns the discovery graph, whose vertices hold a *discoveryValue, and the
+// target repositories of every discovered component keyed by "component:version".
+func discover(
+	ctx context.Context,
+	roots map[string]TransferRoot,
+	cfg transferv1alpha1.Config,
+) (*dagsync.SyncedDirectedAcyclicGraph[string], map[string][]runtime.Typed, error) {
 	// Seed the targetMap and resolverMap from explicit roots.
 	// These maps are shared with the discoverer and multiResolver:
 	// - targetMap: component key → list of target repository specs
@@ -114,34 +155,25 @@ func BuildGraphDefinition(
 	slog.DebugContext(ctx, "starting component discovery",
 		"roots", dagRoots, "recursive", cfg.Recursive)
 
+	var maxDepth int
+	if cfg.Recursive > transferv1alpha1.RecursiveNone {
+		maxDepth = int(cfg.Recursive)
+	}
+
 	dr := dagsync.NewGraphDiscoverer(&dagsync.GraphDiscovererOptions[string, *discoveryValue]{
 		Roots:      dagRoots,
 		Resolver:   res,
 		Discoverer: disc,
+		MaxDepth:   maxDepth,
 	})
 
 	if err := dr.Discover(ctx); err != nil {
-		return nil, fmt.Errorf("recursive discovery failed: %w", err)
+		return nil, nil, fmt.Errorf("recursive discovery failed: %w", err)
 	}
 
 	slog.DebugContext(ctx, "component discovery completed")
 
-	tgd := &transformv1alpha1.TransformationGraphDefinition{
-		Environment: &runtime.Unstructured{
-			Data: map[string]any{},
-		},
-	}
-
-	// Phase 2: walk the discovered DAG and generate transformation nodes per (component, target) pair.
-	g := dr.Graph()
-	err := g.WithReadLock(func(d *dag.DirectedAcyclicGraph[string]) error {
-		return fillGraphDefinitionWithPrefetchedComponents(ctx, d, targetMap, tgd, cfg.CopyMode, cfg.UploadType)
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return tgd, nil
+	return dr.Graph(), targetMap, nil
 }
 
 // fillGraphDefinitionWithPrefetchedComponents iterates over all discovered components in the DAG
@@ -153,6 +185,18 @@ func BuildGraphDefinition(
 //     on the resource access type (local blob, OCI artifact, Helm chart) and the copy mode.
 //  3. A final AddComponentVersion upload transformation is appended, referencing the processed
 //     resources via CEL expressions.
+//  4. If signing is configured, a SignComponentVersion transformation signs the uploaded
+//     descriptor and updates it in the target.
+//
+// If a verification policy or the verifier of the signing configuration applies to a
+// component, a VerifyComponentVersion transformation verifies its signatures and resource
+// digests in the source, and all transformations of the component only run after it
+// succeeded.
+// If the conflict policy is not [transferv1alpha1.ComponentVersionConflictReplace], a
+// CheckComponentVersionConflict transformation precedes the transformations of every
+// (component, target) pair, which only run if the check allows the transfer. Because a
+// skipped transformation also skips everything that depends on it, the temporary files of
+// such a pair are then removed by a cleanup of its own instead of the shared one.
 //
 // When a component has multiple targets, transformation IDs are suffixed (e.g., "T0", "T1")
 // to ensure uniqueness in the DAG. The environment descriptor is shared across targets
@@ -162,8 +206,9 @@ func fillGraphDefinitionWithPrefetchedComponents(
 	d *dag.DirectedAcyclicGraph[string],
 	targetMap map[string][]runtime.Typed,
 	tgd *transformv1alpha1.TransformationGraphDefinition,
-	copyMode transferv1alpha1.CopyMode,
-	uploadType transferv1alpha1.UploadType,
+	cfg transferv1alpha1.Config,
+	filter *resourceFilter,
+	policies *verificationPolicies,
 ) error {
 	slog.DebugContext(ctx, "building transformations for discovered components",
 		"components", len(d.Vertices))
@@ -189,6 +234,13 @@ func fillGraphDefinitionWithPrefetchedComponents(
 			return err
 		}
 
+		var verified string
+		if policy := policies.For(component); policy != nil {
+			if verified, err = addVerifyTransformation(baseID, val.SourceRepository, policy, tgd); err != nil {
+				return err
+			}
+		}
+
 		targets := targetMap[key]
 		slog.DebugContext(ctx, "processing component for transfer",
 			"component", component, "version", version,
@@ -206,25 +258,51 @@ func fillGraphDefinitionWithPrefetchedComponents(
 				"targetIndex", targetIdx, "targetType", fmt.Sprintf("%T", target),
 				"transformID", id)
 
-			resourceTransformIDs, fileRefs, err := processResources(ctx, v2desc, id, val, tgd, target, copyMode, uploadType)
+			var transfer string
+			if policy := cfg.ComponentVersionConflictPolicy; policy != "" && policy != transferv1alpha1.ComponentVersionConflictReplace {
+				if transfer, err = addConflictCheckTransformation(id, baseID, target, policy, tgd); err != nil {
+					return err
+				}
+			}
+			when := joinWhen(verified, transfer)
+			first := len(tgd.Transformations)
+
+			resourceTransformIDs, fileRefs, err := processResources(ctx, v2desc, id, val, tgd, target, cfg.CopyMode, filter, cfg.UploadType)
 			if err != nil {
 				return err
 			}
-			allFileRefs = append(allFileRefs, fileRefs...)
 
 			if err := addUploadTransformation(v2desc, id, baseID, target, tgd, resourceTransformIDs); err != nil {
 				return err
 			}
+
+			if cfg.Signing != nil {
+				if err := addSignTransformation(id, target, cfg.Signing, tgd); err != nil {
+					return err
+				}
+			}
+
+			if when == "" {
+				allFileRefs = append(allFileRefs, fileRefs...)
+				continue
+			}
+			for i := first; i < len(tgd.Transformations); i++ {
+				tgd.Transformations[i].When = when
+			}
+			addFileCleanupTransformation(tgd, id+"Cleanup", fileRefs)
 		}
 	}
 
-	addFileCleanupTransformation(tgd, allFileRefs)
+	addFileCleanupTransformation(tgd, fileCleanupID, allFileRefs)
 
 	return nil
 }
 
 // processResources iterates over resources in a v2 descriptor and creates the appropriate
 // get/add transformation pairs based on access type, copy mode, and upload type.
+// Resources selected by the copy mode are only copied if the resource filter selects them.
+// Local blobs have no access outside of the source repository: they are copied even if no
+// include selector matches them, and explicitly excluding one fails.
 // It returns CEL spec-field expressions for all Get transformations that buffer content to disk.
 func processResources(
 	ctx context.Context,
@@ -234,6 +312,7 @@ func processResources(
 	tgd *transformv1alpha1.TransformationGraphDefinition,
 	toSpec runtime.Typed,
 	copyMode transferv1alpha1.CopyMode,
+	filter *resourceFilter,
 	uploadType transferv1alpha1.UploadType,
 ) (map[int]string, []string, error) {
 	component := val.Descriptor.Component.Name
@@ -255,6 +334,29 @@ func processResources(
 			continue
 		}
 
+		selection, err := filter.Select(&resource)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot filter resource %s of %s:%s: %w", resource.ToIdentity(), component, version, err)
+		}
+		if selection != resourceSelected {
+			if !descriptorv2.IsLocalBlob(access) {
+				slog.InfoContext(ctx, "Skipping copy of resource since it is not selected by the resource filter, it keeps its original access.",
+					"component", component,
+					"version", version,
+					"resource", resource.ToIdentity().String(),
+					"accessType", resource.Access.Type.String())
+				continue
+			}
+			if selection == resourceExcluded {
+				return nil, nil, fmt.Errorf("resource %s of %s:%s is a local blob and cannot be excluded from the transfer by the resource filter",
+					resource.ToIdentity(), component, version)
+			}
+			slog.DebugContext(ctx, "Copying local blob resource although it is not included by the resource filter, since it cannot keep its original access.",
+				"component", component,
+				"version", version,
+				"resource", resource.ToIdentity().String())
+		}
+
 		exprs, err := processResource(resource, access, id, val, tgd, toSpec, resourceTransformIDs, i, uploadType)
 		if err != nil {
 			return nil, nil, err
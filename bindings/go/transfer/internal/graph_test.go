@@ -289,6 +289,61 @@ func TestBuildGraphDefinition_Recursive(t *testing.T) {
 	assert.Len(t, tgd.Transformations, 2)
 }
 
+func TestBuildGraphDefinition_RecursiveDepth(t *testing.T) {
+	sourceRepo := testOCIRepo("ghcr.io/source")
+	targetRepo := testOCIRepo("ghcr.io/target")
+
+	ref := func(component, version string) descriptor.Reference {
+		return descriptor.Reference{
+			ElementMeta: descriptor.ElementMeta{
+				ObjectMeta: descriptor.ObjectMeta{Name: "ref", Version: version},
+			},
+			Component: component,
+		}
+	}
+	grandchildDesc := testDescriptor("ocm.software/grandchild", "3.0.0", nil, nil)
+	childDesc := testDescriptor("ocm.software/child", "2.0.0", nil,
+		[]descriptor.Reference{ref("ocm.software/grandchild", "3.0.0")})
+	rootDesc := testDescriptor("ocm.software/root", "1.0.0", nil,
+		[]descriptor.Reference{ref("ocm.software/child", "2.0.0")})
+
+	resolver := testMultiResolver(map[string]struct {
+		spec runtime.Typed
+		desc *descriptor.Descriptor
+	}{
+		"ocm.software/root:1.0.0":       {spec: sourceRepo, desc: rootDesc},
+		"ocm.software/child:2.0.0":      {spec: sourceRepo, desc: childDesc},
+		"ocm.software/grandchild:3.0.0": {spec: sourceRepo, desc: grandchildDesc},
+	})
+
+	tests := []struct {
+		name      string
+		recursive transferv1alpha1.Recursive
+		want      []string
+	}{
+		{"none", transferv1alpha1.RecursiveNone, []string{"ocm.software/root"}},
+		{"depth 1", 1, []string{"ocm.software/root", "ocm.software/child"}},
+		{"depth 2", 2, []string{"ocm.software/root", "ocm.software/child", "ocm.software/grandchild"}},
+		{"depth beyond graph", 5, []string{"ocm.software/root", "ocm.software/child", "ocm.software/grandchild"}},
+		{"infinite", transferv1alpha1.RecursiveInfinite, []string{"ocm.software/root", "ocm.software/child", "ocm.software/grandchild"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roots := testTransferRoots("ocm.software/root", "1.0.0", targetRepo, resolver)
+
+			tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{Recursive: tt.recursive, CopyMode: transferv1alpha1.CopyModeLocalBlobResources, UploadType: transferv1alpha1.UploadAsDefault})
+			require.NoError(t, err)
+
+			var transferred []string
+			for _, desc := range tgd.Environment.Data {
+				component := desc.(map[string]any)["component"].(map[string]any)
+				transferred = append(transferred, component["name"].(string))
+			}
+			assert.ElementsMatch(t, tt.want, transferred)
+		})
+	}
+}
+
 func TestBuildGraphDefinition_ResolverError(t *testing.T) {
 	targetRepo := testOCIRepo("ghcr.io/target")
 	resolver := &mockCVRepoResolver{
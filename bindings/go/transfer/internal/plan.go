@@ -0,0 +1,321 @@
+package internal
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/dag"
+	dagsync "ocm.software/open-component-model/bindings/go/dag/sync"
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	helmv1 "ocm.software/open-component-model/bindings/go/helm/spec/access/v1"
+	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/access/v1"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+)
+
+// PlanAction is what a transfer does with a component version or resource
+// in a target repository.
+type PlanAction string
+
+const (
+	// PlanActionSkip means the target already holds the element with the same digest.
+	PlanActionSkip PlanAction = "skip"
+	// PlanActionCopy means the element is missing in the target.
+	PlanActionCopy PlanAction = "copy"
+	// PlanActionConflict means the target holds the element with a different
+	// digest, or a resource without a digest that cannot be compared.
+	PlanActionConflict PlanAction = "conflict"
+)
+
+// Plan describes what a transfer would change in its target repositories.
+type Plan struct {
+	// ComponentVersions holds one entry per discovered component version and target,
+	// sorted by component, version and the order of the targets.
+	ComponentVersions []ComponentVersionPlan `json:"componentVersions"`
+	// Bytes is the volume of all copied or conflicting resources whose size is known in advance.
+	Bytes int64 `json:"bytes"`
+	// UnknownSizeResources is the number of copied or conflicting resources whose size
+	// is not known in advance and therefore not part of Bytes.
+	UnknownSizeResources int `json:"unknownSizeResources"`
+}
+
+// ComponentVersionPlan describes what a transfer would change for a single
+// component version in a single target repository.
+type ComponentVersionPlan struct {
+	Component string        `json:"component"`
+	Version   string        `json:"version"`
+	Target    runtime.Typed `json:"target"`
+	Action    PlanAction    `json:"action"`
+	// Digest is the normalised digest of the source component version.
+	Digest string `json:"digest"`
+	// TargetDigest is the normalised digest of the component version present in the target.
+	TargetDigest string `json:"targetDigest,omitempty"`
+	// Resources holds the resources that the transfer copies with the component version.
+	Resources []ResourcePlan `json:"resources,omitempty"`
+}
+
+// ResourcePlan describes what a transfer would change for a single resource.
+type ResourcePlan struct {
+	Identity     runtime.Identity `json:"identity"`
+	Action       PlanAction       `json:"action"`
+	Digest       string           `json:"digest,omitempty"`
+	TargetDigest string           `json:"targetDigest,omitempty"`
+	// Size is the size of the resource in bytes, or [blob.SizeUnknown].
+	Size int64 `json:"size"`
+}
+
+// TargetOpener opens the target repository described by spec. An error wrapping
+// [repository.ErrNotFound] means the target does not exist yet, so everything
+// is planned to be copied to it.
+type TargetOpener func(ctx context.Context, spec runtime.Typed) (repository.ComponentVersionRepository, error)
+
+// BuildPlan discovers the component versions of the roots just like
+// [BuildGraphDefinition] and compares each of them and the resources it would
+// copy with the target repositories, without changing anything.
+//
+// Component versions are compared by their normalised digest, resources by the
+// digest in their descriptor. Sizes are only known for local blobs that report
+// their size without being downloaded.
+func BuildPlan(
+	ctx context.Context,
+	roots map[string]TransferRoot,
+	cfg transferv1alpha1.Config,
+	openTarget TargetOpener,
+) (*Plan, error) {
+	filter, err := newResourceFilter(cfg.Resources)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource filter: %w", err)
+	}
+
+	g, targetMap, err := discover(ctx, roots, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []*discoveryValue
+	_ = g.WithReadLock(func(d *dag.DirectedAcyclicGraph[string]) error {
+		for _, v := range d.Vertices {
+			values = append(values, v.Attributes[dagsync.AttributeValue].(*discoveryValue))
+		}
+		return nil
+	})
+	slices.SortFunc(values, func(a, b *discoveryValue) int {
+		return strings.Compare(
+			a.Descriptor.Component.Name+":"+a.Descriptor.Component.Version,
+			b.Descriptor.Component.Name+":"+b.Descriptor.Component.Version,
+		)
+	})
+
+	plan := &Plan{}
+	for _, val := range values {
+		key := val.Descriptor.Component.Name + ":" + val.Descriptor.Component.Version
+		for _, target := range targetMap[key] {
+			cvPlan, err := planComponentVersion(ctx, val, target, cfg.CopyMode, filter, openTarget)
+			if err != nil {
+				return nil, fmt.Errorf("planning transfer of %s: %w", key, err)
+			}
+			for _, res := range cvPlan.Resources {
+				if res.Action == PlanActionSkip {
+					continue
+				}
+				if res.Size == blob.SizeUnknown {
+					plan.UnknownSizeResources++
+				} else {
+					plan.Bytes += res.Size
+				}
+			}
+			plan.ComponentVersions = append(plan.ComponentVersions, *cvPlan)
+		}
+	}
+	return plan, nil
+}
+
+func planComponentVersion(
+	ctx context.Context,
+	val *discoveryValue,
+	target runtime.Typed,
+	copyMode transferv1alpha1.CopyMode,
+	filter *resourceFilter,
+	openTarget TargetOpener,
+) (*ComponentVersionPlan, error) {
+	desc := val.Descriptor
+	cvPlan := &ComponentVersionPlan{
+		Component: desc.Component.Name,
+		Version:   desc.Component.Version,
+		Target:    target,
+		Action:    PlanActionCopy,
+	}
+
+	digest, err := normalisedDigest(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	cvPlan.Digest = digest
+
+	targetDesc, err := lookupTarget(ctx, desc, target, openTarget)
+	if err != nil {
+		return nil, err
+	}
+	if targetDesc != nil {
+		if cvPlan.TargetDigest, err = normalisedDigest(ctx, targetDesc); err != nil {
+			return nil, err
+		}
+		cvPlan.Action = PlanActionConflict
+		if cvPlan.TargetDigest == cvPlan.Digest {
+			cvPlan.Action = PlanActionSkip
+		}
+	}
+
+	for _, resource := range desc.Component.Resources {
+		access, err := transferredAccess(resource, copyMode, filter)
+		if err != nil {
+			return nil, err
+		}
+		if access == nil {
+			continue
+		}
+		cvPlan.Resources = append(cvPlan.Resources, planResource(ctx, val, resource, access, targetDesc))
+	}
+	return cvPlan, nil
+}
+
+// lookupTarget returns the descriptor of the component version in the target,
+// or nil if the target or the component version in it does not exist.
+func lookupTarget(ctx context.Context, desc *descruntime.Descriptor, target runtime.Typed, openTarget TargetOpener) (*descruntime.Descriptor, error) {
+	repo, err := openTarget(ctx, target)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		slog.DebugContext(ctx, "target repository does not exist", "error", err)
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("opening target repository: %w", err)
+	}
+	targetDesc, err := repo.GetComponentVersion(ctx, desc.Component.Name, desc.Component.Version)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("looking up component version in target repository: %w", err)
+	}
+	return targetDesc, nil
+}
+
+func planResource(ctx context.Context, val *discoveryValue, resource descruntime.Resource, access runtime.Typed, targetDesc *descruntime.Descriptor) ResourcePlan {
+	identity := resource.ToIdentity()
+	resPlan := ResourcePlan{
+		Identity: identity,
+		Action:   PlanActionCopy,
+		Digest:   digestString(resource.Digest),
+		Size:     blob.SizeUnknown,
+	}
+	if targetDesc != nil {
+		for _, targetResource := range targetDesc.Component.Resources {
+			if !identity.Equal(targetResource.ToIdentity()) {
+				continue
+			}
+			resPlan.TargetDigest = digestString(targetResource.Digest)
+			resPlan.Action = PlanActionConflict
+			// without digests on both sides the resources cannot be compared
+			if resPlan.TargetDigest != "" && resPlan.TargetDigest == resPlan.Digest {
+				resPlan.Action = PlanActionSkip
+				resPlan.Size = 0
+				return resPlan
+			}
+			break
+		}
+	}
+	if _, ok := access.(*descriptorv2.LocalBlob); ok {
+		resPlan.Size = localBlobSize(ctx, val, resource)
+	}
+	return resPlan
+}
+
+// localBlobSize returns the size of a local blob resource if the source
+// repository knows it without downloading the blob.
+func localBlobSize(ctx context.Context, val *discoveryValue, resource descruntime.Resource) int64 {
+	if val.Repository == nil {
+		return blob.SizeUnknown
+	}
+	b, _, err := val.Repository.GetLocalResource(ctx, val.Descriptor.Component.Name, val.Descriptor.Component.Version, resource.ToIdentity())
+	if err != nil {
+		slog.DebugContext(ctx, "cannot determine size of local blob", "resource", resource.ToIdentity().String(), "error", err)
+		return blob.SizeUnknown
+	}
+	if sizeAware, ok := b.(blob.SizeAware); ok {
+		return sizeAware.Size()
+	}
+	return blob.SizeUnknown
+}
+
+// transferredAccess returns the typed access of the resource if a transfer with
+// the given copy mode and resource filter copies it, mirroring the decisions of
+// processResources, and nil otherwise.
+func transferredAccess(resource descruntime.Resource, copyMode transferv1alpha1.CopyMode, filter *resourceFilter) (runtime.Typed, error) {
+	if resource.Access == nil {
+		return nil, nil
+	}
+	access, err := scheme.NewObject(resource.Access.GetType())
+	if err != nil {
+		return nil, fmt.Errorf("cannot create new object for resource access type %q: %w", resource.Access.GetType().String(), err)
+	}
+	if err := scheme.Convert(resource.Access, access); err != nil {
+		return nil, fmt.Errorf("cannot convert resource access to typed object: %w", err)
+	}
+	switch access.(type) {
+	case *descriptorv2.LocalBlob:
+	case *ociv1.OCIImage, *helmv1.Helm:
+		if copyMode != transferv1alpha1.CopyModeAllResources {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+
+	v2resource, err := descruntime.ConvertToV2Resource(runtime.NewScheme(runtime.WithAllowUnknown()), &resource)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert resource to v2: %w", err)
+	}
+	selection, err := filter.Select(v2resource)
+	if err != nil {
+		return nil, fmt.Errorf("cannot filter resource %s: %w", resource.ToIdentity(), err)
+	}
+	if selection == resourceSelected {
+		return access, nil
+	}
+	if descriptorv2.IsLocalBlob(access) {
+		if selection == resourceExcluded {
+			return nil, fmt.Errorf("resource %s is a local blob and cannot be excluded from the transfer by the resource filter", resource.ToIdentity())
+		}
+		// local blobs cannot keep their original access, so they are copied even if they are not included
+		return access, nil
+	}
+	return nil, nil
+}
+
+// normalisedDigest returns the SHA-256 digest of the descriptor normalised with
+// [v4alpha1.Algorithm], which excludes resource accesses so that a transferred
+// component version has the same digest as its source.
+func normalisedDigest(ctx context.Context, desc *descruntime.Descriptor) (string, error) {
+	digest, err := signing.GenerateDigest(ctx, desc, slog.Default(), v4alpha1.Algorithm, crypto.SHA256.String())
+	if err != nil {
+		return "", fmt.Errorf("computing digest of %s:%s: %w", desc.Component.Name, desc.Component.Version, err)
+	}
+	return digestString(digest), nil
+}
+
+func digestString(digest *descruntime.Digest) string {
+	if digest == nil {
+		return ""
+	}
+	return digest.HashAlgorithm + ":" + digest.Value
+}
@@ -0,0 +1,251 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+)
+
+// planRepo serves descriptors like mockCVRepo, but reports missing component
+// versions with [repository.ErrNotFound] and serves local blobs of known size.
+type planRepo struct {
+	repository.ComponentVersionRepository
+	descriptors map[string]*descriptor.Descriptor
+	blobSizes   map[string]int64
+}
+
+func (m *planRepo) GetComponentVersion(_ context.Context, component, version string) (*descriptor.Descriptor, error) {
+	key := component + ":" + version
+	d, ok := m.descriptors[key]
+	if !ok {
+		return nil, fmt.Errorf("component version %s: %w", key, repository.ErrNotFound)
+	}
+	return d, nil
+}
+
+func (m *planRepo) GetLocalResource(_ context.Context, _, _ string, identity runtime.Identity) (blob.ReadOnlyBlob, *descriptor.Resource, error) {
+	size, ok := m.blobSizes[identity[descriptor.IdentityAttributeName]]
+	if !ok {
+		return nil, nil, fmt.Errorf("local resource %s not found", identity)
+	}
+	return inmemory.New(strings.NewReader(strings.Repeat("x", int(size))), inmemory.WithSize(size)), nil, nil
+}
+
+func withDigest(resource descriptor.Resource, value string) descriptor.Resource {
+	resource.Digest = &descriptor.Digest{
+		HashAlgorithm:          "SHA-256",
+		NormalisationAlgorithm: "genericBlobDigest/v1",
+		Value:                  value,
+	}
+	return resource
+}
+
+func planTestRoots(desc *descriptor.Descriptor, blobSizes map[string]int64, target runtime.Typed) map[string]TransferRoot {
+	key := desc.Component.Name + ":" + desc.Component.Version
+	source := &planRepo{
+		descriptors: map[string]*descriptor.Descriptor{key: desc},
+		blobSizes:   blobSizes,
+	}
+	resolver := &mockCVRepoResolver{
+		specs:      map[string]runtime.Typed{key: testOCIRepo("ghcr.io/source")},
+		repos:      map[string]repository.ComponentVersionRepository{key: source},
+		sharedRepo: source,
+	}
+	return testTransferRoots(desc.Component.Name, desc.Component.Version, target, resolver)
+}
+
+func targetOpener(descs ...*descriptor.Descriptor) TargetOpener {
+	target := &planRepo{descriptors: map[string]*descriptor.Descriptor{}}
+	for _, desc := range descs {
+		target.descriptors[desc.Component.Name+":"+desc.Component.Version] = desc
+	}
+	return func(context.Context, runtime.Typed) (repository.ComponentVersionRepository, error) {
+		return target, nil
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	const (
+		component = "ocm.software/test"
+		version   = "1.0.0"
+	)
+	blobSizes := map[string]int64{"blob": 42}
+	source := testDescriptor(component, version, []descriptor.Resource{
+		withDigest(localBlobResource("blob", version), "aaa"),
+		withDigest(ociImageResource("image", version, "ghcr.io/test/image:1.0.0"), "bbb"),
+	}, nil)
+	changed := testDescriptor(component, version, []descriptor.Resource{
+		withDigest(localBlobResource("blob", version), "ccc"),
+		withDigest(ociImageResource("image", version, "ghcr.io/test/image:1.0.0"), "bbb"),
+	}, nil)
+
+	tests := []struct {
+		name             string
+		copyMode         transferv1alpha1.CopyMode
+		target           TargetOpener
+		wantAction       PlanAction
+		wantResources    map[string]PlanAction
+		wantBytes        int64
+		wantUnknownSize  int
+		wantTargetDigest bool
+	}{
+		{
+			name:          "missing in target is copied",
+			copyMode:      transferv1alpha1.CopyModeLocalBlobResources,
+			target:        targetOpener(),
+			wantAction:    PlanActionCopy,
+			wantResources: map[string]PlanAction{"blob": PlanActionCopy},
+			wantBytes:     42,
+		},
+		{
+			name:     "missing target repository is copied",
+			copyMode: transferv1alpha1.CopyModeLocalBlobResources,
+			target: func(context.Context, runtime.Typed) (repository.ComponentVersionRepository, error) {
+				return nil, fmt.Errorf("target does not exist: %w", repository.ErrNotFound)
+			},
+			wantAction:    PlanActionCopy,
+			wantResources: map[string]PlanAction{"blob": PlanActionCopy},
+			wantBytes:     42,
+		},
+		{
+			name:            "all resources include external resources of unknown size",
+			copyMode:        transferv1alpha1.CopyModeAllResources,
+			target:          targetOpener(),
+			wantAction:      PlanActionCopy,
+			wantResources:   map[string]PlanAction{"blob": PlanActionCopy, "image": PlanActionCopy},
+			wantBytes:       42,
+			wantUnknownSize: 1,
+		},
+		{
+			name:             "identical in target is skipped",
+			copyMode:         transferv1alpha1.CopyModeAllResources,
+			target:           targetOpener(source),
+			wantAction:       PlanActionSkip,
+			wantResources:    map[string]PlanAction{"blob": PlanActionSkip, "image": PlanActionSkip},
+			wantTargetDigest: true,
+		},
+		{
+			name:             "different in target is a conflict",
+			copyMode:         transferv1alpha1.CopyModeAllResources,
+			target:           targetOpener(changed),
+			wantAction:       PlanActionConflict,
+			wantResources:    map[string]PlanAction{"blob": PlanActionConflict, "image": PlanActionSkip},
+			wantBytes:        42,
+			wantTargetDigest: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+			roots := planTestRoots(source, blobSizes, testOCIRepo("ghcr.io/target"))
+
+			plan, err := BuildPlan(t.Context(), roots, transferv1alpha1.Config{CopyMode: tc.copyMode}, tc.target)
+			r.NoError(err)
+			r.Len(plan.ComponentVersions, 1)
+
+			cv := plan.ComponentVersions[0]
+			assert.Equal(t, component, cv.Component)
+			assert.Equal(t, version, cv.Version)
+			assert.Equal(t, tc.wantAction, cv.Action)
+			assert.NotEmpty(t, cv.Digest)
+			if tc.wantTargetDigest {
+				assert.NotEmpty(t, cv.TargetDigest)
+			} else {
+				assert.Empty(t, cv.TargetDigest)
+			}
+
+			actions := make(map[string]PlanAction, len(cv.Resources))
+			for _, res := range cv.Resources {
+				actions[res.Identity[descriptor.IdentityAttributeName]] = res.Action
+			}
+			assert.Equal(t, tc.wantResources, actions)
+			assert.Equal(t, tc.wantBytes, plan.Bytes)
+			assert.Equal(t, tc.wantUnknownSize, plan.UnknownSizeResources)
+		})
+	}
+}
+
+func TestBuildPlan_SkipsOnlyOnMatchingNormalisedDigest(t *testing.T) {
+	source := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{
+		withDigest(localBlobResource("blob", "1.0.0"), "aaa"),
+	}, nil)
+	// a transferred copy differs only in the access, which is not part of the normalised digest
+	transferred := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{
+		withDigest(localBlobResource("blob", "1.0.0"), "aaa"),
+	}, nil)
+	transferred.Component.Resources[0].Access = ociImageResource("blob", "1.0.0", "ghcr.io/target/blob:1.0.0").Access
+	roots := planTestRoots(source, nil, testOCIRepo("ghcr.io/target"))
+
+	plan, err := BuildPlan(t.Context(), roots, transferv1alpha1.Config{CopyMode: transferv1alpha1.CopyModeLocalBlobResources}, targetOpener(transferred))
+	require.NoError(t, err)
+	require.Len(t, plan.ComponentVersions, 1)
+	assert.Equal(t, PlanActionSkip, plan.ComponentVersions[0].Action)
+	assert.Equal(t, plan.ComponentVersions[0].Digest, plan.ComponentVersions[0].TargetDigest)
+}
+
+func TestBuildPlan_ResourcesWithoutDigestAreNotSkipped(t *testing.T) {
+	source := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{localBlobResource("blob", "1.0.0")}, nil)
+	target := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{localBlobResource("blob", "1.0.0")}, nil)
+	roots := planTestRoots(source, nil, testOCIRepo("ghcr.io/target"))
+
+	plan, err := BuildPlan(t.Context(), roots, transferv1alpha1.Config{CopyMode: transferv1alpha1.CopyModeLocalBlobResources}, targetOpener(target))
+	require.NoError(t, err)
+	require.Len(t, plan.ComponentVersions, 1)
+	require.Len(t, plan.ComponentVersions[0].Resources, 1)
+	assert.Equal(t, PlanActionConflict, plan.ComponentVersions[0].Resources[0].Action)
+}
+
+func TestBuildPlan_ResourceFilter(t *testing.T) {
+	source := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{
+		withDigest(localBlobResource("blob", "1.0.0"), "aaa"),
+		withDigest(ociImageResource("image", "1.0.0", "ghcr.io/test/image:1.0.0"), "bbb"),
+	}, nil)
+	roots := planTestRoots(source, map[string]int64{"blob": 42}, testOCIRepo("ghcr.io/target"))
+
+	plan, err := BuildPlan(t.Context(), roots, transferv1alpha1.Config{
+		CopyMode:  transferv1alpha1.CopyModeAllResources,
+		Resources: &transferv1alpha1.ResourceFilter{Exclude: []transferv1alpha1.ResourceSelector{{Type: "ociImage"}}},
+	}, targetOpener())
+	require.NoError(t, err)
+	require.Len(t, plan.ComponentVersions, 1)
+	require.Len(t, plan.ComponentVersions[0].Resources, 1)
+	assert.Equal(t, "blob", plan.ComponentVersions[0].Resources[0].Identity[descriptor.IdentityAttributeName])
+	assert.Zero(t, plan.UnknownSizeResources)
+
+	plan, err = BuildPlan(t.Context(), roots, transferv1alpha1.Config{
+		CopyMode:  transferv1alpha1.CopyModeAllResources,
+		Resources: &transferv1alpha1.ResourceFilter{Include: []transferv1alpha1.ResourceSelector{{Type: "helmChart"}}},
+	}, targetOpener())
+	require.NoError(t, err)
+	require.Len(t, plan.ComponentVersions[0].Resources, 1)
+	assert.Equal(t, "blob", plan.ComponentVersions[0].Resources[0].Identity[descriptor.IdentityAttributeName])
+
+	_, err = BuildPlan(t.Context(), roots, transferv1alpha1.Config{
+		CopyMode:  transferv1alpha1.CopyModeAllResources,
+		Resources: &transferv1alpha1.ResourceFilter{Exclude: []transferv1alpha1.ResourceSelector{{Identity: map[string]string{"name": "blob"}}}},
+	}, targetOpener())
+	require.ErrorContains(t, err, "is a local blob and cannot be excluded")
+}
+
+func TestBuildPlan_TargetError(t *testing.T) {
+	source := testDescriptor("ocm.software/test", "1.0.0", nil, nil)
+	roots := planTestRoots(source, nil, testOCIRepo("ghcr.io/target"))
+
+	_, err := BuildPlan(t.Context(), roots, transferv1alpha1.Config{CopyMode: transferv1alpha1.CopyModeLocalBlobResources},
+		func(context.Context, runtime.Typed) (repository.ComponentVersionRepository, error) {
+			return nil, errors.New("unreachable")
+		})
+	require.ErrorContains(t, err, "unreachable")
+}
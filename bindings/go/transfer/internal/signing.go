@@ -0,0 +1,380 @@
+package internal
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1/meta"
+)
+
+const (
+	VerifyComponentVersionType    = "VerifyComponentVersion"
+	verifyComponentVersionVersion = "v1alpha1"
+	SignComponentVersionType      = "SignComponentVersion"
+	signComponentVersionVersion   = "v1alpha1"
+)
+
+var (
+	// VerifyComponentVersionVersionedType is the versioned type identifier for
+	// VerifyComponentVersion transformations.
+	VerifyComponentVersionVersionedType = runtime.NewVersionedType(VerifyComponentVersionType, verifyComponentVersionVersion)
+	// SignComponentVersionVersionedType is the versioned type identifier for
+	// SignComponentVersion transformations.
+	SignComponentVersionVersionedType = runtime.NewVersionedType(SignComponentVersionType, signComponentVersionVersion)
+)
+
+// SigningHandlerProvider returns the signing handler for a signing or verification
+// configuration, e.g. the signing registry of the plugin manager.
+type SigningHandlerProvider interface {
+	GetPlugin(ctx context.Context, spec runtime.Typed) (signing.Handler, error)
+}
+
+// VerifyComponentVersionSpec is the input specification for a VerifyComponentVersion transformation.
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type VerifyComponentVersionSpec struct {
+	// Descriptor is the component version in the source repository.
+	Descriptor *descriptorv2.Descriptor `json:"descriptor"`
+	// Verifier is the configuration of the signing handler that verifies the signatures.
+	Verifier *runtime.Raw `json:"verifier"`
+	// Signature is the name of the signature to verify. If empty, all signatures are verified.
+	Signature string `json:"signature,omitempty"`
+	// Repository is the source repository of the component version. If set, the content
+	// digests of its resources are verified against the descriptor as well.
+	Repository *runtime.Raw `json:"repository,omitempty"`
+}
+
+// VerifyComponentVersionOutput is the output of a VerifyComponentVersion transformation.
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type VerifyComponentVersionOutput struct {
+	// Verified is true once all selected signatures were verified.
+	// A failed verification fails the transformation instead.
+	Verified bool `json:"verified"`
+}
+
+// VerifyComponentVersionTransformation is a transformation specification that verifies
+// the signatures of a component version before it is transferred. All other
+// transformations of the component version depend on its output.
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type VerifyComponentVersionTransformation struct {
+	// +ocm:jsonschema-gen:enum=VerifyComponentVersion/v1alpha1
+	Type   runtime.Type                  `json:"type"`
+	ID     string                        `json:"id"`
+	Spec   *VerifyComponentVersionSpec   `json:"spec"`
+	Output *VerifyComponentVersionOutput `json:"output,omitempty"`
+}
+
+// SignComponentVersionSpec is the input specification for a SignComponentVersion transformation.
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type SignComponentVersionSpec struct {
+	// Repository is the target repository the component version was transferred to.
+	Repository *runtime.Raw `json:"repository"`
+	// Descriptor is the component version as it was added to the target repository.
+	Descriptor *descriptorv2.Descriptor `json:"descriptor"`
+	// Signature is the name of the signature to add or replace.
+	Signature string `json:"signature"`
+	// Signer is the configuration of the signing handler.
+	Signer *runtime.Raw `json:"signer"`
+	// NormalisationAlgorithm is the algorithm used to normalise the descriptor before hashing.
+	NormalisationAlgorithm string `json:"normalisationAlgorithm,omitempty"`
+	// HashAlgorithm is the algorithm used to hash the normalised descriptor.
+	HashAlgorithm string `json:"hashAlgorithm,omitempty"`
+}
+
+// SignComponentVersionOutput is the output of a SignComponentVersion transformation.
+// +k8s:deepcopy-gen=true
+// +ocm:jsonschema-gen=true
+type SignComponentVersionOutput struct {
+	// Signature is the signature added to the component version.
+	Signature *descriptorv2.Signature `json:"signature"`
+}
+
+// SignComponentVersionTransformation is a transformation specification that signs a
+// component version after it was added to the target repository and updates it there.
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
+// +ocm:typegen=true
+// +ocm:jsonschema-gen=true
+type SignComponentVersionTransformation struct {
+	// +ocm:jsonschema-gen:enum=SignComponentVersion/v1alpha1
+	Type   runtime.Type                `json:"type"`
+	ID     string                      `json:"id"`
+	Spec   *SignComponentVersionSpec   `json:"spec"`
+	Output *SignComponentVersionOutput `json:"output,omitempty"`
+}
+
+// VerifyComponentVersion is a transformer that verifies the digest and the signatures of
+// a component version in the same way as "ocm verify component-version". If the spec
+// has a repository, it also verifies the content digests of the resources, see
+// [verifyResourceDigests].
+type VerifyComponentVersion struct {
+	Scheme             *runtime.Scheme
+	RepoProvider       repository.ComponentVersionRepositoryProvider
+	Handlers           SigningHandlerProvider
+	DigestProcessors   ResourceDigestProcessorProvider
+	CredentialProvider credentials.Resolver
+}
+
+func (t *VerifyComponentVersion) Transform(ctx context.Context, step runtime.Typed) (runtime.Typed, error) {
+	var transformation VerifyComponentVersionTransformation
+	if err := t.Scheme.Convert(step, &transformation); err != nil {
+		return nil, fmt.Errorf("failed converting generic transformation to component version verification: %w", err)
+	}
+	if transformation.Spec == nil || transformation.Spec.Descriptor == nil || transformation.Spec.Verifier == nil {
+		return nil, fmt.Errorf("descriptor and verifier are required for component version verification")
+	}
+	if t.Handlers == nil {
+		return nil, fmt.Errorf("no signing handlers available to verify component versions")
+	}
+
+	desc, err := descruntime.ConvertFromV2(transformation.Spec.Descriptor)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting component version from v2: %w", err)
+	}
+	component, version := desc.Component.Name, desc.Component.Version
+
+	signatures := desc.Signatures
+	if name := transformation.Spec.Signature; name != "" {
+		signatures = slices.DeleteFunc(slices.Clone(signatures), func(sig descruntime.Signature) bool {
+			return sig.Name != name
+		})
+	}
+	if len(signatures) == 0 {
+		return nil, fmt.Errorf("component version %s:%s has no signatures to verify", component, version)
+	}
+
+	handler, err := t.Handlers.GetPlugin(ctx, transformation.Spec.Verifier)
+	if err != nil {
+		return nil, fmt.Errorf("getting signature handler failed: %w", err)
+	}
+
+	logger := slog.Default()
+	for _, signature := range signatures {
+		if err := signing.VerifyDigestMatchesDescriptor(ctx, desc, signature, logger); err != nil {
+			return nil, fmt.Errorf("verifying signature %q of %s:%s failed: %w", signature.Name, component, version, err)
+		}
+		var creds runtime.Typed
+		if t.CredentialProvider != nil {
+			if consumerID, err := handler.GetVerifyingCredentialConsumerIdentity(ctx, signature, transformation.Spec.Verifier); err == nil {
+				if creds, err = t.CredentialProvider.Resolve(ctx, consumerID); err != nil && !errors.Is(err, credentials.ErrNotFound) {
+					return nil, fmt.Errorf("resolving credentials for verification failed: %w", err)
+				}
+			}
+		}
+		if err := handler.Verify(ctx, signature, transformation.Spec.Verifier, creds); err != nil {
+			return nil, fmt.Errorf("verifying signature %q of %s:%s failed: %w", signature.Name, component, version, err)
+		}
+		slog.InfoContext(ctx, "verified signature", "component", component, "version", version, "signature", signature.Name)
+	}
+
+	if transformation.Spec.Repository != nil {
+		if err := t.verifyResourceDigests(ctx, transformation.Spec.Repository, desc); err != nil {
+			return nil, err
+		}
+	}
+
+	transformation.Output = &VerifyComponentVersionOutput{Verified: true}
+	return &transformation, nil
+}
+
+// SignComponentVersion is a transformer that signs a component version in the same way
+// as "ocm sign component-version --force" and adds it to the target repository again.
+type SignComponentVersion struct {
+	Scheme             *runtime.Scheme
+	RepoProvider       repository.ComponentVersionRepositoryProvider
+	Handlers           SigningHandlerProvider
+	CredentialProvider credentials.Resolver
+}
+
+func (t *SignComponentVersion) Transform(ctx context.Context, step runtime.Typed) (runtime.Typed, error) {
+	var transformation SignComponentVersionTransformation
+	if err := t.Scheme.Convert(step, &transformation); err != nil {
+		return nil, fmt.Errorf("failed converting generic transformation to component version signing: %w", err)
+	}
+	spec := transformation.Spec
+	if spec == nil || spec.Repository == nil || spec.Descriptor == nil || spec.Signer == nil || spec.Signature == "" {
+		return nil, fmt.Errorf("repository, descriptor, signature and signer are required for component version signing")
+	}
+	if t.Handlers == nil {
+		return nil, fmt.Errorf("no signing handlers available to sign component versions")
+	}
+
+	desc, err := descruntime.ConvertFromV2(spec.Descriptor)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting component version from v2: %w", err)
+	}
+	component, version := desc.Component.Name, desc.Component.Version
+
+	logger := slog.Default()
+	if err := signing.IsSafelyDigestible(&desc.Component); err != nil {
+		logger.WarnContext(ctx, "component version not safely digestible", "component", component, "version", version, "error", err.Error())
+	}
+
+	digest, err := signing.GenerateDigest(ctx, desc, logger, spec.NormalisationAlgorithm, spec.HashAlgorithm)
+	if err != nil {
+		return nil, fmt.Errorf("generating digest of %s:%s failed: %w", component, version, err)
+	}
+
+	handler, err := t.Handlers.GetPlugin(ctx, spec.Signer)
+	if err != nil {
+		return nil, fmt.Errorf("getting signature handler failed: %w", err)
+	}
+
+	var signingCreds runtime.Typed
+	if t.CredentialProvider != nil {
+		if consumerID, err := handler.GetSigningCredentialConsumerIdentity(ctx, spec.Signature, *digest, spec.Signer); err == nil {
+			if signingCreds, err = t.CredentialProvider.Resolve(ctx, consumerID); err != nil && !errors.Is(err, credentials.ErrNotFound) {
+				return nil, fmt.Errorf("resolving signing credentials failed: %w", err)
+			}
+		}
+	}
+
+	info, err := handler.Sign(ctx, *digest, spec.Signer, signingCreds)
+	if err != nil {
+		return nil, fmt.Errorf("signing %s:%s failed: %w", component, version, err)
+	}
+	signature := descruntime.Signature{
+		Name:      spec.Signature,
+		Digest:    *digest,
+		Signature: info,
+	}
+	if idx := slices.IndexFunc(desc.Signatures, func(sig descruntime.Signature) bool { return sig.Name == spec.Signature }); idx >= 0 {
+		desc.Signatures[idx] = signature
+	} else {
+		desc.Signatures = append(desc.Signatures, signature)
+	}
+
+	repoSpec, err := convertToConcreteRepo(spec.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("converting repository spec: %w", err)
+	}
+	var repoCreds runtime.Typed
+	if t.CredentialProvider != nil {
+		if consumerID, err := t.RepoProvider.GetComponentVersionRepositoryCredentialConsumerIdentity(ctx, repoSpec); err == nil {
+			if repoCreds, err = t.CredentialProvider.Resolve(ctx, consumerID); err != nil && !errors.Is(err, credentials.ErrNotFound) {
+				return nil, fmt.Errorf("failed resolving credentials: %w", err)
+			}
+		}
+	}
+	repo, err := t.RepoProvider.GetComponentVersionRepository(ctx, repoSpec, repoCreds)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting component version repository: %w", err)
+	}
+	if err := repo.AddComponentVersion(ctx, desc); err != nil {
+		return nil, fmt.Errorf("updating signed component version %s:%s failed: %w", component, version, err)
+	}
+	slog.InfoContext(ctx, "signed component version", "component", component, "version", version,
+		"signature", spec.Signature, "digest", digest.Value)
+
+	transformation.Output = &SignComponentVersionOutput{Signature: descruntime.ConvertToV2Signature(&signature)}
+	return &transformation, nil
+}
+
+// addVerifyTransformation appends a VerifyComponentVersion transformation with the given
+// policy for the component version stored in the environment under envID and returns the
+// when expression that gates the transformations of the component version.
+func addVerifyTransformation(envID string, sourceRepo runtime.Typed, policy *transferv1alpha1.VerificationPolicy, tgd *transformv1alpha1.TransformationGraphDefinition) (string, error) {
+	verifier, err := asUnstructured(policy.Verifier)
+	if err != nil {
+		return "", fmt.Errorf("cannot convert verifier to unstructured: %w", err)
+	}
+	repo, err := asUnstructured(sourceRepo)
+	if err != nil {
+		return "", fmt.Errorf("cannot convert source repository spec to unstructured: %w", err)
+	}
+	data := map[string]any{
+		"descriptor": fmt.Sprintf("${environment.%s}", envID),
+		"verifier":   verifier.Data,
+		"repository": repo.Data,
+	}
+	if policy.Signature != "" {
+		data["signature"] = policy.Signature
+	}
+
+	verifyID := envID + "Verify"
+	tgd.Transformations = append(tgd.Transformations, transformv1alpha1.GenericTransformation{
+		TransformationMeta: meta.TransformationMeta{
+			Type: VerifyComponentVersionVersionedType,
+			ID:   verifyID,
+		},
+		Spec: &runtime.Unstructured{Data: data},
+	})
+	return fmt.Sprintf("${%s.output.verified}", verifyID), nil
+}
+
+// addSignTransformation appends a SignComponentVersion transformation that signs the
+// descriptor uploaded by the upload transformation of id.
+func addSignTransformation(id string, toSpec runtime.Typed, cfg *transferv1alpha1.Signing, tgd *transformv1alpha1.TransformationGraphDefinition) error {
+	toRepo, err := asUnstructured(toSpec)
+	if err != nil {
+		return fmt.Errorf("cannot convert target spec to unstructured: %w", err)
+	}
+	signer, err := asUnstructured(cfg.Signer)
+	if err != nil {
+		return fmt.Errorf("cannot convert signer to unstructured: %w", err)
+	}
+
+	signature := cfg.Signature
+	if signature == "" {
+		signature = transferv1alpha1.DefaultSignatureName
+	}
+	normalisationAlgorithm := cfg.NormalisationAlgorithm
+	if normalisationAlgorithm == "" {
+		normalisationAlgorithm = v4alpha1.Algorithm
+	}
+	hashAlgorithm := cfg.HashAlgorithm
+	if hashAlgorithm == "" {
+		hashAlgorithm = crypto.SHA256.String()
+	}
+
+	tgd.Transformations = append(tgd.Transformations, transformv1alpha1.GenericTransformation{
+		TransformationMeta: meta.TransformationMeta{
+			Type: SignComponentVersionVersionedType,
+			ID:   id + "Sign",
+		},
+		Spec: &runtime.Unstructured{Data: map[string]any{
+			"repository":             toRepo.Data,
+			"descriptor":             fmt.Sprintf("${%sUpload.spec.descriptor}", id),
+			"signature":              signature,
+			"signer":                 signer.Data,
+			"normalisationAlgorithm": normalisationAlgorithm,
+			"hashAlgorithm":          hashAlgorithm,
+		}},
+	})
+	return nil
+}
+
+// joinWhen combines when expressions of the form "${...}" into one expression
+// that holds if all of them hold. Empty expressions are ignored.
+func joinWhen(expressions ...string) string {
+	var conditions []string
+	for _, expression := range expressions {
+		if expression == "" {
+			continue
+		}
+		conditions = append(conditions, strings.TrimSuffix(strings.TrimPrefix(expression, "${"), "}"))
+	}
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "${" + strings.Join(conditions, " && ") + "}"
+}
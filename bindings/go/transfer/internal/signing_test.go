@@ -0,0 +1,267 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+)
+
+// fakeSigningHandler signs a digest with its value and accepts signatures whose
+// value equals their digest value.
+type fakeSigningHandler struct{}
+
+func (fakeSigningHandler) GetSigningCredentialConsumerIdentity(context.Context, string, descriptor.Digest, runtime.Typed) (runtime.Identity, error) {
+	return nil, errors.New("no credentials")
+}
+
+func (fakeSigningHandler) Sign(_ context.Context, unsigned descriptor.Digest, _ runtime.Typed, _ runtime.Typed) (descriptor.SignatureInfo, error) {
+	return descriptor.SignatureInfo{Algorithm: "fake", MediaType: "text/plain", Value: unsigned.Value}, nil
+}
+
+func (fakeSigningHandler) GetVerifyingCredentialConsumerIdentity(context.Context, descriptor.Signature, runtime.Typed) (runtime.Identity, error) {
+	return nil, errors.New("no credentials")
+}
+
+func (fakeSigningHandler) Verify(_ context.Context, signed descriptor.Signature, _ runtime.Typed, _ runtime.Typed) error {
+	if signed.Signature.Value != signed.Digest.Value {
+		return errors.New("signature does not match digest")
+	}
+	return nil
+}
+
+type fakeSigningHandlers struct{}
+
+func (fakeSigningHandlers) GetPlugin(context.Context, runtime.Typed) (signing.Handler, error) {
+	return fakeSigningHandler{}, nil
+}
+
+// signedRepo records the component versions added to it.
+type signedRepo struct {
+	repository.ComponentVersionRepository
+	added []*descriptor.Descriptor
+}
+
+func (r *signedRepo) AddComponentVersion(_ context.Context, desc *descriptor.Descriptor) error {
+	r.added = append(r.added, desc)
+	return nil
+}
+
+func fakeSignerSpec() *runtime.Raw {
+	return &runtime.Raw{Type: runtime.NewUnversionedType("FakeSigningConfiguration"), Data: []byte(`{"type":"FakeSigningConfiguration"}`)}
+}
+
+// signDescriptor signs desc with the fake handler under the given signature name.
+func signDescriptor(t *testing.T, desc *descriptor.Descriptor, name string) *descriptor.Descriptor {
+	t.Helper()
+	repo := &signedRepo{}
+	transformer := newSignTransformer(repo)
+	_, err := transformer.Transform(t.Context(), newSignTransformation(t, desc, name))
+	require.NoError(t, err)
+	require.Len(t, repo.added, 1)
+	return repo.added[0]
+}
+
+func newSignTransformer(repo repository.ComponentVersionRepository) *SignComponentVersion {
+	s := runtime.NewScheme()
+	s.MustRegisterWithAlias(&SignComponentVersionTransformation{}, SignComponentVersionVersionedType)
+	return &SignComponentVersion{
+		Scheme:       s,
+		RepoProvider: &mockRepoProvider{repo: repo},
+		Handlers:     fakeSigningHandlers{},
+	}
+}
+
+func newSignTransformation(t *testing.T, desc *descriptor.Descriptor, name string) *SignComponentVersionTransformation {
+	t.Helper()
+	var repo runtime.Raw
+	require.NoError(t, runtime.NewScheme(runtime.WithAllowUnknown()).Convert(testOCIRepo("ghcr.io/target"), &repo))
+	v2desc, err := descriptor.ConvertToV2(runtime.NewScheme(runtime.WithAllowUnknown()), desc)
+	require.NoError(t, err)
+	return &SignComponentVersionTransformation{
+		Type: SignComponentVersionVersionedType,
+		ID:   "sign",
+		Spec: &SignComponentVersionSpec{
+			Repository:             &repo,
+			Descriptor:             v2desc,
+			Signature:              name,
+			Signer:                 fakeSignerSpec(),
+			NormalisationAlgorithm: "jsonNormalisation/v4alpha1",
+			HashAlgorithm:          "SHA-256",
+		},
+	}
+}
+
+func TestSignComponentVersion_Transform(t *testing.T) {
+	desc := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{
+		withDigest(localBlobResource("blob", "1.0.0"), "aaa"),
+	}, nil)
+
+	signed := signDescriptor(t, desc, "sovereign")
+	require.Len(t, signed.Signatures, 1)
+	assert.Equal(t, "sovereign", signed.Signatures[0].Name)
+	assert.Equal(t, signed.Signatures[0].Digest.Value, signed.Signatures[0].Signature.Value)
+
+	t.Run("signing again replaces the signature with the same name", func(t *testing.T) {
+		resigned := signDescriptor(t, signed, "sovereign")
+		require.Len(t, resigned.Signatures, 1)
+		assert.Equal(t, signed.Signatures[0].Digest, resigned.Signatures[0].Digest)
+	})
+
+	t.Run("another name adds a signature", func(t *testing.T) {
+		resigned := signDescriptor(t, signed, "default")
+		require.Len(t, resigned.Signatures, 2)
+	})
+
+	t.Run("without handlers", func(t *testing.T) {
+		transformer := newSignTransformer(&signedRepo{})
+		transformer.Handlers = nil
+		_, err := transformer.Transform(t.Context(), newSignTransformation(t, desc, "default"))
+		require.ErrorContains(t, err, "no signing handlers available")
+	})
+}
+
+func TestVerifyComponentVersion_Transform(t *testing.T) {
+	desc := testDescriptor("ocm.software/test", "1.0.0", []descriptor.Resource{
+		withDigest(localBlobResource("blob", "1.0.0"), "aaa"),
+	}, nil)
+	signed := signDescriptor(t, desc, "default")
+
+	tampered := signDescriptor(t, desc, "default")
+	tampered.Signatures[0].Signature.Value = "forged"
+
+	modified := signDescriptor(t, desc, "default")
+	modified.Component.Resources[0].Digest.Value = "bbb"
+
+	tests := []struct {
+		name      string
+		desc      *descriptor.Descriptor
+		signature string
+		wantErr   string
+	}{
+		{name: "valid signature", desc: signed},
+		{name: "selected signature", desc: signed, signature: "default"},
+		{name: "unknown signature name", desc: signed, signature: "other", wantErr: "has no signatures to verify"},
+		{name: "unsigned", desc: desc, wantErr: "has no signatures to verify"},
+		{name: "invalid signature", desc: tampered, wantErr: "signature does not match digest"},
+		{name: "modified descriptor", desc: modified, wantErr: `verifying signature "default" of ocm.software/test:1.0.0 failed`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v2desc, err := descriptor.ConvertToV2(runtime.NewScheme(runtime.WithAllowUnknown()), tc.desc)
+			require.NoError(t, err)
+			s := runtime.NewScheme()
+			s.MustRegisterWithAlias(&VerifyComponentVersionTransformation{}, VerifyComponentVersionVersionedType)
+			transformer := &VerifyComponentVersion{Scheme: s, Handlers: fakeSigningHandlers{}}
+
+			result, err := transformer.Transform(t.Context(), &VerifyComponentVersionTransformation{
+				Type: VerifyComponentVersionVersionedType,
+				ID:   "verify",
+				Spec: &VerifyComponentVersionSpec{
+					Descriptor: v2desc,
+					Verifier:   fakeSignerSpec(),
+					Signature:  tc.signature,
+				},
+			})
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			verify, ok := result.(*VerifyComponentVersionTransformation)
+			require.True(t, ok)
+			require.NotNil(t, verify.Output)
+			assert.True(t, verify.Output.Verified)
+		})
+	}
+}
+
+func TestBuildGraphDefinition_Signing(t *testing.T) {
+	sourceRepo := testOCIRepo("ghcr.io/source")
+	targetRepo := testOCIRepo("ghcr.io/target")
+	desc := testDescriptor("ocm.software/test", "1.0.0",
+		[]descriptor.Resource{localBlobResource("my-resource", "1.0.0")}, nil)
+	resolver := testResolverFor("ocm.software/test", "1.0.0", sourceRepo, desc)
+	roots := testTransferRoots("ocm.software/test", "1.0.0", targetRepo, resolver)
+
+	findType := func(tgd *transformv1alpha1.TransformationGraphDefinition, typ runtime.Type) *transformv1alpha1.GenericTransformation {
+		for i, tr := range tgd.Transformations {
+			if tr.Type == typ {
+				return &tgd.Transformations[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("signing requires verification by default", func(t *testing.T) {
+		_, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+			CopyMode: transferv1alpha1.CopyModeLocalBlobResources,
+			Signing:  &transferv1alpha1.Signing{Signer: fakeSignerSpec()},
+		})
+		require.ErrorContains(t, err, "signing requires a verifier")
+	})
+
+	t.Run("signs the uploaded descriptor", func(t *testing.T) {
+		tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+			CopyMode: transferv1alpha1.CopyModeLocalBlobResources,
+			Signing:  &transferv1alpha1.Signing{Signer: fakeSignerSpec(), SkipVerification: true},
+		})
+		require.NoError(t, err)
+
+		assert.Nil(t, findType(tgd, VerifyComponentVersionVersionedType))
+		sign := findType(tgd, SignComponentVersionVersionedType)
+		require.NotNil(t, sign)
+		assert.Equal(t, "${"+strings.TrimSuffix(sign.ID, "Sign")+"Upload.spec.descriptor}", sign.Spec.Data["descriptor"])
+		assert.Equal(t, transferv1alpha1.DefaultSignatureName, sign.Spec.Data["signature"])
+		assert.Equal(t, "SHA-256", sign.Spec.Data["hashAlgorithm"])
+		assert.Empty(t, sign.When)
+	})
+
+	t.Run("verification gates all transformations of the component version", func(t *testing.T) {
+		tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+			CopyMode:                       transferv1alpha1.CopyModeLocalBlobResources,
+			ComponentVersionConflictPolicy: transferv1alpha1.ComponentVersionConflictSkip,
+			Signing: &transferv1alpha1.Signing{
+				Signature:       "sovereign",
+				Signer:          fakeSignerSpec(),
+				Verifier:        fakeSignerSpec(),
+				VerifySignature: "default",
+			},
+		})
+		require.NoError(t, err)
+
+		verify := findType(tgd, VerifyComponentVersionVersionedType)
+		require.NotNil(t, verify)
+		assert.Empty(t, verify.When)
+		assert.Equal(t, "default", verify.Spec.Data["signature"])
+		check := findType(tgd, CheckComponentVersionConflictVersionedType)
+		require.NotNil(t, check)
+		assert.Empty(t, check.When)
+
+		want := "${" + verify.ID + ".output.verified && " + check.ID + ".output.transfer}"
+		for _, tr := range tgd.Transformations {
+			switch tr.Type {
+			case VerifyComponentVersionVersionedType, CheckComponentVersionConflictVersionedType, FileCleanupVersionedType:
+			default:
+				assert.Equal(t, want, tr.When, tr.ID)
+			}
+		}
+		assert.Equal(t, "sovereign", findType(tgd, SignComponentVersionVersionedType).Spec.Data["signature"])
+	})
+}
+
+func TestJoinWhen(t *testing.T) {
+	assert.Empty(t, joinWhen("", ""))
+	assert.Equal(t, "${a.output.x}", joinWhen("", "${a.output.x}"))
+	assert.Equal(t, "${a.output.x && b.output.y}", joinWhen("${a.output.x}", "${b.output.y}"))
+}
@@ -0,0 +1,230 @@
+package internal
+
+import (
+	"context"
+	"crypto"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+)
+
+// ResourceDigestProcessorProvider returns the digest processor for the access of a
+// resource, e.g. the digest processor registry of the plugin manager. The digest
+// processor fails if the digest of the resource does not match its content.
+type ResourceDigestProcessorProvider interface {
+	GetDigestProcessor(ctx context.Context, resource *descruntime.Resource) (repository.ResourceDigestProcessor, error)
+}
+
+// verificationPolicies selects the [transferv1alpha1.VerificationPolicy] of a
+// component by its name.
+type verificationPolicies struct {
+	policies []verificationPolicy
+	// fallback applies to components that match no policy.
+	fallback *transferv1alpha1.VerificationPolicy
+}
+
+type verificationPolicy struct {
+	transferv1alpha1.VerificationPolicy
+	pattern glob.Glob
+}
+
+// newVerificationPolicies compiles the verification policies of cfg. The verifier of
+// the signing configuration applies to components that match no policy, so that
+// re-signed component versions are verified unless verification is skipped explicitly.
+func newVerificationPolicies(cfg transferv1alpha1.Config) (*verificationPolicies, error) {
+	policies := &verificationPolicies{}
+	if cfg.Verification != nil {
+		for i, policy := range cfg.Verification.Policies {
+			pattern, err := glob.Compile(policy.ComponentNamePattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid componentNamePattern %q in policies[%d]: %w", policy.ComponentNamePattern, i, err)
+			}
+			policies.policies = append(policies.policies, verificationPolicy{VerificationPolicy: policy, pattern: pattern})
+		}
+	}
+	if cfg.Signing != nil && !cfg.Signing.SkipVerification {
+		if cfg.Signing.Verifier == nil {
+			return nil, errors.New("signing requires a verifier to verify the source signatures before re-signing, or skipVerification to re-sign without verification")
+		}
+		policies.fallback = &transferv1alpha1.VerificationPolicy{
+			ComponentNamePattern: "*",
+			Verifier:             cfg.Signing.Verifier,
+			Signature:            cfg.Signing.VerifySignature,
+		}
+	}
+	return policies, nil
+}
+
+// For returns the policy that verifies the component, or nil if it is not verified.
+func (p *verificationPolicies) For(component string) *transferv1alpha1.VerificationPolicy {
+	for _, policy := range p.policies {
+		if !policy.pattern.Match(component) {
+			continue
+		}
+		if policy.Skip {
+			return nil
+		}
+		return &policy.VerificationPolicy
+	}
+	return p.fallback
+}
+
+// verifyResourceDigests checks that the content of every resource with an access matches
+// the digest in the descriptor. Local blobs are downloaded from the source repository
+// and hashed, unless they are stored as OCI manifests, whose digest is checked by the
+// repository while downloading them. For all other accesses, the digest processor of
+// the access verifies the digest, as when adding a component version.
+func (t *VerifyComponentVersion) verifyResourceDigests(ctx context.Context, repoSpec *runtime.Raw, desc *descruntime.Descriptor) error {
+	component, version := desc.Component.Name, desc.Component.Version
+	if err := signing.IsSafelyDigestible(&desc.Component); err != nil {
+		return fmt.Errorf("component version %s:%s cannot be verified: %w", component, version, err)
+	}
+
+	var repo repository.ComponentVersionRepository
+	for i := range desc.Component.Resources {
+		resource := &desc.Component.Resources[i]
+		if resource.Access == nil || resource.Access.GetType().String() == signing.AccessTypeNone {
+			continue
+		}
+		identity := resource.ToIdentity()
+
+		var localBlob descriptorv2.LocalBlob
+		if err := descriptorv2.Scheme.Convert(resource.Access, &localBlob); err != nil {
+			if err := t.verifyResourceDigest(ctx, resource); err != nil {
+				return fmt.Errorf("verifying digest of resource %s of %s:%s failed: %w", identity, component, version, err)
+			}
+			continue
+		}
+
+		if repo == nil {
+			var err error
+			if repo, err = t.sourceRepository(ctx, repoSpec); err != nil {
+				return err
+			}
+		}
+		if err := verifyLocalBlobDigest(ctx, repo, component, version, resource, &localBlob); err != nil {
+			return fmt.Errorf("verifying digest of resource %s of %s:%s failed: %w", identity, component, version, err)
+		}
+	}
+	slog.InfoContext(ctx, "verified resource digests", "component", component, "version", version,
+		"resources", len(desc.Component.Resources))
+	return nil
+}
+
+func (t *VerifyComponentVersion) sourceRepository(ctx context.Context, repoSpec *runtime.Raw) (repository.ComponentVersionRepository, error) {
+	if t.RepoProvider == nil {
+		return nil, fmt.Errorf("no repository provider available to verify local resource digests")
+	}
+	spec, err := convertToConcreteRepo(repoSpec)
+	if err != nil {
+		return nil, fmt.Errorf("converting repository spec: %w", err)
+	}
+	var creds runtime.Typed
+	if t.CredentialProvider != nil {
+		if consumerID, err := t.RepoProvider.GetComponentVersionRepositoryCredentialConsumerIdentity(ctx, spec); err == nil {
+			if creds, err = t.CredentialProvider.Resolve(ctx, consumerID); err != nil && !errors.Is(err, credentials.ErrNotFound) {
+				return nil, fmt.Errorf("failed resolving credentials: %w", err)
+			}
+		}
+	}
+	repo, err := t.RepoProvider.GetComponentVersionRepository(ctx, spec, creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting component version repository: %w", err)
+	}
+	return repo, nil
+}
+
+// verifyResourceDigest verifies the digest of a resource with the digest processor of its access.
+func (t *VerifyComponentVersion) verifyResourceDigest(ctx context.Context, resource *descruntime.Resource) error {
+	if t.DigestProcessors == nil {
+		return fmt.Errorf("no digest processors available to verify resources with access type %s", resource.Access.GetType())
+	}
+	processor, err := t.DigestProcessors.GetDigestProcessor(ctx, resource)
+	if err != nil {
+		return fmt.Errorf("getting digest processor for access type %s failed: %w", resource.Access.GetType(), err)
+	}
+	var creds runtime.Typed
+	if t.CredentialProvider != nil {
+		if consumerID, err := processor.GetResourceDigestProcessorCredentialConsumerIdentity(ctx, resource); err == nil {
+			if creds, err = t.CredentialProvider.Resolve(ctx, consumerID); err != nil && !errors.Is(err, credentials.ErrNotFound) {
+				return fmt.Errorf("resolving credentials for digest processing failed: %w", err)
+			}
+		}
+	}
+	processed, err := processor.ProcessResourceDigest(ctx, resource, creds)
+	if err != nil {
+		return err
+	}
+	if processed.Digest == nil || processed.Digest.HashAlgorithm != resource.Digest.HashAlgorithm || processed.Digest.Value != resource.Digest.Value {
+		return fmt.Errorf("digest mismatch: descriptor %+v vs content %+v", resource.Digest, processed.Digest)
+	}
+	return nil
+}
+
+// verifyLocalBlobDigest verifies the digest of a local blob resource against its content
+// in the source repository.
+func verifyLocalBlobDigest(ctx context.Context, repo repository.ComponentVersionRepository, component, version string, resource *descruntime.Resource, localBlob *descriptorv2.LocalBlob) (err error) {
+	if resource.Digest == nil {
+		return fmt.Errorf("resource has no digest")
+	}
+	hash, err := signing.SupportedHash(resource.Digest.HashAlgorithm)
+	if err != nil {
+		return err
+	}
+	expected := resource.Digest.HashAlgorithm + ":" + resource.Digest.Value
+	// The local reference of content-addressed repositories is the digest of the blob.
+	if reference, err := digest.Parse(localBlob.LocalReference); err == nil &&
+		reference.Algorithm() == contentDigestAlgorithm(hash) && reference.Encoded() != resource.Digest.Value {
+		return fmt.Errorf("digest mismatch: descriptor %s vs local reference %s", expected, reference)
+	}
+
+	content, _, err := repo.GetLocalResource(ctx, component, version, resource.ToIdentity())
+	if err != nil {
+		return fmt.Errorf("getting local resource failed: %w", err)
+	}
+	reader, err := content.ReadCloser()
+	if err != nil {
+		return fmt.Errorf("reading local resource failed: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+
+	if isOCICompliantManifest(localBlob.MediaType) {
+		// The blob is an OCI layout assembled from the manifest with the expected digest,
+		// whose content was verified by the repository, so only drain it.
+		if _, err := io.Copy(io.Discard, reader); err != nil {
+			return fmt.Errorf("reading local resource failed: %w", err)
+		}
+		return nil
+	}
+
+	hasher := hash.New()
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return fmt.Errorf("reading local resource failed: %w", err)
+	}
+	if hex.EncodeToString(hasher.Sum(nil)) != resource.Digest.Value {
+		return fmt.Errorf("digest mismatch: content does not match descriptor digest %s", expected)
+	}
+	return nil
+}
+
+// contentDigestAlgorithm returns the name of the hash algorithm in content digests
+// such as local references, e.g. "sha256" for SHA-256.
+func contentDigestAlgorithm(hash crypto.Hash) digest.Algorithm {
+	return digest.Algorithm(strings.ToLower(strings.ReplaceAll(hash.String(), "-", "")))
+}
@@ -0,0 +1,185 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha3"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	descriptorv2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+)
+
+// fakeDigestProcessor accepts resources whose digest value is "good".
+type fakeDigestProcessor struct{}
+
+func (fakeDigestProcessor) GetResourceDigestProcessorCredentialConsumerIdentity(context.Context, *descriptor.Resource) (runtime.Identity, error) {
+	return nil, fmt.Errorf("no credentials")
+}
+
+func (fakeDigestProcessor) ProcessResourceDigest(_ context.Context, resource *descriptor.Resource, _ runtime.Typed) (*descriptor.Resource, error) {
+	if resource.Digest.Value != "good" {
+		return nil, fmt.Errorf("digest value mismatch: expected %s, got good", resource.Digest.Value)
+	}
+	return resource.DeepCopy(), nil
+}
+
+type fakeDigestProcessors struct{}
+
+func (fakeDigestProcessors) GetDigestProcessor(context.Context, *descriptor.Resource) (repository.ResourceDigestProcessor, error) {
+	return fakeDigestProcessor{}, nil
+}
+
+func TestVerificationPolicies_For(t *testing.T) {
+	verifier := fakeSignerSpec()
+	policies, err := newVerificationPolicies(transferv1alpha1.Config{
+		Verification: &transferv1alpha1.Verification{Policies: []transferv1alpha1.VerificationPolicy{
+			{ComponentNamePattern: "ocm.software/internal/*", Skip: true},
+			{ComponentNamePattern: "ocm.software/*", Verifier: verifier, Signature: "release"},
+		}},
+		Signing: &transferv1alpha1.Signing{Signer: fakeSignerSpec(), Verifier: verifier, VerifySignature: "default"},
+	})
+	require.NoError(t, err)
+
+	assert.Nil(t, policies.For("ocm.software/internal/tools"), "skipped by the first matching policy")
+	require.NotNil(t, policies.For("ocm.software/core/test"), "the pattern matches across slashes")
+	assert.Equal(t, "release", policies.For("ocm.software/core/test").Signature)
+	require.NotNil(t, policies.For("example.com/other"))
+	assert.Equal(t, "default", policies.For("example.com/other").Signature, "the signing verifier applies to unmatched components")
+
+	policies, err = newVerificationPolicies(transferv1alpha1.Config{})
+	require.NoError(t, err)
+	assert.Nil(t, policies.For("ocm.software/core/test"))
+
+	policies, err = newVerificationPolicies(transferv1alpha1.Config{
+		Signing: &transferv1alpha1.Signing{Signer: fakeSignerSpec(), SkipVerification: true},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, policies.For("ocm.software/core/test"), "skipped verification has no fallback")
+
+	_, err = newVerificationPolicies(transferv1alpha1.Config{
+		Verification: &transferv1alpha1.Verification{Policies: []transferv1alpha1.VerificationPolicy{
+			{ComponentNamePattern: "ocm.software/[", Verifier: verifier},
+		}},
+	})
+	require.ErrorContains(t, err, "invalid componentNamePattern")
+}
+
+func TestVerifyComponentVersion_ResourceDigests(t *testing.T) {
+	content := strings.Repeat("x", 13)
+	sum := sha256.Sum256([]byte(content))
+	blob := withDigest(localBlobResource("blob", "1.0.0"), hex.EncodeToString(sum[:]))
+	tamperedBlob := withDigest(localBlobResource("blob", "1.0.0"), strings.Repeat("0", 64))
+	sha3Sum := sha3.Sum256([]byte(content))
+	sha3Blob := withDigest(localBlobResource("blob", "1.0.0"), hex.EncodeToString(sha3Sum[:]))
+	sha3Blob.Digest.HashAlgorithm = "SHA3-256"
+	movedBlob := withDigest(localBlobResource("blob", "1.0.0"), hex.EncodeToString(sum[:]))
+	movedBlob.Access.(*descriptorv2.LocalBlob).LocalReference = "sha256:" + strings.Repeat("1", 64)
+	image := withDigest(ociImageResource("image", "1.0.0", "ghcr.io/org/image:1.0.0"), "good")
+	tamperedImage := withDigest(ociImageResource("image", "1.0.0", "ghcr.io/org/image:1.0.0"), "bad")
+	unsafe := ociImageResource("image", "1.0.0", "ghcr.io/org/image:1.0.0")
+
+	tests := []struct {
+		name       string
+		resources  []descriptor.Resource
+		processors ResourceDigestProcessorProvider
+		wantErr    string
+	}{
+		{name: "matching digests", resources: []descriptor.Resource{blob, image}, processors: fakeDigestProcessors{}},
+		{name: "matching SHA-3 digest", resources: []descriptor.Resource{sha3Blob}},
+		{name: "local blob content differs", resources: []descriptor.Resource{tamperedBlob}, wantErr: "content does not match descriptor digest"},
+		{name: "local reference differs", resources: []descriptor.Resource{movedBlob}, wantErr: "vs local reference"},
+		{name: "digest processor rejects digest", resources: []descriptor.Resource{image, tamperedImage}, processors: fakeDigestProcessors{}, wantErr: "digest value mismatch"},
+		{name: "resource without digest", resources: []descriptor.Resource{unsafe}, processors: fakeDigestProcessors{}, wantErr: "missing digest in resource"},
+		{name: "no digest processors", resources: []descriptor.Resource{image}, wantErr: "no digest processors available"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			desc := signDescriptor(t, testDescriptor("ocm.software/test", "1.0.0", tc.resources, nil), "default")
+			v2desc, err := descriptor.ConvertToV2(runtime.NewScheme(runtime.WithAllowUnknown()), desc)
+			require.NoError(t, err)
+			var repo runtime.Raw
+			require.NoError(t, runtime.NewScheme(runtime.WithAllowUnknown()).Convert(testOCIRepo("ghcr.io/source"), &repo))
+
+			s := runtime.NewScheme()
+			s.MustRegisterWithAlias(&VerifyComponentVersionTransformation{}, VerifyComponentVersionVersionedType)
+			transformer := &VerifyComponentVersion{
+				Scheme:           s,
+				RepoProvider:     &mockRepoProvider{repo: &planRepo{blobSizes: map[string]int64{"blob": int64(len(content))}}},
+				Handlers:         fakeSigningHandlers{},
+				DigestProcessors: tc.processors,
+			}
+			_, err = transformer.Transform(t.Context(), &VerifyComponentVersionTransformation{
+				Type: VerifyComponentVersionVersionedType,
+				ID:   "verify",
+				Spec: &VerifyComponentVersionSpec{
+					Descriptor: v2desc,
+					Verifier:   fakeSignerSpec(),
+					Repository: &repo,
+				},
+			})
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBuildGraphDefinition_VerificationPolicy(t *testing.T) {
+	sourceRepo := testOCIRepo("ghcr.io/source")
+	targetRepo := testOCIRepo("ghcr.io/target")
+	desc := testDescriptor("ocm.software/test", "1.0.0",
+		[]descriptor.Resource{localBlobResource("my-resource", "1.0.0")}, nil)
+	resolver := testResolverFor("ocm.software/test", "1.0.0", sourceRepo, desc)
+	roots := testTransferRoots("ocm.software/test", "1.0.0", targetRepo, resolver)
+
+	build := func(t *testing.T, policies ...transferv1alpha1.VerificationPolicy) []string {
+		t.Helper()
+		tgd, err := BuildGraphDefinition(t.Context(), roots, transferv1alpha1.Config{
+			CopyMode:     transferv1alpha1.CopyModeLocalBlobResources,
+			Verification: &transferv1alpha1.Verification{Policies: policies},
+		})
+		require.NoError(t, err)
+		var verifyIDs []string
+		for _, tr := range tgd.Transformations {
+			if tr.Type != VerifyComponentVersionVersionedType {
+				continue
+			}
+			verifyIDs = append(verifyIDs, tr.ID)
+			assert.Equal(t, "release", tr.Spec.Data["signature"])
+			assert.NotNil(t, tr.Spec.Data["repository"], "the source repository enables the resource digest checks")
+		}
+		for _, tr := range tgd.Transformations {
+			switch {
+			case tr.Type == VerifyComponentVersionVersionedType, tr.Type == FileCleanupVersionedType:
+			case len(verifyIDs) == 1:
+				assert.Equal(t, "${"+verifyIDs[0]+".output.verified}", tr.When, tr.ID)
+			default:
+				assert.Empty(t, tr.When, tr.ID)
+			}
+		}
+		return verifyIDs
+	}
+
+	assert.Len(t, build(t, transferv1alpha1.VerificationPolicy{
+		ComponentNamePattern: "ocm.software/*", Verifier: fakeSignerSpec(), Signature: "release",
+	}), 1)
+	assert.Empty(t, build(t, transferv1alpha1.VerificationPolicy{
+		ComponentNamePattern: "example.com/*", Verifier: fakeSignerSpec(), Signature: "release",
+	}))
+	assert.Empty(t, build(t,
+		transferv1alpha1.VerificationPolicy{ComponentNamePattern: "ocm.software/test", Skip: true},
+		transferv1alpha1.VerificationPolicy{ComponentNamePattern: "*", Verifier: fakeSignerSpec(), Signature: "release"},
+	))
+}
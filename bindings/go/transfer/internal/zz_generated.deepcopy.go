@@ -7,9 +7,87 @@ package internal
 
 import (
 	v1alpha1 "ocm.software/open-component-model/bindings/go/blob/filesystem/spec/access/v1alpha1"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
 	runtime "ocm.software/open-component-model/bindings/go/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckComponentVersionConflictOutput) DeepCopyInto(out *CheckComponentVersionConflictOutput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckComponentVersionConflictOutput.
+func (in *CheckComponentVersionConflictOutput) DeepCopy() *CheckComponentVersionConflictOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckComponentVersionConflictOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckComponentVersionConflictSpec) DeepCopyInto(out *CheckComponentVersionConflictSpec) {
+	*out = *in
+	if in.Repository != nil {
+		in, out := &in.Repository, &out.Repository
+		*out = new(runtime.Raw)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Descriptor != nil {
+		in, out := &in.Descriptor, &out.Descriptor
+		*out = new(v2.Descriptor)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckComponentVersionConflictSpec.
+func (in *CheckComponentVersionConflictSpec) DeepCopy() *CheckComponentVersionConflictSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckComponentVersionConflictSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckComponentVersionConflictTransformation) DeepCopyInto(out *CheckComponentVersionConflictTransformation) {
+	*out = *in
+	out.Type = in.Type
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(CheckComponentVersionConflictSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(CheckComponentVersionConflictOutput)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckComponentVersionConflictTransformation.
+func (in *CheckComponentVersionConflictTransformation) DeepCopy() *CheckComponentVersionConflictTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckComponentVersionConflictTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyTyped is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Typed.
+func (in *CheckComponentVersionConflictTransformation) DeepCopyTyped() runtime.Typed {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileCleanupOutput) DeepCopyInto(out *FileCleanupOutput) {
 	*out = *in
@@ -87,3 +165,172 @@ func (in *FileCleanupTransformation) DeepCopyTyped() runtime.Typed {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignComponentVersionOutput) DeepCopyInto(out *SignComponentVersionOutput) {
+	*out = *in
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(v2.Signature)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignComponentVersionOutput.
+func (in *SignComponentVersionOutput) DeepCopy() *SignComponentVersionOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(SignComponentVersionOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignComponentVersionSpec) DeepCopyInto(out *SignComponentVersionSpec) {
+	*out = *in
+	if in.Repository != nil {
+		in, out := &in.Repository, &out.Repository
+		*out = new(runtime.Raw)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Descriptor != nil {
+		in, out := &in.Descriptor, &out.Descriptor
+		*out = new(v2.Descriptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Signer != nil {
+		in, out := &in.Signer, &out.Signer
+		*out = new(runtime.Raw)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignComponentVersionSpec.
+func (in *SignComponentVersionSpec) DeepCopy() *SignComponentVersionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SignComponentVersionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignComponentVersionTransformation) DeepCopyInto(out *SignComponentVersionTransformation) {
+	*out = *in
+	out.Type = in.Type
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(SignComponentVersionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(SignComponentVersionOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignComponentVersionTransformation.
+func (in *SignComponentVersionTransformation) DeepCopy() *SignComponentVersionTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(SignComponentVersionTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyTyped is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Typed.
+func (in *SignComponentVersionTransformation) DeepCopyTyped() runtime.Typed {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerifyComponentVersionOutput) DeepCopyInto(out *VerifyComponentVersionOutput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerifyComponentVersionOutput.
+func (in *VerifyComponentVersionOutput) DeepCopy() *VerifyComponentVersionOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(VerifyComponentVersionOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerifyComponentVersionSpec) DeepCopyInto(out *VerifyComponentVersionSpec) {
+	*out = *in
+	if in.Descriptor != nil {
+		in, out := &in.Descriptor, &out.Descriptor
+		*out = new(v2.Descriptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verifier != nil {
+		in, out := &in.Verifier, &out.Verifier
+		*out = new(runtime.Raw)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Repository != nil {
+		in, out := &in.Repository, &out.Repository
+		*out = new(runtime.Raw)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerifyComponentVersionSpec.
+func (in *VerifyComponentVersionSpec) DeepCopy() *VerifyComponentVersionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VerifyComponentVersionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerifyComponentVersionTransformation) DeepCopyInto(out *VerifyComponentVersionTransformation) {
+	*out = *in
+	out.Type = in.Type
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(VerifyComponentVersionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(VerifyComponentVersionOutput)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerifyComponentVersionTransformation.
+func (in *VerifyComponentVersionTransformation) DeepCopy() *VerifyComponentVersionTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(VerifyComponentVersionTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyTyped is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Typed.
+func (in *VerifyComponentVersionTransformation) DeepCopyTyped() runtime.Typed {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -9,6 +9,15 @@ import (
 	_ "embed"
 )
 
+//go:embed schemas/CheckComponentVersionConflictOutput.schema.json
+var schemaCheckComponentVersionConflictOutput []byte
+
+//go:embed schemas/CheckComponentVersionConflictSpec.schema.json
+var schemaCheckComponentVersionConflictSpec []byte
+
+//go:embed schemas/CheckComponentVersionConflictTransformation.schema.json
+var schemaCheckComponentVersionConflictTransformation []byte
+
 //go:embed schemas/FileCleanupOutput.schema.json
 var schemaFileCleanupOutput []byte
 
@@ -18,6 +27,39 @@ var schemaFileCleanupSpec []byte
 //go:embed schemas/FileCleanupTransformation.schema.json
 var schemaFileCleanupTransformation []byte
 
+//go:embed schemas/SignComponentVersionOutput.schema.json
+var schemaSignComponentVersionOutput []byte
+
+//go:embed schemas/SignComponentVersionSpec.schema.json
+var schemaSignComponentVersionSpec []byte
+
+//go:embed schemas/SignComponentVersionTransformation.schema.json
+var schemaSignComponentVersionTransformation []byte
+
+//go:embed schemas/VerifyComponentVersionOutput.schema.json
+var schemaVerifyComponentVersionOutput []byte
+
+//go:embed schemas/VerifyComponentVersionSpec.schema.json
+var schemaVerifyComponentVersionSpec []byte
+
+//go:embed schemas/VerifyComponentVersionTransformation.schema.json
+var schemaVerifyComponentVersionTransformation []byte
+
+// JSONSchema returns the JSON Schema for CheckComponentVersionConflictOutput.
+func (CheckComponentVersionConflictOutput) JSONSchema() []byte {
+	return schemaCheckComponentVersionConflictOutput
+}
+
+// JSONSchema returns the JSON Schema for CheckComponentVersionConflictSpec.
+func (CheckComponentVersionConflictSpec) JSONSchema() []byte {
+	return schemaCheckComponentVersionConflictSpec
+}
+
+// JSONSchema returns the JSON Schema for CheckComponentVersionConflictTransformation.
+func (CheckComponentVersionConflictTransformation) JSONSchema() []byte {
+	return schemaCheckComponentVersionConflictTransformation
+}
+
 // JSONSchema returns the JSON Schema for FileCleanupOutput.
 func (FileCleanupOutput) JSONSchema() []byte {
 	return schemaFileCleanupOutput
@@ -32,3 +74,33 @@ func (FileCleanupSpec) JSONSchema() []byte {
 func (FileCleanupTransformation) JSONSchema() []byte {
 	return schemaFileCleanupTransformation
 }
+
+// JSONSchema returns the JSON Schema for SignComponentVersionOutput.
+func (SignComponentVersionOutput) JSONSchema() []byte {
+	return schemaSignComponentVersionOutput
+}
+
+// JSONSchema returns the JSON Schema for SignComponentVersionSpec.
+func (SignComponentVersionSpec) JSONSchema() []byte {
+	return schemaSignComponentVersionSpec
+}
+
+// JSONSchema returns the JSON Schema for SignComponentVersionTransformation.
+func (SignComponentVersionTransformation) JSONSchema() []byte {
+	return schemaSignComponentVersionTransformation
+}
+
+// JSONSchema returns the JSON Schema for VerifyComponentVersionOutput.
+func (VerifyComponentVersionOutput) JSONSchema() []byte {
+	return schemaVerifyComponentVersionOutput
+}
+
+// JSONSchema returns the JSON Schema for VerifyComponentVersionSpec.
+func (VerifyComponentVersionSpec) JSONSchema() []byte {
+	return schemaVerifyComponentVersionSpec
+}
+
+// JSONSchema returns the JSON Schema for VerifyComponentVersionTransformation.
+func (VerifyComponentVersionTransformation) JSONSchema() []byte {
+	return schemaVerifyComponentVersionTransformation
+}
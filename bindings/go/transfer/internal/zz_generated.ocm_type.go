@@ -7,6 +7,16 @@ package internal
 
 import "ocm.software/open-component-model/bindings/go/runtime"
 
+// SetType is an autogenerated setter function, useful for type inference and defaulting.
+func (t *CheckComponentVersionConflictTransformation) SetType(typ runtime.Type) {
+	t.Type = typ
+}
+
+// GetType is an autogenerated getter function, useful for type inference and defaulting.
+func (t *CheckComponentVersionConflictTransformation) GetType() runtime.Type {
+	return t.Type
+}
+
 // SetType is an autogenerated setter function, useful for type inference and defaulting.
 func (t *FileCleanupTransformation) SetType(typ runtime.Type) {
 	t.Type = typ
@@ -16,3 +26,23 @@ func (t *FileCleanupTransformation) SetType(typ runtime.Type) {
 func (t *FileCleanupTransformation) GetType() runtime.Type {
 	return t.Type
 }
+
+// SetType is an autogenerated setter function, useful for type inference and defaulting.
+func (t *SignComponentVersionTransformation) SetType(typ runtime.Type) {
+	t.Type = typ
+}
+
+// GetType is an autogenerated getter function, useful for type inference and defaulting.
+func (t *SignComponentVersionTransformation) GetType() runtime.Type {
+	return t.Type
+}
+
+// SetType is an autogenerated setter function, useful for type inference and defaulting.
+func (t *VerifyComponentVersionTransformation) SetType(typ runtime.Type) {
+	t.Type = typ
+}
+
+// GetType is an autogenerated getter function, useful for type inference and defaulting.
+func (t *VerifyComponentVersionTransformation) GetType() runtime.Type {
+	return t.Type
+}
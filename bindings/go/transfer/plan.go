@@ -0,0 +1,84 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/transfer/internal"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+)
+
+type (
+	// Plan describes what a transfer would change in its target repositories.
+	Plan = internal.Plan
+	// ComponentVersionPlan describes what a transfer would change for a single
+	// component version in a single target repository.
+	ComponentVersionPlan = internal.ComponentVersionPlan
+	// ResourcePlan describes what a transfer would change for a single resource.
+	ResourcePlan = internal.ResourcePlan
+	// PlanAction is what a transfer does with a component version or resource
+	// in a target repository.
+	PlanAction = internal.PlanAction
+)
+
+const (
+	// PlanActionSkip means the target already holds the element with the same digest.
+	PlanActionSkip = internal.PlanActionSkip
+	// PlanActionCopy means the element is missing in the target.
+	PlanActionCopy = internal.PlanActionCopy
+	// PlanActionConflict means the target holds the element with a different
+	// digest, or a resource without a digest that cannot be compared.
+	PlanActionConflict = internal.PlanActionConflict
+)
+
+// BuildPlan reports what a transfer with the same cfg and mappings as
+// [BuildGraphDefinition] would change, without changing anything. For every
+// discovered component version and target repository, it queries the target
+// and reports whether the component version and each resource it copies is
+// already present with the same digest (skip), missing (copy) or present with
+// a different or no digest (conflict). A target that does not exist yet is
+// reported by repoProvider with an error wrapping [repository.ErrNotFound], in
+// which case everything is copied.
+//
+// Target repositories are opened with repoProvider, using the credentials
+// resolved by credentialProvider, which may be nil.
+func BuildPlan(
+	ctx context.Context,
+	cfg *transferv1alpha1.Config,
+	repoProvider repository.ComponentVersionRepositoryProvider,
+	credentialProvider credentials.Resolver,
+	mappings ...Mapping,
+) (*Plan, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid transfer config: %w", err)
+	}
+
+	resolved := transferv1alpha1.Config{}
+	if cfg != nil {
+		resolved = *cfg
+	}
+	if resolved.CopyMode == "" {
+		resolved.CopyMode = transferv1alpha1.CopyModeLocalBlobResources
+	}
+
+	roots, err := collectTransferRoots(ctx, mappings)
+	if err != nil {
+		return nil, err
+	}
+
+	return internal.BuildPlan(ctx, roots, resolved, func(ctx context.Context, spec runtime.Typed) (repository.ComponentVersionRepository, error) {
+		var creds runtime.Typed
+		if credentialProvider != nil {
+			if consumerID, err := repoProvider.GetComponentVersionRepositoryCredentialConsumerIdentity(ctx, spec); err == nil {
+				if creds, err = credentialProvider.Resolve(ctx, consumerID); err != nil && !errors.Is(err, credentials.ErrNotFound) {
+					return nil, fmt.Errorf("failed resolving credentials: %w", err)
+				}
+			}
+		}
+		return repoProvider.GetComponentVersionRepository(ctx, spec, creds)
+	})
+}
@@ -40,8 +40,8 @@ type Config struct {
 	Type runtime.Type `json:"type"`
 
 	// Recursive configures transferring component references with the parent
-	// component: -1 means infinite recursion, 0 means no recursion. Positive
-	// depths are reserved but not implemented yet. See [Recursive].
+	// component: -1 means infinite recursion, 0 means no recursion, and a
+	// positive value limits the depth of the recursion. See [Recursive].
 	Recursive Recursive `json:"recursive,omitempty"`
 
 	// CopyMode determines which resources are copied during a transfer operation.
@@ -58,13 +58,51 @@ type Config struct {
 	// embedded as local blobs within the component descriptor or uploaded as separate OCI artifacts
 	// with their own repository references.
 	UploadType UploadType `json:"uploadType,omitempty"`
+
+	// ComponentVersionConflictPolicy determines how component versions that already
+	// exist in the target repository are handled. With [ComponentVersionConflictSkip],
+	// rerunning a transfer does not upload anything for component versions the target
+	// already holds with the same normalised digest.
+	ComponentVersionConflictPolicy ComponentVersionConflictPolicy `json:"componentVersionConflictPolicy,omitempty"`
+
+	// Resources narrows down the resources that are copied by [CopyMode] with include
+	// and exclude selectors on the type, identity, labels or a CEL expression.
+	// If not set, all resources selected by the CopyMode are copied.
+	Resources *ResourceFilter `json:"resources,omitempty"`
+
+	// Signing re-signs every transferred component version in its target repository
+	// and verifies its signatures in the source before it is copied, unless skipped.
+	// If not set, signatures are copied as they are.
+	Signing *Signing `json:"signing,omitempty"`
+
+	// Verification verifies the signatures and resource digests of the component
+	// versions in the source before they are copied, with verifiers selected per
+	// component name pattern.
+	// If not set, only the verifier of Signing is used, if any.
+	Verification *Verification `json:"verification,omitempty"`
+
+	// Concurrency limits how many transformations of the transfer graph are
+	// executed in parallel. Transformations only run in parallel if they do
+	// not depend on each other, e.g. the uploads of different resources.
+	// 0 keeps the default of processing one transformation at a time,
+	// -1 removes the limit. The values match those of the --concurrency-limit
+	// flag and of the graph builder's WithConcurrency.
+	//
+	// +ocm:jsonschema-gen:minimum=-1
+	Concurrency int `json:"concurrency,omitempty"`
 }
 
-// Validate rejects a non-matching [Config.Type] and unknown enum values.
+// ConcurrencyUnlimited removes the limit on the number of transformations
+// executed in parallel. See [Config.Concurrency].
+const ConcurrencyUnlimited = -1
+
+// Validate rejects a non-matching [Config.Type], unknown enum values and
+// out-of-range Recursive or Concurrency values.
 // An empty Type is allowed so callers constructing a Config programmatically
 // (without going through [Scheme.Decode]) do not need to set it explicitly.
 // Empty enum fields are allowed; consumers resolve them to their defaults
-// ([CopyModeLocalBlobResources], [UploadAsDefault]) at the point of use.
+// ([CopyModeLocalBlobResources], [UploadAsDefault], [ComponentVersionConflictReplace])
+// at the point of use.
 func (cfg *Config) Validate() error {
 	if cfg == nil {
 		return nil
@@ -78,10 +116,11 @@ func (cfg *Config) Validate() error {
 	}
 
 	if cfg.Recursive < RecursiveInfinite {
-		return fmt.Errorf("invalid recursive %d (must be -1 for infinite recursion or 0 for none)", cfg.Recursive)
+		return fmt.Errorf("invalid recursive %d (must be -1 for infinite recursion, 0 for none or a positive depth)", cfg.Recursive)
 	}
-	if cfg.Recursive > RecursiveNone {
-		return fmt.Errorf("recursive depth %d is not implemented yet (use -1 for infinite recursion or 0 for none)", cfg.Recursive)
+
+	if cfg.Concurrency < ConcurrencyUnlimited {
+		return fmt.Errorf("invalid concurrency %d (must be -1 for unlimited, 0 for the default or a positive limit)", cfg.Concurrency)
 	}
 
 	switch cfg.CopyMode {
@@ -96,6 +135,21 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid uploadType %q (must be one of %q, %q, %q)",
 			cfg.UploadType, UploadAsDefault, UploadAsLocalBlob, UploadAsOciArtifact)
 	}
+	switch cfg.ComponentVersionConflictPolicy {
+	case "", ComponentVersionConflictReplace, ComponentVersionConflictSkip, ComponentVersionConflictAbortAndFail:
+	default:
+		return fmt.Errorf("invalid componentVersionConflictPolicy %q (must be one of %q, %q, %q)",
+			cfg.ComponentVersionConflictPolicy, ComponentVersionConflictReplace, ComponentVersionConflictSkip, ComponentVersionConflictAbortAndFail)
+	}
+	if err := cfg.Resources.Validate(); err != nil {
+		return fmt.Errorf("invalid resources: %w", err)
+	}
+	if err := cfg.Signing.Validate(); err != nil {
+		return fmt.Errorf("invalid signing: %w", err)
+	}
+	if err := cfg.Verification.Validate(); err != nil {
+		return fmt.Errorf("invalid verification: %w", err)
+	}
 	return nil
 }
 
@@ -127,9 +181,10 @@ func LookupConfig(cfg *genericv1.Config) (*Config, error) {
 }
 
 // Merge merges the provided configs into a single config. Later entries win:
-// a non-empty CopyMode or UploadType and a non-zero Recursive override
+// a non-empty CopyMode, UploadType or ComponentVersionConflictPolicy and a non-zero Recursive or Concurrency override
 // whatever earlier entries set. An explicit "recursive: 0" cannot be
 // distinguished from an omitted field; both leave the default of no recursion.
+// A set Resources filter, Signing or Verification replaces the one of earlier entries as a whole.
 func Merge(configs ...*Config) *Config {
 	if len(configs) == 0 {
 		return nil
@@ -150,6 +205,21 @@ func Merge(configs ...*Config) *Config {
 		if cfg.UploadType != "" {
 			merged.UploadType = cfg.UploadType
 		}
+		if cfg.ComponentVersionConflictPolicy != "" {
+			merged.ComponentVersionConflictPolicy = cfg.ComponentVersionConflictPolicy
+		}
+		if cfg.Concurrency != 0 {
+			merged.Concurrency = cfg.Concurrency
+		}
+		if cfg.Resources != nil {
+			merged.Resources = cfg.Resources.DeepCopy()
+		}
+		if cfg.Signing != nil {
+			merged.Signing = cfg.Signing.DeepCopy()
+		}
+		if cfg.Verification != nil {
+			merged.Verification = cfg.Verification.DeepCopy()
+		}
 	}
 	return merged
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
 )
 
@@ -88,8 +89,45 @@ func TestConfig_Validate(t *testing.T) {
 		{"valid uploadType localBlob", spec.Config{UploadType: spec.UploadAsLocalBlob}, ""},
 		{"invalid copyMode", spec.Config{CopyMode: "garbage"}, "invalid copyMode"},
 		{"invalid uploadType", spec.Config{UploadType: "garbage"}, "invalid uploadType"},
-		{"recursive depth not implemented", spec.Config{Recursive: 3}, "not implemented"},
+		{"valid recursive depth", spec.Config{Recursive: 3}, ""},
 		{"invalid recursive below -1", spec.Config{Recursive: -5}, "invalid recursive"},
+		{"valid concurrency limit", spec.Config{Concurrency: 8}, ""},
+		{"valid concurrency unlimited", spec.Config{Concurrency: spec.ConcurrencyUnlimited}, ""},
+		{"invalid concurrency below -1", spec.Config{Concurrency: -2}, "invalid concurrency"},
+		{"valid conflict policy skip", spec.Config{ComponentVersionConflictPolicy: spec.ComponentVersionConflictSkip}, ""},
+		{"valid conflict policy abort-and-fail", spec.Config{ComponentVersionConflictPolicy: spec.ComponentVersionConflictAbortAndFail}, ""},
+		{"invalid conflict policy", spec.Config{ComponentVersionConflictPolicy: "garbage"}, "invalid componentVersionConflictPolicy"},
+		{"valid resource filter", spec.Config{Resources: &spec.ResourceFilter{
+			Include: []spec.ResourceSelector{{Type: "ociImage"}},
+			Exclude: []spec.ResourceSelector{{Labels: []spec.LabelSelector{{Name: "internal-only"}}}},
+		}}, ""},
+		{"invalid resource selector without criteria", spec.Config{Resources: &spec.ResourceFilter{
+			Exclude: []spec.ResourceSelector{{}},
+		}}, "exclude[0] has no criteria"},
+		{"invalid label selector without name", spec.Config{Resources: &spec.ResourceFilter{
+			Include: []spec.ResourceSelector{{Labels: []spec.LabelSelector{{Value: "true"}}}},
+		}}, "include[0].labels[0] has no name"},
+		{"valid signing", spec.Config{Signing: &spec.Signing{Signer: &runtime.Raw{}, Verifier: &runtime.Raw{}, VerifySignature: "default"}}, ""},
+		{"invalid signing without signer", spec.Config{Signing: &spec.Signing{Signature: "sovereign"}}, "invalid signing: signer is required"},
+		{"valid verification", spec.Config{Verification: &spec.Verification{Policies: []spec.VerificationPolicy{
+			{ComponentNamePattern: "ocm.software/internal/*", Skip: true},
+			{ComponentNamePattern: "*", Verifier: &runtime.Raw{}, Signature: "release"},
+		}}}, ""},
+		{"invalid verification policy without pattern", spec.Config{Verification: &spec.Verification{Policies: []spec.VerificationPolicy{
+			{Verifier: &runtime.Raw{}},
+		}}}, "invalid verification: policies[0] has no componentNamePattern"},
+		{"invalid verification policy pattern", spec.Config{Verification: &spec.Verification{Policies: []spec.VerificationPolicy{
+			{ComponentNamePattern: "ocm.software/[", Verifier: &runtime.Raw{}},
+		}}}, "policies[0] has an invalid componentNamePattern"},
+		{"invalid verification policy without verifier", spec.Config{Verification: &spec.Verification{Policies: []spec.VerificationPolicy{
+			{ComponentNamePattern: "*"},
+		}}}, "policies[0] requires a verifier unless skip is set"},
+		{"invalid verification policy with skip and verifier", spec.Config{Verification: &spec.Verification{Policies: []spec.VerificationPolicy{
+			{ComponentNamePattern: "*", Skip: true, Verifier: &runtime.Raw{}},
+		}}}, "policies[0] sets skip together with a verifier"},
+		{"invalid verifySignature without verifier", spec.Config{Signing: &spec.Signing{Signer: &runtime.Raw{}, VerifySignature: "default"}}, "verifySignature requires a verifier"},
+		{"valid signing without verification", spec.Config{Signing: &spec.Signing{Signer: &runtime.Raw{}, SkipVerification: true}}, ""},
+		{"invalid skipVerification with verifier", spec.Config{Signing: &spec.Signing{Signer: &runtime.Raw{}, Verifier: &runtime.Raw{}, SkipVerification: true}}, "skipVerification cannot be combined with a verifier"},
 	}
 
 	for _, tc := range tests {
@@ -110,14 +148,48 @@ func TestMerge(t *testing.T) {
 	})
 
 	t.Run("later non-empty fields win", func(t *testing.T) {
-		a := &spec.Config{Recursive: spec.RecursiveInfinite, CopyMode: spec.CopyModeLocalBlobResources, UploadType: spec.UploadAsLocalBlob}
-		b := &spec.Config{CopyMode: spec.CopyModeAllResources}
+		a := &spec.Config{Recursive: spec.RecursiveInfinite, CopyMode: spec.CopyModeLocalBlobResources, UploadType: spec.UploadAsLocalBlob, Concurrency: 4}
+		b := &spec.Config{CopyMode: spec.CopyModeAllResources, ComponentVersionConflictPolicy: spec.ComponentVersionConflictSkip}
 
 		merged := spec.Merge(a, b)
 
 		assert.Equal(t, spec.RecursiveInfinite, merged.Recursive)
 		assert.Equal(t, spec.CopyModeAllResources, merged.CopyMode)
 		assert.Equal(t, spec.UploadAsLocalBlob, merged.UploadType)
+		assert.Equal(t, 4, merged.Concurrency)
+		assert.Equal(t, spec.ComponentVersionConflictSkip, merged.ComponentVersionConflictPolicy)
+	})
+
+	t.Run("later resource filter replaces earlier one", func(t *testing.T) {
+		a := &spec.Config{Resources: &spec.ResourceFilter{Include: []spec.ResourceSelector{{Type: "ociImage"}}}}
+		b := &spec.Config{Resources: &spec.ResourceFilter{Exclude: []spec.ResourceSelector{{Type: "blob"}}}}
+
+		merged := spec.Merge(a, b)
+
+		assert.Equal(t, b.Resources, merged.Resources)
+		assert.NotSame(t, b.Resources, merged.Resources)
+		assert.Equal(t, a.Resources, spec.Merge(a, &spec.Config{}).Resources)
+	})
+
+	t.Run("later signing replaces earlier one", func(t *testing.T) {
+		a := &spec.Config{Signing: &spec.Signing{Signer: &runtime.Raw{}, Verifier: &runtime.Raw{}}}
+		b := &spec.Config{Signing: &spec.Signing{Signature: "sovereign", Signer: &runtime.Raw{}}}
+
+		merged := spec.Merge(a, b)
+
+		assert.Equal(t, b.Signing, merged.Signing)
+		assert.NotSame(t, b.Signing, merged.Signing)
+		assert.Nil(t, merged.Signing.Verifier)
+	})
+
+	t.Run("later verification replaces earlier one", func(t *testing.T) {
+		a := &spec.Config{Verification: &spec.Verification{Policies: []spec.VerificationPolicy{{ComponentNamePattern: "*", Skip: true}}}}
+		b := &spec.Config{Verification: &spec.Verification{Policies: []spec.VerificationPolicy{{ComponentNamePattern: "ocm.software/*", Verifier: &runtime.Raw{}}}}}
+
+		merged := spec.Merge(a, b)
+
+		assert.Equal(t, b.Verification, merged.Verification)
+		assert.NotSame(t, b.Verification, merged.Verification)
 	})
 
 	t.Run("nil element is skipped", func(t *testing.T) {
@@ -164,6 +236,85 @@ configurations:
 		assert.Empty(t, cfg.UploadType)
 	})
 
+	t.Run("resource filter", func(t *testing.T) {
+		generic := decode(t, `
+type: generic.config.ocm.software/v1
+configurations:
+  - type: transfer.config.ocm.software/v1alpha1
+    copyMode: allResources
+    resources:
+      exclude:
+        - type: blob
+          identity:
+            name: test-data
+        - labels:
+            - name: internal-only
+              value: "true"
+        - expression: resource.relation == 'external' && resource.version.startsWith('0.')
+`)
+		cfg, err := spec.LookupConfig(generic)
+		require.NoError(t, err)
+		require.NotNil(t, cfg)
+		require.NoError(t, cfg.Validate())
+		require.NotNil(t, cfg.Resources)
+		assert.Empty(t, cfg.Resources.Include)
+		assert.Equal(t, []spec.ResourceSelector{
+			{Type: "blob", Identity: map[string]string{"name": "test-data"}},
+			{Labels: []spec.LabelSelector{{Name: "internal-only", Value: "true"}}},
+			{Expression: "resource.relation == 'external' && resource.version.startsWith('0.')"},
+		}, cfg.Resources.Exclude)
+	})
+
+	t.Run("signing", func(t *testing.T) {
+		generic := decode(t, `
+type: generic.config.ocm.software/v1
+configurations:
+  - type: transfer.config.ocm.software/v1alpha1
+    signing:
+      signature: sovereign
+      signer:
+        type: RSASigningConfiguration/v1alpha1
+        signatureAlgorithm: RSASSA-PSS
+      verifier:
+        type: SigstoreVerificationConfiguration/v1alpha1
+`)
+		cfg, err := spec.LookupConfig(generic)
+		require.NoError(t, err)
+		require.NotNil(t, cfg)
+		require.NoError(t, cfg.Validate())
+		require.NotNil(t, cfg.Signing)
+		assert.Equal(t, "sovereign", cfg.Signing.Signature)
+		require.NotNil(t, cfg.Signing.Signer)
+		assert.Equal(t, "RSASigningConfiguration/v1alpha1", cfg.Signing.Signer.Type.String())
+		require.NotNil(t, cfg.Signing.Verifier)
+		assert.Equal(t, "SigstoreVerificationConfiguration/v1alpha1", cfg.Signing.Verifier.Type.String())
+	})
+
+	t.Run("verification", func(t *testing.T) {
+		generic := decode(t, `
+type: generic.config.ocm.software/v1
+configurations:
+  - type: transfer.config.ocm.software/v1alpha1
+    verification:
+      policies:
+        - componentNamePattern: ocm.software/internal/*
+          skip: true
+        - componentNamePattern: "*"
+          signature: release
+          verifier:
+            type: RSASigningConfiguration/v1alpha1
+`)
+		cfg, err := spec.LookupConfig(generic)
+		require.NoError(t, err)
+		require.NotNil(t, cfg)
+		require.NotNil(t, cfg.Verification)
+		require.Len(t, cfg.Verification.Policies, 2)
+		assert.True(t, cfg.Verification.Policies[0].Skip)
+		assert.Equal(t, "release", cfg.Verification.Policies[1].Signature)
+		require.NotNil(t, cfg.Verification.Policies[1].Verifier)
+		assert.Equal(t, "RSASigningConfiguration/v1alpha1", cfg.Verification.Policies[1].Verifier.Type.String())
+	})
+
 	t.Run("later entry wins, unset fields fall through", func(t *testing.T) {
 		generic := decode(t, `
 type: generic.config.ocm.software/v1
@@ -194,14 +345,16 @@ configurations:
 		require.ErrorContains(t, err, "invalid copyMode")
 	})
 
-	t.Run("recursive depth is rejected", func(t *testing.T) {
+	t.Run("recursive depth is accepted", func(t *testing.T) {
 		generic := decode(t, `
 type: generic.config.ocm.software/v1
 configurations:
   - type: transfer.config.ocm.software/v1alpha1
     recursive: 3
 `)
-		_, err := spec.LookupConfig(generic)
-		require.ErrorContains(t, err, "not implemented")
+		cfg, err := spec.LookupConfig(generic)
+		require.NoError(t, err)
+		require.NotNil(t, cfg)
+		assert.Equal(t, spec.Recursive(3), cfg.Recursive)
 	})
 }
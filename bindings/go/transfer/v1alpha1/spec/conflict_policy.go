@@ -0,0 +1,30 @@
+package spec
+
+// ComponentVersionConflictPolicy determines how a transfer handles a component version
+// that already exists in the target repository. It mirrors the conflict policy of the
+// constructor used by "ocm add component-version".
+// +ocm:jsonschema-gen:enum=replace,skip,abort-and-fail
+type ComponentVersionConflictPolicy string
+
+const (
+	// ComponentVersionConflictReplace is the default policy. The component version and its
+	// resources are transferred again and replace the component version in the target.
+	ComponentVersionConflictReplace ComponentVersionConflictPolicy = "replace"
+
+	// ComponentVersionConflictSkip skips the transfer of a component version and its
+	// resources if the target already holds it with the same normalised digest.
+	// If the target holds it with a different normalised digest, the transfer fails.
+	ComponentVersionConflictSkip ComponentVersionConflictPolicy = "skip"
+
+	// ComponentVersionConflictAbortAndFail fails the transfer if the target already
+	// holds the component version, regardless of its digest.
+	ComponentVersionConflictAbortAndFail ComponentVersionConflictPolicy = "abort-and-fail"
+)
+
+// AllComponentVersionConflictPolicies lists every valid [ComponentVersionConflictPolicy]
+// in declaration order.
+var AllComponentVersionConflictPolicies = []ComponentVersionConflictPolicy{
+	ComponentVersionConflictReplace,
+	ComponentVersionConflictSkip,
+	ComponentVersionConflictAbortAndFail,
+}
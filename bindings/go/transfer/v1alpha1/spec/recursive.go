@@ -2,9 +2,8 @@ package spec
 
 // Recursive controls whether component references are transferred along with
 // their parent component, and how deep that recursion goes: -1 means infinite
-// recursion, 0 means no recursion. Values above 0 are reserved for
-// depth-limited recursion, which is not implemented yet; [Config.Validate]
-// rejects them.
+// recursion, 0 means no recursion, and a positive value limits the recursion
+// to that many levels of references below the root component.
 //
 // +ocm:jsonschema-gen=true
 // +ocm:jsonschema-gen:schema-from=schemas/Recursive.schema.json
@@ -0,0 +1,81 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ResourceFilter narrows down the resources that a transfer copies. It applies
+// on top of [CopyMode]: only resources that the copy mode copies can be selected,
+// and resources that are not selected keep their original access in the target.
+// A resource is selected if it matches at least one Include selector (or Include
+// is empty) and no Exclude selector.
+//
+// Local blob resources are stored with their component version and cannot be left
+// in the source repository. They are copied even if no Include selector matches
+// them, and a transfer fails if an Exclude selector matches one of them.
+//
+// +k8s:deepcopy-gen=true
+type ResourceFilter struct {
+	// Include selects resources for the transfer. If empty, all resources are selected.
+	Include []ResourceSelector `json:"include,omitempty"`
+	// Exclude deselects resources that were selected by Include.
+	Exclude []ResourceSelector `json:"exclude,omitempty"`
+}
+
+// ResourceSelector matches a resource if all of its non-empty fields match.
+//
+// +k8s:deepcopy-gen=true
+type ResourceSelector struct {
+	// Type matches the type of the resource, e.g. "ociImage" or "blob".
+	Type string `json:"type,omitempty"`
+	// Identity matches if every attribute is part of the identity of the resource
+	// with the same value, e.g. {"name": "test-data"}.
+	Identity map[string]string `json:"identity,omitempty"`
+	// Labels matches if the resource has all of the labels.
+	Labels []LabelSelector `json:"labels,omitempty"`
+	// Expression is a CEL expression that must evaluate to a bool, e.g.
+	// "resource.relation == 'external'". The resource is available as "resource"
+	// in its component descriptor v2 format.
+	Expression string `json:"expression,omitempty"`
+}
+
+// LabelSelector matches a label of a resource.
+//
+// +k8s:deepcopy-gen=true
+type LabelSelector struct {
+	// Name is the name of the label.
+	Name string `json:"name"`
+	// Value, if set, must be equal to the value of the label. String values are
+	// compared without quotes, other values by their JSON representation.
+	Value string `json:"value,omitempty"`
+}
+
+// IsEmpty reports whether the selector has no criteria.
+func (s *ResourceSelector) IsEmpty() bool {
+	return s.Type == "" && len(s.Identity) == 0 && len(s.Labels) == 0 && s.Expression == ""
+}
+
+// Validate rejects selectors without criteria and labels without name.
+// CEL expressions are compiled when the transfer is built.
+func (f *ResourceFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	var errs []error
+	validate := func(kind string, selectors []ResourceSelector) {
+		for i, selector := range selectors {
+			if selector.IsEmpty() {
+				errs = append(errs, fmt.Errorf("%s[%d] has no criteria", kind, i))
+			}
+			for j, label := range selector.Labels {
+				if label.Name == "" {
+					errs = append(errs, fmt.Errorf("%s[%d].labels[%d] has no name", kind, i, j))
+				}
+			}
+		}
+	}
+	validate("include", f.Include)
+	validate("exclude", f.Exclude)
+	return errors.Join(errs...)
+}
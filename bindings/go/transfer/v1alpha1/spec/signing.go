@@ -0,0 +1,69 @@
+package spec
+
+import (
+	"errors"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// DefaultSignatureName is the name of the signature added by [Signing] if no
+// signature name is configured.
+const DefaultSignatureName = "default"
+
+// Signing re-signs every transferred component version in its target repository.
+// After a component version was added to the target, its digest is recalculated
+// and a signature is added with the signing handler selected by Signer, or replaces
+// an existing signature with the same name. Component versions that are skipped
+// because of the [ComponentVersionConflictPolicy] are not re-signed.
+//
+// The signatures of every component version are verified in the source with the
+// signing handler selected by Verifier before anything of the component version is
+// copied, so that no unverified component version is re-signed. Component versions
+// matched by a [VerificationPolicy] are verified by that policy instead. Re-signing
+// without verification must be requested explicitly with SkipVerification.
+//
+// +k8s:deepcopy-gen=true
+type Signing struct {
+	// Signature is the name of the signature to add. Defaults to [DefaultSignatureName].
+	Signature string `json:"signature,omitempty"`
+	// Signer is the configuration of the signing handler, e.g. a
+	// RSASigningConfiguration/v1alpha1. It must not contain keys, they are
+	// resolved as credentials of the signing handler.
+	Signer *runtime.Raw `json:"signer"`
+	// NormalisationAlgorithm is the algorithm used to normalise the component
+	// descriptor before hashing. Defaults to jsonNormalisation/v4alpha1.
+	NormalisationAlgorithm string `json:"normalisationAlgorithm,omitempty"`
+	// HashAlgorithm is the algorithm used to hash the normalised component
+	// descriptor. Defaults to SHA-256.
+	HashAlgorithm string `json:"hashAlgorithm,omitempty"`
+	// Verifier is the configuration of the signing handler that verifies the
+	// signatures of the component versions in the source, e.g. a
+	// SigstoreVerificationConfiguration/v1alpha1. It is required unless
+	// SkipVerification is set.
+	Verifier *runtime.Raw `json:"verifier,omitempty"`
+	// VerifySignature is the name of the source signature to verify. If not set,
+	// all signatures of the component version are verified. Only used with Verifier.
+	VerifySignature string `json:"verifySignature,omitempty"`
+	// SkipVerification re-signs the component versions that are not matched by a
+	// [VerificationPolicy] without verifying their source signatures.
+	SkipVerification bool `json:"skipVerification,omitempty"`
+}
+
+// Validate checks that a signer is configured and that verification is either
+// configured or skipped, but not both. A missing verifier is only detected when the
+// transfer is built, as it can be set by a later configuration entry.
+func (s *Signing) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.Signer == nil {
+		return errors.New("signer is required")
+	}
+	if s.SkipVerification && s.Verifier != nil {
+		return errors.New("skipVerification cannot be combined with a verifier")
+	}
+	if s.VerifySignature != "" && s.Verifier == nil {
+		return errors.New("verifySignature requires a verifier")
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gobwas/glob"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Verification gates a transfer on the verification of the component versions in
+// the source. Before anything of a component version is copied, its signatures are
+// verified with the signing handler selected by the Verifier of the first policy whose
+// ComponentNamePattern matches the component name, and the content digests of all of
+// its resources are checked against the descriptor. If a verification fails, the
+// transfer fails without copying the component version.
+//
+// Component versions that match no policy are transferred without verification,
+// unless they are re-signed by [Signing], whose verifier then applies to them.
+//
+// +k8s:deepcopy-gen=true
+type Verification struct {
+	// Policies are matched in order against the component name.
+	Policies []VerificationPolicy `json:"policies"`
+}
+
+// VerificationPolicy configures the verification of the component versions whose
+// name matches ComponentNamePattern.
+//
+// +k8s:deepcopy-gen=true
+type VerificationPolicy struct {
+	// ComponentNamePattern is a glob pattern matched against the component name,
+	// e.g. "ocm.software/*". A "*" also matches "/", so "*" matches every component.
+	ComponentNamePattern string `json:"componentNamePattern"`
+	// Verifier is the configuration of the signing handler that verifies the
+	// signatures, e.g. a SigstoreVerificationConfiguration/v1alpha1. It must not
+	// contain keys, they are resolved as credentials of the signing handler.
+	Verifier *runtime.Raw `json:"verifier,omitempty"`
+	// Signature is the name of the signature to verify. If not set, all signatures
+	// of the component version are verified.
+	Signature string `json:"signature,omitempty"`
+	// Skip transfers the matching component versions without verification.
+	Skip bool `json:"skip,omitempty"`
+}
+
+// Validate checks that every policy has a valid pattern and either a verifier or skip.
+func (v *Verification) Validate() error {
+	if v == nil {
+		return nil
+	}
+	var errs []error
+	for i, policy := range v.Policies {
+		if policy.ComponentNamePattern == "" {
+			errs = append(errs, fmt.Errorf("policies[%d] has no componentNamePattern", i))
+		} else if _, err := glob.Compile(policy.ComponentNamePattern); err != nil {
+			errs = append(errs, fmt.Errorf("policies[%d] has an invalid componentNamePattern %q: %w", i, policy.ComponentNamePattern, err))
+		}
+		switch {
+		case policy.Skip && (policy.Verifier != nil || policy.Signature != ""):
+			errs = append(errs, fmt.Errorf("policies[%d] sets skip together with a verifier or signature", i))
+		case !policy.Skip && policy.Verifier == nil:
+			errs = append(errs, fmt.Errorf("policies[%d] requires a verifier unless skip is set", i))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -9,6 +9,9 @@ import (
 	_ "embed"
 )
 
+//go:embed schemas/ComponentVersionConflictPolicy.schema.json
+var schemaComponentVersionConflictPolicy []byte
+
 //go:embed schemas/Config.schema.json
 var schemaConfig []byte
 
@@ -21,6 +24,11 @@ var schemaRecursive []byte
 //go:embed schemas/UploadType.schema.json
 var schemaUploadType []byte
 
+// JSONSchema returns the JSON Schema for ComponentVersionConflictPolicy.
+func (ComponentVersionConflictPolicy) JSONSchema() []byte {
+	return schemaComponentVersionConflictPolicy
+}
+
 // JSONSchema returns the JSON Schema for Config.
 func (Config) JSONSchema() []byte {
 	return schemaConfig
@@ -7,6 +7,7 @@ import (
 	"slices"
 
 	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
 	"github.com/santhosh-tekuri/jsonschema/v6"
 
 	"ocm.software/open-component-model/bindings/go/cel/expression/fieldpath"
@@ -30,6 +31,22 @@ func (b *StaticPluginAnalysisProcessor) ProcessValue(_ context.Context, transfor
 		return err
 	}
 
+	if transformation.ForEach != nil {
+		itemType, err := compileForEach(celEnv, transformation.ForEach)
+		if err != nil {
+			return err
+		}
+		// the spec and the condition of the transformation refer to the current item
+		if celEnv, err = celEnv.Extend(cel.Variable(graph.ItemVariable, itemType)); err != nil {
+			return fmt.Errorf("declaring %s of transformation %q: %w", graph.ItemVariable, transformation.ID, err)
+		}
+	}
+	if transformation.When != nil {
+		if err := compileWhen(celEnv, transformation.When); err != nil {
+			return err
+		}
+	}
+
 	for i, fieldDescriptor := range transformation.FieldDescriptors {
 		for j, expression := range fieldDescriptor.Expressions {
 			ast, issues := celEnv.Compile(expression.Value)
@@ -67,7 +84,11 @@ func (b *StaticPluginAnalysisProcessor) ProcessValue(_ context.Context, transfor
 
 	declType := stv6jsonschema.NewSchemaDeclType(schema)
 	b.Builder.RegisterDeclTypes(declType)
-	b.Builder.RegisterEnvOption(cel.Variable(transformation.ID, declType.CelType()))
+	if transformation.ForEach != nil {
+		b.Builder.RegisterEnvOption(cel.Variable(transformation.ID, cel.ListType(declType.CelType())))
+	} else {
+		b.Builder.RegisterEnvOption(cel.Variable(transformation.ID, declType.CelType()))
+	}
 
 	_, provider, err := b.Builder.CurrentEnv()
 	if err != nil {
@@ -108,3 +129,36 @@ func (b *StaticPluginAnalysisProcessor) ProcessValue(_ context.Context, transfor
 
 	return nil
 }
+
+// compileForEach compiles the forEach expression, which must evaluate to a
+// list, and returns the type of its elements.
+func compileForEach(env *cel.Env, expression *variable.Expression) (*cel.Type, error) {
+	ast, issues := env.Compile(expression.Value)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("cannot compile forEach expression %q: %w", expression.Value, issues.Err())
+	}
+	expression.AST = ast
+	outputType := ast.OutputType()
+	switch outputType.Kind() {
+	case types.DynKind:
+		return cel.DynType, nil
+	case types.ListKind:
+		return outputType.Parameters()[0], nil
+	default:
+		return nil, fmt.Errorf("forEach expression %q must evaluate to a list, but evaluates to %s", expression.Value, outputType.TypeName())
+	}
+}
+
+// compileWhen compiles the when expression, which must evaluate to a bool.
+func compileWhen(env *cel.Env, expression *variable.Expression) error {
+	ast, issues := env.Compile(expression.Value)
+	if issues.Err() != nil {
+		return fmt.Errorf("cannot compile when expression %q: %w", expression.Value, issues.Err())
+	}
+	expression.AST = ast
+	outputType := ast.OutputType()
+	if outputType.Kind() != types.DynKind && outputType.Kind() != types.BoolKind {
+		return fmt.Errorf("when expression %q must evaluate to a bool, but evaluates to %s", expression.Value, outputType.TypeName())
+	}
+	return nil
+}
@@ -16,14 +16,29 @@ import (
 	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
 )
 
+const (
+	// DefaultConcurrency is the number of transformations processed in parallel
+	// by [Graph.Process] unless configured otherwise with [Builder.WithConcurrency].
+	DefaultConcurrency = 1
+	// ConcurrencyUnlimited removes the limit on the number of transformations
+	// processed in parallel when passed to [Builder.WithConcurrency].
+	ConcurrencyUnlimited = -1
+)
+
 type Builder struct {
 	scheme       *runtime.Scheme
 	transformers map[runtime.Type]graphRuntime.Transformer
 	events       chan graphRuntime.ProgressEvent
+	concurrency  int
+	journal      graphRuntime.Journal
 }
 
 func NewBuilder(scheme *runtime.Scheme) *Builder {
-	return &Builder{scheme: scheme, transformers: map[runtime.Type]graphRuntime.Transformer{}}
+	return &Builder{
+		scheme:       scheme,
+		transformers: map[runtime.Type]graphRuntime.Transformer{},
+		concurrency:  DefaultConcurrency,
+	}
 }
 
 func (b *Builder) BuildAndCheck(original *v1alpha1.TransformationGraphDefinition) (*Graph, error) {
@@ -83,6 +98,8 @@ func (b *Builder) BuildAndCheck(original *v1alpha1.TransformationGraphDefinition
 		checked:      g,
 		transformers: b.transformers,
 		events:       b.events,
+		concurrency:  b.concurrency,
+		journal:      b.journal,
 	}, nil
 }
 
@@ -110,8 +127,14 @@ type Graph struct {
 	checked      *dag.DirectedAcyclicGraph[string]
 	transformers map[runtime.Type]graphRuntime.Transformer
 	events       chan graphRuntime.ProgressEvent
+	concurrency  int
+	journal      graphRuntime.Journal
 }
 
+// Process executes all transformations of the graph in topological order.
+// Transformations that do not depend on each other are processed in parallel,
+// bounded by the concurrency configured with [Builder.WithConcurrency]. The
+// items of forEach transformations count towards the same bound.
 func (g *Graph) Process(ctx context.Context) error {
 	synced := syncdag.ToSyncedGraph(g.checked)
 	runtimeEvaluationProcessor := syncdag.NewGraphProcessor(synced, &syncdag.GraphProcessorOptions[string, graph.Transformation]{
@@ -121,8 +144,10 @@ func (g *Graph) Process(ctx context.Context) error {
 			EvaluatedExpressionCache: make(map[string]any),
 			EvaluatedTransformations: make(map[string]any),
 			Events:                   g.events,
+			Journal:                  g.journal,
+			Concurrency:              g.concurrency,
 		},
-		Concurrency: 1,
+		Concurrency: g.concurrency,
 	})
 
 	err := runtimeEvaluationProcessor.Process(ctx)
@@ -140,6 +165,26 @@ func (b *Builder) WithEvents(events chan graphRuntime.ProgressEvent) *Builder {
 	return b
 }
 
+// WithConcurrency sets the maximum number of transformations processed in
+// parallel during Process(). 0 keeps [DefaultConcurrency], a negative value
+// such as [ConcurrencyUnlimited] removes the limit.
+func (b *Builder) WithConcurrency(concurrency int) *Builder {
+	if concurrency == 0 {
+		concurrency = DefaultConcurrency
+	}
+	b.concurrency = concurrency
+	return b
+}
+
+// WithJournal sets the journal that records completed transformations during
+// Process(). Transformations already present in the journal are not executed
+// again, which allows resuming a graph that failed halfway.
+// This is optional - if not set, every transformation is executed.
+func (b *Builder) WithJournal(journal graphRuntime.Journal) *Builder {
+	b.journal = journal
+	return b
+}
+
 // Events returns the channel where progress events are sent during Process().
 func (g *Graph) Events() <-chan graphRuntime.ProgressEvent {
 	return g.events
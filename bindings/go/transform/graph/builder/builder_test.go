@@ -1,7 +1,12 @@
 package builder
 
 import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"ocm.software/open-component-model/bindings/go/runtime"
@@ -393,6 +398,142 @@ transformations:
 			staticAnalysisErr:    require.NoError,
 			runtimeProcessingErr: require.Error,
 		},
+		{
+			name: "retry and timeout",
+			transformationSpec: `
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  timeout: 1m
+  retry:
+    maxRetries: 2
+    backoff: 1ms
+  spec:
+    name: "my-object"
+    version: "1.0.0"
+`,
+			staticAnalysisErr:    require.NoError,
+			runtimeProcessingErr: require.NoError,
+		},
+		{
+			name: "negative retries",
+			transformationSpec: `
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  retry:
+    maxRetries: -1
+  spec:
+    name: "my-object"
+    version: "1.0.0"
+`,
+			staticAnalysisErr: require.Error,
+		},
+		{
+			name: "zero timeout",
+			transformationSpec: `
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  timeout: 0s
+  spec:
+    name: "my-object"
+    version: "1.0.0"
+`,
+			staticAnalysisErr: require.Error,
+		},
+		{
+			name: "when and forEach",
+			transformationSpec: `
+environment:
+  objects:
+  - name: "a"
+    version: "1.0.0"
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  forEach: ${environment.objects}
+  when: ${item.version.startsWith('1.')}
+  spec:
+    name: ${item.name}
+    version: ${item.version}
+- id: add1
+  type: MockAddObjectTransformer/v1alpha1
+  when: ${size(get1) > 0}
+  spec:
+    object: ${get1[0].output.object}
+`,
+			staticAnalysisErr:    require.NoError,
+			runtimeProcessingErr: require.NoError,
+		},
+		{
+			name: "when is not a bool",
+			transformationSpec: `
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  when: ${'yes'}
+  spec:
+    name: "my-object"
+    version: "1.0.0"
+`,
+			staticAnalysisErr: require.Error,
+		},
+		{
+			name: "when is not a single expression",
+			transformationSpec: `
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  when: "always ${true}"
+  spec:
+    name: "my-object"
+    version: "1.0.0"
+`,
+			staticAnalysisErr: require.Error,
+		},
+		{
+			name: "forEach is not a list",
+			transformationSpec: `
+environment:
+  name: "my-object"
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  forEach: ${environment.name}
+  spec:
+    name: "my-object"
+    version: "1.0.0"
+`,
+			staticAnalysisErr: require.Error,
+		},
+		{
+			name: "item without forEach",
+			transformationSpec: `
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: ${item.name}
+    version: "1.0.0"
+`,
+			staticAnalysisErr: require.Error,
+		},
+		{
+			name: "item of wrong type",
+			transformationSpec: `
+environment:
+  numbers: [1, 2]
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  forEach: ${environment.numbers}
+  spec:
+    name: ${item}
+    version: "1.0.0"
+`,
+			staticAnalysisErr: require.Error,
+		},
 	}
 
 	for _, tc := range tests {
@@ -445,3 +586,349 @@ transformations:
 		require.NoError(t, graph.Process(t.Context()))
 	})
 }
+
+// recordingAddObject records the names of all added objects.
+type recordingAddObject struct {
+	testutils.MockAddObject
+	mu    sync.Mutex
+	added []string
+}
+
+func (r *recordingAddObject) Transform(ctx context.Context, step runtime.Typed) (runtime.Typed, error) {
+	transformed, err := r.MockAddObject.Transform(ctx, step)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.added = append(r.added, transformed.(*testutils.MockAddObjectTransformer).Output.Object.Name)
+	return transformed, nil
+}
+
+func TestBuilder_WhenAndForEach(t *testing.T) {
+	r := require.New(t)
+	tgd := &v1alpha1.TransformationGraphDefinition{}
+	r.NoError(yaml.Unmarshal([]byte(`
+environment:
+  objects:
+  - name: "a"
+    version: "1.0.0"
+  - name: "b"
+    version: "2.0.0"
+  - name: "c"
+    version: "3.0.0"
+transformations:
+- id: getAll
+  type: MockGetObjectTransformer/v1alpha1
+  forEach: ${environment.objects}
+  when: ${item.name != 'b'}
+  spec:
+    name: ${item.name}
+    version: ${item.version}
+- id: addAll
+  type: MockAddObjectTransformer/v1alpha1
+  forEach: ${getAll}
+  spec:
+    object: ${item.output.object}
+- id: getSkipped
+  type: MockGetObjectTransformer/v1alpha1
+  when: ${size(getAll) > 2}
+  spec:
+    name: "skipped"
+    version: "1.0.0"
+- id: addSkipped
+  type: MockAddObjectTransformer/v1alpha1
+  spec:
+    object: ${getSkipped.output.object}
+`), tgd))
+
+	scheme := runtime.NewScheme()
+	scheme.MustRegisterScheme(testutils.Scheme)
+	add := &recordingAddObject{MockAddObject: testutils.MockAddObject{Scheme: scheme}}
+	events := make(chan graphRuntime.ProgressEvent, 20)
+	graph, err := NewBuilder(scheme).
+		WithTransformer(&testutils.MockGetObjectTransformer{}, &testutils.MockGetObject{Scheme: scheme}).
+		WithTransformer(&testutils.MockAddObjectTransformer{}, add).
+		WithEvents(events).
+		BuildAndCheck(tgd)
+	r.NoError(err)
+	r.NoError(graph.Process(t.Context()))
+
+	r.Equal([]string{"a", "c"}, add.added, "every item except the filtered one should be added in order")
+
+	final := map[string]graphRuntime.State{}
+	for event := range events {
+		final[event.Transformation.ID] = event.State
+	}
+	r.Equal(map[string]graphRuntime.State{
+		"getAll":     graphRuntime.Completed,
+		"addAll":     graphRuntime.Completed,
+		"getSkipped": graphRuntime.Skipped,
+		"addSkipped": graphRuntime.Skipped,
+	}, final)
+}
+
+// barrierGetObject blocks every transformation until parties transformations
+// run at the same time, proving that they are processed in parallel.
+type barrierGetObject struct {
+	testutils.MockGetObject
+	parties int
+	timeout time.Duration
+
+	mu      sync.Mutex
+	arrived int
+	release chan struct{}
+}
+
+func (b *barrierGetObject) Transform(ctx context.Context, step runtime.Typed) (runtime.Typed, error) {
+	b.mu.Lock()
+	b.arrived++
+	if b.arrived == b.parties {
+		close(b.release)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-b.release:
+	case <-time.After(b.timeout):
+		return nil, fmt.Errorf("timed out waiting for %d concurrent transformations", b.parties)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return b.MockGetObject.Transform(ctx, step)
+}
+
+func TestBuilder_WithConcurrency(t *testing.T) {
+	tgd := &v1alpha1.TransformationGraphDefinition{}
+	require.NoError(t, yaml.Unmarshal([]byte(`
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: "one"
+    version: "1.0.0"
+- id: get2
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: "two"
+    version: "1.0.0"
+- id: get3
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: "three"
+    version: "1.0.0"
+- id: add1
+  type: MockAddObjectTransformer/v1alpha1
+  spec:
+    object: ${get1.output.object}
+- id: add2
+  type: MockAddObjectTransformer/v1alpha1
+  spec:
+    object: ${get2.output.object}
+- id: add3
+  type: MockAddObjectTransformer/v1alpha1
+  spec:
+    object: ${get3.output.object}
+`), tgd))
+
+	newBuilder := func(parties int, timeout time.Duration) *Builder {
+		scheme := runtime.NewScheme()
+		scheme.MustRegisterScheme(testutils.Scheme)
+		return NewBuilder(scheme).
+			WithTransformer(&testutils.MockGetObjectTransformer{}, &barrierGetObject{
+				MockGetObject: testutils.MockGetObject{Scheme: scheme},
+				parties:       parties,
+				timeout:       timeout,
+				release:       make(chan struct{}),
+			}).
+			WithTransformer(&testutils.MockAddObjectTransformer{}, &testutils.MockAddObject{Scheme: scheme})
+	}
+
+	for _, concurrency := range []int{3, ConcurrencyUnlimited} {
+		t.Run(fmt.Sprintf("independent transformations run in parallel with concurrency %d", concurrency), func(t *testing.T) {
+			r := require.New(t)
+			events := make(chan graphRuntime.ProgressEvent, 12)
+			graph, err := newBuilder(3, 5*time.Second).WithConcurrency(concurrency).WithEvents(events).BuildAndCheck(tgd)
+			r.NoError(err)
+			r.NoError(graph.Process(t.Context()))
+
+			states := map[string][]graphRuntime.State{}
+			for event := range events {
+				states[event.Transformation.ID] = append(states[event.Transformation.ID], event.State)
+			}
+			r.Len(states, graph.NodeCount())
+			for id, s := range states {
+				r.Equal([]graphRuntime.State{graphRuntime.Running, graphRuntime.Completed}, s, "events of %s", id)
+			}
+		})
+	}
+
+	t.Run("default concurrency processes sequentially", func(t *testing.T) {
+		graph, err := newBuilder(2, 100*time.Millisecond).BuildAndCheck(tgd)
+		require.NoError(t, err)
+		require.ErrorContains(t, graph.Process(t.Context()), "timed out waiting for 2 concurrent transformations")
+	})
+
+	t.Run("zero concurrency keeps the default", func(t *testing.T) {
+		graph, err := newBuilder(2, 100*time.Millisecond).WithConcurrency(0).BuildAndCheck(tgd)
+		require.NoError(t, err)
+		require.ErrorContains(t, graph.Process(t.Context()), "timed out waiting for 2 concurrent transformations")
+	})
+}
+
+func TestBuilder_ForEachConcurrency(t *testing.T) {
+	tgd := &v1alpha1.TransformationGraphDefinition{}
+	require.NoError(t, yaml.Unmarshal([]byte(`
+environment:
+  names: ["a", "b", "c"]
+transformations:
+- id: getAll
+  type: MockGetObjectTransformer/v1alpha1
+  forEach: ${environment.names}
+  spec:
+    name: ${item}
+    version: "1.0.0"
+`), tgd))
+
+	newBuilder := func(parties int, timeout time.Duration) *Builder {
+		scheme := runtime.NewScheme()
+		scheme.MustRegisterScheme(testutils.Scheme)
+		return NewBuilder(scheme).WithTransformer(&testutils.MockGetObjectTransformer{}, &barrierGetObject{
+			MockGetObject: testutils.MockGetObject{Scheme: scheme},
+			parties:       parties,
+			timeout:       timeout,
+			release:       make(chan struct{}),
+		})
+	}
+
+	t.Run("items run in parallel up to the concurrency", func(t *testing.T) {
+		graph, err := newBuilder(3, 5*time.Second).WithConcurrency(3).BuildAndCheck(tgd)
+		require.NoError(t, err)
+		require.NoError(t, graph.Process(t.Context()))
+	})
+
+	t.Run("default concurrency processes items sequentially", func(t *testing.T) {
+		graph, err := newBuilder(2, 100*time.Millisecond).BuildAndCheck(tgd)
+		require.NoError(t, err)
+		require.ErrorContains(t, graph.Process(t.Context()), "timed out waiting for 2 concurrent transformations")
+	})
+}
+
+// countingGetObject records the maximum number of transformations running at
+// the same time.
+type countingGetObject struct {
+	testutils.MockGetObject
+
+	mu            sync.Mutex
+	running, peak int
+}
+
+func (c *countingGetObject) Transform(ctx context.Context, step runtime.Typed) (runtime.Typed, error) {
+	c.mu.Lock()
+	c.running++
+	c.peak = max(c.peak, c.running)
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.running--
+		c.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(20 * time.Millisecond):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return c.MockGetObject.Transform(ctx, step)
+}
+
+func TestBuilder_ForEachConcurrencyIsShared(t *testing.T) {
+	r := require.New(t)
+	tgd := &v1alpha1.TransformationGraphDefinition{}
+	r.NoError(yaml.Unmarshal([]byte(`
+environment:
+  names: ["a", "b", "c", "d"]
+transformations:
+- id: getAll1
+  type: MockGetObjectTransformer/v1alpha1
+  forEach: ${environment.names}
+  spec:
+    name: ${item}
+    version: "1.0.0"
+- id: getAll2
+  type: MockGetObjectTransformer/v1alpha1
+  forEach: ${environment.names}
+  spec:
+    name: ${item}
+    version: "2.0.0"
+- id: get
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: "single"
+    version: "1.0.0"
+`), tgd))
+
+	scheme := runtime.NewScheme()
+	scheme.MustRegisterScheme(testutils.Scheme)
+	get := &countingGetObject{MockGetObject: testutils.MockGetObject{Scheme: scheme}}
+	graph, err := NewBuilder(scheme).WithTransformer(&testutils.MockGetObjectTransformer{}, get).WithConcurrency(2).BuildAndCheck(tgd)
+	r.NoError(err)
+	r.NoError(graph.Process(t.Context()))
+
+	r.Equal(2, get.peak, "transformations and forEach items must share the concurrency limit")
+}
+
+type failingGetObject struct{}
+
+func (failingGetObject) Transform(context.Context, runtime.Typed) (runtime.Typed, error) {
+	return nil, fmt.Errorf("get must not be executed again")
+}
+
+func TestBuilder_WithJournal(t *testing.T) {
+	r := require.New(t)
+	tgd := &v1alpha1.TransformationGraphDefinition{}
+	r.NoError(yaml.Unmarshal([]byte(`
+transformations:
+- id: get1
+  type: MockGetObjectTransformer/v1alpha1
+  spec:
+    name: "test"
+    version: "1.0.0"
+- id: add1
+  type: MockAddObjectTransformer/v1alpha1
+  spec:
+    object: ${get1.output.object}
+`), tgd))
+
+	path := filepath.Join(t.TempDir(), "journal")
+	journal, err := graphRuntime.OpenFileJournal(path)
+	r.NoError(err)
+	graph, err := newTestBuilder(t).WithJournal(journal).BuildAndCheck(tgd)
+	r.NoError(err)
+	r.NoError(graph.Process(t.Context()))
+	r.NoError(journal.Close())
+
+	// Drop add1 from the journal so that it has to run again on resume.
+	journal, err = graphRuntime.OpenFileJournal(filepath.Join(t.TempDir(), "resume"))
+	r.NoError(err)
+	t.Cleanup(func() { _ = journal.Close() })
+	recorded, err := graphRuntime.OpenFileJournal(path)
+	r.NoError(err)
+	get1, ok := recorded.Lookup("get1")
+	r.True(ok)
+	r.NoError(recorded.Close())
+	r.NoError(journal.Record("get1", get1))
+
+	scheme := runtime.NewScheme()
+	scheme.MustRegisterScheme(testutils.Scheme)
+	resumed, err := NewBuilder(scheme).
+		WithTransformer(&testutils.MockGetObjectTransformer{}, failingGetObject{}).
+		WithTransformer(&testutils.MockAddObjectTransformer{}, &testutils.MockAddObject{Scheme: scheme}).
+		WithJournal(journal).
+		BuildAndCheck(tgd)
+	r.NoError(err)
+	r.NoError(resumed.Process(t.Context()), "get1 should be resumed from the journal and add1 should resolve its output")
+
+	_, ok = journal.Lookup("add1")
+	r.True(ok, "add1 should be recorded after resuming")
+}
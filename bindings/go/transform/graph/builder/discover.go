@@ -27,12 +27,22 @@ func getTransformationNodes(tgd *v1alpha1.TransformationGraphDefinition) (map[st
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse resource of type %s: %w", typ, err)
 		}
+		when, err := parseStandaloneExpression(transformation.When)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse when of transformation %s: %w", transformation.ID, err)
+		}
+		forEach, err := parseStandaloneExpression(transformation.ForEach)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse forEach of transformation %s: %w", transformation.ID, err)
+		}
 		if _, exists := transformations[transformation.ID]; exists {
 			return nil, fmt.Errorf("duplicate transformation ID %s", transformation.ID)
 		}
 		transformations[transformation.ID] = graph.Transformation{
 			GenericTransformation: transformation,
 			FieldDescriptors:      fieldDescriptors,
+			When:                  when,
+			ForEach:               forEach,
 		}
 	}
 
@@ -43,6 +53,26 @@ func getTransformationNodes(tgd *v1alpha1.TransformationGraphDefinition) (map[st
 	return transformations, nil
 }
 
+// parseStandaloneExpression parses a value of the form "${expression}".
+// It returns nil if the value is empty.
+func parseStandaloneExpression(value string) (*variable.Expression, error) {
+	if value == "" {
+		return nil, nil
+	}
+	standalone, err := parser.IsStandaloneExpression(value)
+	if err != nil {
+		return nil, err
+	}
+	if !standalone {
+		return nil, fmt.Errorf("%q is not a single expression of the form ${...}", value)
+	}
+	expressions, err := parser.ExtractExpressions(value)
+	if err != nil {
+		return nil, err
+	}
+	return &variable.Expression{Value: expressions[0]}, nil
+}
+
 func discoverDependencies(g *dag.DirectedAcyclicGraph[string], env *cel.Env) error {
 	keys := slices.Collect(maps.Keys(g.Vertices))
 
@@ -60,6 +90,19 @@ func discoverDependencies(g *dag.DirectedAcyclicGraph[string], env *cel.Env) err
 			}
 			ttransformation.Expressions = append(ttransformation.Expressions, expressions...)
 		}
+		for _, expression := range []*variable.Expression{ttransformation.When, ttransformation.ForEach} {
+			if expression == nil {
+				continue
+			}
+			expressions, err := discoverExpressions(inspector, g, id, variable.FieldDescriptor{
+				Expressions: []variable.Expression{*expression},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to discover resource expressions of transformation %q: %w", id, err)
+			}
+			ttransformation.Expressions = append(ttransformation.Expressions, expressions...)
+		}
+		vertex.Attributes[syncdag.AttributeValue] = ttransformation
 	}
 
 	return nil
@@ -10,12 +10,29 @@ import (
 // ErrNamingConvention is the base error message for naming convention violations
 var ErrNamingConvention = "naming convention violation"
 
-// ValidateTransformations validates the naming conventions of the transformations.
+// ValidateTransformations validates the naming conventions and the retry and
+// timeout settings of the transformations.
 func ValidateTransformations(transformations map[string]graph.Transformation) error {
 	err := validateResourceIDs(transformations)
 	if err != nil {
 		return fmt.Errorf("%s: %w", ErrNamingConvention, err)
 	}
+	return validateExecutionPolicies(transformations)
+}
+
+// validateExecutionPolicies checks that retry policies and timeouts are usable.
+func validateExecutionPolicies(transformations map[string]graph.Transformation) error {
+	for _, transformation := range transformations {
+		meta := transformation.TransformationMeta
+		if meta.Retry != nil {
+			if err := meta.Retry.Validate(); err != nil {
+				return fmt.Errorf("invalid retry policy of transformation %s: %w", meta.ID, err)
+			}
+		}
+		if meta.Timeout != nil && *meta.Timeout <= 0 {
+			return fmt.Errorf("invalid timeout %s of transformation %s: must be positive", meta.Timeout, meta.ID)
+		}
+	}
 	return nil
 }
 
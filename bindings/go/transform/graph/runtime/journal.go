@@ -0,0 +1,132 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+// Journal records the evaluated transformations of completed graph nodes so
+// that an interrupted graph can be resumed without repeating finished work.
+// Implementations must be safe for concurrent use.
+type Journal interface {
+	// Lookup returns the evaluated transformation recorded for the given ID.
+	Lookup(id string) (map[string]any, bool)
+	// Record persists the evaluated transformation of a completed transformation.
+	Record(id string, evaluated map[string]any) error
+}
+
+// OutputVerifier can be implemented by a Transformer whose output refers to
+// state outside of the graph, e.g. files buffered on the local filesystem.
+// Before a journaled transformation is skipped, the Runtime calls VerifyOutput
+// with the journaled transformation. If it returns an error, the transformation
+// is executed again.
+type OutputVerifier interface {
+	VerifyOutput(ctx context.Context, transformed runtime.Typed) error
+}
+
+// journalEntry is a single line of a [FileJournal].
+type journalEntry struct {
+	ID             string         `json:"id"`
+	Transformation map[string]any `json:"transformation"`
+}
+
+// FileJournal is a [Journal] stored as newline delimited JSON in a local file.
+// Every completed transformation is appended and synced to disk immediately,
+// so the journal survives a crash of the process.
+type FileJournal struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]map[string]any
+}
+
+var _ Journal = (*FileJournal)(nil)
+
+// OpenFileJournal opens the journal at path, creating it if it does not exist.
+// Entries of an existing journal are loaded and can be looked up; new entries
+// are appended. A trailing incomplete line, left behind if the process died
+// while writing, is ignored.
+func OpenFileJournal(path string) (*FileJournal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal %q: %w", path, err)
+	}
+	entries, err := readJournal(file)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to read journal %q: %w", path, err), file.Close())
+	}
+	return &FileJournal{file: file, entries: entries}, nil
+}
+
+// readJournal reads all complete entries and truncates the file after the last one.
+func readJournal(file *os.File) (map[string]map[string]any, error) {
+	entries := map[string]map[string]any{}
+	reader := bufio.NewReader(file)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// anything after the last newline was not completely written
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(line)) > 0 {
+			var entry journalEntry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				return nil, fmt.Errorf("invalid entry at offset %d: %w", offset, err)
+			}
+			entries[entry.ID] = entry.Transformation
+		}
+		offset += int64(len(line))
+	}
+	if err := file.Truncate(offset); err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Lookup returns the evaluated transformation recorded for the given ID.
+func (j *FileJournal) Lookup(id string) (map[string]any, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	evaluated, ok := j.entries[id]
+	return evaluated, ok
+}
+
+// Record appends the evaluated transformation to the journal.
+func (j *FileJournal) Record(id string, evaluated map[string]any) error {
+	line, err := json.Marshal(journalEntry{ID: id, Transformation: evaluated})
+	if err != nil {
+		return fmt.Errorf("failed to encode journal entry for %q: %w", id, err)
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write journal entry for %q: %w", id, err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal entry for %q: %w", id, err)
+	}
+	j.entries[id] = evaluated
+	return nil
+}
+
+// Close closes the underlying journal file.
+func (j *FileJournal) Close() error {
+	return j.file.Close()
+}
@@ -0,0 +1,75 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileJournal(t *testing.T) {
+	evaluated := map[string]any{
+		"id":     "get1",
+		"type":   "MockGetObjectTransformer/v1alpha1",
+		"output": map[string]any{"size": float64(42)},
+	}
+
+	t.Run("recorded entries are available after reopening", func(t *testing.T) {
+		r := require.New(t)
+		path := filepath.Join(t.TempDir(), "journal")
+
+		journal, err := OpenFileJournal(path)
+		r.NoError(err)
+		_, ok := journal.Lookup("get1")
+		r.False(ok)
+		r.NoError(journal.Record("get1", evaluated))
+		r.NoError(journal.Close())
+
+		journal, err = OpenFileJournal(path)
+		r.NoError(err)
+		t.Cleanup(func() { _ = journal.Close() })
+		got, ok := journal.Lookup("get1")
+		r.True(ok)
+		r.Equal(evaluated, got)
+	})
+
+	t.Run("incomplete trailing entry is discarded", func(t *testing.T) {
+		r := require.New(t)
+		path := filepath.Join(t.TempDir(), "journal")
+
+		journal, err := OpenFileJournal(path)
+		r.NoError(err)
+		r.NoError(journal.Record("get1", evaluated))
+		r.NoError(journal.Close())
+
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+		r.NoError(err)
+		_, err = file.WriteString(`{"id":"get2","transformation":{"id":`)
+		r.NoError(err)
+		r.NoError(file.Close())
+
+		journal, err = OpenFileJournal(path)
+		r.NoError(err)
+		_, ok := journal.Lookup("get2")
+		r.False(ok, "incomplete entry must not be returned")
+		r.NoError(journal.Record("get3", evaluated))
+		r.NoError(journal.Close())
+
+		journal, err = OpenFileJournal(path)
+		r.NoError(err, "journal must stay readable after appending to a truncated entry")
+		t.Cleanup(func() { _ = journal.Close() })
+		_, ok = journal.Lookup("get1")
+		r.True(ok)
+		_, ok = journal.Lookup("get3")
+		r.True(ok)
+	})
+
+	t.Run("corrupt entry is rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "journal")
+		require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+
+		_, err := OpenFileJournal(path)
+		require.ErrorContains(t, err, "invalid entry")
+	})
+}
@@ -1,13 +1,21 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
 
 	"github.com/google/cel-go/cel"
 	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/sync/errgroup"
 
+	"ocm.software/open-component-model/bindings/go/cel/expression/variable"
 	stv6jsonschema "ocm.software/open-component-model/bindings/go/cel/jsonschema/santhosh-tekuri/v6"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/transform/graph"
@@ -33,6 +41,10 @@ func (s State) String() string {
 		return "completed"
 	case Failed:
 		return "failed"
+	case Retrying:
+		return "retrying"
+	case Skipped:
+		return "skipped"
 	default:
 		return fmt.Sprintf("unknown(%d)", s)
 	}
@@ -45,6 +57,12 @@ const (
 	Completed
 	// Failed means the transformation failed.
 	Failed
+	// Retrying means an attempt of the transformation failed and it is retried
+	// according to its retry policy.
+	Retrying
+	// Skipped means the transformation was not executed because its when
+	// expression evaluated to false or because a dependency was skipped.
+	Skipped
 )
 
 // ProgressEvent represents a state change during graph execution.
@@ -52,15 +70,44 @@ type ProgressEvent struct {
 	Transformation *graph.Transformation
 	State          State
 	Err            error
+	// Attempt is the number of the failed attempt for Retrying events, starting at 1.
+	Attempt int
 }
 
+// Runtime evaluates and executes the transformations of a checked graph.
+// It is safe for concurrent use by multiple goroutines, so independent
+// transformations can be processed in parallel.
 type Runtime struct {
-	Environment              *cel.Env
+	Environment *cel.Env
+	// EvaluatedExpressionCache and EvaluatedTransformations are guarded by mu
+	// once processing has started and must not be accessed concurrently from
+	// outside the Runtime.
 	EvaluatedExpressionCache map[string]any
 	EvaluatedTransformations map[string]any
 
 	Transformers map[runtime.Type]Transformer
-	Events       chan<- ProgressEvent
+	// Events receives a Running event, zero or more Retrying events (one per
+	// failed attempt that is retried) and then exactly one Completed, Skipped
+	// or Failed event per transformation. Events of different transformations may
+	// interleave when transformations are processed concurrently.
+	Events chan<- ProgressEvent
+	// Journal, if set, records every completed transformation. Transformations
+	// already recorded with the same resolved spec are not executed again;
+	// their journaled output is used instead.
+	Journal Journal
+	// Concurrency limits how many transformations, including the items of
+	// forEach transformations, are executed in parallel across all calls to
+	// ProcessValue. 0 executes one transformation at a time, a negative value
+	// removes the limit.
+	Concurrency int
+
+	semOnce sync.Once
+	// sem holds one token per executing transformation, nil if unlimited.
+	sem chan struct{}
+
+	mu sync.RWMutex
+	// skipped holds the IDs of skipped transformations.
+	skipped map[string]struct{}
 }
 
 func (b *Runtime) ProcessValue(ctx context.Context, transformation graph.Transformation) error {
@@ -68,7 +115,8 @@ func (b *Runtime) ProcessValue(ctx context.Context, transformation graph.Transfo
 	if b.Events != nil {
 		b.Events <- ProgressEvent{Transformation: t, State: Running}
 	}
-	if err := b.processTransformation(ctx, transformation); err != nil {
+	skipped, err := b.processTransformation(ctx, transformation)
+	if err != nil {
 		if b.Events != nil {
 			b.Events <- ProgressEvent{Transformation: t, State: Failed, Err: err}
 		}
@@ -76,37 +124,165 @@ func (b *Runtime) ProcessValue(ctx context.Context, transformation graph.Transfo
 	}
 
 	if b.Events != nil {
-		b.Events <- ProgressEvent{Transformation: t, State: Completed}
+		state := Completed
+		if skipped {
+			state = Skipped
+		}
+		b.Events <- ProgressEvent{Transformation: t, State: state}
+	}
+	return nil
+}
+
+// processTransformation executes the transformation, once per item if it has
+// a forEach expression. It reports whether the transformation was skipped,
+// either because its when expression evaluated to false or because one of its
+// dependencies was skipped.
+func (b *Runtime) processTransformation(ctx context.Context, transformation graph.Transformation) (bool, error) {
+	logger := slog.With("transformation", transformation.ID)
+	if dependency, ok := b.skippedDependency(transformation); ok {
+		logger.InfoContext(ctx, "skipping transformation because a dependency was skipped", "dependency", dependency)
+		b.skip(transformation.ID)
+		return true, nil
+	}
+
+	if transformation.ForEach != nil {
+		return false, b.processForEach(ctx, transformation)
+	}
+
+	b.mu.RLock()
+	ok, err := b.evaluateWhen(transformation, b.EvaluatedTransformations)
+	b.mu.RUnlock()
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		logger.InfoContext(ctx, "skipping transformation because its when expression evaluated to false")
+		b.skip(transformation.ID)
+		return true, nil
+	}
+
+	evaluated, err := b.execute(ctx, transformation, transformation.ID, b.EvaluatedTransformations, b.EvaluatedExpressionCache)
+	if err != nil {
+		return false, err
+	}
+	b.mu.Lock()
+	b.EvaluatedTransformations[transformation.ID] = evaluated
+	b.mu.Unlock()
+	return false, nil
+}
+
+// processForEach executes the transformation once per item of its forEach
+// expression, in parallel within the Concurrency limit of the runtime. Items for which the when
+// expression evaluates to false are left out. The evaluated transformations of
+// all executed items are stored as a list in the order of the items under the
+// ID of the transformation.
+func (b *Runtime) processForEach(ctx context.Context, transformation graph.Transformation) error {
+	b.mu.RLock()
+	value, err := b.evaluate(*transformation.ForEach, b.EvaluatedTransformations)
+	variables := maps.Clone(b.EvaluatedTransformations)
+	b.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to evaluate forEach of transformation %q: %w", transformation.ID, err)
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return fmt.Errorf("forEach of transformation %q evaluated to %T, expected a list", transformation.ID, value)
+	}
+
+	// every item gets its own variables, so items can be executed in parallel
+	itemVariables := make([]map[string]any, len(items))
+	for i, item := range items {
+		vars := maps.Clone(variables)
+		vars[graph.ItemVariable] = item
+		ok, err := b.evaluateWhen(transformation, vars)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+		if !ok {
+			slog.DebugContext(ctx, "skipping item because the when expression evaluated to false", "transformation", transformation.ID, "item", i)
+			continue
+		}
+		itemVariables[i] = vars
 	}
+
+	// the group only bounds the goroutines of the items and starts them in
+	// order, the limit shared with all other transformations is applied in execute
+	group, groupCtx := errgroup.WithContext(ctx)
+	switch {
+	case b.Concurrency == 0:
+		group.SetLimit(1)
+	case b.Concurrency > 0:
+		group.SetLimit(b.Concurrency)
+	}
+	results := make([]map[string]any, len(items))
+	for i, vars := range itemVariables {
+		if vars == nil {
+			continue
+		}
+		group.Go(func() error {
+			// the spec is resolved in place, so every item needs its own copy
+			itemTransformation := transformation
+			itemTransformation.GenericTransformation = *transformation.GenericTransformation.DeepCopy()
+			evaluated, err := b.execute(groupCtx, itemTransformation, fmt.Sprintf("%s[%d]", transformation.ID, i), vars, map[string]any{})
+			if err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+			results[i] = evaluated
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	evaluatedItems := make([]any, 0, len(items))
+	for i, vars := range itemVariables {
+		if vars != nil {
+			evaluatedItems = append(evaluatedItems, results[i])
+		}
+	}
+
+	b.mu.Lock()
+	b.EvaluatedTransformations[transformation.ID] = evaluatedItems
+	b.mu.Unlock()
 	return nil
 }
 
-func (b *Runtime) processTransformation(ctx context.Context, transformation graph.Transformation) error {
+// execute resolves the spec of the transformation against the given variables
+// and runs its transformer, unless the journal holds a reusable result under
+// journalID. Evaluated expressions are stored in and reused from cache.
+func (b *Runtime) execute(ctx context.Context, transformation graph.Transformation, journalID string, variables, cache map[string]any) (map[string]any, error) {
+	release, err := b.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	for _, fieldDescriptor := range transformation.FieldDescriptors {
 		for _, expression := range fieldDescriptor.Expressions {
-			if _, found := b.EvaluatedExpressionCache[expression.String()]; found {
+			b.mu.RLock()
+			_, found := cache[expression.String()]
+			b.mu.RUnlock()
+			if found {
 				continue
 			}
-			program, err := b.Environment.Program(expression.AST)
-			if err != nil {
-				return fmt.Errorf("failed to create program for expression %q: %w", expression.String(), err)
-			}
-			result, _, err := program.Eval(b.EvaluatedTransformations)
+			b.mu.RLock()
+			val, err := b.evaluate(expression, variables)
+			b.mu.RUnlock()
 			if err != nil {
-				return fmt.Errorf("failed to evaluate expression %q: %w", expression.String(), err)
+				return nil, err
 			}
-
-			val, err := GoNativeValue(result)
-			if err != nil {
-				return fmt.Errorf("failed to convert result of expression %q to go native type: %w", expression.String(), err)
-			}
-			b.EvaluatedExpressionCache[expression.String()] = val
+			b.mu.Lock()
+			cache[expression.String()] = val
+			b.mu.Unlock()
 		}
 	}
-	res := resolver.NewResolver(transformation.Spec.Data, b.EvaluatedExpressionCache, specSubSchema(transformation.Schema))
+	b.mu.RLock()
+	res := resolver.NewResolver(transformation.Spec.Data, cache, specSubSchema(transformation.Schema))
 	summary := res.Resolve(transformation.FieldDescriptors)
+	b.mu.RUnlock()
 	if len(summary.Errors) > 0 {
-		return fmt.Errorf("failed to resolve transformation %q: %w", transformation.ID, errors.Join(summary.Errors...))
+		return nil, fmt.Errorf("failed to resolve transformation %q: %w", transformation.ID, errors.Join(summary.Errors...))
 	}
 
 	unstructuredTransformationData := transformation.GenericTransformation.AsUnstructured().Data
@@ -115,29 +291,33 @@ func (b *Runtime) processTransformation(ctx context.Context, transformation grap
 		transformation.Schema,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to parse resolved transformation %q: %w", transformation.ID, err)
+		return nil, fmt.Errorf("failed to parse resolved transformation %q: %w", transformation.ID, err)
 	}
 	if len(fieldDescriptors) > 0 {
-		return fmt.Errorf("transformation %q has unresolved fields after resolution", transformation.ID)
+		return nil, fmt.Errorf("transformation %q has unresolved fields after resolution", transformation.ID)
 	}
 
 	runtimeType := transformation.GetType()
 	if runtimeType.IsEmpty() {
-		return fmt.Errorf("transformation type after render is empty")
+		return nil, fmt.Errorf("transformation type after render is empty")
 	}
 
 	transformer, ok := b.Transformers[runtimeType]
 	if !ok {
-		return fmt.Errorf("no transformer runtime registered for type %s", runtimeType)
+		return nil, fmt.Errorf("no transformer runtime registered for type %s", runtimeType)
+	}
+
+	if evaluated, ok := b.lookupJournal(ctx, transformation, journalID, unstructuredTransformationData, transformer); ok {
+		return evaluated, nil
 	}
 
-	transformed, err := transformer.Transform(ctx, transformation.AsRaw())
+	transformed, err := b.transform(ctx, transformation, transformer)
 	if err != nil {
-		return fmt.Errorf("failed to transform transformation %q: %w", transformation.ID, err)
+		return nil, fmt.Errorf("failed to transform transformation %q: %w", transformation.ID, err)
 	}
 	updated, err := v1alpha1.GenericTransformationFromTyped(transformed)
 	if err != nil {
-		return fmt.Errorf("failed to convert updated transformation %q to generic transformation: %w", transformation.ID, err)
+		return nil, fmt.Errorf("failed to convert updated transformation %q to generic transformation: %w", transformation.ID, err)
 	}
 	evaluatedTransformation := updated.AsUnstructured().Data
 
@@ -146,14 +326,193 @@ func (b *Runtime) processTransformation(ctx context.Context, transformation grap
 		transformation.Schema,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to parse evaluated transformation %q: %w", transformation.ID, err)
+		return nil, fmt.Errorf("failed to parse evaluated transformation %q: %w", transformation.ID, err)
 	}
 	if len(fieldDescriptors) > 0 {
-		return fmt.Errorf("transformation %q has unresolved fields after evaluation", transformation.ID)
+		return nil, fmt.Errorf("transformation %q has unresolved fields after evaluation", transformation.ID)
 	}
 
-	b.EvaluatedTransformations[transformation.ID] = evaluatedTransformation
-	return nil
+	if b.Journal != nil {
+		if err := b.Journal.Record(journalID, evaluatedTransformation); err != nil {
+			return nil, fmt.Errorf("failed to record transformation %q in journal: %w", transformation.ID, err)
+		}
+	}
+	return evaluatedTransformation, nil
+}
+
+// acquire blocks until a transformation can be executed within the Concurrency
+// limit and returns the function that frees its slot again.
+func (b *Runtime) acquire(ctx context.Context) (func(), error) {
+	b.semOnce.Do(func() {
+		switch {
+		case b.Concurrency == 0:
+			b.sem = make(chan struct{}, 1)
+		case b.Concurrency > 0:
+			b.sem = make(chan struct{}, b.Concurrency)
+		}
+	})
+	if b.sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case b.sem <- struct{}{}:
+		return func() { <-b.sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// evaluate evaluates a checked expression and converts the result to a go native value.
+func (b *Runtime) evaluate(expression variable.Expression, variables map[string]any) (any, error) {
+	program, err := b.Environment.Program(expression.AST)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create program for expression %q: %w", expression.String(), err)
+	}
+	result, _, err := program.Eval(variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate expression %q: %w", expression.String(), err)
+	}
+	val, err := GoNativeValue(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert result of expression %q to go native type: %w", expression.String(), err)
+	}
+	return val, nil
+}
+
+// evaluateWhen reports whether the when expression of the transformation
+// holds. A transformation without when expression is always executed.
+func (b *Runtime) evaluateWhen(transformation graph.Transformation, variables map[string]any) (bool, error) {
+	if transformation.When == nil {
+		return true, nil
+	}
+	value, err := b.evaluate(*transformation.When, variables)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate when of transformation %q: %w", transformation.ID, err)
+	}
+	ok, isBool := value.(bool)
+	if !isBool {
+		return false, fmt.Errorf("when of transformation %q evaluated to %T, expected a bool", transformation.ID, value)
+	}
+	return ok, nil
+}
+
+// skippedDependency returns a skipped transformation the given transformation depends on.
+func (b *Runtime) skippedDependency(transformation graph.Transformation) (string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, dependency := range transformation.Dependencies() {
+		if _, ok := b.skipped[dependency]; ok {
+			return dependency, true
+		}
+	}
+	return "", false
+}
+
+// skip marks the transformation as skipped.
+func (b *Runtime) skip(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.skipped == nil {
+		b.skipped = map[string]struct{}{}
+	}
+	b.skipped[id] = struct{}{}
+}
+
+// transform calls the transformer, applying the timeout and retry policy of the
+// transformation. Every failed attempt that is retried emits a Retrying event.
+func (b *Runtime) transform(ctx context.Context, transformation graph.Transformation, transformer Transformer) (runtime.Typed, error) {
+	maxRetries := 0
+	if transformation.Retry != nil {
+		maxRetries = transformation.Retry.MaxRetries
+	}
+	for attempt := 1; ; attempt++ {
+		transformed, err := b.attempt(ctx, transformation, transformer)
+		if err == nil {
+			return transformed, nil
+		}
+		if attempt > maxRetries || ctx.Err() != nil {
+			return nil, err
+		}
+
+		delay := transformation.Retry.Delay(attempt)
+		slog.WarnContext(ctx, "transformation failed, retrying",
+			"transformation", transformation.ID, "attempt", attempt, "delay", delay, "error", err)
+		if b.Events != nil {
+			b.Events <- ProgressEvent{Transformation: &transformation, State: Retrying, Err: err, Attempt: attempt}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// attempt calls the transformer once, limited by the timeout of the transformation.
+func (b *Runtime) attempt(ctx context.Context, transformation graph.Transformation, transformer Transformer) (runtime.Typed, error) {
+	if transformation.Timeout == nil {
+		return transformer.Transform(ctx, transformation.AsRaw())
+	}
+	timeout := time.Duration(*transformation.Timeout)
+	attemptCtx, cancel := context.WithTimeoutCause(ctx, timeout, fmt.Errorf("transformation timed out after %s", timeout))
+	defer cancel()
+	transformed, err := transformer.Transform(attemptCtx, transformation.AsRaw())
+	if err != nil && ctx.Err() == nil && attemptCtx.Err() != nil {
+		return nil, errors.Join(context.Cause(attemptCtx), err)
+	}
+	return transformed, err
+}
+
+// lookupJournal returns the journaled evaluation of the transformation if it can
+// be reused: the journaled spec must equal the resolved spec, the journaled
+// output must be complete and, if the transformer is an [OutputVerifier], it
+// must still accept the output.
+func (b *Runtime) lookupJournal(ctx context.Context, transformation graph.Transformation, journalID string, resolved map[string]any, transformer Transformer) (map[string]any, bool) {
+	if b.Journal == nil {
+		return nil, false
+	}
+	evaluated, ok := b.Journal.Lookup(journalID)
+	if !ok {
+		return nil, false
+	}
+	logger := slog.With("transformation", journalID)
+
+	if !jsonEqual(evaluated["type"], resolved["type"]) || !jsonEqual(evaluated["spec"], resolved["spec"]) {
+		logger.DebugContext(ctx, "journaled transformation does not match resolved spec, executing again")
+		return nil, false
+	}
+	fieldDescriptors, err := stv6jsonschema.ParseResource(evaluated, transformation.Schema)
+	if err != nil || len(fieldDescriptors) > 0 {
+		logger.DebugContext(ctx, "journaled transformation is incomplete, executing again", "error", err)
+		return nil, false
+	}
+	if verifier, ok := transformer.(OutputVerifier); ok {
+		generic, err := v1alpha1.GenericTransformationFromTyped(&runtime.Unstructured{Data: evaluated})
+		if err == nil {
+			err = verifier.VerifyOutput(ctx, generic.AsRaw())
+		}
+		if err != nil {
+			logger.InfoContext(ctx, "journaled output is no longer valid, executing again", "error", err)
+			return nil, false
+		}
+	}
+
+	logger.InfoContext(ctx, "resumed transformation from journal")
+	return evaluated, true
+}
+
+// jsonEqual compares two values by their JSON encoding, so that values read
+// back from a journal compare equal to their in-memory counterparts.
+func jsonEqual(a, b any) bool {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aJSON, bJSON)
 }
 
 // specSubSchema extracts the "spec" sub-schema from a full transformation
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/stretchr/testify/require"
@@ -22,6 +24,36 @@ func (m *mockFailingTransformer) Transform(_ context.Context, _ runtime.Typed) (
 	return nil, fmt.Errorf("transformer failed")
 }
 
+// mockFlakyTransformer fails the first failures calls and then delegates to the wrapped transformer.
+type mockFlakyTransformer struct {
+	Transformer
+	failures int
+	calls    int
+}
+
+func (m *mockFlakyTransformer) Transform(ctx context.Context, step runtime.Typed) (runtime.Typed, error) {
+	m.calls++
+	if m.calls <= m.failures {
+		return nil, fmt.Errorf("attempt %d failed", m.calls)
+	}
+	return m.Transformer.Transform(ctx, step)
+}
+
+type mockBlockingTransformer struct{}
+
+func (m *mockBlockingTransformer) Transform(ctx context.Context, _ runtime.Typed) (runtime.Typed, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type mockRejectingVerifier struct {
+	mockFailingTransformer
+}
+
+func (m *mockRejectingVerifier) VerifyOutput(_ context.Context, _ runtime.Typed) error {
+	return fmt.Errorf("output gone")
+}
+
 func newTestRuntime(t *testing.T, transformer Transformer, events chan ProgressEvent) *Runtime {
 	t.Helper()
 
@@ -95,3 +127,116 @@ func TestProcessValueEvents(t *testing.T) {
 		require.NoError(t, rt.ProcessValue(t.Context(), transformation), "ProcessValue should succeed without events")
 	})
 }
+
+func TestProcessValueJournal(t *testing.T) {
+	transformation := newTestTransformation(t)
+
+	recordJournal := func(t *testing.T) *FileJournal {
+		t.Helper()
+		journal, err := OpenFileJournal(filepath.Join(t.TempDir(), "journal"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = journal.Close() })
+
+		rt := newTestRuntime(t, nil, nil)
+		rt.Journal = journal
+		require.NoError(t, rt.ProcessValue(t.Context(), transformation))
+		_, ok := journal.Lookup(transformation.ID)
+		require.True(t, ok, "completed transformation should be journaled")
+		return journal
+	}
+
+	t.Run("journaled transformation is not executed again", func(t *testing.T) {
+		journal := recordJournal(t)
+		events := make(chan ProgressEvent, 2)
+		rt := newTestRuntime(t, &mockFailingTransformer{}, events)
+		rt.Journal = journal
+
+		require.NoError(t, rt.ProcessValue(t.Context(), transformation))
+		require.Equal(t, Running, (<-events).State)
+		require.Equal(t, Completed, (<-events).State)
+
+		evaluated, ok := rt.EvaluatedTransformations[transformation.ID].(map[string]any)
+		require.True(t, ok, "journaled output should be available for CEL evaluation")
+		require.Equal(t, "test-name", evaluated["output"].(map[string]any)["object"].(map[string]any)["name"])
+	})
+
+	t.Run("changed spec is executed again", func(t *testing.T) {
+		journal := recordJournal(t)
+		changed := newTestTransformation(t)
+		changed.Spec.Data["version"] = "2.0.0"
+		rt := newTestRuntime(t, &mockFailingTransformer{}, nil)
+		rt.Journal = journal
+
+		require.ErrorContains(t, rt.ProcessValue(t.Context(), changed), "transformer failed")
+	})
+
+	t.Run("rejected output is executed again", func(t *testing.T) {
+		journal := recordJournal(t)
+		rt := newTestRuntime(t, &mockRejectingVerifier{}, nil)
+		rt.Journal = journal
+
+		require.ErrorContains(t, rt.ProcessValue(t.Context(), transformation), "transformer failed")
+	})
+}
+
+func TestProcessValueRetry(t *testing.T) {
+	newFlaky := func(failures int) *mockFlakyTransformer {
+		scheme := runtime.NewScheme()
+		scheme.MustRegisterScheme(testutils.Scheme)
+		return &mockFlakyTransformer{Transformer: &testutils.MockGetObject{Scheme: scheme}, failures: failures}
+	}
+	withRetry := func(maxRetries int) graph.Transformation {
+		transformation := newTestTransformation(t)
+		transformation.Retry = &meta.RetryPolicy{MaxRetries: maxRetries, Backoff: meta.NewDuration(time.Millisecond)}
+		return transformation
+	}
+
+	t.Run("failed attempts are retried and emit Retrying", func(t *testing.T) {
+		events := make(chan ProgressEvent, 4)
+		flaky := newFlaky(2)
+		rt := newTestRuntime(t, flaky, events)
+
+		require.NoError(t, rt.ProcessValue(t.Context(), withRetry(3)))
+		require.Equal(t, 3, flaky.calls)
+		require.Equal(t, Running, (<-events).State)
+		for attempt := 1; attempt <= 2; attempt++ {
+			event := <-events
+			require.Equal(t, Retrying, event.State)
+			require.Equal(t, attempt, event.Attempt)
+			require.ErrorContains(t, event.Err, fmt.Sprintf("attempt %d failed", attempt))
+		}
+		require.Equal(t, Completed, (<-events).State)
+	})
+
+	t.Run("exhausted retries fail the transformation", func(t *testing.T) {
+		events := make(chan ProgressEvent, 4)
+		flaky := newFlaky(5)
+		rt := newTestRuntime(t, flaky, events)
+
+		require.ErrorContains(t, rt.ProcessValue(t.Context(), withRetry(1)), "attempt 2 failed")
+		require.Equal(t, 2, flaky.calls)
+		require.Equal(t, Running, (<-events).State)
+		require.Equal(t, Retrying, (<-events).State)
+		require.Equal(t, Failed, (<-events).State)
+	})
+
+	t.Run("without retry policy the first failure is final", func(t *testing.T) {
+		flaky := newFlaky(1)
+		rt := newTestRuntime(t, flaky, nil)
+
+		require.Error(t, rt.ProcessValue(t.Context(), newTestTransformation(t)))
+		require.Equal(t, 1, flaky.calls)
+	})
+
+	t.Run("timeout limits every attempt", func(t *testing.T) {
+		transformation := withRetry(1)
+		transformation.Timeout = meta.NewDuration(10 * time.Millisecond)
+		events := make(chan ProgressEvent, 3)
+		rt := newTestRuntime(t, &mockBlockingTransformer{}, events)
+
+		require.ErrorContains(t, rt.ProcessValue(t.Context(), transformation), "transformation timed out after 10ms")
+		require.Equal(t, Running, (<-events).State)
+		require.Equal(t, Retrying, (<-events).State)
+		require.Equal(t, Failed, (<-events).State)
+	})
+}
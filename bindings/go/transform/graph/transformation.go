@@ -8,9 +8,29 @@ import (
 	"ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
 )
 
+// ItemVariable is the name of the variable that holds the current element
+// of a forEach transformation.
+const ItemVariable = "item"
+
 type Transformation struct {
 	v1alpha1.GenericTransformation
 	FieldDescriptors []variable.FieldDescriptor
 	Expressions      []inspector.ExpressionInspection
 	Schema           *jsonschema.Schema
+	// When is the parsed condition of the transformation, nil if it has none.
+	When *variable.Expression
+	// ForEach is the parsed iteration of the transformation, nil if it has none.
+	ForEach *variable.Expression
+}
+
+// Dependencies returns the IDs of the transformations referenced by the
+// expressions of the transformation.
+func (t *Transformation) Dependencies() []string {
+	var ids []string
+	for _, expression := range t.Expressions {
+		for _, dependency := range expression.ResourceDependencies {
+			ids = append(ids, dependency.ID)
+		}
+	}
+	return ids
 }
@@ -1,6 +1,12 @@
 package meta
 
-import "ocm.software/open-component-model/bindings/go/runtime"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
 
 // TransformationMeta contains metadata for a transformation.
 // +k8s:deepcopy-gen:interfaces=ocm.software/open-component-model/bindings/go/runtime.Typed
@@ -9,4 +15,119 @@ import "ocm.software/open-component-model/bindings/go/runtime"
 type TransformationMeta struct {
 	Type runtime.Type `json:"type"`
 	ID   string       `json:"id"`
+	// Retry configures how a failed transformation is retried.
+	// If not set, a failed transformation fails the graph immediately.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	// Timeout limits the duration of a single attempt of the transformation,
+	// e.g. "10m". If not set, an attempt is not limited.
+	Timeout *Duration `json:"timeout,omitempty"`
+	// When is a CEL expression, e.g. "${getCV.output.descriptor.component.name != ''}",
+	// that must evaluate to a bool. If it evaluates to false, the transformation
+	// is skipped, and so is every transformation that depends on it.
+	// For a ForEach transformation, it is evaluated once per item.
+	When string `json:"when,omitempty"`
+	// ForEach is a CEL expression, e.g. "${getCV.output.descriptor.component.resources}",
+	// that must evaluate to a list. The transformation is executed once per
+	// element, which is available as "item" in When and in the spec.
+	// Other transformations refer to the evaluated transformations of all
+	// executed items as a list.
+	ForEach string `json:"forEach,omitempty"`
+}
+
+// RetryPolicy configures how a failed transformation is retried.
+// The delay between two attempts starts at Backoff and doubles after
+// every attempt until it reaches MaxBackoff.
+//
+//	retry:
+//	  maxRetries: 3
+//	  backoff: 5s
+//	  maxBackoff: 1m
+//
+// +k8s:deepcopy-gen=true
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first failed attempt.
+	MaxRetries int `json:"maxRetries"`
+	// Backoff is the delay before the first retry. Defaults to [DefaultBackoff].
+	Backoff *Duration `json:"backoff,omitempty"`
+	// MaxBackoff is the upper limit of the delay between two attempts.
+	// Defaults to [DefaultMaxBackoff].
+	MaxBackoff *Duration `json:"maxBackoff,omitempty"`
+}
+
+const (
+	// DefaultBackoff is the delay before the first retry if [RetryPolicy.Backoff] is not set.
+	DefaultBackoff = time.Second
+	// DefaultMaxBackoff is the upper limit of the delay between two attempts
+	// if [RetryPolicy.MaxBackoff] is not set.
+	DefaultMaxBackoff = time.Minute
+)
+
+// Delay returns the delay before the given retry, starting with 1 for the first retry.
+func (p *RetryPolicy) Delay(retry int) time.Duration {
+	backoff, maxBackoff := DefaultBackoff, DefaultMaxBackoff
+	if p.Backoff != nil {
+		backoff = time.Duration(*p.Backoff)
+	}
+	if p.MaxBackoff != nil {
+		maxBackoff = time.Duration(*p.MaxBackoff)
+	}
+	delay := backoff
+	for i := 1; i < retry && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// Validate rejects negative retries and durations.
+func (p *RetryPolicy) Validate() error {
+	if p.MaxRetries < 0 {
+		return fmt.Errorf("invalid maxRetries %d (must not be negative)", p.MaxRetries)
+	}
+	if p.Backoff != nil && *p.Backoff < 0 {
+		return fmt.Errorf("invalid backoff %s (must not be negative)", p.Backoff)
+	}
+	if p.MaxBackoff != nil && *p.MaxBackoff < 0 {
+		return fmt.Errorf("invalid maxBackoff %s (must not be negative)", p.MaxBackoff)
+	}
+	return nil
+}
+
+// Duration wraps time.Duration to support JSON/YAML marshaling
+// of human-readable duration strings (e.g. "30s", "5m", "1h").
+type Duration time.Duration
+
+// NewDuration creates a pointer to a Duration value.
+func NewDuration(d time.Duration) *Duration {
+	duration := Duration(d)
+	return &duration
+}
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("failed to parse duration: %w", err)
+	}
+
+	switch value := v.(type) {
+	case float64:
+		*d = Duration(time.Duration(value))
+		return nil
+	case string:
+		tmp, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: must be a duration like 30s, 5m, or nanoseconds number: %w", value, err)
+		}
+		*d = Duration(tmp)
+		return nil
+	default:
+		return fmt.Errorf("duration must be a duration string or nanoseconds number, got %T", v)
+	}
 }
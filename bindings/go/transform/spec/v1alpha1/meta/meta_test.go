@@ -0,0 +1,47 @@
+package meta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestTransformationMeta_RetryAndTimeout(t *testing.T) {
+	r := require.New(t)
+	var m TransformationMeta
+	r.NoError(yaml.Unmarshal([]byte(`
+type: MockGetObjectTransformer/v1alpha1
+id: get1
+timeout: 10m
+retry:
+  maxRetries: 3
+  backoff: 2s
+  maxBackoff: 5s
+`), &m))
+
+	r.Equal(Duration(10*time.Minute), *m.Timeout)
+	r.NotNil(m.Retry)
+	r.NoError(m.Retry.Validate())
+	r.Equal(3, m.Retry.MaxRetries)
+	r.Equal(2*time.Second, m.Retry.Delay(1))
+	r.Equal(4*time.Second, m.Retry.Delay(2))
+	r.Equal(5*time.Second, m.Retry.Delay(3), "delay must be capped at maxBackoff")
+
+	data, err := yaml.Marshal(m)
+	r.NoError(err)
+	r.Contains(string(data), "timeout: 10m0s")
+}
+
+func TestRetryPolicy_Defaults(t *testing.T) {
+	policy := &RetryPolicy{MaxRetries: 10}
+	require.Equal(t, DefaultBackoff, policy.Delay(1))
+	require.Equal(t, DefaultMaxBackoff, policy.Delay(10))
+}
+
+func TestRetryPolicy_Validate(t *testing.T) {
+	require.ErrorContains(t, (&RetryPolicy{MaxRetries: -1}).Validate(), "invalid maxRetries")
+	require.ErrorContains(t, (&RetryPolicy{Backoff: NewDuration(-time.Second)}).Validate(), "invalid backoff")
+	require.ErrorContains(t, (&RetryPolicy{MaxBackoff: NewDuration(-time.Second)}).Validate(), "invalid maxBackoff")
+}
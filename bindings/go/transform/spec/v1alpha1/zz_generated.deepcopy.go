@@ -12,7 +12,7 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GenericTransformation) DeepCopyInto(out *GenericTransformation) {
 	*out = *in
-	out.TransformationMeta = in.TransformationMeta
+	in.TransformationMeta.DeepCopyInto(&out.TransformationMeta)
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
 		*out = (*in).DeepCopy()
@@ -2,12 +2,15 @@ package componentversion
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"runtime/debug"
 	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -16,6 +19,7 @@ import (
 
 	"ocm.software/open-component-model/bindings/go/blob"
 	"ocm.software/open-component-model/bindings/go/constructor"
+	"ocm.software/open-component-model/bindings/go/constructor/provenance"
 	constructorruntime "ocm.software/open-component-model/bindings/go/constructor/runtime"
 	constructorv1 "ocm.software/open-component-model/bindings/go/constructor/spec/v1"
 	"ocm.software/open-component-model/bindings/go/credentials"
@@ -32,6 +36,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/cli/cmd/setup/hooks"
+	"ocm.software/open-component-model/cli/cmd/version"
 	ocmctx "ocm.software/open-component-model/cli/internal/context"
 	"ocm.software/open-component-model/cli/internal/flags/enum"
 	"ocm.software/open-component-model/cli/internal/flags/file"
@@ -53,8 +58,10 @@ const (
 	FlagSkipReferenceDigestProcessing      = "skip-reference-digest-processing"
 	FlagOutput                             = "output"
 	FlagDisplayMode                        = "display-mode"
+	FlagProvenance                         = "provenance"
 
 	DefaultComponentConstructorBaseName = "component-constructor"
+	ProvenanceBuilderID                 = "https://ocm.software/cli"
 	LegacyDefaultArchiveName            = "transport-archive"
 )
 
@@ -162,10 +169,20 @@ If no type is given, the repository specification is interpreted based on intros
 
 In case the CTF archive does not exist, it will be created by default.
 If not specified, it will be created with the name "transport-archive".
+
+Provenance:
+
+With --%[4]s, a SLSA v1 provenance is generated for every constructed component version and
+added to it as local resource %[5]q, so that it is covered by signatures of the component version.
+It records the digests of the resources, how they were resolved (input or access type), the digests of
+component references, the digest of the %[1]q file and the build environment.
+Use "get provenance" to read and "verify provenance" to check it.
 `,
 			DefaultComponentConstructorBaseName,
 			strings.Join([]string{ociv1.Type, ctfv1.Type}, "|"),
 			strings.Join([]string{ociv1.ShortType, ociv1.ShortType2, ctfv1.ShortType, ctfv1.ShortType2}, "|"),
+			FlagProvenance,
+			provenance.ResourceName,
 		),
 		Example: strings.TrimSpace(fmt.Sprintf(`
 Adding component versions to a CTF archive:
@@ -190,7 +207,11 @@ export COMPONENT_NAME="github.com/my-org/my-app"
 export COMPONENT_VERSION="1.2.3"
 export REGISTRY_URL="ghcr.io/my-org"
 add component-version --%[1]s ./archive --%[2]s %[3]s.yaml
-`, FlagRepositoryRef, FlagComponentConstructorPath, DefaultComponentConstructorBaseName)),
+
+Adding component versions with a SLSA provenance:
+
+add component-version --%[1]s ./archive --%[2]s %[3]s.yaml --%[4]s
+`, FlagRepositoryRef, FlagComponentConstructorPath, DefaultComponentConstructorBaseName, FlagProvenance)),
 		RunE:              AddComponentVersion,
 		PersistentPreRunE: persistentPreRunE,
 		DisableAutoGenTag: true,
@@ -206,6 +227,7 @@ add component-version --%[1]s ./archive --%[2]s %[3]s.yaml
 	enum.Var(cmd.Flags(), FlagComponentVersionConflictPolicy, ComponentVersionConflictPolicies(), "policy to apply when a component version already exists in the repository")
 	enum.Var(cmd.Flags(), FlagExternalComponentVersionCopyPolicy, ExternalComponentVersionCopyPolicies(), "policy to apply when a component reference to a component version outside of the constructor or target repository is encountered")
 	cmd.Flags().Bool(FlagSkipReferenceDigestProcessing, false, "skip digest processing for resources and sources. Any resource referenced via access type will not have their digest updated.")
+	cmd.Flags().Bool(FlagProvenance, false, fmt.Sprintf("generate a SLSA provenance for every constructed component version and add it as local resource %q", provenance.ResourceName))
 	enum.VarP(cmd.Flags(), FlagOutput, "o", []string{render.OutputFormatTable.String(), render.OutputFormatYAML.String(), render.OutputFormatJSON.String(), render.OutputFormatNDJSON.String(), render.OutputFormatTree.String()}, "output format of the component descriptors")
 	enum.VarP(cmd.Flags(), FlagDisplayMode, "", []string{render.StaticRenderMode, render.LiveRenderMode}, `static: print the output once the complete component graph is discovered
   live (experimental): continuously updates the output to represent the current construction state of the component graph`)
@@ -297,6 +319,11 @@ func AddComponentVersion(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("getting component constructor failed: %w", err)
 	}
 
+	withProvenance, err := cmd.Flags().GetBool(FlagProvenance)
+	if err != nil {
+		return fmt.Errorf("getting provenance flag failed: %w", err)
+	}
+
 	output, err := enum.Get(cmd.Flags(), FlagOutput)
 	if err != nil {
 		return fmt.Errorf("getting output flag failed: %w", err)
@@ -349,6 +376,11 @@ func AddComponentVersion(cmd *cobra.Command, _ []string) error {
 	if !skipReferenceDigestProcessing {
 		opts.ResourceDigestProcessorProvider = instance
 	}
+	if withProvenance {
+		if opts.Provenance, err = getProvenanceOptions(constructorFile); err != nil {
+			return fmt.Errorf("getting provenance options failed: %w", err)
+		}
+	}
 
 	constr := constructor.NewDefaultConstructor(constructorSpec, opts)
 	if err := renderComponents(cmd, constr, output, displayMode); err != nil {
@@ -410,6 +442,37 @@ func GetComponentConstructor(file *file.Flag) (*constructorruntime.ComponentCons
 	return constructorruntime.ConvertToRuntimeConstructor(&data), nil
 }
 
+// getProvenanceOptions identifies the CLI as builder and records the digest of the
+// component constructor file as read from disk, before environment variable substitution.
+func getProvenanceOptions(file *file.Flag) (*provenance.Options, error) {
+	path := file.String()
+	constructorStream, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("opening component constructor %q failed: %w", path, err)
+	}
+	defer func() { _ = constructorStream.Close() }()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, constructorStream); err != nil {
+		return nil, fmt.Errorf("reading component constructor %q failed: %w", path, err)
+	}
+
+	builderVersion := version.BuildVersion
+	if info, ok := debug.ReadBuildInfo(); ok && builderVersion == "n/a" {
+		builderVersion = info.Main.Version
+	}
+
+	return &provenance.Options{
+		Builder: provenance.Builder{
+			ID:      ProvenanceBuilderID,
+			Version: map[string]string{"ocm": builderVersion},
+		},
+		Constructor: &provenance.ResourceDescriptor{
+			Name:   path,
+			Digest: map[string]string{"sha256": hex.EncodeToString(hash.Sum(nil))},
+		},
+	}, nil
+}
+
 func getComponentConstructorFile(cmd *cobra.Command) (*file.Flag, error) {
 	constructorFlag, err := file.Get(cmd.Flags(), FlagComponentConstructorPath)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 
 	"ocm.software/open-component-model/cli/cmd/add"
 	"ocm.software/open-component-model/cli/cmd/configuration"
+	"ocm.software/open-component-model/cli/cmd/delete"
 	"ocm.software/open-component-model/cli/cmd/describe"
 	"ocm.software/open-component-model/cli/cmd/download"
 	"ocm.software/open-component-model/cli/cmd/generate"
@@ -66,5 +67,6 @@ func New() *cobra.Command {
 	cmd.AddCommand(pluginregistry.New())
 	cmd.AddCommand(transfer.New())
 	cmd.AddCommand(describe.New())
+	cmd.AddCommand(delete.New())
 	return cmd
 }
@@ -2,10 +2,17 @@ package cmd_test
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
@@ -17,21 +24,31 @@ import (
 	"testing"
 	"time"
 
+	"github.com/opencontainers/image-spec/specs-go"
+	ociImageSpecV1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
 
 	"ocm.software/open-component-model/bindings/go/blob"
 	"ocm.software/open-component-model/bindings/go/blob/filesystem"
+	"ocm.software/open-component-model/bindings/go/constructor/provenance"
 	"ocm.software/open-component-model/bindings/go/credentials"
 	"ocm.software/open-component-model/bindings/go/ctf"
 	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/oci"
 	"ocm.software/open-component-model/bindings/go/oci/compref"
 	ocictf "ocm.software/open-component-model/bindings/go/oci/ctf"
+	"ocm.software/open-component-model/bindings/go/oci/spec/layout"
 	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
+	"ocm.software/open-component-model/bindings/go/oci/tar"
 	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/tsa/tsatest"
+	sigstorev1alpha1 "ocm.software/open-component-model/bindings/go/sigstore/signing/v1alpha1"
 	componentversion "ocm.software/open-component-model/cli/cmd/add/component-version"
 	"ocm.software/open-component-model/cli/cmd/configuration"
+	"ocm.software/open-component-model/cli/cmd/get/signatures"
 	"ocm.software/open-component-model/cli/cmd/internal/test"
+	signcv "ocm.software/open-component-model/cli/cmd/sign/component-version"
 	ocmctx "ocm.software/open-component-model/cli/internal/context"
 )
 
@@ -230,6 +247,46 @@ func Test_Get_Component_Version_Invalid_Semver(t *testing.T) {
 	require.Error(t, err, "expected error but got none")
 }
 
+// Test_Get_Component_Version_Recursive_Depth tests that the get cv command stops at a positive recursion depth
+func Test_Get_Component_Version_Recursive_Depth(t *testing.T) {
+	r := require.New(t)
+	reference := func(to *descriptor.Descriptor) descriptor.Reference {
+		return descriptor.Reference{
+			ElementMeta: descriptor.ElementMeta{
+				ObjectMeta: descriptor.ObjectMeta{
+					Name:    "ref",
+					Version: to.Component.Version,
+				},
+			},
+			Component: to.Component.Name,
+		}
+	}
+	leaf := createTestDescriptor("ocm.software/leaf", "0.0.1")
+	middle := createTestDescriptor("ocm.software/middle", "0.0.1")
+	middle.Component.References = []descriptor.Reference{reference(leaf)}
+	root := createTestDescriptor("ocm.software/root", "0.0.1")
+	root.Component.References = []descriptor.Reference{reference(middle)}
+
+	archivePath, err := setupTestRepositoryWithDescriptorLibrary(t, root, middle, leaf)
+	r.NoError(err)
+
+	ref := compref.Ref{
+		Repository: &ctfv1.Repository{
+			FilePath: archivePath,
+		},
+		Component: root.Component.Name,
+		Version:   root.Component.Version,
+	}
+
+	result := new(bytes.Buffer)
+	_, err = test.OCM(t, test.WithArgs("get", "cv", ref.String(), "--recursive=1"), test.WithOutput(result))
+	r.NoError(err, "failed to run command")
+
+	r.Contains(result.String(), "ocm.software/root")
+	r.Contains(result.String(), "ocm.software/middle")
+	r.NotContains(result.String(), "ocm.software/leaf", "leaf is beyond the recursion depth")
+}
+
 // Test_Get_Component_Version_Formats_Recursive tests the different output formats for the get cv command
 func Test_Get_Component_Version_Formats_Recursive(t *testing.T) {
 	// Setup test repository
@@ -1534,6 +1591,495 @@ configurations:
 	r.NoError(err, "failed to verify component version")
 }
 
+func Test_Sign_And_Verify_Component_Version_With_SHA3_And_References(t *testing.T) {
+	r := require.New(t)
+	tmp := t.TempDir()
+
+	name, version := "ocm.software/examples-01", "1.0.0"
+	constructorYAML := fmt.Sprintf(`
+components:
+- name: %[1]s
+  version: %[2]s
+  provider:
+    name: ocm.software
+  componentReferences:
+  - name: child
+    componentName: %[1]s-child
+    version: %[2]s
+  resources:
+  - name: my-secure-resource
+    type: blob
+    input:
+      type: utf8/v1
+      text: "I want to be signed"
+- name: %[1]s-child
+  version: %[2]s
+  provider:
+    name: ocm.software
+`, name, version)
+
+	constructorYAMLFilePath := filepath.Join(tmp, "component-constructor.yaml")
+	r.NoError(os.WriteFile(constructorYAMLFilePath, []byte(constructorYAML), 0o600))
+
+	archiveFilePath := filepath.Join(tmp, "transport-archive")
+	_, err := test.OCM(t, test.WithArgs("add", "cv",
+		"--constructor", constructorYAMLFilePath,
+		"--repository", archiveFilePath,
+	))
+	r.NoError(err, "could not construct component version")
+
+	signatureName := "test-signature"
+	aKey := mustKey(t)
+	cert := mustSelfSigned(t, "CN=signer", aKey)
+	privateKeyPath, publicKeyChainPath := writeKeyAndChain(t, t.TempDir(), aKey, cert)
+
+	ocmConfigYAML := fmt.Sprintf(`
+type: generic.config.ocm.software/v1
+configurations:
+- type: credentials.config.ocm.software
+  consumers:
+  - identity:
+      type: RSA/v1alpha1
+      algorithm: RSASSA-PSS
+      signature: %[1]s
+    credentials:
+    - type: Credentials/v1
+      properties:
+        public_key_pem_file: %[2]s
+        private_key_pem_file: %[3]s
+`, signatureName, publicKeyChainPath, privateKeyPath)
+
+	ocmConfigFilePath := filepath.Join(tmp, "ocm-config.yaml")
+	r.NoError(os.WriteFile(ocmConfigFilePath, []byte(ocmConfigYAML), 0o600))
+
+	reference := archiveFilePath + "//" + name + ":" + version
+
+	// the reference digest is SHA-256, the signature digest SHA3-256
+	signature := new(bytes.Buffer)
+	_, err = test.OCM(t, test.WithArgs("sign", "component-version",
+		reference,
+		"--signature", signatureName,
+		"--hash", "SHA3-256",
+		"--output", "json",
+		"--config", ocmConfigFilePath),
+		test.WithOutput(signature),
+	)
+	r.NoError(err, "failed to sign component version")
+	r.Contains(signature.String(), `"hashAlgorithm": "SHA3-256"`)
+
+	_, err = test.OCM(t, test.WithArgs("verify", "component-version",
+		reference,
+		"--signature", signatureName,
+		"--config", ocmConfigFilePath),
+	)
+	r.NoError(err, "failed to verify component version")
+}
+
+func Test_Sign_And_Verify_Component_Version_With_Resource_Signatures(t *testing.T) {
+	r := require.New(t)
+	tmp := t.TempDir()
+
+	name, version := "ocm.software/examples-01", "1.0.0"
+	layoutPath := writeSingleLayerOCILayout(t, tmp, []byte("I want to be signed on my own"))
+
+	constructorYAML := fmt.Sprintf(`
+name: %[1]s
+version: %[2]s
+provider:
+  name: ocm.software
+resources:
+  - name: my-image
+    type: ociArtifact
+    input:
+      type: file/v1
+      path: %[3]s
+      mediaType: %[4]s
+  - name: my-blob
+    type: blob
+    input:
+      type: utf8/v1
+      text: "I cannot carry a detached signature"
+`, name, version, layoutPath, layout.MediaTypeOCIImageLayoutTarV1)
+
+	constructorYAMLFilePath := filepath.Join(tmp, "component-constructor.yaml")
+	r.NoError(os.WriteFile(constructorYAMLFilePath, []byte(constructorYAML), 0o600))
+
+	archiveFilePath := filepath.Join(tmp, "transport-archive")
+	_, err := test.OCM(t, test.WithArgs("add", "cv",
+		"--constructor", constructorYAMLFilePath,
+		"--repository", archiveFilePath,
+	))
+	r.NoError(err, "could not construct component version")
+
+	signatureName := "test-signature"
+	aKey := mustKey(t)
+	cert := mustSelfSigned(t, "CN=signer", aKey)
+	privateKeyPath, publicKeyChainPath := writeKeyAndChain(t, t.TempDir(), aKey, cert)
+
+	ocmConfigYAML := fmt.Sprintf(`
+type: generic.config.ocm.software/v1
+configurations:
+- type: credentials.config.ocm.software
+  consumers:
+  - identity:
+      type: RSA/v1alpha1
+      algorithm: RSASSA-PSS
+      signature: %[1]s
+    credentials:
+    - type: Credentials/v1
+      properties:
+        public_key_pem_file: %[2]s
+        private_key_pem_file: %[3]s
+`, signatureName, publicKeyChainPath, privateKeyPath)
+
+	ocmConfigFilePath := filepath.Join(tmp, "ocm-config.yaml")
+	r.NoError(os.WriteFile(ocmConfigFilePath, []byte(ocmConfigYAML), 0o600))
+
+	reference := archiveFilePath + "//" + name + ":" + version
+	verify := func() error {
+		_, err := test.OCM(t, test.WithArgs("verify", "component-version",
+			reference,
+			"--signature", signatureName,
+			"--verify-resources",
+			"--config", ocmConfigFilePath),
+		)
+		return err
+	}
+
+	_, err = test.OCM(t, test.WithArgs("sign", "component-version",
+		reference,
+		"--signature", signatureName,
+		"--config", ocmConfigFilePath),
+	)
+	r.NoError(err, "failed to sign component version")
+	r.ErrorContains(verify(), "has no detached signature", "resources signed without --sign-resources must fail resource verification")
+
+	_, err = test.OCM(t, test.WithArgs("sign", "component-version",
+		reference,
+		"--signature", signatureName,
+		"--sign-resources",
+		"--force",
+		"--dry-run",
+		"--config", ocmConfigFilePath),
+		test.WithOutput(new(bytes.Buffer)),
+	)
+	r.NoError(err, "failed to sign component version with resource signatures in dry run")
+	r.ErrorContains(verify(), "has no detached signature", "a dry run must not attach resource signatures")
+
+	logs := test.NewJSONLogReader()
+	_, err = test.OCM(t, test.WithArgs("sign", "component-version",
+		reference,
+		"--signature", signatureName,
+		"--sign-resources",
+		"--force",
+		"--config", ocmConfigFilePath),
+		test.WithErrorOutput(logs),
+	)
+	r.NoError(err, "failed to sign component version with resource signatures")
+
+	entries, err := logs.List()
+	r.NoError(err)
+	var signed, skipped []string
+	for _, entry := range entries {
+		switch entry.Msg {
+		case "signed resource":
+			signed = append(signed, fmt.Sprint(entry.Extras["resource"]))
+		case "skipping resource that cannot carry a detached signature":
+			skipped = append(skipped, fmt.Sprint(entry.Extras["resource"]))
+		}
+	}
+	r.Len(signed, 1)
+	r.Contains(signed[0], "my-image")
+	r.Len(skipped, 1)
+	r.Contains(skipped[0], "my-blob")
+
+	r.NoError(verify(), "failed to verify component version with resource signatures")
+}
+
+func Test_Sign_And_Verify_Component_Version_With_ECC_Spec(t *testing.T) {
+	tmp := t.TempDir()
+	name, version := "ocm.software/examples-01", "1.0.0"
+
+	constructorYAML := fmt.Sprintf(`
+name: %[1]s
+version: %[2]s
+provider:
+  name: ocm.software
+resources:
+  - name: my-secure-resource
+    type: blob
+    input:
+      type: utf8/v1
+      text: "I want to be signed"
+`, name, version)
+	constructorYAMLFilePath := filepath.Join(tmp, "component-constructor.yaml")
+	require.NoError(t, os.WriteFile(constructorYAMLFilePath, []byte(constructorYAML), 0o600))
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, ed, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name      string
+		key       crypto.Signer
+		algorithm string
+		encoding  string
+	}{
+		{name: "ECDSA P-384", key: p384, algorithm: "ECDSA", encoding: "Plain"},
+		{name: "ECDSA P-384 PEM", key: p384, algorithm: "ECDSA", encoding: "PEM"},
+		{name: "ECDSA P-384 DSSE", key: p384, algorithm: "ECDSA", encoding: "DSSE"},
+		{name: "Ed25519", key: ed, algorithm: "Ed25519", encoding: "Plain"},
+		{name: "Ed25519 DSSE", key: ed, algorithm: "Ed25519", encoding: "DSSE"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+			dir := t.TempDir()
+			archiveFilePath := filepath.Join(dir, "transport-archive")
+			_, err := test.OCM(t, test.WithArgs("add", "cv",
+				"--constructor", constructorYAMLFilePath,
+				"--repository", archiveFilePath,
+			))
+			r.NoError(err, "could not construct component version")
+
+			der, err := x509.MarshalPKCS8PrivateKey(tc.key)
+			r.NoError(err)
+			privateKeyPath := filepath.Join(dir, "key.pem")
+			writePEMFile(t, privateKeyPath, "PRIVATE KEY", der)
+			n, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+			r.NoError(err)
+			tmpl := &x509.Certificate{
+				SerialNumber:          n,
+				Subject:               pkix.Name{CommonName: "signer"},
+				NotBefore:             time.Now().Add(-time.Hour),
+				NotAfter:              time.Now().Add(24 * time.Hour),
+				KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+				BasicConstraintsValid: true,
+				IsCA:                  true,
+			}
+			certDER, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, tc.key.Public(), tc.key)
+			r.NoError(err)
+			certPath := filepath.Join(dir, "cert.pem")
+			writePEMFile(t, certPath, "CERTIFICATE", certDER)
+
+			ocmConfigYAML := fmt.Sprintf(`
+type: generic.config.ocm.software/v1
+configurations:
+- type: credentials.config.ocm.software
+  consumers:
+  - identity:
+      type: ECC/v1alpha1
+      algorithm: %[1]s
+      signature: default
+    credentials:
+    - type: Credentials/v1
+      properties:
+        publicKeyPEMFile: %[2]s
+        privateKeyPEMFile: %[3]s
+`, tc.algorithm, certPath, privateKeyPath)
+			ocmConfigFilePath := filepath.Join(dir, "ocm-config.yaml")
+			r.NoError(os.WriteFile(ocmConfigFilePath, []byte(ocmConfigYAML), 0o600))
+
+			specFilePath := filepath.Join(dir, "ecc-spec.yaml")
+			r.NoError(os.WriteFile(specFilePath, []byte(fmt.Sprintf(
+				"type: ECCSigningConfiguration/v1alpha1\nsignatureAlgorithm: %s\nsignatureEncodingPolicy: %s\n",
+				tc.algorithm, tc.encoding)), 0o600))
+
+			reference := archiveFilePath + "//" + name + ":" + version
+			_, err = test.OCM(t, test.WithArgs("sign", "component-version", reference,
+				"--signer-spec", specFilePath,
+				"--config", ocmConfigFilePath),
+			)
+			r.NoError(err, "failed to sign component version")
+
+			_, err = test.OCM(t, test.WithArgs("verify", "component-version", reference,
+				"--verifier-spec", specFilePath,
+				"--config", ocmConfigFilePath),
+			)
+			r.NoError(err, "failed to verify component version")
+		})
+	}
+}
+
+func Test_Sign_And_Verify_Component_Version_With_Timestamp(t *testing.T) {
+	r := require.New(t)
+	tmp := t.TempDir()
+	name, version := "ocm.software/examples-01", "1.0.0"
+
+	constructorYAML := fmt.Sprintf(`
+name: %[1]s
+version: %[2]s
+provider:
+  name: ocm.software
+resources:
+  - name: my-secure-resource
+    type: blob
+    input:
+      type: utf8/v1
+      text: "I want to be signed"
+`, name, version)
+	constructorYAMLFilePath := filepath.Join(tmp, "component-constructor.yaml")
+	r.NoError(os.WriteFile(constructorYAMLFilePath, []byte(constructorYAML), 0o600))
+
+	archiveFilePath := filepath.Join(tmp, "transport-archive")
+	_, err := test.OCM(t, test.WithArgs("add", "cv",
+		"--constructor", constructorYAMLFilePath,
+		"--repository", archiveFilePath,
+	))
+	r.NoError(err, "could not construct component version")
+
+	// The signing certificate expired yesterday, the signature was timestamped while it was valid.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	r.NoError(err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	r.NoError(err)
+	privateKeyPath := filepath.Join(tmp, "key.pem")
+	writePEMFile(t, privateKeyPath, "PRIVATE KEY", der)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "signer"},
+		NotBefore:             time.Now().Add(-72 * time.Hour),
+		NotAfter:              time.Now().Add(-24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	r.NoError(err)
+	certPath := filepath.Join(tmp, "cert.pem")
+	writePEMFile(t, certPath, "CERTIFICATE", certDER)
+
+	server := tsatest.NewServer(t)
+	server.Now = func() time.Time { return time.Now().Add(-48 * time.Hour) }
+	tsaRootPath := filepath.Join(tmp, "tsa-root.pem")
+	r.NoError(os.WriteFile(tsaRootPath, server.RootPEM(), 0o600))
+
+	ocmConfigYAML := fmt.Sprintf(`
+type: generic.config.ocm.software/v1
+configurations:
+- type: credentials.config.ocm.software
+  consumers:
+  - identity:
+      type: ECC/v1alpha1
+      algorithm: ECDSA
+      signature: default
+    credentials:
+    - type: Credentials/v1
+      properties:
+        publicKeyPEMFile: %[1]s
+        privateKeyPEMFile: %[2]s
+`, certPath, privateKeyPath)
+	ocmConfigFilePath := filepath.Join(tmp, "ocm-config.yaml")
+	r.NoError(os.WriteFile(ocmConfigFilePath, []byte(ocmConfigYAML), 0o600))
+
+	specFilePath := filepath.Join(tmp, "ecc-spec.yaml")
+	r.NoError(os.WriteFile(specFilePath, []byte("type: ECCSigningConfiguration/v1alpha1\nsignatureAlgorithm: ECDSA\nsignatureEncodingPolicy: PEM\n"), 0o600))
+
+	reference := archiveFilePath + "//" + name + ":" + version
+	_, err = test.OCM(t, test.WithArgs("sign", "component-version", reference,
+		"--signer-spec", specFilePath,
+		"--tsa-url", server.URL,
+		"--config", ocmConfigFilePath),
+	)
+	r.NoError(err, "failed to sign component version")
+
+	t.Run("without TSA roots the expired certificate is rejected", func(t *testing.T) {
+		_, err := test.OCM(t, test.WithArgs("verify", "component-version", reference,
+			"--verifier-spec", specFilePath,
+			"--config", ocmConfigFilePath),
+		)
+		require.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("with TSA roots the certificate is validated at the timestamp", func(t *testing.T) {
+		_, err := test.OCM(t, test.WithArgs("verify", "component-version", reference,
+			"--verifier-spec", specFilePath,
+			"--tsa-root-certs", tsaRootPath,
+			"--config", ocmConfigFilePath),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("untrusted TSA roots are rejected", func(t *testing.T) {
+		otherRootPath := filepath.Join(t.TempDir(), "other-root.pem")
+		require.NoError(t, os.WriteFile(otherRootPath, tsatest.NewServer(t).RootPEM(), 0o600))
+		_, err := test.OCM(t, test.WithArgs("verify", "component-version", reference,
+			"--verifier-spec", specFilePath,
+			"--tsa-root-certs", otherRootPath,
+			"--config", ocmConfigFilePath),
+		)
+		require.ErrorContains(t, err, "verifying timestamp of signature \"default\" failed")
+	})
+
+	t.Run("rotation verifies the old signature at the timestamp", func(t *testing.T) {
+		r := require.New(t)
+		newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		r.NoError(err)
+		newDER, err := x509.MarshalPKCS8PrivateKey(newKey)
+		r.NoError(err)
+		newPrivateKeyPath := filepath.Join(t.TempDir(), "new-key.pem")
+		writePEMFile(t, newPrivateKeyPath, "PRIVATE KEY", newDER)
+		newTmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(2),
+			Subject:               pkix.Name{CommonName: "rotated"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(24 * time.Hour),
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+		newCertDER, err := x509.CreateCertificate(rand.Reader, newTmpl, newTmpl, newKey.Public(), newKey)
+		r.NoError(err)
+		newCertPath := filepath.Join(t.TempDir(), "new-cert.pem")
+		writePEMFile(t, newCertPath, "CERTIFICATE", newCertDER)
+
+		rotateConfigFilePath := filepath.Join(t.TempDir(), "rotate-config.yaml")
+		r.NoError(os.WriteFile(rotateConfigFilePath, []byte(ocmConfigYAML+fmt.Sprintf(`
+  - identity:
+      type: ECC/v1alpha1
+      algorithm: ECDSA
+      signature: rotated
+    credentials:
+    - type: Credentials/v1
+      properties:
+        publicKeyPEMFile: %[1]s
+        privateKeyPEMFile: %[2]s
+`, newCertPath, newPrivateKeyPath)), 0o600))
+
+		rotate := func(extraArgs ...string) (signcv.RotationReport, error) {
+			out := new(bytes.Buffer)
+			_, err := test.OCM(t, test.WithArgs(append([]string{"sign", "component-version",
+				archiveFilePath,
+				"--rotate", "default",
+				"--signature", "rotated",
+				"--signer-spec", specFilePath,
+				"--verifier-spec", specFilePath,
+				"--output", "json",
+				"--config", rotateConfigFilePath,
+			}, extraArgs...)...), test.WithOutput(out), test.WithErrorOutput(test.NewJSONLogReader()))
+			var report signcv.RotationReport
+			r.NoError(json.Unmarshal(out.Bytes(), &report), "could not decode rotation report %q", out.String())
+			return report, err
+		}
+
+		report, err := rotate()
+		r.ErrorIs(err, signcv.ErrRotationFailed)
+		r.Len(report.Results, 1)
+		r.Contains(report.Results[0].Reason, "expired")
+
+		report, err = rotate("--tsa-root-certs", tsaRootPath)
+		r.NoError(err)
+		r.Equal(signcv.RotationSummary{Total: 1, Rotated: 1}, report.Summary)
+
+		_, err = test.OCM(t, test.WithArgs("verify", "component-version", reference,
+			"--signature", "rotated",
+			"--verifier-spec", specFilePath,
+			"--config", rotateConfigFilePath),
+		)
+		r.NoError(err, "could not verify rotated signature")
+	})
+}
+
 func Test_Sign_With_Sigstore_Spec_Selects_Cosign_Handler(t *testing.T) {
 	t.Setenv("SIGSTORE_ID_TOKEN", "")
 	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "")
@@ -1769,6 +2315,39 @@ func writeCertsPEM(t *testing.T, dir, name string, certs ...*x509.Certificate) s
 	return p
 }
 
+// writeSingleLayerOCILayout writes an OCI image layout tar with a single image
+// manifest holding data as its only layer and returns its path.
+func writeSingleLayerOCILayout(t *testing.T, dir string, data []byte) string {
+	t.Helper()
+	r := require.New(t)
+	ctx := t.Context()
+
+	var buf bytes.Buffer
+	w, err := tar.NewOCILayoutWriterWithTempFile(&buf, t.TempDir())
+	r.NoError(err)
+
+	layer := content.NewDescriptorFromBytes(ociImageSpecV1.MediaTypeImageLayer, data)
+	r.NoError(w.Push(ctx, layer, bytes.NewReader(data)))
+	config := ociImageSpecV1.DescriptorEmptyJSON
+	r.NoError(w.Push(ctx, config, bytes.NewReader(config.Data)))
+
+	manifestRaw, err := json.Marshal(ociImageSpecV1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ociImageSpecV1.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ociImageSpecV1.Descriptor{layer},
+	})
+	r.NoError(err)
+	manifest := content.NewDescriptorFromBytes(ociImageSpecV1.MediaTypeImageManifest, manifestRaw)
+	r.NoError(w.Push(ctx, manifest, bytes.NewReader(manifestRaw)))
+	r.NoError(w.Tag(ctx, manifest, "image:v1.0.0"))
+	r.NoError(w.Close())
+
+	path := filepath.Join(dir, "image-layout.tar")
+	r.NoError(os.WriteFile(path, buf.Bytes(), 0o600))
+	return path
+}
+
 // Test_Transfer_Component_Version_With_Local_Blob tests transferring a component from source to target
 // repository and verifies that local blob resources are properly transferred and accessible.
 func Test_Transfer_Component_Version_With_Local_Blob(t *testing.T) {
@@ -2314,3 +2893,386 @@ func TestGetOCMConfigForCommand(t *testing.T) {
 		r.Len(cfg.Configurations, 6)
 	})
 }
+
+func Test_Add_Component_Version_With_Provenance(t *testing.T) {
+	r := require.New(t)
+	tmp := t.TempDir()
+
+	constructorYAML := `
+components:
+- name: ocm.software/referenced
+  version: 1.0.0
+  provider:
+    name: ocm.software
+  resources:
+  - name: my-resource
+    type: blob
+    input:
+      type: utf8/v1
+      text: "I am a referenced component!"
+- name: ocm.software/referencing
+  version: 1.0.0
+  provider:
+    name: ocm.software
+  componentReferences:
+  - name: referenced
+    version: 1.0.0
+    componentName: ocm.software/referenced
+  resources:
+  - name: my-resource
+    type: blob
+    input:
+      type: utf8/v1
+      text: "I reference another component"
+`
+	constructorYAMLFilePath := filepath.Join(tmp, "component-constructor.yaml")
+	r.NoError(os.WriteFile(constructorYAMLFilePath, []byte(constructorYAML), 0o600))
+	archiveFilePath := filepath.Join(tmp, "transport-archive")
+
+	_, err := test.OCM(t, test.WithArgs("add", "cv",
+		"--constructor", constructorYAMLFilePath,
+		"--repository", archiveFilePath,
+		"--provenance",
+	), test.WithErrorOutput(test.NewJSONLogReader()))
+	r.NoError(err, "could not construct component versions with provenance")
+
+	reference := archiveFilePath + "//ocm.software/referencing:1.0.0"
+
+	t.Run("get provenance", func(t *testing.T) {
+		r := require.New(t)
+		out := new(bytes.Buffer)
+		_, err := test.OCM(t, test.WithArgs("get", "provenance", reference), test.WithOutput(out))
+		r.NoError(err)
+
+		statement, err := provenance.Parse(out.Bytes())
+		r.NoError(err)
+		buildDefinition := statement.Predicate.BuildDefinition
+		r.Equal("ocm.software/referencing", buildDefinition.ExternalParameters.Component)
+		r.Equal(componentversion.ProvenanceBuilderID, statement.Predicate.RunDetails.Builder.ID)
+		r.Len(statement.Subject, 1)
+
+		sum := sha256.Sum256([]byte(constructorYAML))
+		r.NotNil(buildDefinition.ExternalParameters.Constructor)
+		r.Equal(hex.EncodeToString(sum[:]), buildDefinition.ExternalParameters.Constructor.Digest["sha256"])
+
+		r.Len(buildDefinition.ResolvedDependencies, 2)
+		r.Equal("utf8/v1", buildDefinition.ResolvedDependencies[0].Annotations[provenance.AnnotationInput])
+		r.Equal("referenced", buildDefinition.ResolvedDependencies[1].Annotations[provenance.AnnotationReference])
+	})
+
+	t.Run("verify provenance", func(t *testing.T) {
+		r := require.New(t)
+		_, err := test.OCM(t, test.WithArgs("verify", "provenance", reference,
+			"--constructor", constructorYAMLFilePath,
+		), test.WithErrorOutput(test.NewJSONLogReader()))
+		r.NoError(err)
+	})
+
+	t.Run("verify provenance against another constructor fails", func(t *testing.T) {
+		r := require.New(t)
+		otherPath := filepath.Join(tmp, "other-constructor.yaml")
+		r.NoError(os.WriteFile(otherPath, []byte(constructorYAML+"\n# changed\n"), 0o600))
+		_, err := test.OCM(t, test.WithArgs("verify", "provenance", reference,
+			"--constructor", otherPath,
+		), test.WithErrorOutput(test.NewJSONLogReader()))
+		r.ErrorContains(err, "is not the constructor recorded in the provenance")
+	})
+
+	t.Run("component version without provenance", func(t *testing.T) {
+		r := require.New(t)
+		plainConstructorPath := filepath.Join(tmp, "plain-constructor.yaml")
+		r.NoError(os.WriteFile(plainConstructorPath, []byte(`
+name: ocm.software/plain
+version: 1.0.0
+provider:
+  name: ocm.software
+`), 0o600))
+		_, err := test.OCM(t, test.WithArgs("add", "cv",
+			"--constructor", plainConstructorPath,
+			"--repository", archiveFilePath,
+		), test.WithErrorOutput(test.NewJSONLogReader()))
+		r.NoError(err)
+
+		_, err = test.OCM(t, test.WithArgs("get", "provenance", archiveFilePath+"//ocm.software/plain:1.0.0"))
+		r.ErrorIs(err, provenance.ErrNotFound)
+	})
+}
+
+func Test_Sign_Component_Version_Rotate(t *testing.T) {
+	r := require.New(t)
+	tmp := t.TempDir()
+
+	constructorYAML := `
+components:
+- name: ocm.software/rotate-a
+  version: 1.0.0
+  provider:
+    name: ocm.software
+- name: ocm.software/rotate-a
+  version: 2.0.0
+  provider:
+    name: ocm.software
+- name: ocm.software/rotate-b
+  version: 1.0.0
+  provider:
+    name: ocm.software
+- name: ocm.software/unsigned
+  version: 1.0.0
+  provider:
+    name: ocm.software
+- name: ocm.software/tampered
+  version: 1.0.0
+  provider:
+    name: ocm.software
+`
+	constructorYAMLFilePath := filepath.Join(tmp, "component-constructor.yaml")
+	r.NoError(os.WriteFile(constructorYAMLFilePath, []byte(constructorYAML), 0o600))
+	archiveFilePath := filepath.Join(tmp, "transport-archive")
+	_, err := test.OCM(t, test.WithArgs("add", "cv",
+		"--constructor", constructorYAMLFilePath,
+		"--repository", archiveFilePath,
+	))
+	r.NoError(err, "could not construct component versions")
+
+	writeConfig := func(name string, keys map[string]*rsa.PrivateKey) string {
+		var consumers strings.Builder
+		for signature, key := range keys {
+			privateKeyPath, publicKeyChainPath := writeKeyAndChain(t, t.TempDir(), key, mustSelfSigned(t, "CN="+signature, key))
+			fmt.Fprintf(&consumers, `
+  - identity:
+      type: RSA/v1alpha1
+      algorithm: RSASSA-PSS
+      signature: %[1]s
+    credentials:
+    - type: Credentials/v1
+      properties:
+        public_key_pem_file: %[2]s
+        private_key_pem_file: %[3]s`, signature, publicKeyChainPath, privateKeyPath)
+		}
+		path := filepath.Join(tmp, name)
+		r.NoError(os.WriteFile(path, []byte(`
+type: generic.config.ocm.software/v1
+configurations:
+- type: credentials.config.ocm.software
+  consumers:`+consumers.String()+"\n"), 0o600))
+		return path
+	}
+	oldKey, newKey := mustKey(t), mustKey(t)
+	ocmConfigFilePath := writeConfig("ocm-config.yaml", map[string]*rsa.PrivateKey{"old": oldKey, "new": newKey})
+	rogueConfigFilePath := writeConfig("rogue-config.yaml", map[string]*rsa.PrivateKey{"old": mustKey(t)})
+
+	sign := func(component, config string) {
+		_, err := test.OCM(t, test.WithArgs("sign", "component-version",
+			archiveFilePath+"//"+component,
+			"--signature", "old",
+			"--config", config,
+		), test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+		r.NoError(err, "could not sign %s", component)
+	}
+	sign("ocm.software/rotate-a:1.0.0", ocmConfigFilePath)
+	sign("ocm.software/rotate-a:2.0.0", ocmConfigFilePath)
+	sign("ocm.software/rotate-b:1.0.0", ocmConfigFilePath)
+	sign("ocm.software/tampered:1.0.0", rogueConfigFilePath)
+
+	rotate := func() (signcv.RotationReport, error) {
+		out := new(bytes.Buffer)
+		_, err := test.OCM(t, test.WithArgs("sign", "component-version",
+			archiveFilePath,
+			"--rotate", "old",
+			"--signature", "new",
+			"--revoke",
+			"--output", "json",
+			"--config", ocmConfigFilePath,
+		), test.WithOutput(out), test.WithErrorOutput(test.NewJSONLogReader()))
+		var report signcv.RotationReport
+		r.NoError(json.Unmarshal(out.Bytes(), &report), "could not decode rotation report %q", out.String())
+		return report, err
+	}
+
+	report, err := rotate()
+	r.ErrorIs(err, signcv.ErrRotationFailed)
+	r.Equal(signcv.RotationSummary{Total: 5, Rotated: 3, Skipped: 1, Failed: 1}, report.Summary)
+	r.Contains(report.Results, signcv.RotationResult{Component: "ocm.software/unsigned", Version: "1.0.0", Status: signcv.RotationStatusSkipped})
+	for _, result := range report.Results {
+		if result.Component == "ocm.software/tampered" {
+			r.Equal(signcv.RotationStatusFailed, result.Status)
+			r.Contains(result.Reason, `verifying signature "old" failed`)
+		}
+	}
+
+	fs, err := filesystem.NewFS(archiveFilePath, os.O_RDONLY)
+	r.NoError(err)
+	helperRepo, err := oci.NewRepository(ocictf.WithCTF(ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))))
+	r.NoError(err)
+	for _, cv := range [][2]string{{"ocm.software/rotate-a", "1.0.0"}, {"ocm.software/rotate-a", "2.0.0"}, {"ocm.software/rotate-b", "1.0.0"}} {
+		desc, err := helperRepo.GetComponentVersion(t.Context(), cv[0], cv[1])
+		r.NoError(err)
+		r.Len(desc.Signatures, 1)
+		r.Equal("new", desc.Signatures[0].Name)
+
+		_, err = test.OCM(t, test.WithArgs("verify", "component-version",
+			archiveFilePath+"//"+cv[0]+":"+cv[1],
+			"--signature", "new",
+			"--config", ocmConfigFilePath,
+		), test.WithErrorOutput(test.NewJSONLogReader()))
+		r.NoError(err, "could not verify rotated signature of %s:%s", cv[0], cv[1])
+	}
+	tampered, err := helperRepo.GetComponentVersion(t.Context(), "ocm.software/tampered", "1.0.0")
+	r.NoError(err)
+	r.Len(tampered.Signatures, 1)
+	r.Equal("old", tampered.Signatures[0].Name, "a component version failing verification must not be re-signed")
+
+	t.Run("rotation resumes", func(t *testing.T) {
+		report, err := rotate()
+		require.ErrorIs(t, err, signcv.ErrRotationFailed)
+		require.Equal(t, signcv.RotationSummary{Total: 5, Skipped: 4, Failed: 1}, report.Summary)
+	})
+}
+
+func Test_Get_And_Delete_Signatures(t *testing.T) {
+	r := require.New(t)
+	tmp := t.TempDir()
+
+	name, version := "ocm.software/signatures", "1.0.0"
+	constructorYAML := fmt.Sprintf(`
+name: %[1]s
+version: %[2]s
+provider:
+  name: ocm.software
+`, name, version)
+	constructorYAMLFilePath := filepath.Join(tmp, "component-constructor.yaml")
+	r.NoError(os.WriteFile(constructorYAMLFilePath, []byte(constructorYAML), 0o600))
+	archiveFilePath := filepath.Join(tmp, "transport-archive")
+	_, err := test.OCM(t, test.WithArgs("add", "cv",
+		"--constructor", constructorYAMLFilePath,
+		"--repository", archiveFilePath,
+	))
+	r.NoError(err, "could not construct component version")
+
+	key := mustKey(t)
+	cert := mustSelfSigned(t, "signer", key)
+	privateKeyPath, publicKeyChainPath := writeKeyAndChain(t, t.TempDir(), key, cert)
+	var consumers strings.Builder
+	for _, signature := range []string{"plain", "pem"} {
+		fmt.Fprintf(&consumers, `
+  - identity:
+      type: RSA/v1alpha1
+      algorithm: RSASSA-PSS
+      signature: %[1]s
+    credentials:
+    - type: Credentials/v1
+      properties:
+        public_key_pem_file: %[2]s
+        private_key_pem_file: %[3]s`, signature, publicKeyChainPath, privateKeyPath)
+	}
+	ocmConfigFilePath := filepath.Join(tmp, "ocm-config.yaml")
+	r.NoError(os.WriteFile(ocmConfigFilePath, []byte(`
+type: generic.config.ocm.software/v1
+configurations:
+- type: credentials.config.ocm.software
+  consumers:`+consumers.String()+"\n"), 0o600))
+	pemSpecFilePath := filepath.Join(tmp, "signer-spec.yaml")
+	r.NoError(os.WriteFile(pemSpecFilePath, []byte(`
+type: RSASigningConfiguration/v1alpha1
+signatureAlgorithm: RSASSA-PSS
+signatureEncodingPolicy: PEM
+`), 0o600))
+
+	reference := archiveFilePath + "//" + name + ":" + version
+	_, err = test.OCM(t, test.WithArgs("sign", "component-version", reference,
+		"--signature", "plain",
+		"--config", ocmConfigFilePath,
+	), test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+	r.NoError(err, "could not sign with plain encoding")
+	_, err = test.OCM(t, test.WithArgs("sign", "component-version", reference,
+		"--signature", "pem",
+		"--signer-spec", pemSpecFilePath,
+		"--config", ocmConfigFilePath,
+	), test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+	r.NoError(err, "could not sign with pem encoding")
+
+	getSignatures := func() []signatures.Signature {
+		out := new(bytes.Buffer)
+		_, err := test.OCM(t, test.WithArgs("get", "signatures", reference, "--output", "json"), test.WithOutput(out))
+		r.NoError(err, "could not get signatures")
+		var listed []signatures.Signature
+		r.NoError(json.Unmarshal(out.Bytes(), &listed), "could not decode signatures %q", out.String())
+		return listed
+	}
+
+	listed := getSignatures()
+	r.Len(listed, 2)
+	r.Equal("plain", listed[0].Name)
+	r.Equal("RSASSA-PSS", listed[0].Algorithm)
+	r.Equal("application/vnd.ocm.signature.rsa.pss", listed[0].MediaType)
+	r.Nil(listed[0].Certificate)
+	r.Equal("pem", listed[1].Name)
+	r.Equal("application/x-pem-file", listed[1].MediaType)
+	r.Equal(listed[0].Digest, listed[1].Digest)
+	r.NotNil(listed[1].Certificate)
+	r.Equal("CN=signer", listed[1].Certificate.Subject)
+	r.True(cert.NotAfter.Equal(listed[1].Certificate.NotAfter))
+
+	table := new(bytes.Buffer)
+	_, err = test.OCM(t, test.WithArgs("get", "signatures", reference), test.WithOutput(table))
+	r.NoError(err, "could not get signatures as table")
+	r.Contains(table.String(), "CN=signer")
+
+	_, err = test.OCM(t, test.WithArgs("delete", "signature", reference, "--signature", "missing"),
+		test.WithErrorOutput(test.NewJSONLogReader()))
+	r.ErrorContains(err, `signature "missing" not found`)
+
+	_, err = test.OCM(t, test.WithArgs("delete", "signature", reference, "--signature", "plain", "--dry-run"),
+		test.WithErrorOutput(test.NewJSONLogReader()))
+	r.NoError(err, "could not delete signature in dry run")
+	r.Len(getSignatures(), 2)
+
+	_, err = test.OCM(t, test.WithArgs("delete", "signature", reference, "--signature", "plain"),
+		test.WithErrorOutput(test.NewJSONLogReader()))
+	r.NoError(err, "could not delete signature")
+	listed = getSignatures()
+	r.Len(listed, 1)
+	r.Equal("pem", listed[0].Name)
+
+	_, err = test.OCM(t, test.WithArgs("verify", "component-version", reference,
+		"--signature", "pem",
+		"--config", ocmConfigFilePath,
+	), test.WithErrorOutput(test.NewJSONLogReader()))
+	r.NoError(err, "remaining signature must still verify")
+
+	t.Run("sigstore bundles list their certificate", func(t *testing.T) {
+		r := require.New(t)
+		fulcioCert := mustSelfSigned(t, "fulcio", key)
+		bundleJSON, err := json.Marshal(map[string]any{
+			"mediaType": sigstorev1alpha1.MediaTypeSigstoreBundle,
+			"verificationMaterial": map[string]any{
+				"certificate": map[string]any{"rawBytes": fulcioCert.Raw},
+			},
+			"messageSignature": map[string]any{"signature": []byte("signature")},
+		})
+		r.NoError(err)
+
+		fs, err := filesystem.NewFS(archiveFilePath, os.O_RDWR)
+		r.NoError(err)
+		helperRepo, err := oci.NewRepository(ocictf.WithCTF(ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))))
+		r.NoError(err)
+		desc, err := helperRepo.GetComponentVersion(t.Context(), name, version)
+		r.NoError(err)
+		desc.Signatures = append(desc.Signatures, descriptor.Signature{
+			Name:   "sigstore",
+			Digest: desc.Signatures[0].Digest,
+			Signature: descriptor.SignatureInfo{
+				Algorithm: sigstorev1alpha1.AlgorithmSigstore,
+				MediaType: sigstorev1alpha1.MediaTypeSigstoreBundle,
+				Value:     base64.StdEncoding.EncodeToString(bundleJSON),
+			},
+		})
+		r.NoError(helperRepo.AddComponentVersion(t.Context(), desc))
+
+		listed := getSignatures()
+		r.Len(listed, 2)
+		r.Equal("sigstore", listed[1].Name)
+		r.NotNil(listed[1].Certificate)
+		r.Equal("CN=fulcio", listed[1].Certificate.Subject)
+	})
+}
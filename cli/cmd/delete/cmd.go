@@ -0,0 +1,20 @@
+package delete
+
+import (
+	"github.com/spf13/cobra"
+
+	"ocm.software/open-component-model/cli/cmd/delete/signature"
+)
+
+// New represents any command that is related to deleting objects
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete {signature|signatures|sig}",
+		Short: "Delete anything from OCM",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(signature.New())
+	return cmd
+}
@@ -0,0 +1,141 @@
+package signature
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/compref"
+	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
+	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
+	getprovenance "ocm.software/open-component-model/cli/cmd/get/provenance"
+	ocmctx "ocm.software/open-component-model/cli/internal/context"
+	"ocm.software/open-component-model/cli/internal/flags/log"
+	"ocm.software/open-component-model/cli/internal/repository/ocm"
+)
+
+const (
+	FlagSignature = "signature"
+	FlagDryRun    = "dry-run"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "signature {reference}",
+		Aliases: []string{"signatures", "sig", "sigs"},
+		Short:   "Delete a signature of a component version",
+		Args:    cobra.MatchAll(cobra.ExactArgs(1), getprovenance.ComponentReferenceAsFirstPositional),
+		Long: fmt.Sprintf(`Delete a signature of a component version inside an OCM repository.
+
+The signature with the name given by --%[4]s is removed from the component descriptor,
+and the component descriptor is written back to the repository. All other signatures are kept.
+Deleting a signature that does not exist is an error.
+
+## Reference Format
+
+	[type::]{repository}/[valid-prefix]/{component}[:version]
+
+- Prefixes: {%[1]s|none} (default: %[1]q)
+- Repo types: {%[2]s} (short: {%[3]s})
+
+Use "get signatures" to list the signatures of a component version.`,
+			compref.DefaultPrefix,
+			strings.Join([]string{ociv1.Type, ctfv1.Type}, "|"),
+			strings.Join([]string{ociv1.ShortType, ociv1.ShortType2, ctfv1.ShortType, ctfv1.ShortType2}, "|"),
+			FlagSignature,
+		),
+		Example: strings.TrimSpace(`
+# Delete the signature "old" of a component version
+delete signature ./transport-archive//ocm.software/my-component:1.0.0 --signature old
+
+# Check that the signature exists without deleting it
+delete signature ghcr.io/my-org/ocm//ocm.software/my-component:1.0.0 --signature old --dry-run
+`),
+		RunE:              DeleteSignature,
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(FlagSignature, "", "name of the signature to delete")
+	cmd.Flags().Bool(FlagDryRun, false, "do not write the component descriptor back to the repository")
+	_ = cmd.MarkFlagRequired(FlagSignature)
+
+	return cmd
+}
+
+func DeleteSignature(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	logger, err := log.GetBaseLogger(cmd)
+	if err != nil {
+		return fmt.Errorf("getting base logger failed: %w", err)
+	}
+
+	ocmContext := ocmctx.FromContext(ctx)
+	if ocmContext == nil {
+		return fmt.Errorf("no OCM context found")
+	}
+
+	pluginManager := ocmContext.PluginManager()
+	if pluginManager == nil {
+		return fmt.Errorf("could not retrieve plugin manager from context")
+	}
+
+	credentialGraph := ocmContext.CredentialGraph()
+	if credentialGraph == nil {
+		return fmt.Errorf("could not retrieve credential graph from context")
+	}
+
+	signatureName, err := cmd.Flags().GetString(FlagSignature)
+	if err != nil {
+		return fmt.Errorf("getting signature flag failed: %w", err)
+	}
+	if signatureName == "" {
+		return fmt.Errorf("--%s must not be empty", FlagSignature)
+	}
+	dryRun, err := cmd.Flags().GetBool(FlagDryRun)
+	if err != nil {
+		return fmt.Errorf("getting dry-run flag failed: %w", err)
+	}
+
+	reference := args[0]
+	ref, err := compref.Parse(reference, compref.WithCTFAccessMode(ctfv1.AccessModeReadWrite))
+	if err != nil {
+		return fmt.Errorf("parsing component reference %q failed: %w", reference, err)
+	}
+	repoProvider, err := ocm.NewComponentVersionRepositoryForComponentProvider(ctx, pluginManager.ComponentVersionRepositoryRegistry, credentialGraph, ocmContext.Configuration(), ref)
+	if err != nil {
+		return fmt.Errorf("could not initialize ocm repository: %w", err)
+	}
+
+	repo, err := repoProvider.GetComponentVersionRepositoryForComponent(ctx, ref.Component, ref.Version)
+	if err != nil {
+		return fmt.Errorf("could not access ocm repository: %w", err)
+	}
+
+	desc, err := repo.GetComponentVersion(ctx, ref.Component, ref.Version)
+	if err != nil {
+		return fmt.Errorf("getting component version failed: %w", err)
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(desc.Signatures), func(signature descruntime.Signature) bool {
+		return signature.Name == signatureName
+	})
+	if len(remaining) == len(desc.Signatures) {
+		return fmt.Errorf("signature %q not found in component version %s", signatureName, desc.Component.ToIdentity())
+	}
+	desc.Signatures = remaining
+
+	if dryRun {
+		logger.InfoContext(ctx, "dry run: signature not deleted", "signature", signatureName, "component", desc.Component.Name, "version", desc.Component.Version)
+		return nil
+	}
+
+	if err := repo.AddComponentVersion(ctx, desc); err != nil {
+		return fmt.Errorf("writing component version without signature %q failed: %w", signatureName, err)
+	}
+
+	logger.InfoContext(ctx, "SIGNATURE DELETED", "signature", signatureName, "component", desc.Component.Name, "version", desc.Component.Version, "remaining", len(remaining))
+	return nil
+}
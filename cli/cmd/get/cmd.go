@@ -6,12 +6,14 @@ import (
 	"ocm.software/open-component-model/cli/cmd/describe/types"
 	componentversion "ocm.software/open-component-model/cli/cmd/get/component-version"
 	config "ocm.software/open-component-model/cli/cmd/get/config"
+	"ocm.software/open-component-model/cli/cmd/get/provenance"
+	"ocm.software/open-component-model/cli/cmd/get/signatures"
 )
 
 // New represents any command that is related to retrieving ( "get"ting ) objects
 func New() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "get {component-version|component-versions|cv|cvs|config|cfg}",
+		Use:   "get {component-version|component-versions|cv|cvs|config|cfg|provenance|signatures}",
 		Short: "Get anything from OCM",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
@@ -20,5 +22,7 @@ func New() *cobra.Command {
 	cmd.AddCommand(types.New())
 	cmd.AddCommand(componentversion.New())
 	cmd.AddCommand(config.New())
+	cmd.AddCommand(provenance.New())
+	cmd.AddCommand(signatures.New())
 	return cmd
 }
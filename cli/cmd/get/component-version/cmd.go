@@ -25,6 +25,7 @@ import (
 	"ocm.software/open-component-model/bindings/go/runtime"
 	ocmctx "ocm.software/open-component-model/cli/internal/context"
 	"ocm.software/open-component-model/cli/internal/flags/enum"
+	"ocm.software/open-component-model/cli/internal/flags/recursion"
 	"ocm.software/open-component-model/cli/internal/render"
 	"ocm.software/open-component-model/cli/internal/render/graph/list"
 	"ocm.software/open-component-model/cli/internal/render/graph/tree"
@@ -93,8 +94,7 @@ get cvs oci::http://localhost:8080//ocm.software/ocmcli
 	// TODO(fabianburth): add concurrency limit to the dag discovery (https://github.com/open-component-model/ocm-project/issues/705)
 	// cmd.Flags().Int(FlagConcurrencyLimit, 4, "maximum amount of parallel requests to the repository for resolving component versions")
 	cmd.Flags().Bool(FlagLatest, false, "if set, only the latest version of the component is returned")
-	cmd.Flags().Int(FlagRecursive, 0, "depth of recursion for resolving referenced component versions (0=none, -1=unlimited, >0=levels (not implemented yet))")
-	cmd.Flags().Lookup(FlagRecursive).NoOptDefVal = "-1"
+	recursion.Var(cmd.Flags(), FlagRecursive, recursion.None, "depth of recursion for resolving referenced component versions")
 
 	return cmd
 }
@@ -145,7 +145,7 @@ func GetComponentVersion(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("getting latest flag failed: %w", err)
 	}
-	recursive, err := cmd.Flags().GetInt(FlagRecursive)
+	recursive, err := recursion.Get(cmd.Flags(), FlagRecursive)
 	if err != nil {
 		return fmt.Errorf("getting recursive flag failed: %w", err)
 	}
@@ -236,10 +236,15 @@ func renderComponents(cmd *cobra.Command, repoResolver resolvers.ComponentVersio
 		repositoryResolver: repoResolver,
 		recursive:          recursive,
 	}
+	var maxDepth int
+	if recursive > 0 {
+		maxDepth = recursive
+	}
 	discoverer := syncdag.NewGraphDiscoverer(&syncdag.GraphDiscovererOptions[string, *descruntime.Descriptor]{
 		Roots:      roots,
 		Resolver:   &resAndDis,
 		Discoverer: &resAndDis,
+		MaxDepth:   maxDepth,
 	})
 	renderer, err := buildRenderer(cmd.Context(), discoverer.Graph(), roots, format)
 	if err != nil {
@@ -370,28 +375,23 @@ func (r *resolverAndDiscoverer) Resolve(ctx context.Context, key string) (*descr
 	return desc, nil
 }
 
+// Discover returns the references of parent. A positive recursion depth is
+// enforced by the graph discoverer, which does not expand vertices at that depth.
 func (r *resolverAndDiscoverer) Discover(ctx context.Context, parent *descruntime.Descriptor) ([]string, error) {
-	// TODO(fabianburth): Recursion depth has to be implemented as option for
-	//  the dag discovery. Once that's implemented, we can pass the recursion
-	//  depth to the discovery and remove this check here (https://github.com/open-component-model/ocm-project/issues/706).
 	switch {
 	case r.recursive < -1:
 		return nil, fmt.Errorf("invalid recursion depth %d: must be -1 (unlimited) or >= 0", r.recursive)
 	case r.recursive == 0:
 		slog.DebugContext(ctx, "not discovering children, recursion depth 0", "component", parent.Component.ToIdentity().String())
 		return nil, nil
-	case r.recursive == -1:
-		// unlimited recursion
+	default:
 		children := make([]string, len(parent.Component.References))
 		for index, reference := range parent.Component.References {
 			children[index] = reference.ToComponentIdentity().String()
 		}
 		slog.DebugContext(ctx, "discovering children", "component", parent.Component.ToIdentity().String(), "children", children)
 		return children, nil
-	case r.recursive > 0:
-		return nil, fmt.Errorf("recursion depth > 0 not implemented yet")
 	}
-	return nil, fmt.Errorf("invalid recursion depth %d", r.recursive)
 }
 
 // Params holds the values of the flags for the `get cv` command.
@@ -0,0 +1,153 @@
+package provenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"ocm.software/open-component-model/bindings/go/constructor/provenance"
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/compref"
+	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
+	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
+	ocmctx "ocm.software/open-component-model/cli/internal/context"
+	"ocm.software/open-component-model/cli/internal/flags/enum"
+	"ocm.software/open-component-model/cli/internal/render"
+	"ocm.software/open-component-model/cli/internal/repository/ocm"
+)
+
+const (
+	FlagOutput = "output"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:        "provenance {reference}",
+		Aliases:    []string{"provenances", "slsa-provenance"},
+		SuggestFor: []string{"attestation", "attestations"},
+		Short:      "Get the SLSA provenance of a component version",
+		Args:       cobra.MatchAll(cobra.ExactArgs(1), ComponentReferenceAsFirstPositional),
+		Long: fmt.Sprintf(`Get the SLSA provenance of a component version inside an OCM repository.
+
+The provenance is an in-toto statement with a SLSA provenance v1 predicate, generated
+by "add component-version --provenance" and stored as local resource %[4]q of the component version.
+
+## Reference Format
+
+	[type::]{repository}/[valid-prefix]/{component}[:version]
+
+- Prefixes: {%[1]s|none} (default: %[1]q)
+- Repo types: {%[2]s} (short: {%[3]s})
+
+Use "verify provenance" to check that the provenance describes the component version.`,
+			compref.DefaultPrefix,
+			strings.Join([]string{ociv1.Type, ctfv1.Type}, "|"),
+			strings.Join([]string{ociv1.ShortType, ociv1.ShortType2, ctfv1.ShortType, ctfv1.ShortType2}, "|"),
+			provenance.ResourceName,
+		),
+		Example: strings.TrimSpace(`
+# Get the provenance of a component version as JSON
+get provenance ./transport-archive//ocm.software/my-component:1.0.0
+
+# Get the provenance of a component version as YAML
+get provenance ghcr.io/my-org/ocm//ocm.software/my-component:1.0.0 --output yaml
+`),
+		RunE:              GetProvenance,
+		DisableAutoGenTag: true,
+	}
+
+	enum.VarP(cmd.Flags(), FlagOutput, "o", []string{render.OutputFormatJSON.String(), render.OutputFormatYAML.String(), render.OutputFormatNDJSON.String()}, "output format of the provenance")
+
+	return cmd
+}
+
+func ComponentReferenceAsFirstPositional(_ *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing component reference as first positional argument")
+	}
+	if _, err := compref.Parse(args[0]); err != nil {
+		return fmt.Errorf("parsing component reference from first position argument %q failed: %w", args[0], err)
+	}
+	return nil
+}
+
+func GetProvenance(cmd *cobra.Command, args []string) error {
+	output, err := enum.Get(cmd.Flags(), FlagOutput)
+	if err != nil {
+		return fmt.Errorf("getting output flag failed: %w", err)
+	}
+
+	_, statement, err := FetchProvenance(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case render.OutputFormatJSON.String():
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(statement)
+	case render.OutputFormatNDJSON.String():
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetEscapeHTML(false)
+		return enc.Encode(statement)
+	case render.OutputFormatYAML.String():
+		data, err := yaml.Marshal(statement)
+		if err != nil {
+			return fmt.Errorf("failed to marshal provenance: %w", err)
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	default:
+		return fmt.Errorf("unsupported output format: %s", output)
+	}
+}
+
+// FetchProvenance resolves the component version referenced by reference and
+// returns its descriptor together with its provenance.
+func FetchProvenance(cmd *cobra.Command, reference string) (*descruntime.Descriptor, *provenance.Statement, error) {
+	ctx := cmd.Context()
+	ocmContext := ocmctx.FromContext(ctx)
+	if ocmContext == nil {
+		return nil, nil, fmt.Errorf("no OCM context found")
+	}
+
+	pluginManager := ocmContext.PluginManager()
+	if pluginManager == nil {
+		return nil, nil, fmt.Errorf("could not retrieve plugin manager from context")
+	}
+
+	credentialGraph := ocmContext.CredentialGraph()
+	if credentialGraph == nil {
+		return nil, nil, fmt.Errorf("could not retrieve credential graph from context")
+	}
+
+	ref, err := compref.Parse(reference)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing component reference %q failed: %w", reference, err)
+	}
+	repoProvider, err := ocm.NewComponentVersionRepositoryForComponentProvider(ctx, pluginManager.ComponentVersionRepositoryRegistry, credentialGraph, ocmContext.Configuration(), ref)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not initialize ocm repository: %w", err)
+	}
+
+	repo, err := repoProvider.GetComponentVersionRepositoryForComponent(ctx, ref.Component, ref.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not access ocm repository: %w", err)
+	}
+
+	desc, err := repo.GetComponentVersion(ctx, ref.Component, ref.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting component version failed: %w", err)
+	}
+
+	statement, err := provenance.Fetch(ctx, repo, desc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting provenance failed: %w", err)
+	}
+	return desc, statement, nil
+}
@@ -0,0 +1,251 @@
+package signatures
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/oci/compref"
+	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
+	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
+	rsav1alpha1 "ocm.software/open-component-model/bindings/go/rsa/signing/v1alpha1"
+	sigstore "ocm.software/open-component-model/bindings/go/sigstore/signing/handler"
+	sigstorev1alpha1 "ocm.software/open-component-model/bindings/go/sigstore/signing/v1alpha1"
+	getprovenance "ocm.software/open-component-model/cli/cmd/get/provenance"
+	ocmctx "ocm.software/open-component-model/cli/internal/context"
+	"ocm.software/open-component-model/cli/internal/flags/enum"
+	"ocm.software/open-component-model/cli/internal/render"
+	"ocm.software/open-component-model/cli/internal/repository/ocm"
+)
+
+const (
+	FlagOutput = "output"
+)
+
+// Signature is the listed representation of a signature of a component version.
+type Signature struct {
+	Name        string       `json:"name"`
+	Algorithm   string       `json:"algorithm"`
+	MediaType   string       `json:"mediaType"`
+	Issuer      string       `json:"issuer,omitempty"`
+	Digest      string       `json:"digest"`
+	Certificate *Certificate `json:"certificate,omitempty"`
+	Timestamp   *time.Time   `json:"timestamp,omitempty"`
+}
+
+// Certificate describes the leaf certificate embedded in a certificate-based signature.
+type Certificate struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:        "signatures {reference}",
+		Aliases:    []string{"signature", "sigs", "sig"},
+		SuggestFor: []string{"signing", "signed"},
+		Short:      "Get the signatures of a component version",
+		Args:       cobra.MatchAll(cobra.ExactArgs(1), getprovenance.ComponentReferenceAsFirstPositional),
+		Long: fmt.Sprintf(`Get the signatures of a component version inside an OCM repository.
+
+For every signature, its name, algorithm, media type, issuer and the signed digest are listed.
+For certificate-based signatures (media types %[4]q and %[5]q), the subject, issuer and validity
+of the signing certificate are listed as well. A trusted timestamp is listed if the signature has one.
+
+The signatures are not verified. Use "verify component-version" to verify them.
+
+## Reference Format
+
+	[type::]{repository}/[valid-prefix]/{component}[:version]
+
+- Prefixes: {%[1]s|none} (default: %[1]q)
+- Repo types: {%[2]s} (short: {%[3]s})`,
+			compref.DefaultPrefix,
+			strings.Join([]string{ociv1.Type, ctfv1.Type}, "|"),
+			strings.Join([]string{ociv1.ShortType, ociv1.ShortType2, ctfv1.ShortType, ctfv1.ShortType2}, "|"),
+			rsav1alpha1.MediaTypePEM,
+			sigstorev1alpha1.MediaTypeSigstoreBundle,
+		),
+		Example: strings.TrimSpace(`
+# List the signatures of a component version
+get signatures ./transport-archive//ocm.software/my-component:1.0.0
+
+# List the signatures of a component version as YAML
+get signatures ghcr.io/my-org/ocm//ocm.software/my-component:1.0.0 --output yaml
+`),
+		RunE:              GetSignatures,
+		DisableAutoGenTag: true,
+	}
+
+	enum.VarP(cmd.Flags(), FlagOutput, "o", []string{render.OutputFormatTable.String(), render.OutputFormatYAML.String(), render.OutputFormatJSON.String(), render.OutputFormatNDJSON.String()}, "output format of the signatures")
+
+	return cmd
+}
+
+func GetSignatures(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	ocmContext := ocmctx.FromContext(ctx)
+	if ocmContext == nil {
+		return fmt.Errorf("no OCM context found")
+	}
+
+	pluginManager := ocmContext.PluginManager()
+	if pluginManager == nil {
+		return fmt.Errorf("could not retrieve plugin manager from context")
+	}
+
+	credentialGraph := ocmContext.CredentialGraph()
+	if credentialGraph == nil {
+		return fmt.Errorf("could not retrieve credential graph from context")
+	}
+
+	output, err := enum.Get(cmd.Flags(), FlagOutput)
+	if err != nil {
+		return fmt.Errorf("getting output flag failed: %w", err)
+	}
+
+	reference := args[0]
+	ref, err := compref.Parse(reference)
+	if err != nil {
+		return fmt.Errorf("parsing component reference %q failed: %w", reference, err)
+	}
+	repoProvider, err := ocm.NewComponentVersionRepositoryForComponentProvider(ctx, pluginManager.ComponentVersionRepositoryRegistry, credentialGraph, ocmContext.Configuration(), ref)
+	if err != nil {
+		return fmt.Errorf("could not initialize ocm repository: %w", err)
+	}
+
+	repo, err := repoProvider.GetComponentVersionRepositoryForComponent(ctx, ref.Component, ref.Version)
+	if err != nil {
+		return fmt.Errorf("could not access ocm repository: %w", err)
+	}
+
+	desc, err := repo.GetComponentVersion(ctx, ref.Component, ref.Version)
+	if err != nil {
+		return fmt.Errorf("getting component version failed: %w", err)
+	}
+
+	signatures := make([]Signature, 0, len(desc.Signatures))
+	for _, signature := range desc.Signatures {
+		listed, err := newSignature(signature)
+		if err != nil {
+			return fmt.Errorf("reading signature %q failed: %w", signature.Name, err)
+		}
+		signatures = append(signatures, listed)
+	}
+
+	switch output {
+	case render.OutputFormatTable.String():
+		renderTable(cmd, signatures)
+		return nil
+	case render.OutputFormatJSON.String():
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(signatures)
+	case render.OutputFormatNDJSON.String():
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetEscapeHTML(false)
+		for _, signature := range signatures {
+			if err := enc.Encode(signature); err != nil {
+				return err
+			}
+		}
+		return nil
+	case render.OutputFormatYAML.String():
+		data, err := yaml.Marshal(signatures)
+		if err != nil {
+			return fmt.Errorf("failed to marshal signatures: %w", err)
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	default:
+		return fmt.Errorf("unsupported output format: %s", output)
+	}
+}
+
+func newSignature(signature descruntime.Signature) (Signature, error) {
+	listed := Signature{
+		Name:      signature.Name,
+		Algorithm: signature.Signature.Algorithm,
+		MediaType: signature.Signature.MediaType,
+		Issuer:    signature.Signature.Issuer,
+		Digest:    fmt.Sprintf("%s:%s", signature.Digest.HashAlgorithm, signature.Digest.Value),
+	}
+	if signature.Timestamp != nil {
+		listed.Timestamp = &signature.Timestamp.Time
+	}
+	var (
+		leaf *x509.Certificate
+		err  error
+	)
+	switch signature.Signature.MediaType {
+	// PEM encoded ECC signatures share the media type of PEM encoded RSA signatures
+	case rsav1alpha1.MediaTypePEM:
+		leaf, err = leafCertificate([]byte(signature.Signature.Value))
+	case sigstorev1alpha1.MediaTypeSigstoreBundle:
+		if leaf, err = sigstore.SigningCertificate(signature.Signature); err != nil {
+			err = fmt.Errorf("reading certificate of signature %q failed: %w", signature.Name, err)
+		}
+	}
+	if err != nil {
+		return Signature{}, err
+	}
+	if leaf != nil {
+		listed.Certificate = &Certificate{
+			Subject:   leaf.Subject.String(),
+			Issuer:    leaf.Issuer.String(),
+			NotBefore: leaf.NotBefore.UTC(),
+			NotAfter:  leaf.NotAfter.UTC(),
+		}
+	}
+	return listed, nil
+}
+
+// leafCertificate returns the first certificate embedded in a PEM encoded signature value,
+// or nil if the value embeds no certificate.
+func leafCertificate(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing embedded certificate failed: %w", err)
+		}
+		return cert, nil
+	}
+}
+
+func renderTable(cmd *cobra.Command, signatures []Signature) {
+	t := table.NewWriter()
+	t.SetOutputMirror(cmd.OutOrStdout())
+	t.AppendHeader(table.Row{"Name", "Algorithm", "Media Type", "Issuer", "Digest", "Subject", "Not Before", "Not After"})
+	for _, signature := range signatures {
+		var subject, notBefore, notAfter string
+		if cert := signature.Certificate; cert != nil {
+			subject = cert.Subject
+			notBefore = cert.NotBefore.Format(time.RFC3339)
+			notAfter = cert.NotAfter.Format(time.RFC3339)
+		}
+		t.AppendRow(table.Row{signature.Name, signature.Algorithm, signature.MediaType, signature.Issuer, signature.Digest, subject, notBefore, notAfter})
+	}
+	style := table.StyleLight
+	style.Options.DrawBorder = false
+	t.SetStyle(style)
+	t.Render()
+}
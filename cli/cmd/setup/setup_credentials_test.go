@@ -11,6 +11,8 @@ import (
 	"ocm.software/open-component-model/bindings/go/credentials"
 	credconfigruntime "ocm.software/open-component-model/bindings/go/credentials/spec/config/runtime"
 	credv1 "ocm.software/open-component-model/bindings/go/credentials/spec/config/v1"
+	ecccredsv1alpha1 "ocm.software/open-component-model/bindings/go/ecc/spec/credentials/v1alpha1"
+	eccidentityv1alpha1 "ocm.software/open-component-model/bindings/go/ecc/spec/identity/v1alpha1"
 	gpgcredsv1alpha1 "ocm.software/open-component-model/bindings/go/gpg/spec/credentials/v1alpha1"
 	gpgidentityv1alpha1 "ocm.software/open-component-model/bindings/go/gpg/spec/identity/v1alpha1"
 	helmcredsv1 "ocm.software/open-component-model/bindings/go/helm/spec/credentials/v1"
@@ -47,6 +49,7 @@ func TestCredentialTypeSchemePopulatedByBuiltinRegister(t *testing.T) {
 		{"HelmHTTPCredentials/v1", runtime.NewVersionedType(helmcredsv1.HelmHTTPCredentialsType, helmcredsv1.Version)},
 		{"RSACredentials/v1", rsacredsv1.VersionedType},
 		{"GPGCredentials/v1alpha1", runtime.NewVersionedType(gpgcredsv1alpha1.GPGCredentialsType, gpgcredsv1alpha1.Version)},
+		{"ECCCredentials/v1alpha1", ecccredsv1alpha1.VersionedType},
 		{"OIDCIdentityToken/v1alpha1", oidctokenv1alpha1.VersionedType},
 		{"TrustedRoot/v1alpha1", trustedrootv1alpha1.VersionedType},
 	}
@@ -148,6 +151,25 @@ func TestCredentialGraphResolvesTypedCredentials(t *testing.T) {
 				require.Equal(t, "placeholder", creds.PrivateKeyPGP)
 			},
 		},
+		{
+			name: "ECCCredentials/v1alpha1",
+			identity: runtime.Identity{
+				"type":      eccidentityv1alpha1.V1Alpha1Type.String(),
+				"algorithm": "Ed25519",
+				"signature": "default",
+			},
+			credential: &ecccredsv1alpha1.ECCCredentials{
+				Type:          ecccredsv1alpha1.VersionedType,
+				PrivateKeyPEM: "placeholder",
+				PublicKeyPEM:  "placeholder",
+			},
+			assertType: func(t *testing.T, resolved runtime.Typed) {
+				t.Helper()
+				creds, ok := resolved.(*ecccredsv1alpha1.ECCCredentials)
+				require.True(t, ok, "expected *ECCCredentials, got %T", resolved)
+				require.Equal(t, "placeholder", creds.PrivateKeyPEM)
+			},
+		},
 		{
 			name: "OIDCIdentityToken/v1alpha1",
 			identity: runtime.Identity{
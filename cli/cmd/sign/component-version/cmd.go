@@ -1,6 +1,8 @@
 package componentversion
 
 import (
+	"bytes"
+	"context"
 	"crypto"
 	"encoding/json"
 	"errors"
@@ -16,12 +18,16 @@ import (
 	"ocm.software/open-component-model/bindings/go/credentials"
 	"ocm.software/open-component-model/bindings/go/descriptor/normalisation/json/v4alpha1"
 	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
+	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/oci/compref"
 	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
 	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
+	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/rsa/signing/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/signing"
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
 	ocmctx "ocm.software/open-component-model/cli/internal/context"
 	"ocm.software/open-component-model/cli/internal/flags/enum"
 	"ocm.software/open-component-model/cli/internal/flags/log"
@@ -38,6 +44,12 @@ const (
 	FlagHashAlgorithm          = "hash"
 	FlagDryRun                 = "dry-run"
 	FlagForce                  = "force"
+	FlagTSAURL                 = "tsa-url"
+	FlagSignResources          = "sign-resources"
+	FlagRotate                 = "rotate"
+	FlagRevoke                 = "revoke"
+	FlagVerifierSpec           = "verifier-spec"
+	FlagTSARootCerts           = "tsa-root-certs"
 )
 
 const (
@@ -51,7 +63,7 @@ func New() *cobra.Command {
 		Aliases:    []string{"cv", "component-versions", "cvs", "componentversion", "componentversions", "component", "components", "comp", "comps", "c"},
 		SuggestFor: []string{"version", "versions"},
 		Short:      "Sign component version(s) inside an OCM repository",
-		Args:       cobra.MatchAll(cobra.ExactArgs(1), ComponentReferenceAsFirstPositional),
+		Args:       cobra.MatchAll(cobra.ExactArgs(1), referenceAsFirstPositional),
 		Long: fmt.Sprintf(`Creates or update cryptographic signatures on component descriptors.
 
 ## Reference Format
@@ -74,9 +86,27 @@ func New() *cobra.Command {
 
 - Conflicting signatures cause failure unless --force is set (then overwrite)
 - --dry-run: compute only, do not persist signature
+- all signatures are computed before the component version is written, and the component version is written once
 - Default signature name: default
 - Default signer: RSASSA-PSS plugin (needs private key)
 - For Sigstore keyless signing (no keys needed), pass --signer-spec with a SigstoreSigningConfiguration/v1alpha1 config
+- For ECDSA or Ed25519 keys, pass --signer-spec with an ECCSigningConfiguration/v1alpha1 config (credential identity type ECC/v1alpha1)
+- --tsa-url: attach an RFC 3161 timestamp of the signature from the given time stamping authority, so that the signature can be verified after the signing certificate expired
+- --sign-resources: also sign the digest of every resource under the same signature name and attach the detached signatures to the resources as OCI referrers, so that a single resource can be verified without its component descriptor. Resources without a digest or stored as plain blobs are skipped with a warning
+
+## Key Rotation
+
+With --rotate {old-signature}, the reference is a repository reference ([type::]{repository}) and every
+version of every component listed in the repository is re-signed (currently CTF archives only):
+
+- the old signature is verified (--verifier-spec, default RSASSA-PSS verifier); with --tsa-root-certs, its RFC 3161 timestamp is
+  verified and certificate chains are validated at the timestamp time, so that signatures of expired certificates can be rotated
+- a new signature is added under --signature (--signer-spec)
+- with --revoke, the old signature is removed
+- component versions without the old signature are skipped
+- component versions are processed concurrently (--concurrency-limit) and written once each; failures are reported and fail the command at the end without stopping the others
+- an interrupted rotation is resumed by running it again: component versions already carrying the new signature are skipped (or only revoked) unless --force is set
+- a summary report of all component versions is printed in the --output format
 
 Use this command to establish provenance of component versions.`,
 			compref.DefaultPrefix,
@@ -136,12 +166,16 @@ sign component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.2
 # Supported fields:
 #   type:                    RSASigningConfiguration/v1alpha1
 #   signatureAlgorithm:      RSASSA-PSS (default) | RSASSA-PKCS1-V1_5
-#   signatureEncodingPolicy: Plain (default) | PEM
+#   signatureEncodingPolicy: Plain (default) | PEM | DSSE
 #
 # signatureEncodingPolicy controls the *signature output* format:
 #   Plain — signature stored as hex string; verification needs an external public key
 #   PEM   — signature wrapped in a PEM SIGNATURE block with embedded certificate chain
 #           (experimental; credentials must provide certificates, not bare public keys)
+#   DSSE  — signature stored as DSSE envelope (application/vnd.dsse.envelope.v1+json)
+#           carrying an in-toto statement of the descriptor digest, for supply-chain
+#           tooling that understands in-toto attestations (experimental; verification
+#           needs an external public key)
 
     type: RSASigningConfiguration/v1alpha1
     signatureAlgorithm: RSASSA-PSS
@@ -153,6 +187,12 @@ sign component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.2
     signatureAlgorithm: RSASSA-PSS
     signatureEncodingPolicy: PEM
 
+# Example signer spec for DSSE encoding (also supported by ECCSigningConfiguration/v1alpha1):
+
+    type: RSASigningConfiguration/v1alpha1
+    signatureAlgorithm: RSASSA-PSS
+    signatureEncodingPolicy: DSSE
+
 ## Example Signer Spec File — Sigstore keyless (SigstoreSigningConfiguration/v1alpha1)
 #
 # Use when signing without private keys via Sigstore/Fulcio OIDC.
@@ -204,20 +244,35 @@ sign component-version ./repo/ocm//ocm.software/ocmcli:0.23.0 --signer-spec ./rs
 sign component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --signature test --dry-run
 
 # Force overwrite an existing signature
-sign component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --signature my-signature --force`),
+sign component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --signature my-signature --force
+
+# Additionally attach detached signatures to the resources of the component version
+sign component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --sign-resources
+
+# Attach a trusted timestamp to the signature
+sign component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --signer-spec ./rsassa-pss-pem.yaml --tsa-url https://timestamp.example.com
+
+# Rotate the signing key: re-sign all component versions in an archive signed as "release-2025" as "release-2026" and revoke the old signature
+sign component-version ./transport-archive --rotate release-2025 --signature release-2026 --revoke`),
 		RunE:              SignComponentVersion,
 		DisableAutoGenTag: true,
 	}
 
-	enum.VarP(cmd.Flags(), FlagOutput, "o", []string{render.OutputFormatYAML.String(), render.OutputFormatJSON.String()}, "output format of the resulting signature")
+	enum.VarP(cmd.Flags(), FlagOutput, "o", []string{render.OutputFormatYAML.String(), render.OutputFormatJSON.String()}, "output format of the resulting signature (or of the rotation report with --rotate)")
 
 	cmd.Flags().Int(FlagConcurrencyLimit, 4, "maximum amount of parallel requests to the repository for resolving component versions")
 	cmd.Flags().String(FlagSignature, DefaultSignatureName, "name of the signature to create or update. defaults to \"default\"")
 	cmd.Flags().String(FlagSignerSpec, "", "path to a signer specification file (configures algorithm and encoding, not credentials). If empty, defaults to RSASSA-PSS with Plain encoding.")
 	cmd.Flags().Bool(FlagDryRun, false, "compute signature but do not persist it to the repository")
 	cmd.Flags().String(FlagNormalisationAlgorithm, v4alpha1.Algorithm, "normalisation algorithm to use (default jsonNormalisation/v4alpha1)")
-	cmd.Flags().String(FlagHashAlgorithm, crypto.SHA256.String(), "hash algorithm to use (SHA256, SHA512)")
+	cmd.Flags().String(FlagHashAlgorithm, crypto.SHA256.String(), fmt.Sprintf("hash algorithm to use (%s)", strings.Join(signing.SupportedHashAlgorithms(), ", ")))
 	cmd.Flags().Bool(FlagForce, false, "overwrite existing signatures under the same name")
+	cmd.Flags().String(FlagTSAURL, "", "URL of an RFC 3161 time stamping authority to timestamp the signature with")
+	cmd.Flags().Bool(FlagSignResources, false, "also attach detached signatures of the resource digests to the resources (OCI repositories only)")
+	cmd.Flags().String(FlagRotate, "", "name of a signature to rotate: re-sign every component version in the referenced repository that carries it under the name given by --signature")
+	cmd.Flags().Bool(FlagRevoke, false, "remove the rotated signature after re-signing (requires --rotate)")
+	cmd.Flags().String(FlagVerifierSpec, "", "path to a verifier specification file used to verify the rotated signature (requires --rotate). If empty, defaults to RSASSA-PSS.")
+	cmd.Flags().String(FlagTSARootCerts, "", "path to a PEM file with the root certificates of trusted time stamping authorities. If set, timestamps of verified signatures are verified and used as the time of certificate validation (requires --rotate).")
 
 	return cmd
 }
@@ -233,6 +288,13 @@ func ComponentReferenceAsFirstPositional(_ *cobra.Command, args []string) error
 }
 
 func SignComponentVersion(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed(FlagRotate) {
+		return RotateSignatures(cmd, args)
+	}
+	if revoke, _ := cmd.Flags().GetBool(FlagRevoke); revoke {
+		return fmt.Errorf("--%s requires --%s", FlagRevoke, FlagRotate)
+	}
+
 	ctx := cmd.Context()
 
 	logger, err := log.GetBaseLogger(cmd)
@@ -263,6 +325,8 @@ func SignComponentVersion(cmd *cobra.Command, args []string) error {
 	signerSpecPath, _ := cmd.Flags().GetString(FlagSignerSpec)
 	force, _ := cmd.Flags().GetBool(FlagForce)
 	dryRun, _ := cmd.Flags().GetBool(FlagDryRun)
+	tsaURL, _ := cmd.Flags().GetString(FlagTSAURL)
+	signResources, _ := cmd.Flags().GetBool(FlagSignResources)
 
 	reference := args[0]
 	ref, err := compref.Parse(reference, compref.WithCTFAccessMode(ctfv1.AccessModeReadWrite))
@@ -279,6 +343,10 @@ func SignComponentVersion(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("could not access ocm repository: %w", err)
 	}
+	resourceSignatureRepo, ok := repo.(repository.ResourceSignatureRepository)
+	if signResources && !ok {
+		return fmt.Errorf("--%s is not supported by repositories of type %s", FlagSignResources, ref.Repository.GetType())
+	}
 
 	desc, err := repo.GetComponentVersion(ctx, ref.Component, ref.Version)
 	if err != nil {
@@ -346,6 +414,41 @@ func SignComponentVersion(cmd *cobra.Command, args []string) error {
 		Signature: sigBytes,
 	}
 
+	// timestamp
+	var tsaClient *tsa.Client
+	if tsaURL != "" {
+		httpConfig, err := httpv1alpha1.ResolveHTTPConfig(config)
+		if err != nil {
+			return fmt.Errorf("could not get http configuration: %w", err)
+		}
+		tsaClient = &tsa.Client{URL: tsaURL, HTTPClient: ocmhttp.New(ocmhttp.WithConfig(httpConfig))}
+		if out.Timestamp, err = tsaClient.Timestamp(ctx, out.Signature); err != nil {
+			return fmt.Errorf("timestamping signature failed: %w", err)
+		}
+		logger.InfoContext(ctx, "timestamped signature", "tsa", tsaURL, "time", out.Timestamp.Time)
+	}
+
+	// resource signatures are computed before anything is persisted, so that a
+	// failing resource does not leave a partially signed component version behind
+	var resourceSignatures []resourceSignature
+	if signResources {
+		sign := func(ctx context.Context, digest descruntime.Digest) (signature descruntime.Signature, err error) {
+			signature = descruntime.Signature{Name: signatureName, Digest: digest}
+			if signature.Signature, err = handler.Sign(ctx, digest, signerSpec, foundCreds); err != nil {
+				return signature, err
+			}
+			if tsaClient != nil {
+				if signature.Timestamp, err = tsaClient.Timestamp(ctx, signature.Signature); err != nil {
+					return signature, fmt.Errorf("timestamping signature failed: %w", err)
+				}
+			}
+			return signature, nil
+		}
+		if resourceSignatures, err = signResourceDigests(ctx, desc, sign, logger); err != nil {
+			return err
+		}
+	}
+
 	if err := printSignature(cmd, out); err != nil {
 		return err
 	}
@@ -355,6 +458,10 @@ func SignComponentVersion(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if err := attachResourceSignatures(ctx, resourceSignatureRepo, desc, resourceSignatures, logger); err != nil {
+		return err
+	}
+
 	// persist signature
 	if idx := slices.IndexFunc(desc.Signatures, sigExists); idx >= 0 {
 		desc.Signatures[idx] = out
@@ -375,6 +482,49 @@ func SignComponentVersion(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// resourceSignature is the detached signature of the digest of a resource.
+type resourceSignature struct {
+	resource  *descruntime.Resource
+	signature descruntime.Signature
+}
+
+// signResourceDigests signs the digest of every resource of desc with sign.
+// Resources without a digest are skipped.
+func signResourceDigests(ctx context.Context, desc *descruntime.Descriptor, sign func(context.Context, descruntime.Digest) (descruntime.Signature, error), logger *slog.Logger) ([]resourceSignature, error) {
+	var signatures []resourceSignature
+	for i := range desc.Component.Resources {
+		res := &desc.Component.Resources[i]
+		if res.Digest == nil {
+			logger.WarnContext(ctx, "skipping resource without digest", "resource", res.ToIdentity())
+			continue
+		}
+		signature, err := sign(ctx, *res.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("signing resource %s failed: %w", res.ToIdentity(), err)
+		}
+		signatures = append(signatures, resourceSignature{resource: res, signature: signature})
+	}
+	return signatures, nil
+}
+
+// attachResourceSignatures attaches the signatures to their resources. Resources
+// that cannot carry detached signatures are skipped.
+func attachResourceSignatures(ctx context.Context, repo repository.ResourceSignatureRepository, desc *descruntime.Descriptor, signatures []resourceSignature, logger *slog.Logger) error {
+	for _, rs := range signatures {
+		identity := rs.resource.ToIdentity()
+		err := repo.AddResourceSignature(ctx, desc.Component.Name, desc.Component.Version, rs.resource, rs.signature, nil)
+		switch {
+		case errors.Is(err, repository.ErrResourceSignatureNotSupported):
+			logger.WarnContext(ctx, "skipping resource that cannot carry a detached signature", "resource", identity, "error", err.Error())
+		case err != nil:
+			return fmt.Errorf("attaching signature to resource %s failed: %w", identity, err)
+		default:
+			logger.InfoContext(ctx, "signed resource", "resource", identity, "name", rs.signature.Name, "digest", rs.resource.Digest.Value)
+		}
+	}
+	return nil
+}
+
 func loadSignerSpec(path string, logger *slog.Logger) (_ runtime.Typed, err error) {
 	if path == "" {
 		spec := &v1alpha1.Config{
@@ -403,23 +553,26 @@ func loadSignerSpec(path string, logger *slog.Logger) (_ runtime.Typed, err erro
 }
 
 func printSignature(cmd *cobra.Command, sig descruntime.Signature) error {
+	return printOutput(cmd, descruntime.ConvertToV2Signature(&sig))
+}
+
+// printOutput prints v in the format given by FlagOutput.
+func printOutput(cmd *cobra.Command, v any) error {
 	output, err := enum.Get(cmd.Flags(), FlagOutput)
 	if err != nil {
 		return fmt.Errorf("getting output flag failed: %w", err)
 	}
 
-	v2sig := descruntime.ConvertToV2Signature(&sig)
-
 	var b []byte
 	switch strings.ToLower(output) {
 	case render.OutputFormatJSON.String():
-		if b, err = json.MarshalIndent(v2sig, "", "  "); err != nil {
-			return fmt.Errorf("marshalling signature to json failed: %w", err)
+		if b, err = json.MarshalIndent(v, "", "  "); err != nil {
+			return fmt.Errorf("marshalling output to json failed: %w", err)
 		}
 		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(b))
 	case render.OutputFormatYAML.String():
-		if b, err = yaml.Marshal(v2sig); err != nil {
-			return fmt.Errorf("marshalling signature to yaml failed: %w", err)
+		if b, err = yaml.Marshal(v); err != nil {
+			return fmt.Errorf("marshalling output to yaml failed: %w", err)
 		}
 		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(b))
 	default:
@@ -428,3 +581,23 @@ func printSignature(cmd *cobra.Command, sig descruntime.Signature) error {
 
 	return err
 }
+
+func loadVerifierSpec(path string, logger *slog.Logger) (runtime.Typed, error) {
+	if path == "" {
+		logger.Info("no verifier spec file provided, using default RSASSA-PSS")
+		spec := &v1alpha1.Config{}
+		_, _ = v1alpha1.Scheme.DefaultType(spec)
+		return spec, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading verifier spec %q failed: %w", path, err)
+	}
+	scheme := runtime.NewScheme(runtime.WithAllowUnknown())
+	raw := &runtime.Raw{}
+	if err := scheme.Decode(bytes.NewReader(data), raw); err != nil {
+		return nil, fmt.Errorf("decoding verifier spec %q failed: %w", path, err)
+	}
+	return raw, nil
+}
@@ -0,0 +1,407 @@
+package componentversion
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"ocm.software/open-component-model/bindings/go/credentials"
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	ocmhttp "ocm.software/open-component-model/bindings/go/http"
+	httpv1alpha1 "ocm.software/open-component-model/bindings/go/http/spec/config/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/oci/compref"
+	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
+	"ocm.software/open-component-model/bindings/go/repository/component/resolvers"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/signing"
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
+	ocmctx "ocm.software/open-component-model/cli/internal/context"
+	"ocm.software/open-component-model/cli/internal/flags/log"
+	"ocm.software/open-component-model/cli/internal/repository/ocm"
+	"ocm.software/open-component-model/cli/internal/timestamp"
+)
+
+// Outcomes of the rotation of the signature of a single component version.
+const (
+	// RotationStatusRotated means the old signature was verified and the new signature added
+	// (and the old one removed if requested).
+	RotationStatusRotated = "rotated"
+	// RotationStatusRevoked means the new signature already existed and only the old one was removed.
+	RotationStatusRevoked = "revoked"
+	// RotationStatusSkipped means there was nothing to do, e.g. because the component version was
+	// rotated by a previous, interrupted run or never carried the old signature.
+	RotationStatusSkipped = "skipped"
+	// RotationStatusFailed means the rotation failed, see the reason of the result.
+	RotationStatusFailed = "failed"
+)
+
+// RotationReport summarizes the rotation of a signature across a repository.
+type RotationReport struct {
+	Repository string           `json:"repository"`
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	Revoke     bool             `json:"revoke"`
+	DryRun     bool             `json:"dryRun,omitempty"`
+	Summary    RotationSummary  `json:"summary"`
+	Results    []RotationResult `json:"results"`
+}
+
+// RotationSummary counts the results of a RotationReport by status.
+type RotationSummary struct {
+	Total   int `json:"total"`
+	Rotated int `json:"rotated"`
+	Revoked int `json:"revoked"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+// RotationResult is the outcome of the rotation of a single component version.
+type RotationResult struct {
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// ErrRotationFailed is returned if the rotation of at least one component version failed.
+var ErrRotationFailed = errors.New("signature rotation failed")
+
+func RepositoryReferenceAsFirstPositional(_ *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing repository reference as first positional argument")
+	}
+	if _, err := compref.ParseRepository(args[0]); err != nil {
+		return fmt.Errorf("parsing repository reference from first position argument %q failed: %w", args[0], err)
+	}
+	return nil
+}
+
+// referenceAsFirstPositional expects a repository reference when rotating signatures
+// and a component reference otherwise.
+func referenceAsFirstPositional(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed(FlagRotate) {
+		return RepositoryReferenceAsFirstPositional(cmd, args)
+	}
+	return ComponentReferenceAsFirstPositional(cmd, args)
+}
+
+// RotateSignatures re-signs every component version in a repository that carries the
+// signature given by FlagRotate under the name given by FlagSignature.
+//
+// Component versions are processed concurrently. Every component version is written at
+// most once, so a run that was interrupted can be resumed by running it again: component
+// versions that already carry the new signature (and no longer the old one, if it is revoked)
+// are skipped. Failures of single component versions do not stop the run, they are reported
+// and fail the command at the end.
+func RotateSignatures(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	logger, err := log.GetBaseLogger(cmd)
+	if err != nil {
+		return fmt.Errorf("getting base logger failed: %w", err)
+	}
+
+	ocmContext := ocmctx.FromContext(ctx)
+	if ocmContext == nil {
+		return fmt.Errorf("no OCM context found")
+	}
+
+	pluginManager := ocmContext.PluginManager()
+	if pluginManager == nil {
+		return fmt.Errorf("plugin manager not available in context")
+	}
+
+	credentialGraph := ocmContext.CredentialGraph()
+	if credentialGraph == nil {
+		return fmt.Errorf("credential graph not available in context")
+	}
+
+	// flags
+	from, _ := cmd.Flags().GetString(FlagRotate)
+	to, _ := cmd.Flags().GetString(FlagSignature)
+	if to == "" {
+		to = DefaultSignatureName
+	}
+	if from == "" || from == to {
+		return fmt.Errorf("--%s must name a signature other than the new signature %q", FlagRotate, to)
+	}
+	if signResources, _ := cmd.Flags().GetBool(FlagSignResources); signResources {
+		return fmt.Errorf("--%s cannot be combined with --%s", FlagSignResources, FlagRotate)
+	}
+	revoke, _ := cmd.Flags().GetBool(FlagRevoke)
+	force, _ := cmd.Flags().GetBool(FlagForce)
+	dryRun, _ := cmd.Flags().GetBool(FlagDryRun)
+	tsaURL, _ := cmd.Flags().GetString(FlagTSAURL)
+	signerSpecPath, _ := cmd.Flags().GetString(FlagSignerSpec)
+	verifierSpecPath, _ := cmd.Flags().GetString(FlagVerifierSpec)
+	tsaRootCertsPath, _ := cmd.Flags().GetString(FlagTSARootCerts)
+	concurrencyLimit, _ := cmd.Flags().GetInt(FlagConcurrencyLimit)
+	if concurrencyLimit < 1 {
+		concurrencyLimit = 1
+	}
+
+	repositoryRef := args[0]
+	repoSpec, err := compref.ParseRepository(repositoryRef, compref.WithCTFAccessMode(ctfv1.AccessModeReadWrite))
+	if err != nil {
+		return fmt.Errorf("parsing repository reference %q failed: %w", repositoryRef, err)
+	}
+
+	signerSpec, err := loadSignerSpec(signerSpecPath, logger)
+	if err != nil {
+		return err
+	}
+	signer, err := pluginManager.SigningRegistry.GetPlugin(ctx, signerSpec)
+	if err != nil {
+		return fmt.Errorf("getting signature handler failed: %w", err)
+	}
+	verifierSpec, err := loadVerifierSpec(verifierSpecPath, logger)
+	if err != nil {
+		return err
+	}
+	verifier, err := pluginManager.SigningRegistry.GetPlugin(ctx, verifierSpec)
+	if err != nil {
+		return fmt.Errorf("getting verification handler failed: %w", err)
+	}
+
+	tsaRoots, err := timestamp.LoadRoots(tsaRootCertsPath)
+	if err != nil {
+		return err
+	}
+
+	var tsaClient *tsa.Client
+	if tsaURL != "" {
+		httpConfig, err := httpv1alpha1.ResolveHTTPConfig(ocmContext.Configuration())
+		if err != nil {
+			return fmt.Errorf("could not get http configuration: %w", err)
+		}
+		tsaClient = &tsa.Client{URL: tsaURL, HTTPClient: ocmhttp.New(ocmhttp.WithConfig(httpConfig))}
+	}
+
+	// walk the repository
+	lister, err := pluginManager.ComponentListerRegistry.GetComponentLister(ctx, repoSpec, nil)
+	if err != nil {
+		return fmt.Errorf("could not get component lister for repository %q: %w", repositoryRef, err)
+	}
+	var components []string
+	if err := lister.ListComponents(ctx, "", func(names []string) error {
+		components = append(components, names...)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("could not list components in repository %q: %w", repositoryRef, err)
+	}
+	slices.Sort(components)
+
+	repoResolver, err := ocm.NewComponentRepositoryResolver(ctx, pluginManager.ComponentVersionRepositoryRegistry, credentialGraph, ocm.WithRepository(repoSpec))
+	if err != nil {
+		return fmt.Errorf("could not initialize ocm repository: %w", err)
+	}
+
+	report := &RotationReport{Repository: repositoryRef, From: from, To: to, Revoke: revoke, DryRun: dryRun}
+	for _, component := range components {
+		repo, err := repoResolver.GetComponentVersionRepositoryForComponent(ctx, component, "")
+		if err != nil {
+			return fmt.Errorf("could not access ocm repository for component %q: %w", component, err)
+		}
+		versions, err := repo.ListComponentVersions(ctx, component)
+		if err != nil {
+			return fmt.Errorf("listing versions of component %q failed: %w", component, err)
+		}
+		slices.Sort(versions)
+		for _, version := range versions {
+			report.Results = append(report.Results, RotationResult{Component: component, Version: version})
+		}
+	}
+	logger.InfoContext(ctx, "rotating signatures", "from", from, "to", to, "revoke", revoke, "componentVersions", len(report.Results))
+
+	rotator := &signatureRotator{
+		from:            from,
+		to:              to,
+		revoke:          revoke,
+		force:           force,
+		dryRun:          dryRun,
+		repoResolver:    repoResolver,
+		credentialGraph: credentialGraph,
+		signer:          signer,
+		signerSpec:      signerSpec,
+		verifier:        verifier,
+		verifierSpec:    verifierSpec,
+		tsaClient:       tsaClient,
+		tsaRoots:        tsaRoots,
+		normalisation:   cmd.Flag(FlagNormalisationAlgorithm).Value.String(),
+		hash:            cmd.Flag(FlagHashAlgorithm).Value.String(),
+		logger:          logger,
+	}
+
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, concurrencyLimit)
+	for i := range report.Results {
+		result := &report.Results[i]
+		wg.Go(func() {
+			var err error
+			select {
+			case limit <- struct{}{}:
+				defer func() { <-limit }()
+				result.Status, err = rotator.rotate(ctx, result.Component, result.Version)
+			case <-ctx.Done():
+				result.Status, err = RotationStatusFailed, ctx.Err()
+			}
+			if err != nil {
+				result.Reason = err.Error()
+			}
+		})
+	}
+	wg.Wait()
+
+	for _, result := range report.Results {
+		switch result.Status {
+		case RotationStatusRotated:
+			report.Summary.Rotated++
+		case RotationStatusRevoked:
+			report.Summary.Revoked++
+		case RotationStatusSkipped:
+			report.Summary.Skipped++
+		case RotationStatusFailed:
+			report.Summary.Failed++
+		}
+	}
+	report.Summary.Total = len(report.Results)
+
+	if err := printOutput(cmd, report); err != nil {
+		return err
+	}
+	logger.InfoContext(ctx, "signature rotation completed",
+		"total", report.Summary.Total, "rotated", report.Summary.Rotated, "revoked", report.Summary.Revoked,
+		"skipped", report.Summary.Skipped, "failed", report.Summary.Failed)
+	if report.Summary.Failed > 0 {
+		return fmt.Errorf("%w: %d of %d component versions failed", ErrRotationFailed, report.Summary.Failed, report.Summary.Total)
+	}
+	return nil
+}
+
+// signatureRotator rotates the signature of single component versions.
+type signatureRotator struct {
+	from, to              string
+	revoke, force, dryRun bool
+
+	repoResolver    resolvers.ComponentVersionRepositoryResolver
+	credentialGraph credentials.Resolver
+	signer          signing.Handler
+	signerSpec      runtime.Typed
+	verifier        signing.Handler
+	verifierSpec    runtime.Typed
+	tsaClient       *tsa.Client
+	tsaRoots        *x509.CertPool
+	normalisation   string
+	hash            string
+	logger          *slog.Logger
+}
+
+// rotate verifies the old signature of a component version, adds the new signature and
+// removes the old one if requested, writing the component version once.
+// Component versions that already carry the new signature are only revoked (or skipped),
+// unless forced, so that an interrupted rotation can be resumed.
+func (r *signatureRotator) rotate(ctx context.Context, component, version string) (string, error) {
+	logger := r.logger.With("component", component, "version", version)
+
+	repo, err := r.repoResolver.GetComponentVersionRepositoryForComponent(ctx, component, version)
+	if err != nil {
+		return RotationStatusFailed, fmt.Errorf("could not access ocm repository: %w", err)
+	}
+	desc, err := repo.GetComponentVersion(ctx, component, version)
+	if err != nil {
+		return RotationStatusFailed, fmt.Errorf("getting component version failed: %w", err)
+	}
+
+	isOld := func(sig descruntime.Signature) bool { return sig.Name == r.from }
+	isNew := func(sig descruntime.Signature) bool { return sig.Name == r.to }
+	oldIdx := slices.IndexFunc(desc.Signatures, isOld)
+	hasNew := slices.ContainsFunc(desc.Signatures, isNew)
+
+	status := RotationStatusRotated
+	switch {
+	case hasNew && !r.force:
+		if !r.revoke || oldIdx < 0 {
+			logger.DebugContext(ctx, "skipping component version already signed with new signature")
+			return RotationStatusSkipped, nil
+		}
+		status = RotationStatusRevoked
+	case oldIdx < 0:
+		logger.DebugContext(ctx, "skipping component version without signature to rotate")
+		return RotationStatusSkipped, nil
+	default:
+		if err := r.verify(ctx, desc, desc.Signatures[oldIdx]); err != nil {
+			return RotationStatusFailed, fmt.Errorf("verifying signature %q failed: %w", r.from, err)
+		}
+		signature, err := r.sign(ctx, desc)
+		if err != nil {
+			return RotationStatusFailed, err
+		}
+		if idx := slices.IndexFunc(desc.Signatures, isNew); idx >= 0 {
+			desc.Signatures[idx] = signature
+		} else {
+			desc.Signatures = append(desc.Signatures, signature)
+		}
+	}
+	if r.revoke {
+		desc.Signatures = slices.DeleteFunc(desc.Signatures, isOld)
+	}
+
+	if r.dryRun {
+		logger.InfoContext(ctx, "dry run: rotated signature not persisted", "status", status)
+		return status, nil
+	}
+	if err := repo.AddComponentVersion(ctx, desc); err != nil {
+		return RotationStatusFailed, fmt.Errorf("updating component version failed: %w", err)
+	}
+	logger.InfoContext(ctx, "rotated signature", "status", status, "from", r.from, "to", r.to, "revoked", r.revoke)
+	return status, nil
+}
+
+// verify checks that signature signs desc and is valid according to the verifier,
+// at the time of its timestamp if TSA root certificates are given.
+func (r *signatureRotator) verify(ctx context.Context, desc *descruntime.Descriptor, signature descruntime.Signature) error {
+	if err := signing.VerifyDigestMatchesDescriptor(ctx, desc, signature, r.logger); err != nil {
+		return err
+	}
+	var creds runtime.Typed
+	if consumerID, err := r.verifier.GetVerifyingCredentialConsumerIdentity(ctx, signature, r.verifierSpec); err == nil {
+		if creds, err = r.credentialGraph.Resolve(ctx, consumerID); err != nil && !errors.Is(err, credentials.ErrNotFound) {
+			return fmt.Errorf("resolving credentials for verification failed: %w", err)
+		}
+	}
+	verifyCtx, err := timestamp.VerificationContext(ctx, r.logger, signature, r.tsaRoots)
+	if err != nil {
+		return err
+	}
+	return r.verifier.Verify(verifyCtx, signature, r.verifierSpec, creds)
+}
+
+// sign creates the new signature of desc.
+func (r *signatureRotator) sign(ctx context.Context, desc *descruntime.Descriptor) (descruntime.Signature, error) {
+	digest, err := signing.GenerateDigest(ctx, desc, r.logger, r.normalisation, r.hash)
+	if err != nil {
+		return descruntime.Signature{}, fmt.Errorf("generating digest failed: %w", err)
+	}
+	var creds runtime.Typed
+	if consumerID, err := r.signer.GetSigningCredentialConsumerIdentity(ctx, r.to, *digest, r.signerSpec); err == nil {
+		if creds, err = r.credentialGraph.Resolve(ctx, consumerID); err != nil && !errors.Is(err, credentials.ErrNotFound) {
+			return descruntime.Signature{}, fmt.Errorf("resolving signing credentials failed: %w", err)
+		}
+	}
+	signature := descruntime.Signature{Name: r.to, Digest: *digest}
+	if signature.Signature, err = r.signer.Sign(ctx, *digest, r.signerSpec, creds); err != nil {
+		return descruntime.Signature{}, fmt.Errorf("signing failed: %w", err)
+	}
+	if r.tsaClient != nil {
+		if signature.Timestamp, err = r.tsaClient.Timestamp(ctx, signature.Signature); err != nil {
+			return descruntime.Signature{}, fmt.Errorf("timestamping signature failed: %w", err)
+		}
+	}
+	return signature, nil
+}
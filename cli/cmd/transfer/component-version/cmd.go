@@ -2,28 +2,38 @@ package component_version
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"os"
 	"strings"
 
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/yaml"
 
+	"ocm.software/open-component-model/bindings/go/blob"
 	"ocm.software/open-component-model/bindings/go/credentials"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/oci/compref"
 	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
 	"ocm.software/open-component-model/bindings/go/plugin/manager"
+	"ocm.software/open-component-model/bindings/go/repository"
+	rsav1alpha1 "ocm.software/open-component-model/bindings/go/rsa/signing/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/transfer"
 	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
 	graphPkg "ocm.software/open-component-model/bindings/go/transform/graph"
+	graphBuilder "ocm.software/open-component-model/bindings/go/transform/graph/builder"
 	graphRuntime "ocm.software/open-component-model/bindings/go/transform/graph/runtime"
 	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
 	ocmctx "ocm.software/open-component-model/cli/internal/context"
 	"ocm.software/open-component-model/cli/internal/flags/enum"
+	"ocm.software/open-component-model/cli/internal/flags/recursion"
 	"ocm.software/open-component-model/cli/internal/render"
 	"ocm.software/open-component-model/cli/internal/render/progress"
 	"ocm.software/open-component-model/cli/internal/render/progress/bar"
@@ -31,15 +41,26 @@ import (
 )
 
 const (
-	FlagDryRun        = "dry-run"
-	FlagOutput        = "output"
-	FlagRecursive     = "recursive"
-	FlagCopyResources = "copy-resources"
-	FlagUploadAs      = "upload-as"
-	FlagTransferSpec  = "transfer-spec"
-
-	// Each node emits 2 events (Running + Completed/Failed) and since the tracker consumes
-	// them faster than the transfer produces, 16 is enough to avoid blocking with room to grow.
+	FlagDryRun           = "dry-run"
+	FlagOutput           = "output"
+	FlagRecursive        = "recursive"
+	FlagCopyResources    = "copy-resources"
+	FlagUploadAs         = "upload-as"
+	FlagTransferSpec     = "transfer-spec"
+	FlagConcurrencyLimit = "concurrency-limit"
+	FlagJournal          = "journal"
+	FlagResume           = "resume"
+	FlagPlan             = "plan"
+	FlagSignerSpec       = "signer-spec"
+	FlagSignature        = "signature"
+	FlagVerifierSpec     = "verifier-spec"
+	FlagSkipVerification = "skip-verification"
+
+	FlagComponentVersionConflictPolicy = "component-version-conflict-policy"
+
+	// Each node emits 2 events (Running + Completed/Failed). Even with parallel processing
+	// the tracker consumes them faster than the transfer produces, so 16 is enough to avoid
+	// blocking with room to grow.
 	eventBufferSize = 16
 )
 
@@ -58,12 +79,51 @@ OCI and CTF repositories are supported as transfer targets, while Helm repositor
 By default, only the component version itself is transferred. Use --copy-resources to also
 copy (and, when needed, transform) the resources it references. --upload-as controls whether
 those resources land as OCI artifacts or as local blobs in the target. --recursive walks the
-component's references and transfers them too.
+component's references and transfers them too. Without a value it follows references without
+limit; --recursive=N stops N levels of references below the transferred component.
+--recursive=true and --recursive=false are accepted as aliases for -1 (unlimited) and 0 (none).
+
+Component versions that already exist in the target:
+  By default, a component version and its resources are transferred again and replace the
+  component version in the target. With --component-version-conflict-policy skip, component
+  versions that the target already holds with the same normalised digest are skipped together
+  with their resources, which makes rerunning a transfer cheap; if the digests differ, the
+  transfer fails. abort-and-fail fails the transfer for any existing component version.
+
+Re-signing component versions:
+  --signer-spec signs every transferred component version in the target with the signing
+  handler selected by the signer specification file (e.g. RSASigningConfiguration/v1alpha1 or
+  SigstoreSigningConfiguration/v1alpha1), under the name given by --signature ("default" if
+  not set). An existing signature with that name is replaced. Keys are resolved from the
+  credentials in the OCM configuration, as for "ocm sign component-version".
+  Before anything of a component version is copied, its signatures are verified in the source
+  with the verifier given by --verifier-spec (RSASSA-PSS if not set), so that no unverified
+  component version is re-signed. --skip-verification re-signs without verifying the source
+  signatures.
+
+Verifying component versions before the transfer:
+  With "verification" policies in the transfer configuration, the signatures of the component
+  versions in the source are verified before anything of them is copied, with a verifier
+  selected by the first policy whose glob pattern matches the component name, and the content
+  digests of all of their resources are checked against the descriptor. The transfer fails if a
+  verification fails. Component versions that match no policy (or a policy with "skip") are
+  transferred without verification, unless they are re-signed with --signer-spec, e.g.
+    verification: {policies: [{componentNamePattern: "ocm.software/*", verifier: {type: RSASigningConfiguration/v1alpha1}}]}
 
 Driving defaults from the OCM configuration:
   A transfer.config.ocm.software/v1alpha1 entry inside the central OCM configuration
-  (passed via --config) sets defaults for --recursive, --copy-resources, and --upload-as.
+  (passed via --config) sets defaults for --recursive, --copy-resources, --upload-as,
+  --component-version-conflict-policy, --concurrency-limit and, with "signing", for
+  --signer-spec, --signature, --verifier-spec and --skip-verification.
   Explicit command-line flags always override the values from the configuration.
+  The entry can also narrow down the resources copied by --copy-resources with "resources"
+  include/exclude selectors that match the resource type, identity attributes, labels or a
+  CEL expression over the resource, e.g.
+    resources: {exclude: [{type: helmChart}, {labels: [{name: internal-only}]}]}
+  Resources that are not selected keep their original access. Local blobs have no access
+  outside of the source repository, so they are copied even if no include selector matches
+  them, and the transfer fails if an exclude selector matches one. Exclude selectors should
+  only match resources with an external access such as OCI images or Helm charts.
 
 Two-step workflow (generate, review, replay):
   --dry-run builds and validates the graph without executing it, and with -o yaml|json prints
@@ -71,10 +131,39 @@ Two-step workflow (generate, review, replay):
   from a file (or stdin with "-"):
     1. Generate the spec:  transfer cv --dry-run -o yaml --copy-resources -r {reference} {target} > spec.yaml
     2. Review/edit spec.yaml, then execute: transfer cv --transfer-spec spec.yaml
-  All graph-shaping flags (--recursive, --copy-resources, --upload-as) and any transfer
-  configuration entry are baked into the spec during step 1 and are therefore ignored in
-  step 2 - the spec is the full graph definition. Only --dry-run and --output remain
-  meaningful when replaying a spec.
+  While reviewing, any transformation can be given a per-attempt "timeout" (e.g. 10m) and a
+  "retry" policy (maxRetries, backoff, maxBackoff) to cope with flaky registries.
+  A "when" expression (e.g. ${getCV.output.descriptor.component.name != ''}) skips a
+  transformation and everything depending on it, and a "forEach" expression (e.g.
+  ${getCV.output.descriptor.component.resources}) runs a transformation once per list
+  element, available as ${item} in its spec.
+  All graph-shaping flags (--recursive, --copy-resources, --upload-as,
+  --component-version-conflict-policy, --signer-spec, --signature, --verifier-spec,
+  --skip-verification) and any transfer configuration entry are baked into the spec during
+  step 1 and are therefore ignored in step 2 - the spec is the full graph definition. Only --dry-run, --output,
+  --concurrency-limit, --journal and --resume remain meaningful when replaying a spec.
+
+Planning a transfer:
+  --plan discovers the same component versions as the transfer would, compares them and the
+  resources that would be copied with the target repository, and reports for each of them
+  whether it would be skipped (already present with the same digest), copied (missing) or is
+  in conflict (present with a different digest, or without a digest to compare), together
+  with the expected number of bytes to copy. Component versions are compared by their
+  normalised digest. Nothing is written to the target: a CTF target is opened read-only and,
+  if it does not exist yet, everything is reported as copied. The plan is printed as a table
+  by default, or with -o json|yaml|ndjson.
+
+Parallel execution:
+  Transformations that do not depend on each other, such as the uploads of different
+  resources, are executed in parallel up to --concurrency-limit. 0 keeps the default of one
+  transformation at a time, -1 removes the limit.
+
+Resuming a failed transfer:
+  --journal records every completed transformation together with its output in a local
+  file. If the transfer fails, rerun the same command with --resume pointing to that file:
+  transformations that already completed with the same input are skipped and their recorded
+  outputs are reused, everything else is executed again and added to the journal.
+  --journal refuses to overwrite a journal that already records transformations.
 
 How the graph is built:
   Internally the command assembles a TransformationGraphDefinition from these node types,
@@ -105,6 +194,9 @@ transfer component-version ctf::./my-archive//ocm.software/mycomponent:1.0.0 ghc
 # Recursively transfer a component version and all its references
 transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm -r --copy-resources
 
+# Transfer a component version and only its direct references
+transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm --recursive=1 --copy-resources
+
 # Drive defaults from the OCM configuration. With --config ./ocmconfig.yaml containing:
 #   type: generic.config.ocm.software/v1
 #   configurations:
@@ -116,6 +208,22 @@ transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.
 # Any explicit flag still overrides the corresponding configuration value.
 transfer component-version --config ./ocmconfig.yaml ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm
 
+# Recursively transfer with up to 8 resources being copied in parallel
+transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm -r --copy-resources --concurrency-limit 8
+
+# Record progress in a journal and resume the transfer after a failure
+transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm -r --copy-resources --journal transfer.journal
+transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm -r --copy-resources --resume transfer.journal
+
+# Rerun a recursive transfer, skipping component versions that the target already holds
+transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm -r --copy-resources --component-version-conflict-policy skip
+
+# Show what a recursive transfer would copy to the target without transferring anything
+transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm -r --copy-resources --plan
+
+# Transfer a component version and re-sign it in the target, after verifying its source signatures
+transfer component-version ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm --copy-resources --signer-spec ./rsassa-pss.yaml --signature sovereign --verifier-spec ./sigstore-verify.yaml
+
 # Two-step transfer: generate a spec with all desired flags, then review and execute
 transfer component-version --dry-run -o yaml --copy-resources -r ghcr.io/source-org/ocm//ocm.software/mycomponent:1.0.0 ghcr.io/target-org/ocm > spec.yaml
 # (review/edit spec.yaml as needed, e.g. change the target registry)
@@ -126,9 +234,9 @@ transfer component-version --transfer-spec spec.yaml
 		DisableAutoGenTag: true,
 	}
 
-	enum.VarP(cmd.Flags(), FlagOutput, "o", []string{render.OutputFormatYAML.String(), render.OutputFormatJSON.String(), render.OutputFormatNDJSON.String()}, "output format of the component descriptors")
+	enum.VarP(cmd.Flags(), FlagOutput, "o", []string{render.OutputFormatYAML.String(), render.OutputFormatJSON.String(), render.OutputFormatNDJSON.String(), render.OutputFormatTable.String()}, "output format of the transformation graph or, with --"+FlagPlan+", of the plan (defaults to table for --"+FlagPlan+")")
 	cmd.Flags().Bool(FlagDryRun, false, "build and validate the graph but do not execute")
-	cmd.Flags().BoolP(FlagRecursive, "r", false, "recursively discover and transfer component versions")
+	recursion.VarP(cmd.Flags(), FlagRecursive, "r", recursion.None, "depth of recursion for discovering and transferring referenced component versions")
 	cmd.Flags().Bool(FlagCopyResources, false, "copy all resources in the component version")
 	uploadAsValues := make([]string, len(transferv1alpha1.AllUploadTypes))
 	for i, t := range transferv1alpha1.AllUploadTypes {
@@ -136,7 +244,25 @@ transfer component-version --transfer-spec spec.yaml
 	}
 	enum.VarP(cmd.Flags(), FlagUploadAs, "u", uploadAsValues,
 		"Define whether copied resources should be uploaded as OCI artifacts (instead of local blob resources). This option is only relevant if --copy-resources is set.")
+	conflictPolicyValues := make([]string, len(transferv1alpha1.AllComponentVersionConflictPolicies))
+	for i, p := range transferv1alpha1.AllComponentVersionConflictPolicies {
+		conflictPolicyValues[i] = string(p)
+	}
+	enum.Var(cmd.Flags(), FlagComponentVersionConflictPolicy, conflictPolicyValues,
+		"policy to apply when a component version already exists in the target repository; skip compares normalised digests")
 	cmd.Flags().String(FlagTransferSpec, "", "path to a transfer specification file (use \"-\" for stdin)")
+	cmd.Flags().Int(FlagConcurrencyLimit, graphBuilder.DefaultConcurrency, "maximum number of transformations executed in parallel (0=default, -1=unlimited)")
+	cmd.Flags().String(FlagJournal, "", "path to a journal file recording completed transformations, so that a failed transfer can be resumed with --"+FlagResume)
+	cmd.Flags().String(FlagResume, "", "path to a journal file of a previous transfer; transformations recorded in it are skipped")
+	cmd.Flags().Bool(FlagPlan, false, "report what the transfer would change in the target repository without transferring anything")
+	cmd.Flags().String(FlagSignerSpec, "", "path to a signer specification file; if set, every transferred component version is signed in the target")
+	cmd.Flags().String(FlagSignature, "", "name of the signature added with --"+FlagSignerSpec+" (defaults to \"default\")")
+	cmd.Flags().String(FlagVerifierSpec, "", "path to a verifier specification file used to verify the signatures of every component version in the source before it is re-signed. If empty, defaults to RSASSA-PSS.")
+	cmd.Flags().Bool(FlagSkipVerification, false, "re-sign component versions with --"+FlagSignerSpec+" without verifying their signatures in the source")
+	cmd.MarkFlagsMutuallyExclusive(FlagVerifierSpec, FlagSkipVerification)
+	cmd.MarkFlagsMutuallyExclusive(FlagJournal, FlagResume)
+	cmd.MarkFlagsMutuallyExclusive(FlagPlan, FlagTransferSpec)
+	cmd.MarkFlagsMutuallyExclusive(FlagPlan, FlagDryRun)
 
 	return cmd
 }
@@ -151,7 +277,7 @@ func transferArgs(cmd *cobra.Command, args []string) error {
 		if len(args) > 0 {
 			return fmt.Errorf("positional arguments are not allowed when --%s is set", FlagTransferSpec)
 		}
-		ignoredFlags := []string{FlagRecursive, FlagCopyResources, FlagUploadAs}
+		ignoredFlags := []string{FlagRecursive, FlagCopyResources, FlagUploadAs, FlagComponentVersionConflictPolicy, FlagSignerSpec, FlagSignature, FlagVerifierSpec, FlagSkipVerification}
 		for _, name := range ignoredFlags {
 			if cmd.Flags().Changed(name) {
 				slog.Warn(fmt.Sprintf("--%s has no effect when --%s is set", name, FlagTransferSpec))
@@ -192,10 +318,43 @@ func TransferComponentVersion(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("getting transfer-spec flag failed: %w", err)
 	}
 
+	transferCfg, err := transferv1alpha1.LookupConfig(octx.Configuration())
+	if err != nil {
+		return fmt.Errorf("looking up transfer config failed: %w", err)
+	}
+	if transferCfg == nil {
+		// LookupConfig returns nil when the central config has no transfer entry; start
+		// from a zero value so the override branches can write unconditionally.
+		transferCfg = &transferv1alpha1.Config{}
+	}
+
+	concurrency, err := concurrencyLimit(cmd, transferCfg)
+	if err != nil {
+		return err
+	}
+
 	// Progress goes to stderr so dry-run spec output on stdout remains clean for piping.
 	tracker := progress.NewTracker(ctx, cmd.ErrOrStderr(), bar.NewVisualizer[*graphPkg.Transformation])
 	defer tracker.Stop()
 
+	plan, err := cmd.Flags().GetBool(FlagPlan)
+	if err != nil {
+		return fmt.Errorf("getting plan flag failed: %w", err)
+	}
+	if plan {
+		if !cmd.Flags().Changed(FlagOutput) {
+			output = render.OutputFormatTable.String()
+		}
+		op := tracker.StartOperation("Planning transfer")
+		p, err := buildPlanFromArgs(cmd, args, octx, pm, credGraph, transferCfg)
+		op.Finish(err)
+		if err != nil {
+			return err
+		}
+		tracker.Stop()
+		return renderPlan(cmd.OutOrStdout(), p, output)
+	}
+
 	var tgd *transformv1alpha1.TransformationGraphDefinition
 
 	if specPath != "" {
@@ -211,7 +370,7 @@ func TransferComponentVersion(cmd *cobra.Command, args []string) error {
 			opName += " (dry run)"
 		}
 		op := tracker.StartOperation(opName)
-		tgd, err = buildGraphDefinitionFromArgs(cmd, args, octx, pm, credGraph)
+		tgd, err = buildGraphDefinitionFromArgs(cmd, args, octx, pm, credGraph, transferCfg)
 		op.Finish(err)
 		if err != nil {
 			return err
@@ -219,10 +378,30 @@ func TransferComponentVersion(cmd *cobra.Command, args []string) error {
 	}
 
 	// Build transformation graph
-	b := transfer.NewDefaultBuilder(pm.ComponentVersionRepositoryRegistry, pm.ResourcePluginRegistry, credGraph)
-	graph, err := b.
+	b := transfer.NewDefaultBuilder(pm.ComponentVersionRepositoryRegistry, pm.ResourcePluginRegistry, credGraph,
+		transfer.WithSigningHandlers(pm.SigningRegistry),
+		transfer.WithResourceDigestProcessors(&digestProcessorProvider{pm: pm})).
 		WithEvents(make(chan graphRuntime.ProgressEvent, eventBufferSize)).
-		BuildAndCheck(tgd)
+		WithConcurrency(concurrency)
+
+	journalPath, err := getJournalPath(cmd)
+	if err != nil {
+		return err
+	}
+	if journalPath != "" && !dryRun {
+		journal, err := graphRuntime.OpenFileJournal(journalPath)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := journal.Close(); err != nil {
+				slog.WarnContext(ctx, "closing transfer journal failed", "error", err)
+			}
+		}()
+		b = b.WithJournal(journal)
+	}
+
+	graph, err := b.BuildAndCheck(tgd)
 	if err != nil {
 		reader, rerr := renderTGD(tgd, output)
 		if rerr != nil {
@@ -266,6 +445,9 @@ func TransferComponentVersion(cmd *cobra.Command, args []string) error {
 
 	if err := graph.Process(ctx); err != nil {
 		op.Finish(err)
+		if journalPath != "" {
+			return fmt.Errorf("graph execution failed, rerun with --%s %s to continue: %w", FlagResume, journalPath, err)
+		}
 		return fmt.Errorf("graph execution failed: %w", err)
 	}
 	op.Finish(nil)
@@ -306,58 +488,107 @@ func buildGraphDefinitionFromArgs(
 	octx *ocmctx.Context,
 	pm *manager.PluginManager,
 	credGraph credentials.Resolver,
+	transferCfg *transferv1alpha1.Config,
 ) (*transformv1alpha1.TransformationGraphDefinition, error) {
+	mapping, err := mappingFromArgs(cmd, args, octx, pm, credGraph, transferCfg, ctfv1.AccessModeReadWrite+"|"+ctfv1.AccessModeCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	tgd, err := transfer.BuildGraphDefinition(cmd.Context(), transferCfg, mapping)
+	if err != nil {
+		return nil, fmt.Errorf("building graph definition failed: %w", err)
+	}
+
+	return tgd, nil
+}
+
+func buildPlanFromArgs(
+	cmd *cobra.Command,
+	args []string,
+	octx *ocmctx.Context,
+	pm *manager.PluginManager,
+	credGraph credentials.Resolver,
+	transferCfg *transferv1alpha1.Config,
+) (*transfer.Plan, error) {
+	// planning must not change the target, so a CTF target is neither created nor opened for writing
+	mapping, err := mappingFromArgs(cmd, args, octx, pm, credGraph, transferCfg, ctfv1.AccessModeReadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := transfer.BuildPlan(cmd.Context(), transferCfg, planTargetProvider{pm.ComponentVersionRepositoryRegistry}, credGraph, mapping)
+	if err != nil {
+		return nil, fmt.Errorf("building transfer plan failed: %w", err)
+	}
+
+	return plan, nil
+}
+
+// planTargetProvider reports a CTF target that does not exist yet as not found
+// instead of failing to open it read-only, so that the plan copies everything to it.
+type planTargetProvider struct {
+	repository.ComponentVersionRepositoryProvider
+}
+
+func (p planTargetProvider) GetComponentVersionRepository(ctx context.Context, spec runtime.Typed, creds runtime.Typed) (repository.ComponentVersionRepository, error) {
+	if ctf, ok := spec.(*ctfv1.Repository); ok {
+		if _, err := os.Stat(ctf.FilePath); errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("CTF %q does not exist: %w", ctf.FilePath, repository.ErrNotFound)
+		}
+	}
+	return p.ComponentVersionRepositoryProvider.GetComponentVersionRepository(ctx, spec, creds)
+}
+
+// mappingFromArgs resolves the source component version and the target repository
+// from the positional arguments and applies the graph-shaping flags to transferCfg.
+// A CTF target is opened with targetAccessMode.
+func mappingFromArgs(
+	cmd *cobra.Command,
+	args []string,
+	octx *ocmctx.Context,
+	pm *manager.PluginManager,
+	credGraph credentials.Resolver,
+	transferCfg *transferv1alpha1.Config,
+	targetAccessMode ctfv1.AccessMode,
+) (transfer.Mapping, error) {
 	ctx := cmd.Context()
 	cfg := octx.Configuration()
 
 	if len(args) != 2 {
-		return nil, fmt.Errorf("source component reference and target repository spec are required as positional arguments")
+		return transfer.Mapping{}, fmt.Errorf("source component reference and target repository spec are required as positional arguments")
 	}
 
 	fromSpec, compErr := compref.Parse(args[0])
 	if compErr != nil {
-		return nil, fmt.Errorf("invalid source component reference: %w", compErr)
+		return transfer.Mapping{}, fmt.Errorf("invalid source component reference: %w", compErr)
 	}
 
 	repoProvider, err := ocm.NewComponentRepositoryResolver(
 		ctx, pm.ComponentVersionRepositoryRegistry, credGraph, ocm.WithConfig(cfg), ocm.WithComponentRef(fromSpec),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("could not initialize ocm repositoryProvider: %w", err)
+		return transfer.Mapping{}, fmt.Errorf("could not initialize ocm repositoryProvider: %w", err)
 	}
 
 	toSpec, err := compref.ParseRepository(args[1],
-		compref.WithCTFAccessMode(ctfv1.AccessModeReadWrite+"|"+ctfv1.AccessModeCreate),
+		compref.WithCTFAccessMode(targetAccessMode),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("invalid target repository spec: %w", err)
-	}
-
-	transferCfg, err := transferv1alpha1.LookupConfig(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("looking up transfer config failed: %w", err)
-	}
-	if transferCfg == nil {
-		// LookupConfig returns nil when the central config has no transfer entry; start
-		// from a zero value so the override branches below can write unconditionally.
-		transferCfg = &transferv1alpha1.Config{}
+		return transfer.Mapping{}, fmt.Errorf("invalid target repository spec: %w", err)
 	}
 
 	if cmd.Flags().Changed(FlagRecursive) {
-		recursive, err := cmd.Flags().GetBool(FlagRecursive)
+		recursive, err := recursion.Get(cmd.Flags(), FlagRecursive)
 		if err != nil {
-			return nil, fmt.Errorf("getting recursive flag failed: %w", err)
-		}
-		if recursive {
-			transferCfg.Recursive = transferv1alpha1.RecursiveInfinite
-		} else {
-			transferCfg.Recursive = transferv1alpha1.RecursiveNone
+			return transfer.Mapping{}, fmt.Errorf("getting recursive flag failed: %w", err)
 		}
+		transferCfg.Recursive = transferv1alpha1.Recursive(recursive)
 	}
 	if cmd.Flags().Changed(FlagCopyResources) {
 		copyResources, err := cmd.Flags().GetBool(FlagCopyResources)
 		if err != nil {
-			return nil, fmt.Errorf("getting copy-resources flag failed: %w", err)
+			return transfer.Mapping{}, fmt.Errorf("getting copy-resources flag failed: %w", err)
 		}
 		if copyResources {
 			transferCfg.CopyMode = transferv1alpha1.CopyModeAllResources
@@ -368,23 +599,157 @@ func buildGraphDefinitionFromArgs(
 	if cmd.Flags().Changed(FlagUploadAs) {
 		uploadAs, err := enum.Get(cmd.Flags(), FlagUploadAs)
 		if err != nil {
-			return nil, fmt.Errorf("getting upload-as flag failed: %w", err)
+			return transfer.Mapping{}, fmt.Errorf("getting upload-as flag failed: %w", err)
 		}
 		transferCfg.UploadType = transferv1alpha1.UploadType(uploadAs)
 	}
+	if cmd.Flags().Changed(FlagComponentVersionConflictPolicy) {
+		policy, err := enum.Get(cmd.Flags(), FlagComponentVersionConflictPolicy)
+		if err != nil {
+			return transfer.Mapping{}, fmt.Errorf("getting component-version-conflict-policy flag failed: %w", err)
+		}
+		transferCfg.ComponentVersionConflictPolicy = transferv1alpha1.ComponentVersionConflictPolicy(policy)
+	}
+	if err := applySigningFlags(cmd, transferCfg); err != nil {
+		return transfer.Mapping{}, err
+	}
 
-	tgd, err := transfer.BuildGraphDefinition(ctx, transferCfg,
-		transfer.Mapping{
-			Components: []transfer.ComponentID{{Component: fromSpec.Component, Version: fromSpec.Version}},
-			Target:     toSpec,
-			Resolver:   repoProvider,
-		},
-	)
+	return transfer.Mapping{
+		Components: []transfer.ComponentID{{Component: fromSpec.Component, Version: fromSpec.Version}},
+		Target:     toSpec,
+		Resolver:   repoProvider,
+	}, nil
+}
+
+// applySigningFlags enables re-signing with --signer-spec and overrides the signing
+// configuration from the OCM configuration with --signature, --verifier-spec and
+// --skip-verification. Source signatures are verified with RSASSA-PSS if neither
+// a verifier is configured nor verification is skipped.
+func applySigningFlags(cmd *cobra.Command, transferCfg *transferv1alpha1.Config) error {
+	signerSpecPath, err := cmd.Flags().GetString(FlagSignerSpec)
 	if err != nil {
-		return nil, fmt.Errorf("building graph definition failed: %w", err)
+		return fmt.Errorf("getting signer-spec flag failed: %w", err)
+	}
+	signature, err := cmd.Flags().GetString(FlagSignature)
+	if err != nil {
+		return fmt.Errorf("getting signature flag failed: %w", err)
+	}
+	verifierSpecPath, err := cmd.Flags().GetString(FlagVerifierSpec)
+	if err != nil {
+		return fmt.Errorf("getting verifier-spec flag failed: %w", err)
+	}
+	skipVerification, err := cmd.Flags().GetBool(FlagSkipVerification)
+	if err != nil {
+		return fmt.Errorf("getting skip-verification flag failed: %w", err)
 	}
 
-	return tgd, nil
+	if signerSpecPath == "" && transferCfg.Signing == nil {
+		if signature != "" || verifierSpecPath != "" || skipVerification {
+			return fmt.Errorf("--%s, --%s and --%s require --%s or signing in the transfer configuration", FlagSignature, FlagVerifierSpec, FlagSkipVerification, FlagSignerSpec)
+		}
+		return nil
+	}
+	if transferCfg.Signing == nil {
+		transferCfg.Signing = &transferv1alpha1.Signing{}
+	}
+	signing := transferCfg.Signing
+	if signerSpecPath != "" {
+		if signing.Signer, err = loadSpecFile(signerSpecPath); err != nil {
+			return fmt.Errorf("loading signer specification failed: %w", err)
+		}
+	}
+	if signature != "" {
+		signing.Signature = signature
+	}
+	switch {
+	case verifierSpecPath != "":
+		if signing.Verifier, err = loadSpecFile(verifierSpecPath); err != nil {
+			return fmt.Errorf("loading verifier specification failed: %w", err)
+		}
+		signing.SkipVerification = false
+	case skipVerification:
+		signing.Verifier, signing.VerifySignature = nil, ""
+		signing.SkipVerification = true
+	case signing.Verifier == nil && !signing.SkipVerification:
+		slog.Info("no verifier spec file provided, verifying source signatures with default RSASSA-PSS")
+		spec := &rsav1alpha1.Config{}
+		_, _ = rsav1alpha1.Scheme.DefaultType(spec)
+		signing.Verifier = &runtime.Raw{}
+		if err := rsav1alpha1.Scheme.Convert(spec, signing.Verifier); err != nil {
+			return fmt.Errorf("converting default verifier specification failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadSpecFile decodes a typed specification such as a signer specification from a file.
+func loadSpecFile(path string) (_ *runtime.Raw, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q failed: %w", path, err)
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	raw := &runtime.Raw{}
+	if err := runtime.NewScheme(runtime.WithAllowUnknown()).Decode(file, raw); err != nil {
+		return nil, fmt.Errorf("decoding %q failed: %w", path, err)
+	}
+	return raw, nil
+}
+
+// digestProcessorProvider selects the digest processor of a resource by its access.
+type digestProcessorProvider struct {
+	pm *manager.PluginManager
+}
+
+func (p *digestProcessorProvider) GetDigestProcessor(ctx context.Context, resource *descriptor.Resource) (repository.ResourceDigestProcessor, error) {
+	return p.pm.DigestProcessorRegistry.GetPlugin(ctx, resource.Access)
+}
+
+// getJournalPath returns the journal file given by either --journal or --resume.
+// For --resume the journal must already exist. --journal refuses a non-empty file,
+// so that an unrelated transfer is never skipped based on a leftover journal.
+func getJournalPath(cmd *cobra.Command) (string, error) {
+	resume, err := cmd.Flags().GetString(FlagResume)
+	if err != nil {
+		return "", fmt.Errorf("getting resume flag failed: %w", err)
+	}
+	if resume != "" {
+		if _, err := os.Stat(resume); err != nil {
+			return "", fmt.Errorf("cannot resume from journal: %w", err)
+		}
+		return resume, nil
+	}
+	journal, err := cmd.Flags().GetString(FlagJournal)
+	if err != nil {
+		return "", fmt.Errorf("getting journal flag failed: %w", err)
+	}
+	if journal != "" {
+		if info, err := os.Stat(journal); err == nil && info.Size() > 0 {
+			return "", fmt.Errorf("journal %q already exists, use --%s to continue the transfer it records or remove it to start over", journal, FlagResume)
+		}
+	}
+	return journal, nil
+}
+
+// concurrencyLimit resolves the number of transformations executed in parallel.
+// An explicit --concurrency-limit overrides the transfer configuration. The result
+// has the meaning of [graphBuilder.Builder.WithConcurrency]: 0 keeps the builder
+// default and -1 removes the limit.
+func concurrencyLimit(cmd *cobra.Command, transferCfg *transferv1alpha1.Config) (int, error) {
+	if cmd.Flags().Changed(FlagConcurrencyLimit) {
+		limit, err := cmd.Flags().GetInt(FlagConcurrencyLimit)
+		if err != nil {
+			return 0, fmt.Errorf("getting concurrency-limit flag failed: %w", err)
+		}
+		transferCfg.Concurrency = limit
+	}
+	if err := transferCfg.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid transfer configuration: %w", err)
+	}
+	return transferCfg.Concurrency, nil
 }
 
 func renderTGD(tgd *transformv1alpha1.TransformationGraphDefinition, format string) (io.ReadCloser, error) {
@@ -416,3 +781,75 @@ func renderTGD(tgd *transformv1alpha1.TransformationGraphDefinition, format stri
 		return nil, fmt.Errorf("invalid output format %q", format)
 	}
 }
+
+func renderPlan(w io.Writer, plan *transfer.Plan, format string) error {
+	switch format {
+	case render.OutputFormatTable.String():
+		renderPlanTable(w, plan)
+		return nil
+	case render.OutputFormatJSON.String():
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	case render.OutputFormatNDJSON.String():
+		return json.NewEncoder(w).Encode(plan)
+	case render.OutputFormatYAML.String():
+		data, err := yaml.Marshal(plan)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	default:
+		return fmt.Errorf("invalid output format %q", format)
+	}
+}
+
+// renderPlanTable renders one row per component version and target, followed by
+// one row per resource copied with it, and the total volume as footer.
+func renderPlanTable(w io.Writer, plan *transfer.Plan) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Component", "Version", "Target", "Resource", "Action", "Size"})
+	for _, cv := range plan.ComponentVersions {
+		target := fmt.Sprint(cv.Target)
+		t.AppendRow(table.Row{cv.Component, cv.Version, target, "", cv.Action, ""})
+		for _, res := range cv.Resources {
+			size := "-"
+			switch {
+			case res.Action == transfer.PlanActionSkip:
+			case res.Size == blob.SizeUnknown:
+				size = "unknown"
+			default:
+				size = formatBytes(res.Size)
+			}
+			t.AppendRow(table.Row{cv.Component, cv.Version, target, res.Identity.String(), res.Action, size})
+		}
+	}
+	total := formatBytes(plan.Bytes)
+	if plan.UnknownSizeResources > 0 {
+		total += fmt.Sprintf(" + %d unknown", plan.UnknownSizeResources)
+	}
+	t.AppendFooter(table.Row{"", "", "", "", "Total", total})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, AutoMerge: true},
+	})
+	style := table.StyleLight
+	style.Options.DrawBorder = false
+	t.SetStyle(style)
+	t.Render()
+}
+
+// formatBytes formats size with binary units, e.g. 1.5 MiB.
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -3,11 +3,20 @@ package component_version_test
 import (
 	"bytes"
 	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"log/slog"
+	"math/big"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -22,6 +31,7 @@ import (
 	ocictf "ocm.software/open-component-model/bindings/go/oci/ctf"
 	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
 	"ocm.software/open-component-model/bindings/go/signing"
+	"ocm.software/open-component-model/bindings/go/transfer"
 	"ocm.software/open-component-model/cli/cmd/internal/test"
 )
 
@@ -365,6 +375,326 @@ func TestTransferComponentVersionRecursive(t *testing.T) {
 	require.True(t, found, "expected success log message")
 }
 
+func TestTransferComponentVersionRecursiveDepth(t *testing.T) {
+	r := require.New(t)
+
+	grandchildDesc := createTestDescriptor("ocm.software/grandchild-component", "0.0.1")
+	childDesc := createTestDescriptor("ocm.software/child-component", "0.0.1")
+	parentDesc := createTestDescriptor("ocm.software/parent-component", "1.0.0")
+	addReference(t, childDesc, grandchildDesc, "grandchild")
+	addReference(t, parentDesc, childDesc, "child")
+
+	fromPath, err := setupTestRepositoryWithDescriptorLibrary(t, grandchildDesc, childDesc, parentDesc)
+	r.NoError(err)
+
+	toPath := t.TempDir()
+
+	fromRef := compref.Ref{
+		Repository: &ctfv1.Repository{
+			FilePath: fromPath,
+		},
+		Component: parentDesc.Component.Name,
+		Version:   parentDesc.Component.Version,
+	}
+
+	targetArg := fmt.Sprintf("ctf::%s", toPath)
+
+	_, err = test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), targetArg, "--recursive=1"), test.WithOutput(new(bytes.Buffer)))
+	r.NoError(err)
+
+	fs, err := filesystem.NewFS(toPath, os.O_RDWR)
+	r.NoError(err)
+	archive := ctf.NewFileSystemCTF(fs)
+	targetRepo, err := oci.NewRepository(ocictf.WithCTF(ocictf.NewFromCTF(archive)))
+	r.NoError(err)
+
+	ctx := t.Context()
+
+	_, err = targetRepo.GetComponentVersion(ctx, parentDesc.Component.Name, parentDesc.Component.Version)
+	r.NoError(err, "parent must be transferred")
+	_, err = targetRepo.GetComponentVersion(ctx, childDesc.Component.Name, childDesc.Component.Version)
+	r.NoError(err, "direct reference must be transferred with --recursive=1")
+	_, err = targetRepo.GetComponentVersion(ctx, grandchildDesc.Component.Name, grandchildDesc.Component.Version)
+	r.Error(err, "transitive reference must not be transferred with --recursive=1")
+}
+
+func TestTransferComponentVersionConcurrencyLimit(t *testing.T) {
+	parentDesc := createTestDescriptor("ocm.software/parent-component", "1.0.0")
+	descs := []*descriptor.Descriptor{parentDesc}
+	for _, name := range []string{"first", "second", "third"} {
+		childDesc := createTestDescriptor("ocm.software/"+name+"-component", "0.0.1")
+		addReference(t, parentDesc, childDesc, name)
+		descs = append([]*descriptor.Descriptor{childDesc}, descs...)
+	}
+
+	fromPath, err := setupTestRepositoryWithDescriptorLibrary(t, descs...)
+	require.NoError(t, err)
+
+	fromRef := compref.Ref{
+		Repository: &ctfv1.Repository{
+			FilePath: fromPath,
+		},
+		Component: parentDesc.Component.Name,
+		Version:   parentDesc.Component.Version,
+	}
+
+	t.Run("unlimited concurrency transfers all references", func(t *testing.T) {
+		r := require.New(t)
+		toPath := t.TempDir()
+
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), fmt.Sprintf("ctf::%s", toPath),
+			"--recursive", "--concurrency-limit=-1"), test.WithOutput(new(bytes.Buffer)))
+		r.NoError(err)
+
+		fs, err := filesystem.NewFS(toPath, os.O_RDWR)
+		r.NoError(err)
+		targetRepo, err := oci.NewRepository(ocictf.WithCTF(ocictf.NewFromCTF(ctf.NewFileSystemCTF(fs))))
+		r.NoError(err)
+		for _, desc := range descs {
+			_, err = targetRepo.GetComponentVersion(t.Context(), desc.Component.Name, desc.Component.Version)
+			r.NoError(err, "%s must be transferred", desc.Component.Name)
+		}
+	})
+
+	t.Run("invalid concurrency limit is rejected", func(t *testing.T) {
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), fmt.Sprintf("ctf::%s", t.TempDir()),
+			"--concurrency-limit=-2"), test.WithOutput(new(bytes.Buffer)))
+		require.ErrorContains(t, err, "invalid concurrency")
+	})
+}
+
+func TestTransferComponentVersionResume(t *testing.T) {
+	childDesc := createTestDescriptor("ocm.software/child-component", "0.0.1")
+	parentDesc := createTestDescriptor("ocm.software/parent-component", "1.0.0")
+	addReference(t, parentDesc, childDesc, "child")
+
+	fromPath, err := setupTestRepositoryWithDescriptorLibrary(t, childDesc, parentDesc)
+	require.NoError(t, err)
+
+	fromRef := compref.Ref{
+		Repository: &ctfv1.Repository{
+			FilePath: fromPath,
+		},
+		Component: parentDesc.Component.Name,
+		Version:   parentDesc.Component.Version,
+	}
+
+	t.Run("completed transformations are not executed again", func(t *testing.T) {
+		r := require.New(t)
+		toPath := t.TempDir()
+		targetArg := fmt.Sprintf("ctf::%s", toPath)
+		journal := filepath.Join(t.TempDir(), "transfer.journal")
+
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), targetArg, "--recursive", "--journal", journal),
+			test.WithOutput(new(bytes.Buffer)))
+		r.NoError(err)
+		info, err := os.Stat(journal)
+		r.NoError(err)
+		r.NotZero(info.Size(), "journal should record the completed transformations")
+
+		// Wipe the target: a resumed transfer trusts the journal and must not upload again.
+		r.NoError(os.RemoveAll(toPath))
+		r.NoError(os.Mkdir(toPath, 0o755))
+
+		_, err = test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), targetArg, "--recursive", "--resume", journal),
+			test.WithOutput(new(bytes.Buffer)))
+		r.NoError(err)
+
+		entries, err := os.ReadDir(toPath)
+		r.NoError(err)
+		r.Empty(entries, "no transformation should have been executed again")
+	})
+
+	t.Run("missing journal cannot be resumed", func(t *testing.T) {
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), fmt.Sprintf("ctf::%s", t.TempDir()),
+			"--resume", filepath.Join(t.TempDir(), "missing.journal")), test.WithOutput(new(bytes.Buffer)))
+		require.ErrorContains(t, err, "cannot resume from journal")
+	})
+
+	t.Run("existing journal is not reused without resume", func(t *testing.T) {
+		journal := filepath.Join(t.TempDir(), "transfer.journal")
+		require.NoError(t, os.WriteFile(journal, []byte("{}\n"), 0o600))
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), fmt.Sprintf("ctf::%s", t.TempDir()),
+			"--journal", journal), test.WithOutput(new(bytes.Buffer)))
+		require.ErrorContains(t, err, "already exists, use --resume")
+	})
+
+	t.Run("journal and resume are mutually exclusive", func(t *testing.T) {
+		journal := filepath.Join(t.TempDir(), "transfer.journal")
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), fmt.Sprintf("ctf::%s", t.TempDir()),
+			"--journal", journal, "--resume", journal), test.WithOutput(new(bytes.Buffer)))
+		require.Error(t, err)
+	})
+}
+
+func TestTransferComponentVersionPlan(t *testing.T) {
+	childDesc := createTestDescriptor("ocm.software/child-component", "0.0.1")
+	parentDesc := createTestDescriptor("ocm.software/parent-component", "1.0.0")
+	addReference(t, parentDesc, childDesc, "child")
+
+	fromPath, err := setupTestRepositoryWithDescriptorLibrary(t, childDesc, parentDesc)
+	require.NoError(t, err)
+
+	fromRef := compref.Ref{
+		Repository: &ctfv1.Repository{
+			FilePath: fromPath,
+		},
+		Component: parentDesc.Component.Name,
+		Version:   parentDesc.Component.Version,
+	}
+	toPath := t.TempDir()
+	targetArg := fmt.Sprintf("ctf::%s", toPath)
+
+	planActions := func(t *testing.T) map[string]transfer.PlanAction {
+		t.Helper()
+		out := new(bytes.Buffer)
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), targetArg, "--recursive", "--plan", "-o", "json"),
+			test.WithOutput(out))
+		require.NoError(t, err)
+		var plan struct {
+			ComponentVersions []struct {
+				Component string              `json:"component"`
+				Action    transfer.PlanAction `json:"action"`
+			} `json:"componentVersions"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &plan))
+		actions := make(map[string]transfer.PlanAction, len(plan.ComponentVersions))
+		for _, cv := range plan.ComponentVersions {
+			actions[cv.Component] = cv.Action
+		}
+		return actions
+	}
+
+	t.Run("missing component versions are copied", func(t *testing.T) {
+		require.Equal(t, map[string]transfer.PlanAction{
+			parentDesc.Component.Name: transfer.PlanActionCopy,
+			childDesc.Component.Name:  transfer.PlanActionCopy,
+		}, planActions(t))
+
+		_, err := openCTFRepo(t, toPath).GetComponentVersion(t.Context(), parentDesc.Component.Name, parentDesc.Component.Version)
+		require.Error(t, err, "planning must not transfer anything")
+	})
+
+	t.Run("missing target is not created", func(t *testing.T) {
+		missingPath := filepath.Join(t.TempDir(), "missing")
+		out := new(bytes.Buffer)
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), fmt.Sprintf("ctf::%s", missingPath), "--recursive", "--plan", "-o", "json"),
+			test.WithOutput(out))
+		require.NoError(t, err)
+		var plan struct {
+			ComponentVersions []struct {
+				Component string              `json:"component"`
+				Action    transfer.PlanAction `json:"action"`
+			} `json:"componentVersions"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &plan))
+		require.Len(t, plan.ComponentVersions, 2)
+		for _, cv := range plan.ComponentVersions {
+			require.Equal(t, transfer.PlanActionCopy, cv.Action, cv.Component)
+		}
+		require.NoDirExists(t, missingPath, "planning must not create the target")
+	})
+
+	t.Run("transferred component versions are skipped", func(t *testing.T) {
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), targetArg, "--recursive"),
+			test.WithOutput(new(bytes.Buffer)))
+		require.NoError(t, err)
+
+		require.Equal(t, map[string]transfer.PlanAction{
+			parentDesc.Component.Name: transfer.PlanActionSkip,
+			childDesc.Component.Name:  transfer.PlanActionSkip,
+		}, planActions(t))
+	})
+
+	t.Run("table output", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), targetArg, "--plan"),
+			test.WithOutput(out))
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "COMPONENT")
+		require.Contains(t, out.String(), parentDesc.Component.Name)
+		require.Contains(t, out.String(), string(transfer.PlanActionSkip))
+		require.NotContains(t, out.String(), childDesc.Component.Name)
+	})
+
+	t.Run("plan and transfer spec are mutually exclusive", func(t *testing.T) {
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", "--transfer-spec", writeSpecFile(t, "{}"), "--plan"),
+			test.WithOutput(new(bytes.Buffer)))
+		require.Error(t, err)
+	})
+}
+
+func TestTransferComponentVersionConflictPolicy(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+
+	fromDesc := createTestDescriptor("ocm.software/blob-component", "1.0.0")
+	fromDesc.Component.Resources = []descriptor.Resource{
+		{
+			ElementMeta: descriptor.ElementMeta{
+				ObjectMeta: descriptor.ObjectMeta{
+					Name:    "test-blob",
+					Version: "1.0.0",
+				},
+			},
+			Type:     "plainText",
+			Relation: descriptor.LocalRelation,
+			Access: &v2.LocalBlob{
+				MediaType: "text/plain",
+			},
+		},
+	}
+	fromPath := t.TempDir()
+	sourceRepo := openCTFRepo(t, fromPath)
+	updatedRes, err := sourceRepo.AddLocalResource(ctx, fromDesc.Component.Name, fromDesc.Component.Version,
+		&fromDesc.Component.Resources[0], inmemory.New(bytes.NewReader([]byte("Hello, world!"))))
+	r.NoError(err)
+	fromDesc.Component.Resources[0] = *updatedRes
+	r.NoError(sourceRepo.AddComponentVersion(ctx, fromDesc))
+
+	fromRef := compref.Ref{
+		Repository: &ctfv1.Repository{
+			FilePath: fromPath,
+		},
+		Component: fromDesc.Component.Name,
+		Version:   fromDesc.Component.Version,
+	}
+	targetArg := fmt.Sprintf("ctf::%s", t.TempDir())
+
+	transferWithPolicy := func(policy string) (*test.JSONLogReader, error) {
+		logs := test.NewJSONLogReader()
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), targetArg,
+			"--copy-resources", "--component-version-conflict-policy", policy),
+			test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(logs))
+		return logs, err
+	}
+	skipped := func(logs *test.JSONLogReader) bool {
+		entries, err := logs.List()
+		r.NoError(err)
+		for _, e := range entries {
+			if strings.Contains(fmt.Sprint(e), "skipping transfer") {
+				return true
+			}
+		}
+		return false
+	}
+
+	logs, err := transferWithPolicy("skip")
+	r.NoError(err, "missing component version should be transferred")
+	r.False(skipped(logs))
+
+	logs, err = transferWithPolicy("skip")
+	r.NoError(err, "identical component version should be skipped")
+	r.True(skipped(logs), "expected the transfer of the identical component version to be skipped")
+
+	_, err = transferWithPolicy("abort-and-fail")
+	r.ErrorContains(err, "already exists in target repository")
+
+	logs, err = transferWithPolicy("replace")
+	r.NoError(err, "existing component version should be replaced")
+	r.False(skipped(logs))
+}
+
 // TestTransferComponentVersionPreservesSignatures verifies that signatures on a component
 // descriptor are preserved when transferring a component version that has local blob resources.
 func TestTransferComponentVersionPreservesSignatures(t *testing.T) {
@@ -477,3 +807,182 @@ func TestTransferComponentVersionPreservesSignatures(t *testing.T) {
 	r.Len(targetDesc.Component.Resources, 1, "transferred descriptor should have 1 resource")
 	r.Equal("test-blob", targetDesc.Component.Resources[0].Name)
 }
+
+// writeRSAKeys writes a RSA private key and a self-signed certificate for it to dir.
+func writeRSAKeys(t *testing.T, dir string) (privPath, certPath string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "signer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	privPath = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+	certPath = filepath.Join(dir, "cert.pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	return privPath, certPath
+}
+
+// TestTransferComponentVersionResign verifies that --signer-spec signs the transferred component
+// version in the target and that the source signatures are verified before copying, unless
+// --skip-verification is set.
+func TestTransferComponentVersionResign(t *testing.T) {
+	r := require.New(t)
+	ctx := t.Context()
+	tmp := t.TempDir()
+
+	fromDesc := createTestDescriptor("ocm.software/resigned-component", "1.0.0")
+	fromDesc.Component.Resources = []descriptor.Resource{
+		{
+			ElementMeta: descriptor.ElementMeta{
+				ObjectMeta: descriptor.ObjectMeta{
+					Name:    "test-blob",
+					Version: "1.0.0",
+				},
+			},
+			Type:     "plainText",
+			Relation: descriptor.LocalRelation,
+			Access: &v2.LocalBlob{
+				MediaType: "text/plain",
+			},
+		},
+	}
+	fromPath := t.TempDir()
+	sourceRepo := openCTFRepo(t, fromPath)
+	updatedRes, err := sourceRepo.AddLocalResource(ctx, fromDesc.Component.Name, fromDesc.Component.Version,
+		&fromDesc.Component.Resources[0], inmemory.New(bytes.NewReader([]byte("Hello, world!"))))
+	r.NoError(err)
+	fromDesc.Component.Resources[0] = *updatedRes
+	r.NoError(sourceRepo.AddComponentVersion(ctx, fromDesc))
+
+	signature := "sovereign"
+	privPath, certPath := writeRSAKeys(t, tmp)
+	configPath := filepath.Join(tmp, "ocm-config.yaml")
+	r.NoError(os.WriteFile(configPath, fmt.Appendf(nil, `
+type: generic.config.ocm.software/v1
+configurations:
+- type: credentials.config.ocm.software
+  consumers:
+  - identity:
+      type: RSA/v1alpha1
+      algorithm: RSASSA-PSS
+      signature: %[1]s
+    credentials:
+    - type: Credentials/v1
+      properties:
+        public_key_pem_file: %[2]s
+        private_key_pem_file: %[3]s
+`, signature, certPath, privPath), 0o600))
+	signerSpecPath := filepath.Join(tmp, "rsassa-pss.yaml")
+	r.NoError(os.WriteFile(signerSpecPath, []byte(`
+type: RSASigningConfiguration/v1alpha1
+signatureAlgorithm: RSASSA-PSS
+signatureEncodingPolicy: Plain
+`), 0o600))
+
+	fromRef := compref.Ref{
+		Repository: &ctfv1.Repository{FilePath: fromPath},
+		Component:  fromDesc.Component.Name,
+		Version:    fromDesc.Component.Version,
+	}
+	signedPath := t.TempDir()
+	_, err = test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), "ctf::"+signedPath,
+		"--copy-resources", "--signer-spec", signerSpecPath, "--signature", signature, "--skip-verification",
+		"--config", configPath),
+		test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+	r.NoError(err, "transfer with re-signing should succeed")
+
+	signedDesc, err := openCTFRepo(t, signedPath).GetComponentVersion(ctx, fromDesc.Component.Name, fromDesc.Component.Version)
+	r.NoError(err)
+	r.Len(signedDesc.Signatures, 1)
+	r.Equal(signature, signedDesc.Signatures[0].Name)
+
+	signedRef := compref.Ref{
+		Repository: &ctfv1.Repository{FilePath: signedPath},
+		Component:  fromDesc.Component.Name,
+		Version:    fromDesc.Component.Version,
+	}
+	_, err = test.OCM(t, test.WithArgs("verify", "component-version", signedRef.String(),
+		"--signature", signature, "--config", configPath),
+		test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+	r.NoError(err, "signature added by the transfer should verify")
+
+	t.Run("source is verified by default", func(t *testing.T) {
+		r := require.New(t)
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", signedRef.String(), "ctf::"+t.TempDir(),
+			"--copy-resources", "--signer-spec", signerSpecPath, "--signature", signature, "--config", configPath),
+			test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+		r.NoError(err, "signed source should verify with the default verifier")
+
+		_, err = test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), "ctf::"+t.TempDir(),
+			"--copy-resources", "--signer-spec", signerSpecPath, "--signature", signature, "--config", configPath),
+			test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+		r.ErrorContains(err, "has no signatures to verify")
+	})
+
+	t.Run("verifier spec verifies the source", func(t *testing.T) {
+		r := require.New(t)
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", signedRef.String(), "ctf::"+t.TempDir(),
+			"--copy-resources", "--signer-spec", signerSpecPath, "--signature", signature,
+			"--verifier-spec", signerSpecPath, "--config", configPath),
+			test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+		r.NoError(err, "signed source should verify")
+
+		_, err = test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), "ctf::"+t.TempDir(),
+			"--copy-resources", "--signer-spec", signerSpecPath, "--signature", signature,
+			"--verifier-spec", signerSpecPath, "--config", configPath),
+			test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+		r.ErrorContains(err, "has no signatures to verify")
+	})
+
+	t.Run("verification policies from the configuration", func(t *testing.T) {
+		policyConfig := func(t *testing.T, policy string) string {
+			t.Helper()
+			base, err := os.ReadFile(configPath)
+			require.NoError(t, err)
+			path := filepath.Join(t.TempDir(), "ocm-config.yaml")
+			require.NoError(t, os.WriteFile(path, fmt.Appendf(base, `- type: transfer.config.ocm.software/v1alpha1
+  verification:
+    policies:
+    - %s
+`, policy), 0o600))
+			return path
+		}
+		transfer := func(t *testing.T, ref compref.Ref, config string) error {
+			t.Helper()
+			_, err := test.OCM(t, test.WithArgs("transfer", "component-version", ref.String(), "ctf::"+t.TempDir(),
+				"--copy-resources", "--config", config),
+				test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+			return err
+		}
+		verify := policyConfig(t, `{componentNamePattern: "ocm.software/*", signature: `+signature+`, verifier: {type: RSASigningConfiguration/v1alpha1}}`)
+
+		require.NoError(t, transfer(t, signedRef, verify), "signed source should verify")
+		require.ErrorContains(t, transfer(t, fromRef, verify), "has no signatures to verify")
+		require.NoError(t, transfer(t, fromRef, policyConfig(t, `{componentNamePattern: "ocm.software/resigned-*", skip: true}`)))
+		require.NoError(t, transfer(t, fromRef, policyConfig(t, `{componentNamePattern: "example.com/*", verifier: {type: RSASigningConfiguration/v1alpha1}}`)))
+	})
+
+	t.Run("verifier spec requires a signer", func(t *testing.T) {
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), "ctf::"+t.TempDir(),
+			"--verifier-spec", signerSpecPath),
+			test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+		require.ErrorContains(t, err, "require --signer-spec")
+	})
+
+	t.Run("skip verification excludes a verifier spec", func(t *testing.T) {
+		_, err := test.OCM(t, test.WithArgs("transfer", "component-version", fromRef.String(), "ctf::"+t.TempDir(),
+			"--signer-spec", signerSpecPath, "--verifier-spec", signerSpecPath, "--skip-verification"),
+			test.WithOutput(new(bytes.Buffer)), test.WithErrorOutput(test.NewJSONLogReader()))
+		require.ErrorContains(t, err, "none of the others can be")
+	})
+}
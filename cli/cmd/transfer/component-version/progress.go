@@ -11,10 +11,19 @@ import (
 )
 
 // mapEvent converts a graph runtime progress event to a typed progress.Event.
+// A retried transformation stays running and a skipped transformation counts
+// as completed; both are shown in its name.
 func mapEvent(e graphRuntime.ProgressEvent) progress.Event[*graphPkg.Transformation] {
+	name := formatTransformationName(e.Transformation)
+	switch e.State {
+	case graphRuntime.Retrying:
+		name = fmt.Sprintf("%s (retry %d)", name, e.Attempt)
+	case graphRuntime.Skipped:
+		name += " (skipped)"
+	}
 	return progress.Event[*graphPkg.Transformation]{
 		ID:    e.Transformation.ID,
-		Name:  formatTransformationName(e.Transformation),
+		Name:  name,
 		State: mapState(e.State),
 		Err:   e.Err,
 		Data:  e.Transformation,
@@ -24,9 +33,9 @@ func mapEvent(e graphRuntime.ProgressEvent) progress.Event[*graphPkg.Transformat
 // mapState converts a graph runtime state to a progress state.
 func mapState(s graphRuntime.State) progress.State {
 	switch s {
-	case graphRuntime.Running:
+	case graphRuntime.Running, graphRuntime.Retrying:
 		return progress.Running
-	case graphRuntime.Completed:
+	case graphRuntime.Completed, graphRuntime.Skipped:
 		return progress.Completed
 	case graphRuntime.Failed:
 		return progress.Failed
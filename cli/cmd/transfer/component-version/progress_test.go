@@ -92,6 +92,41 @@ func TestMapEvent(t *testing.T) {
 			expectedState: progress.Failed,
 			expectedErr:   testErr,
 		},
+		{
+			name: "retrying state stays running",
+			input: graphRuntime.ProgressEvent{
+				Transformation: &graphPkg.Transformation{
+					GenericTransformation: v1alpha1.GenericTransformation{
+						TransformationMeta: meta.TransformationMeta{
+							Type: runtime.Type{Name: "AddOCIArtifact"},
+							ID:   "transform4",
+						},
+					},
+				},
+				State:   graphRuntime.Retrying,
+				Err:     testErr,
+				Attempt: 1,
+			},
+			expectedID:    "transform4",
+			expectedState: progress.Running,
+			expectedErr:   testErr,
+		},
+		{
+			name: "skipped state counts as completed",
+			input: graphRuntime.ProgressEvent{
+				Transformation: &graphPkg.Transformation{
+					GenericTransformation: v1alpha1.GenericTransformation{
+						TransformationMeta: meta.TransformationMeta{
+							Type: runtime.Type{Name: "AddOCIArtifact"},
+							ID:   "transform5",
+						},
+					},
+				},
+				State: graphRuntime.Skipped,
+			},
+			expectedID:    "transform5",
+			expectedState: progress.Completed,
+		},
 	}
 
 	for _, tt := range tests {
@@ -121,4 +156,13 @@ func TestMapEvent_NameFormattedAsIDAndType(t *testing.T) {
 
 	result := mapEvent(input)
 	assert.Equal(t, "myTransform [AddComponentVersion]", result.Name)
+
+	input.State = graphRuntime.Retrying
+	input.Attempt = 2
+	result = mapEvent(input)
+	assert.Equal(t, "myTransform [AddComponentVersion] (retry 2)", result.Name)
+
+	input.State = graphRuntime.Skipped
+	result = mapEvent(input)
+	assert.Equal(t, "myTransform [AddComponentVersion] (skipped)", result.Name)
 }
@@ -2,32 +2,45 @@ package componentversion
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
 	"ocm.software/open-component-model/bindings/go/credentials"
 	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"ocm.software/open-component-model/bindings/go/oci/compref"
 	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
 	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
+	"ocm.software/open-component-model/bindings/go/repository"
 	"ocm.software/open-component-model/bindings/go/rsa/signing/v1alpha1"
 	"ocm.software/open-component-model/bindings/go/runtime"
 	"ocm.software/open-component-model/bindings/go/signing"
+	"ocm.software/open-component-model/bindings/go/signing/policy"
+	policyv1alpha1 "ocm.software/open-component-model/bindings/go/signing/policy/v1alpha1"
 	ocmctx "ocm.software/open-component-model/cli/internal/context"
 	"ocm.software/open-component-model/cli/internal/flags/log"
 	"ocm.software/open-component-model/cli/internal/repository/ocm"
+	"ocm.software/open-component-model/cli/internal/timestamp"
 )
 
 const (
 	FlagConcurrencyLimit = "concurrency-limit"
 	FlagSignature        = "signature"
 	FlagVerifierSpec     = "verifier-spec"
+	FlagTSARootCerts     = "tsa-root-certs"
+	FlagPolicy           = "verification-policy"
+	FlagVerifyResources  = "verify-resources"
 )
 
 func New() *cobra.Command {
@@ -60,6 +73,18 @@ func New() *cobra.Command {
 - Signatures are verified concurrently (--concurrency-limit); the command exits non-zero on the first failure
 - Default verifier: RSASSA-PSS, resolves the public key from credentials in .ocmconfig
 - For Sigstore keyless verification, pass --verifier-spec with a SigstoreVerificationConfiguration/v1alpha1 config
+- For ECDSA or Ed25519 signatures, pass --verifier-spec with an ECCSigningConfiguration/v1alpha1 config (credential identity type ECC/v1alpha1)
+- --tsa-root-certs: verify the RFC 3161 timestamps of signatures against the given time stamping authority roots and validate certificate chains at the timestamp time, so that signatures remain valid after their signing certificate expired. Without it, timestamps are ignored
+- --verification-policy: evaluate a verification.config.ocm.software/v1alpha1 policy instead of requiring every signature to be valid.
+  The policy can also be set in .ocmconfig; the flag takes precedence. It cannot be combined with --signature
+  - trusted signatures are verified with their own verifier (or --verifier-spec), at least "threshold" of them must be valid (default: all)
+  - optional signatures (name patterns) are verified if present, but may fail
+  - ignored signatures (name patterns) are not verified
+  - any other signature fails the policy
+  - the outcome of every policy clause is printed as a table
+- --verify-resources: after the descriptor signatures are verified, also verify the detached resource signatures created by "sign component-version --sign-resources".
+  Every resource with a digest must carry a detached signature under the name of at least one valid descriptor signature, and each such detached signature must sign the digest recorded in the descriptor and be valid.
+  Resources stored as plain blobs cannot carry detached signatures and are skipped with a warning
 
 Use to validate component versions before promotion, deployment, or further usage to ensure integrity and provenance.`,
 			compref.DefaultPrefix,
@@ -166,6 +191,36 @@ verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0
 
 # Use a verifier specification file
 verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --verifier-spec ./rsassa-pss.yaml
+
+# Verify timestamped signatures at the time of their timestamp
+verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --tsa-root-certs ./tsa-root.pem
+
+## Example Verification Policy
+#
+# At least 2 of the 3 trusted signatures must be valid. Signatures without a verifier
+# use --verifier-spec (default RSASSA-PSS). legacy-* signatures are optional,
+# experimental-* signatures are ignored.
+
+    type: verification.config.ocm.software/v1alpha1
+    threshold: 2
+    trusted:
+    - signature: release-bot
+      verifier:
+        type: SigstoreVerificationConfiguration/v1alpha1
+        certificateOIDCIssuer: https://token.actions.githubusercontent.com
+        certificateIdentity: https://github.com/acme/release/.github/workflows/release.yaml@refs/heads/main
+    - signature: security-team
+    - signature: product-owner
+    optional:
+    - signature: legacy-*
+    ignored:
+    - experimental-*
+
+# Verify the detached signatures of the resources as well
+verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --verify-resources
+
+# Verify against a verification policy
+verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.23.0 --verification-policy ./release-policy.yaml
 `),
 		RunE:              VerifyComponentVersion,
 		DisableAutoGenTag: true,
@@ -174,6 +229,9 @@ verify component-version ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0
 	cmd.Flags().Int(FlagConcurrencyLimit, 4, "maximum amount of parallel requests to the repository for resolving component versions")
 	cmd.Flags().String(FlagSignature, "", "name of the signature to verify. If not set, all signatures are verified.")
 	cmd.Flags().String(FlagVerifierSpec, "", "path to a verifier specification file. If empty, defaults to RSASSA-PSS.")
+	cmd.Flags().String(FlagTSARootCerts, "", "path to a PEM file with the root certificates of trusted time stamping authorities. If set, signature timestamps are verified and used as the time of certificate validation.")
+	cmd.Flags().String(FlagPolicy, "", "path to a verification policy file (verification.config.ocm.software/v1alpha1). If set, signatures are verified against the policy instead of all being required to be valid.")
+	cmd.Flags().Bool(FlagVerifyResources, false, "also verify the detached signatures attached to the resources (OCI repositories only)")
 
 	return cmd
 }
@@ -225,9 +283,36 @@ func VerifyComponentVersion(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("getting verifier-spec flag failed: %w", err)
 	}
 
-	reference := args[0]
+	tsaRootCertsPath, err := cmd.Flags().GetString(FlagTSARootCerts)
+	if err != nil {
+		return fmt.Errorf("getting tsa-root-certs flag failed: %w", err)
+	}
+	tsaRoots, err := timestamp.LoadRoots(tsaRootCertsPath)
+	if err != nil {
+		return err
+	}
+
+	policyPath, err := cmd.Flags().GetString(FlagPolicy)
+	if err != nil {
+		return fmt.Errorf("getting verification-policy flag failed: %w", err)
+	}
+
+	verifyResources, err := cmd.Flags().GetBool(FlagVerifyResources)
+	if err != nil {
+		return fmt.Errorf("getting verify-resources flag failed: %w", err)
+	}
 
 	config := ocmContext.Configuration()
+	verificationPolicy, err := loadVerificationPolicy(policyPath, config)
+	if err != nil {
+		return err
+	}
+	if verificationPolicy != nil && signatureName != "" {
+		return fmt.Errorf("--%s cannot be combined with a verification policy", FlagSignature)
+	}
+
+	reference := args[0]
+
 	ref, err := compref.Parse(reference)
 	if err != nil {
 		return fmt.Errorf("parsing component reference %q failed: %w", reference, err)
@@ -241,28 +326,16 @@ func VerifyComponentVersion(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("could not access ocm repository: %w", err)
 	}
+	resourceSignatureRepo, ok := repo.(repository.ResourceSignatureRepository)
+	if verifyResources && !ok {
+		return fmt.Errorf("--%s is not supported by repositories of type %s", FlagVerifyResources, ref.Repository.GetType())
+	}
 
 	desc, err := repo.GetComponentVersion(ctx, ref.Component, ref.Version)
 	if err != nil {
 		return fmt.Errorf("getting component reference and versions failed: %w", err)
 	}
 
-	var sigs []descruntime.Signature
-	if signatureName != "" {
-		for _, sig := range desc.Signatures {
-			if sig.Name == signatureName {
-				sigs = append(sigs, sig)
-				break
-			}
-		}
-	} else {
-		sigs = desc.Signatures
-	}
-
-	if len(sigs) == 0 {
-		return fmt.Errorf("no signatures found to verify")
-	}
-
 	if err := signing.IsSafelyDigestible(&desc.Component); err != nil {
 		logger.WarnContext(ctx, "component version is not considered safely digestable", "error", err.Error())
 	}
@@ -284,41 +357,102 @@ func VerifyComponentVersion(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	handler, err := pluginManager.SigningRegistry.GetPlugin(ctx, verifierSpec)
-	if err != nil {
-		return fmt.Errorf("getting signature handler plugin failed: %w", err)
-	}
+	// verifyWithHandler verifies the cryptographic signature and its timestamp,
+	// but not the digest it signs.
+	verifyWithHandler := func(ctx context.Context, signature descruntime.Signature, verifierSpec runtime.Typed) error {
+		handler, err := pluginManager.SigningRegistry.GetPlugin(ctx, verifierSpec)
+		if err != nil {
+			return fmt.Errorf("getting signature handler plugin failed: %w", err)
+		}
 
-	eg, egctx := errgroup.WithContext(ctx)
-	eg.SetLimit(concurrencyLimit)
-	for _, signature := range sigs {
-		eg.Go(func() error {
-			start := time.Now()
-			logger.InfoContext(egctx, "verifying signature", "name", signature.Name)
-			defer func() {
-				logger.InfoContext(egctx, "signature verification completed", "name", signature.Name, "duration", time.Since(start).String())
-			}()
-
-			if err := signing.VerifyDigestMatchesDescriptor(egctx, desc, signature, logger); err != nil {
-				return err
+		var creds runtime.Typed
+		if consumerID, err := handler.GetVerifyingCredentialConsumerIdentity(ctx, signature, verifierSpec); err == nil {
+			if creds, err = credentialGraph.Resolve(ctx, consumerID); err != nil {
+				if errors.Is(err, credentials.ErrNotFound) {
+					logger.DebugContext(ctx, "could not resolve credentials for verification", "error", err.Error())
+				} else {
+					return fmt.Errorf("resolving credentials for verification failed: %w", err)
+				}
 			}
+		}
 
-			var creds runtime.Typed
-			if consumerID, err := handler.GetVerifyingCredentialConsumerIdentity(egctx, signature, verifierSpec); err == nil {
-				if creds, err = credentialGraph.Resolve(egctx, consumerID); err != nil {
-					if errors.Is(err, credentials.ErrNotFound) {
-						logger.DebugContext(egctx, "could not resolve credentials for verification", "error", err.Error())
-					} else {
-						return fmt.Errorf("resolving credentials for verification failed: %w", err)
-					}
-				}
+		if creds != nil {
+			logger.DebugContext(ctx, "using discovered credentials for verification", "type", creds.GetType())
+		}
+
+		verifyCtx, err := timestamp.VerificationContext(ctx, logger, signature, tsaRoots)
+		if err != nil {
+			return err
+		}
+
+		return handler.Verify(verifyCtx, signature, verifierSpec, creds)
+	}
+
+	// verifiers records the verifier of every valid descriptor signature, so that
+	// detached resource signatures of the same name are verified the same way.
+	var (
+		verifiersMu sync.Mutex
+		verifiers   = make(map[string]runtime.Typed)
+	)
+
+	verifySignature := func(ctx context.Context, signature descruntime.Signature, verifierSpec runtime.Typed) error {
+		start := time.Now()
+		logger.InfoContext(ctx, "verifying signature", "name", signature.Name)
+		defer func() {
+			logger.InfoContext(ctx, "signature verification completed", "name", signature.Name, "duration", time.Since(start).String())
+		}()
+
+		if err := signing.VerifyDigestMatchesDescriptor(ctx, desc, signature, logger); err != nil {
+			return err
+		}
+		if err := verifyWithHandler(ctx, signature, verifierSpec); err != nil {
+			return err
+		}
+		verifiersMu.Lock()
+		defer verifiersMu.Unlock()
+		verifiers[signature.Name] = verifierSpec
+		return nil
+	}
+
+	if verificationPolicy != nil {
+		result := policy.Evaluate(ctx, verificationPolicy, desc.Signatures, func(ctx context.Context, signature descruntime.Signature, verifier *runtime.Raw) error {
+			if verifier != nil {
+				return verifySignature(ctx, signature, verifier)
 			}
+			return verifySignature(ctx, signature, verifierSpec)
+		}, policy.Options{Concurrency: concurrencyLimit})
+		renderPolicyResult(cmd.OutOrStdout(), result)
+		if err := result.Err(); err != nil {
+			return fmt.Errorf("SIGNATURE VERIFICATION FAILED: policy not satisfied: %w", err)
+		}
+		logger.InfoContext(ctx, "SIGNATURE VERIFICATION SUCCESSFUL", "valid", result.Valid, "required", result.Required)
+		if verifyResources {
+			return verifyResourceSignatures(ctx, resourceSignatureRepo, desc, verifiers, verifyWithHandler, logger)
+		}
+		return nil
+	}
 
-			if creds != nil {
-				logger.DebugContext(egctx, "using discovered credentials for verification", "type", creds.GetType())
+	var sigs []descruntime.Signature
+	if signatureName != "" {
+		for _, sig := range desc.Signatures {
+			if sig.Name == signatureName {
+				sigs = append(sigs, sig)
+				break
 			}
+		}
+	} else {
+		sigs = desc.Signatures
+	}
+
+	if len(sigs) == 0 {
+		return fmt.Errorf("no signatures found to verify")
+	}
 
-			return handler.Verify(egctx, signature, verifierSpec, creds)
+	eg, egctx := errgroup.WithContext(ctx)
+	eg.SetLimit(concurrencyLimit)
+	for _, signature := range sigs {
+		eg.Go(func() error {
+			return verifySignature(egctx, signature, verifierSpec)
 		})
 	}
 
@@ -327,5 +461,102 @@ func VerifyComponentVersion(cmd *cobra.Command, args []string) error {
 	}
 
 	logger.InfoContext(ctx, "SIGNATURE VERIFICATION SUCCESSFUL")
+	if verifyResources {
+		return verifyResourceSignatures(ctx, resourceSignatureRepo, desc, verifiers, verifyWithHandler, logger)
+	}
+	return nil
+}
+
+// verifyResourceSignatures verifies the detached signatures attached to the resources
+// of desc. verifiers holds the valid descriptor signatures by name together with
+// their verifier. Every resource with a digest must carry at least one detached
+// signature of such a name, and all of them must sign the resource digest and be valid.
+func verifyResourceSignatures(
+	ctx context.Context,
+	repo repository.ResourceSignatureRepository,
+	desc *descruntime.Descriptor,
+	verifiers map[string]runtime.Typed,
+	verify func(ctx context.Context, signature descruntime.Signature, verifierSpec runtime.Typed) error,
+	logger *slog.Logger,
+) error {
+	for i := range desc.Component.Resources {
+		res := &desc.Component.Resources[i]
+		identity := res.ToIdentity()
+		if res.Digest == nil {
+			logger.WarnContext(ctx, "skipping resource without digest", "resource", identity)
+			continue
+		}
+		signatures, err := repo.GetResourceSignatures(ctx, desc.Component.Name, desc.Component.Version, res, nil)
+		if errors.Is(err, repository.ErrResourceSignatureNotSupported) {
+			logger.WarnContext(ctx, "skipping resource that cannot carry a detached signature", "resource", identity, "error", err.Error())
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("RESOURCE SIGNATURE VERIFICATION FAILED: getting signatures of resource %s failed: %w", identity, err)
+		}
+
+		verified := 0
+		for _, signature := range signatures {
+			verifierSpec, ok := verifiers[signature.Name]
+			if !ok {
+				continue
+			}
+			if signature.Digest.HashAlgorithm != res.Digest.HashAlgorithm ||
+				signature.Digest.NormalisationAlgorithm != res.Digest.NormalisationAlgorithm ||
+				signature.Digest.Value != res.Digest.Value {
+				return fmt.Errorf("RESOURCE SIGNATURE VERIFICATION FAILED: signature %q of resource %s does not sign the digest %s of the component descriptor", signature.Name, identity, res.Digest.Value)
+			}
+			if err := verify(ctx, signature, verifierSpec); err != nil {
+				return fmt.Errorf("RESOURCE SIGNATURE VERIFICATION FAILED: signature %q of resource %s: %w", signature.Name, identity, err)
+			}
+			verified++
+		}
+		if verified == 0 {
+			return fmt.Errorf("RESOURCE SIGNATURE VERIFICATION FAILED: resource %s has no detached signature named after a valid descriptor signature", identity)
+		}
+		logger.InfoContext(ctx, "verified resource signatures", "resource", identity, "count", verified)
+	}
+
+	logger.InfoContext(ctx, "RESOURCE SIGNATURE VERIFICATION SUCCESSFUL")
 	return nil
 }
+
+// loadVerificationPolicy reads the verification policy from the file at path or,
+// if path is empty, looks it up in the central configuration. It returns nil if
+// neither provides a policy.
+func loadVerificationPolicy(path string, cfg *genericv1.Config) (*policyv1alpha1.Config, error) {
+	if path == "" {
+		verificationPolicy, err := policyv1alpha1.LookupConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("config lookup failed for verification policy: %w", err)
+		}
+		return verificationPolicy, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading verification policy file %q failed: %w", path, err)
+	}
+	verificationPolicy := &policyv1alpha1.Config{}
+	if err := policyv1alpha1.Scheme.Decode(bytes.NewReader(data), verificationPolicy); err != nil {
+		return nil, fmt.Errorf("decoding verification policy file %q failed: %w", path, err)
+	}
+	if err := verificationPolicy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid verification policy file %q: %w", path, err)
+	}
+	return verificationPolicy, nil
+}
+
+// renderPolicyResult renders one row per evaluated policy clause.
+func renderPolicyResult(w io.Writer, result *policy.Result) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Clause", "Signature", "Rule", "Outcome", "Reason"})
+	for _, clause := range result.Clauses {
+		t.AppendRow(table.Row{clause.Kind, clause.Signature, clause.Rule, clause.Outcome, clause.Error})
+	}
+	style := table.StyleLight
+	style.Options.DrawBorder = false
+	t.SetStyle(style)
+	t.Render()
+}
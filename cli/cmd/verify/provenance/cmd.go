@@ -0,0 +1,111 @@
+package provenance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"ocm.software/open-component-model/bindings/go/constructor/provenance"
+	"ocm.software/open-component-model/bindings/go/oci/compref"
+	ctfv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/ctf"
+	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
+	getprovenance "ocm.software/open-component-model/cli/cmd/get/provenance"
+	"ocm.software/open-component-model/cli/internal/flags/log"
+)
+
+const (
+	FlagConstructor = "constructor"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:        "provenance {reference}",
+		Aliases:    []string{"provenances", "slsa-provenance"},
+		SuggestFor: []string{"attestation", "attestations"},
+		Short:      "Verify the SLSA provenance of a component version",
+		Args:       cobra.MatchAll(cobra.ExactArgs(1), getprovenance.ComponentReferenceAsFirstPositional),
+		Long: fmt.Sprintf(`Verify the SLSA provenance of a component version inside an OCM repository.
+
+The provenance generated by "add component-version --provenance" is stored as local resource %[4]q
+of the component version. Its integrity against the digest of that resource is checked when it is read.
+
+## Reference Format
+
+	[type::]{repository}/[valid-prefix]/{component}[:version]
+
+- Prefixes: {%[1]s|none} (default: %[1]q)
+- Repo types: {%[2]s} (short: {%[3]s})
+
+## Behavior
+
+- the provenance must name the component version
+- every resource with a digest must be a subject of the provenance with the same digest, and every subject must be such a resource
+- every component reference must be a resolved dependency of the provenance with the same digest
+- --%[5]s: the digest of the given component constructor file must match the constructor recorded in the provenance
+
+The provenance is covered by the signatures of the component version. Use "verify component-version"
+to verify them, so that the provenance can be trusted.`,
+			compref.DefaultPrefix,
+			strings.Join([]string{ociv1.Type, ctfv1.Type}, "|"),
+			strings.Join([]string{ociv1.ShortType, ociv1.ShortType2, ctfv1.ShortType, ctfv1.ShortType2}, "|"),
+			provenance.ResourceName,
+			FlagConstructor,
+		),
+		Example: strings.TrimSpace(`
+# Verify the provenance of a component version
+verify provenance ./transport-archive//ocm.software/my-component:1.0.0
+
+# Verify the provenance of a component version was generated from the given constructor file
+verify provenance ./transport-archive//ocm.software/my-component:1.0.0 --constructor ./component-constructor.yaml
+`),
+		RunE:              VerifyProvenance,
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(FlagConstructor, "", "path to the component constructor file the component version is expected to be constructed from")
+
+	return cmd
+}
+
+func VerifyProvenance(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	logger, err := log.GetBaseLogger(cmd)
+	if err != nil {
+		return fmt.Errorf("getting base logger failed: %w", err)
+	}
+
+	constructorPath, err := cmd.Flags().GetString(FlagConstructor)
+	if err != nil {
+		return fmt.Errorf("getting constructor flag failed: %w", err)
+	}
+
+	desc, statement, err := getprovenance.FetchProvenance(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := provenance.Verify(desc, statement); err != nil {
+		return fmt.Errorf("verifying provenance failed: %w", err)
+	}
+
+	if constructorPath != "" {
+		data, err := os.ReadFile(constructorPath)
+		if err != nil {
+			return fmt.Errorf("reading component constructor %q failed: %w", constructorPath, err)
+		}
+		sum := sha256.Sum256(data)
+		recorded := statement.Predicate.BuildDefinition.ExternalParameters.Constructor
+		if recorded == nil || recorded.Digest["sha256"] != hex.EncodeToString(sum[:]) {
+			return fmt.Errorf("verifying provenance failed: component constructor %q is not the constructor recorded in the provenance", constructorPath)
+		}
+	}
+
+	logger.InfoContext(ctx, "PROVENANCE VERIFICATION SUCCESSFUL",
+		"component", desc.Component.Name, "version", desc.Component.Version,
+		"subjects", len(statement.Subject), "builder", statement.Predicate.RunDetails.Builder.ID)
+	return nil
+}
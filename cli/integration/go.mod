@@ -164,6 +164,7 @@ require (
 	ocm.software/open-component-model/bindings/go/cel v0.0.0-20260616162616-fac66c3e8710 // indirect
 	ocm.software/open-component-model/bindings/go/constructor v0.0.10 // indirect
 	ocm.software/open-component-model/bindings/go/dag v0.0.6 // indirect
+	ocm.software/open-component-model/bindings/go/ecc v0.0.0-20260616162616-fac66c3e8710 // indirect
 	ocm.software/open-component-model/bindings/go/gpg v0.0.0-20260616162616-fac66c3e8710 // indirect
 	ocm.software/open-component-model/bindings/go/http v0.0.0-20260616162616-fac66c3e8710 // indirect
 	ocm.software/open-component-model/bindings/go/input/dir v0.0.4 // indirect
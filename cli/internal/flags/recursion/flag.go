@@ -0,0 +1,80 @@
+package recursion
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/pflag"
+)
+
+const Type = "depth"
+
+const (
+	// None disables recursion.
+	None = 0
+	// Unlimited follows references at any depth.
+	Unlimited = -1
+)
+
+// Flag is a flag.Value implementation for parsing a recursion depth. Besides a
+// depth (0=none, -1=unlimited, >0=levels) it accepts "true" and "false" as aliases
+// for unlimited and no recursion, so that a formerly boolean flag keeps working.
+type Flag struct {
+	depth *int
+}
+
+// New returns a flag.Value implementation for parsing a recursion depth with the
+// given default.
+func New(depth int) *Flag {
+	return &Flag{depth: &depth}
+}
+
+func (f *Flag) Type() string {
+	return Type
+}
+
+func (f *Flag) String() string {
+	return strconv.Itoa(*f.depth)
+}
+
+func (f *Flag) Set(value string) error {
+	switch value {
+	case "true":
+		*f.depth = Unlimited
+		return nil
+	case "false":
+		*f.depth = None
+		return nil
+	}
+
+	depth, err := strconv.Atoi(value)
+	if err != nil || depth < Unlimited {
+		return fmt.Errorf("expected true, false or a depth of %d or more", Unlimited)
+	}
+	*f.depth = depth
+
+	return nil
+}
+
+// Get returns the recursion depth of the flag with the given name.
+func Get(f *pflag.FlagSet, name string) (int, error) {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return 0, fmt.Errorf("flag accessed but not defined: %s", name)
+	}
+	if flag.Value.Type() != Type {
+		return 0, fmt.Errorf("trying to get %s value of flag of type %s", Type, flag.Value.Type())
+	}
+	return strconv.Atoi(flag.Value.String())
+}
+
+// Var defines a recursion depth flag. Passing the flag without a value means unlimited recursion.
+func Var(f *pflag.FlagSet, name string, value int, usage string) {
+	VarP(f, name, "", value, usage)
+}
+
+// VarP is like Var, but accepts a shorthand letter that can be used after a single dash.
+func VarP(f *pflag.FlagSet, name string, shorthand string, value int, usage string) {
+	f.VarP(New(value), name, shorthand, fmt.Sprintf("%s\n(0 or false=none, -1 or true=unlimited, >0=levels)", usage))
+	f.Lookup(name).NoOptDefVal = "true"
+}
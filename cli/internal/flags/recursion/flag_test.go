@@ -0,0 +1,72 @@
+package recursion
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlag_Set(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expectError bool
+		expected    int
+	}{
+		{name: "true is unlimited", value: "true", expected: Unlimited},
+		{name: "false is none", value: "false", expected: None},
+		{name: "depth", value: "2", expected: 2},
+		{name: "unlimited depth", value: "-1", expected: Unlimited},
+		{name: "depth below unlimited", value: "-2", expectError: true},
+		{name: "invalid value", value: "yes", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag := New(None)
+			err := flag.Set(tt.value)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, "0", flag.String())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, *flag.depth)
+		})
+	}
+}
+
+func TestVarP(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{name: "default", args: nil, expected: None},
+		{name: "without value", args: []string{"-r"}, expected: Unlimited},
+		{name: "boolean true", args: []string{"--recursive=true"}, expected: Unlimited},
+		{name: "boolean false", args: []string{"--recursive=false"}, expected: None},
+		{name: "depth", args: []string{"--recursive=3"}, expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			VarP(flags, "recursive", "r", None, "recursion depth")
+			require.NoError(t, flags.Parse(tt.args))
+
+			depth, err := Get(flags, "recursive")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, depth)
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.Int("recursive", 0, "")
+		_, err := Get(flags, "recursive")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,28 @@
+package ecc
+
+import (
+	filesystemv1alpha1 "ocm.software/open-component-model/bindings/go/configuration/filesystem/v1alpha1/spec"
+	"ocm.software/open-component-model/bindings/go/ecc/signing/handler"
+	ecccredentialsv1alpha1 "ocm.software/open-component-model/bindings/go/ecc/spec/credentials/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/ecc/spec/signing/v1alpha1"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/credentialrepository"
+	"ocm.software/open-component-model/bindings/go/plugin/manager/registries/signinghandler"
+	"ocm.software/open-component-model/bindings/go/runtime"
+)
+
+func Register(
+	signingHandlerRegistry *signinghandler.SigningRegistry,
+	repositoryRegistry *credentialrepository.RepositoryRegistry,
+	_ *filesystemv1alpha1.Config,
+) error {
+	credScheme := runtime.NewScheme()
+	ecccredentialsv1alpha1.MustRegisterCredentialType(credScheme)
+	repositoryRegistry.Register(credScheme)
+
+	hdlr, err := handler.New(v1alpha1.Scheme, true)
+	if err != nil {
+		return err
+	}
+
+	return signingHandlerRegistry.RegisterInternalComponentSignatureHandler(hdlr)
+}
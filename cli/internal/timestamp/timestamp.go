@@ -0,0 +1,52 @@
+// Package timestamp verifies the RFC 3161 timestamps of signatures for the
+// commands that verify signatures.
+package timestamp
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+
+	descruntime "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	"ocm.software/open-component-model/bindings/go/signing/tsa"
+)
+
+// LoadRoots reads the PEM encoded root certificates of trusted time stamping
+// authorities at path into a pool. An empty path returns a nil pool, with which
+// signature timestamps are ignored.
+func LoadRoots(path string) (*x509.CertPool, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading certificates %q failed: %w", path, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %q", path)
+	}
+	return pool, nil
+}
+
+// VerificationContext returns the context to verify signature with. If the
+// signature carries a timestamp and roots are given, the timestamp is verified
+// against roots and the returned context validates certificate chains at the
+// time of the timestamp.
+func VerificationContext(ctx context.Context, logger *slog.Logger, signature descruntime.Signature, roots *x509.CertPool) (context.Context, error) {
+	switch {
+	case signature.Timestamp == nil:
+		return ctx, nil
+	case roots == nil:
+		logger.DebugContext(ctx, "ignoring signature timestamp, no TSA root certificates given", "name", signature.Name)
+		return ctx, nil
+	}
+	timestamp, err := tsa.Verify(signature, roots)
+	if err != nil {
+		return nil, fmt.Errorf("verifying timestamp of signature %q failed: %w", signature.Name, err)
+	}
+	logger.InfoContext(ctx, "verified signature timestamp", "name", signature.Name, "time", timestamp)
+	return tsa.WithVerifiedTime(ctx, timestamp), nil
+}
@@ -80,6 +80,25 @@ type Verification struct {
 	Value string `json:"value,omitempty"`
 }
 
+// SignatureVerificationPolicy defines which of the signatures of a component version
+// must be valid. The signatures listed in Verify are trusted, unless they match an
+// Optional pattern.
+type SignatureVerificationPolicy struct {
+	// Threshold is the minimum number of trusted signatures that must be valid.
+	// 0 requires all of them.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Threshold int `json:"threshold,omitempty"`
+	// Optional lists signature names or patterns (e.g. "legacy-*") of signatures in
+	// Verify that are verified if present on the component version, but whose
+	// verification failure does not fail the policy.
+	// +optional
+	Optional []string `json:"optional,omitempty"`
+	// Ignored lists signature names or patterns of signatures that are not verified.
+	// +optional
+	Ignored []string `json:"ignored,omitempty"`
+}
+
 // ResourceID defines the configuration of the repository.
 type ResourceID struct {
 	// +required
@@ -51,6 +51,13 @@ type ComponentSpec struct {
 	// +optional
 	Verify []Verification `json:"verify,omitempty"`
 
+	// VerifyPolicy relaxes the requirement that every signature in Verify must be
+	// valid. If set, only the threshold of required signatures must be valid and
+	// signatures of the component version that are neither listed in Verify nor
+	// ignored by the policy fail the verification.
+	// +optional
+	VerifyPolicy *SignatureVerificationPolicy `json:"verifyPolicy,omitempty"`
+
 	// OCMConfig defines references to secrets, config maps or ocm api
 	// objects providing configuration data including credentials.
 	// +optional
@@ -89,6 +96,36 @@ type ComponentStatus struct {
 	// in the order the configuration data was applied.
 	// +optional
 	EffectiveOCMConfig []OCMConfiguration `json:"effectiveOCMConfig,omitempty"`
+
+	// VerificationPolicy reports the outcome of every clause of the verification
+	// policy for the fetched component version, if a policy is configured.
+	// +optional
+	VerificationPolicy *VerificationPolicyStatus `json:"verificationPolicy,omitempty"`
+}
+
+// VerificationPolicyStatus is the evaluation of a SignatureVerificationPolicy.
+type VerificationPolicyStatus struct {
+	// Valid is the number of valid trusted signatures.
+	Valid int `json:"valid"`
+	// Required is the number of valid trusted signatures required by the policy.
+	Required int `json:"required"`
+	// Clauses holds the outcome of every clause of the policy.
+	// +optional
+	Clauses []VerificationPolicyClause `json:"clauses,omitempty"`
+}
+
+// VerificationPolicyClause is the outcome of one clause of a SignatureVerificationPolicy.
+type VerificationPolicyClause struct {
+	// Kind is one of "trusted", "optional", "ignored", "untrusted" or "threshold".
+	Kind string `json:"kind"`
+	// Signature is the name of the evaluated signature. Empty for the threshold clause.
+	// +optional
+	Signature string `json:"signature,omitempty"`
+	// Passed reports whether the clause is met.
+	Passed bool `json:"passed"`
+	// Reason explains the outcome of the clause.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // Component is the Schema for the components API.
@@ -156,6 +193,10 @@ func (in *Component) GetVerifications() []Verification {
 	return in.Spec.Verify
 }
 
+func (in *Component) GetVerificationPolicy() *SignatureVerificationPolicy {
+	return in.Spec.VerifyPolicy
+}
+
 // +kubebuilder:object:root=true
 
 // ComponentList contains a list of Component.
@@ -8,6 +8,10 @@ const (
 	ReconcilingCondition = "Reconciling"
 	// StalledCondition indicates the resource has stalled and will not be retried.
 	StalledCondition = "Stalled"
+	// TransferInProgressCondition indicates a replication is transferring a component version.
+	TransferInProgressCondition = "TransferInProgress"
+	// HealthyCondition indicates the objects deployed by a deployer are healthy.
+	HealthyCondition = "Healthy"
 )
 
 // Generic condition reasons.
@@ -39,6 +43,15 @@ const (
 	// ReplicationFailedReason is used when the referenced component is not Ready yet.
 	ReplicationFailedReason = "ReplicationFailed"
 
+	// TransferCompleteReason is used when a replication transferred the component version.
+	TransferCompleteReason = "TransferComplete"
+
+	// TransferringReason is used when a replication is transferring the component version.
+	TransferringReason = "Transferring"
+
+	// IdleReason is used when a replication is not transferring a component version.
+	IdleReason = "Idle"
+
 	// GetRepositoryFailedReason is used when the OCM repository cannot be fetched.
 	GetRepositoryFailedReason = "GetRepositoryFailed"
 
@@ -54,6 +67,27 @@ const (
 	// ApplyFailed is used when we fail to create or update a resource.
 	ApplyFailed = "ApplyFailed"
 
+	// GetHelmValuesFailedReason is used when we fail to get the values of a Helm chart.
+	GetHelmValuesFailedReason = "GetHelmValuesFailed"
+
+	// RenderHelmChartFailedReason is used when we fail to render a Helm chart.
+	RenderHelmChartFailedReason = "RenderHelmChartFailed"
+
+	// HealthCheckFailedReason is used when deployed objects failed or did not become healthy in time.
+	HealthCheckFailedReason = "HealthCheckFailed"
+
+	// HealthCheckInProgressReason is used when deployed objects are not healthy yet.
+	HealthCheckInProgressReason = "HealthCheckInProgress"
+
+	// InvalidHealthCheckReason is used when a custom health check expression is invalid.
+	InvalidHealthCheckReason = "InvalidHealthCheck"
+
+	// DependencyNotReadyReason is used when dependencies of a deployer are not ready yet.
+	DependencyNotReadyReason = "DependencyNotReady"
+
+	// DependencyCycleReason is used when the dependencies of a deployer form a cycle.
+	DependencyCycleReason = "DependencyCycle"
+
 	// GetReferenceFailedReason is used when we fail to get a reference.
 	GetReferenceFailedReason = "GetReferenceFailed"
 
@@ -25,4 +25,7 @@ const (
 	// RepositoryFinalizer makes sure that the OCM repository is only deleted when it is no longer referenced by any
 	// other component.
 	RepositoryFinalizer = "finalizers.ocm.software/repository"
+	// ReplicationFinalizer makes sure that an in-flight transfer of a replication is canceled before the replication
+	// is deleted.
+	ReplicationFinalizer = "delivery.ocm.software/replication-finalizer"
 )
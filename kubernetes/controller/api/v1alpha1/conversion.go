@@ -4,10 +4,11 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func (*Component) Hub()  {}
-func (*Deployer) Hub()   {}
-func (*Repository) Hub() {}
-func (*Resource) Hub()   {}
+func (*Component) Hub()   {}
+func (*Deployer) Hub()    {}
+func (*Replication) Hub() {}
+func (*Repository) Hub()  {}
+func (*Resource) Hub()    {}
 
 func (r *Component) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, r).Complete()
@@ -17,6 +18,10 @@ func (r *Deployer) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, r).Complete()
 }
 
+func (r *Replication) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, r).Complete()
+}
+
 func (r *Repository) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, r).Complete()
 }
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"fmt"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -10,6 +11,7 @@ import (
 const KindDeployer = "Deployer"
 
 // DeployerSpec defines the desired state of Deployer.
+// +kubebuilder:validation:XValidation:rule="!(has(self.helm) && has(self.kustomize))",message="helm and kustomize are mutually exclusive"
 type DeployerSpec struct {
 	// ResourceRef is the k8s resource name of an OCM resource containing the ResourceGroupDefinition.
 	// +required
@@ -24,6 +26,250 @@ type DeployerSpec struct {
 	// Resource.
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
+
+	// Helm renders the referenced resource as a Helm chart and applies the
+	// rendered manifests instead of decoding the resource as plain manifests.
+	// The resource can be a chart archive, a Helm resource downloaded from a
+	// Helm repository or an OCI artifact containing a chart.
+	// +optional
+	Helm *HelmDeployment `json:"helm,omitempty"`
+
+	// Kustomize builds the referenced resource as a kustomization and applies
+	// the built manifests. The resource must be a tar archive of a directory,
+	// e.g. created from a dir input. Such archives are built as kustomization
+	// even without this field.
+	// +optional
+	Kustomize *KustomizeDeployment `json:"kustomize,omitempty"`
+
+	// Health configures the health assessment of the deployed objects. The
+	// Deployer only becomes Ready once all deployed objects are healthy.
+	// +optional
+	Health *DeployerHealth `json:"health,omitempty"`
+
+	// DependsOn references Deployers or Resources that must be Ready before
+	// the referenced resource is deployed. Dependency cycles between
+	// Deployers are rejected.
+	// +optional
+	DependsOn []DeployerDependency `json:"dependsOn,omitempty"`
+}
+
+// DeployerDependency references a Deployer or Resource a Deployer depends on.
+type DeployerDependency struct {
+	// Kind of the dependency.
+	// +kubebuilder:validation:Enum=Deployer;Resource
+	// +kubebuilder:default=Deployer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the dependency.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of a Resource dependency, defaults to the namespace of the
+	// referenced Resource of the Deployer. Deployers are cluster-scoped, so
+	// the namespace is ignored for Deployer dependencies.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// DeployerHealth configures the health assessment of the deployed objects.
+//
+// Without custom expressions, objects are assessed by built-in rules similar
+// to kstatus: Deployments, StatefulSets and DaemonSets must be rolled out,
+// Jobs must be completed and custom resources must have a Ready condition
+// with status True, if they have one.
+type DeployerHealth struct {
+	// Disabled disables the health assessment. The Deployer becomes Ready as
+	// soon as the objects are applied.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Timeout is the time the deployed objects have to become healthy after
+	// they were changed by the Deployer. Objects that are still progressing
+	// after the timeout are considered failed. Defaults to 5m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Expressions are custom health checks for objects of a group and kind.
+	// They replace the built-in health assessment of these objects.
+	// +optional
+	Expressions []HealthCheckExpression `json:"expressions,omitempty"`
+}
+
+// HealthCheckExpression assesses the health of objects of a group and kind
+// with CEL expressions. The object is available as `self` to the
+// expressions. The expressions are evaluated in the order failed, current
+// and inProgress, the first expression evaluating to true determines the
+// health of the object. If no expression evaluates to true, the object is
+// considered progressing.
+type HealthCheckExpression struct {
+	// Group of the objects, empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Kind of the objects.
+	// +required
+	Kind string `json:"kind"`
+
+	// Current is a CEL expression that evaluates to true if the object is
+	// healthy.
+	// +required
+	Current string `json:"current"`
+
+	// InProgress is a CEL expression that evaluates to true if the object is
+	// still progressing.
+	// +optional
+	InProgress string `json:"inProgress,omitempty"`
+
+	// Failed is a CEL expression that evaluates to true if the object failed.
+	// +optional
+	Failed string `json:"failed,omitempty"`
+}
+
+// KustomizeDeployment configures the build of a kustomization.
+type KustomizeDeployment struct {
+	// Path is the path of the kustomization directory in the resource.
+	// Defaults to the root of the resource.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Namespace sets the namespace of all namespaced objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Patches are strategic merge or JSON 6902 patches applied to the built
+	// objects.
+	// +optional
+	Patches []KustomizePatch `json:"patches,omitempty"`
+
+	// Images override the names, tags or digests of container images.
+	// +optional
+	Images []KustomizeImage `json:"images,omitempty"`
+}
+
+// KustomizePatch is a strategic merge or JSON 6902 patch and the objects it
+// is applied to.
+type KustomizePatch struct {
+	// Patch is the content of the patch.
+	// +required
+	Patch string `json:"patch"`
+
+	// Target selects the objects the patch is applied to. Strategic merge
+	// patches select the object by their own metadata if no target is given.
+	// +optional
+	Target *KustomizePatchSelector `json:"target,omitempty"`
+}
+
+// KustomizePatchSelector selects the objects a patch is applied to. All given
+// fields must match.
+type KustomizePatchSelector struct {
+	// Group of the objects.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version of the objects.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Kind of the objects.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the objects, can be a regular expression.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the objects, can be a regular expression.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// LabelSelector selects the objects by their labels.
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// AnnotationSelector selects the objects by their annotations.
+	// +optional
+	AnnotationSelector string `json:"annotationSelector,omitempty"`
+}
+
+// KustomizeImage overrides a container image.
+type KustomizeImage struct {
+	// Name of the image to override.
+	// +required
+	Name string `json:"name"`
+
+	// NewName replaces the name of the image.
+	// +optional
+	NewName string `json:"newName,omitempty"`
+
+	// NewTag replaces the tag of the image.
+	// +optional
+	NewTag string `json:"newTag,omitempty"`
+
+	// Digest replaces the tag of the image with a digest.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// HelmDeployment configures the rendering of a Helm chart.
+type HelmDeployment struct {
+	// ReleaseName is the name of the release, available as .Release.Name to
+	// the chart templates. Defaults to the name of the Deployer.
+	// +kubebuilder:validation:MaxLength=53
+	// +optional
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	// Namespace is the namespace of the release, available as
+	// .Release.Namespace to the chart templates. Namespaced objects rendered
+	// without a namespace are deployed to it. Defaults to "default".
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ValuesFrom references config maps or secrets containing values for the
+	// chart. They are merged in the given order, later references take
+	// precedence.
+	// +optional
+	ValuesFrom []HelmValuesReference `json:"valuesFrom,omitempty"`
+
+	// Values holds values for the chart. They take precedence over the values
+	// from ValuesFrom.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Values *apiextensionsv1.JSON `json:"values,omitempty"`
+
+	// SkipCRDs skips the custom resource definitions in the crds directory
+	// of the chart.
+	// +optional
+	SkipCRDs bool `json:"skipCRDs,omitempty"`
+}
+
+// HelmValuesReference references a config map or secret containing values
+// for a Helm chart.
+type HelmValuesReference struct {
+	// Kind of the referent.
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// +required
+	Kind string `json:"kind"`
+
+	// Name of the referent.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the referent, defaults to the namespace of the referenced
+	// Resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ValuesKey is the data key of the values, defaults to "values.yaml".
+	// +optional
+	ValuesKey string `json:"valuesKey,omitempty"`
+
+	// Optional marks the reference as optional. A missing referent or key is
+	// ignored instead of failing the deployment.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
 }
 
 // DeployerStatus defines the observed state of Deployer.
@@ -46,6 +292,41 @@ type DeployerStatus struct {
 	// Deployed contains references to the objects that have been deployed by the Deployer through
 	// the Resource.
 	Deployed []DeployedObjectReference `json:"deployed,omitempty"`
+
+	// Helm contains the state of the Helm release, if the Deployer renders a
+	// Helm chart.
+	// +optional
+	Helm *HelmReleaseStatus `json:"helm,omitempty"`
+}
+
+// HelmReleaseStatus is the state of the Helm release applied by a Deployer.
+type HelmReleaseStatus struct {
+	// ReleaseName is the name of the release.
+	ReleaseName string `json:"releaseName"`
+
+	// Namespace is the namespace of the release.
+	Namespace string `json:"namespace"`
+
+	// ChartName is the name of the applied chart.
+	ChartName string `json:"chartName"`
+
+	// ChartVersion is the version of the applied chart.
+	ChartVersion string `json:"chartVersion"`
+
+	// AppVersion is the app version of the applied chart.
+	// +optional
+	AppVersion string `json:"appVersion,omitempty"`
+
+	// Revision is incremented whenever another chart or other values are
+	// applied. It is available as .Release.Revision to the chart templates.
+	Revision int `json:"revision"`
+
+	// Digest identifies the chart and values of the revision.
+	Digest string `json:"digest"`
+
+	// AppliedTime is the time the revision was first applied.
+	// +optional
+	AppliedTime metav1.Time `json:"appliedTime,omitempty"`
 }
 
 // DeployedObjectReference is a reference to an object that has been deployed by the Deployer.
@@ -48,4 +48,5 @@ type OCMK8SObject interface {
 type VerificationProvider interface {
 	GetNamespace() string
 	GetVerifications() []Verification
+	GetVerificationPolicy() *SignatureVerificationPolicy
 }
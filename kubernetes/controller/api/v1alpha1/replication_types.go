@@ -0,0 +1,179 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const KindReplication = "Replication"
+
+// TransferConfigKey defines the config map key to look for in case a user references a transfer config.
+const TransferConfigKey = "transferConfig"
+
+// ReplicationSpec defines the desired state of Replication.
+type ReplicationSpec struct {
+	// ComponentRef is a reference to the Component whose latest resolved
+	// version is replicated.
+	// +required
+	ComponentRef ObjectKey `json:"componentRef"`
+
+	// TargetRepositoryRef is a reference to the Repository the component
+	// version is transferred to.
+	// +required
+	TargetRepositoryRef ObjectKey `json:"targetRepositoryRef"`
+
+	// TransferConfig configures the transfer, for example whether referenced
+	// component versions or resources are transferred as well.
+	// +optional
+	TransferConfig *TransferConfigReference `json:"transferConfig,omitempty"`
+
+	// OCMConfig defines references to secrets, config maps or ocm api
+	// objects providing configuration data including credentials for the
+	// source and the target repository.
+	// +optional
+	OCMConfig []OCMConfiguration `json:"ocmConfig,omitempty"`
+
+	// Interval at which the replication is checked even if neither the
+	// component nor the Replication changed.
+	// +required
+	Interval metav1.Duration `json:"interval"`
+
+	// Suspend tells the controller to suspend the reconciliation of this
+	// Replication.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// TransferConfigReference references a config map containing a transfer
+// config under the key "transferConfig" or inlines the transfer config.
+// The transfer config is a generic ocm config containing entries of type
+// transfer.config.ocm.software/v1alpha1.
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.inlined)",message="exactly one of name or inlined must be set"
+type TransferConfigReference struct {
+	// Name of the config map containing the transfer config.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the config map, defaults to the namespace of the Replication.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Inlined is the transfer config.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Inlined *apiextensionsv1.JSON `json:"inlined,omitempty"`
+}
+
+// ReplicationStatus defines the observed state of Replication.
+type ReplicationStatus struct {
+	// ObservedGeneration is the last observed generation of the Replication
+	// object.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions holds the conditions for the Replication.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastTransferredVersion is the component version transferred by the last
+	// successful transfer.
+	// +optional
+	LastTransferredVersion string `json:"lastTransferredVersion,omitempty"`
+
+	// LastTransferredDigest is the digest of the component version transferred
+	// by the last successful transfer, e.g. "sha256:...".
+	// +optional
+	LastTransferredDigest string `json:"lastTransferredDigest,omitempty"`
+
+	// ComponentInfo is the currently observed source component version. If it
+	// differs from the last transferred version, a transfer is pending,
+	// in progress or failed.
+	// +optional
+	ComponentInfo *ComponentInfo `json:"componentInfo,omitempty"`
+
+	// EffectiveOCMConfig specifies the entirety of config maps and secrets
+	// whose configuration data was applied to the Replication reconciliation,
+	// in the order the configuration data was applied.
+	// +optional
+	EffectiveOCMConfig []OCMConfiguration `json:"effectiveOCMConfig,omitempty"`
+}
+
+// Replication is the Schema for the replications API.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].message`,description="Indicates if the Replication is Ready",priority=1
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.lastTransferredVersion`,description="Displays the last transferred version"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Displays the Age of the Replication"
+type Replication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReplicationSpec   `json:"spec"`
+	Status ReplicationStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the conditions of the Replication.
+func (in *Replication) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the conditions of the Replication.
+func (in *Replication) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+// GetVID unique identifier of the object.
+func (in *Replication) GetVID() map[string]string {
+	vid := fmt.Sprintf("%s:%s", in.GetNamespace(), in.GetName())
+	metadata := make(map[string]string)
+	metadata[GroupVersion.Group+"/replication"] = vid
+
+	return metadata
+}
+
+func (in *Replication) SetObservedGeneration(v int64) {
+	in.Status.ObservedGeneration = v
+}
+
+func (in *Replication) GetObjectMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+func (in *Replication) GetKind() string {
+	return KindReplication
+}
+
+// GetRequeueAfter returns the duration after which the Replication must be
+// reconciled again.
+func (in *Replication) GetRequeueAfter() time.Duration {
+	if in == nil {
+		return 0
+	}
+	return in.Spec.Interval.Duration
+}
+
+func (in *Replication) GetSpecifiedOCMConfig() []OCMConfiguration {
+	return in.Spec.OCMConfig
+}
+
+func (in *Replication) GetEffectiveOCMConfig() []OCMConfiguration {
+	return in.Status.EffectiveOCMConfig
+}
+
+// +kubebuilder:object:root=true
+
+// ReplicationList contains a list of Replication.
+type ReplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Replication `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Replication{}, &ReplicationList{})
+}
@@ -46,6 +46,7 @@ import (
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/cache"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/dynamic"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/replication"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/repository"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/resource"
 	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
@@ -92,6 +93,9 @@ func main() {
 		resolverWorkerQueueLength int
 		resolverSubscriberBuffer  int
 		resolverCacheTTL          int
+		replicationWorkerCount    int
+		replicationQueueLength    int
+		replicationDrainTimeout   time.Duration
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metric endpoint binds to. "+
@@ -119,6 +123,12 @@ func main() {
 			"Tune upward if the resolver_event_channel_drops_total metric is non-zero.")
 	flag.IntVar(&resolverCacheTTL, "resolver-cache-ttl", 30, //nolint:mnd // no magic number
 		"The time-to-live (TTL) for the resolver cache entries in minutes. Setting TTL to less than 30 minutes is discouraged in productive use as it can lead to unintended performance issues.")
+	flag.IntVar(&replicationWorkerCount, "replication-worker-count", 4, //nolint:mnd // no magic number
+		"This is the number of active replication workers transferring component versions.")
+	flag.IntVar(&replicationQueueLength, "replication-worker-queue-length", 100, //nolint:mnd // no magic number
+		"The maximum number of work items in the queue for the replication workers to pick up component versions to transfer from.")
+	flag.DurationVar(&replicationDrainTimeout, "replication-drain-timeout", replication.DefaultDrainTimeout,
+		"The time the deletion of a Replication waits for its canceled in-flight transfer to stop.")
 
 	opts := zap.Options{
 		Development: true,
@@ -281,6 +291,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Transfers run in their own worker pool, so that they do not block component version resolutions.
+	transferPool := workerpool.NewWorkerPool(workerpool.PoolOptions{
+		WorkerCount:          replicationWorkerCount,
+		QueueSize:            replicationQueueLength,
+		SubscriberBufferSize: resolverSubscriberBuffer,
+		Logger:               &setupLog,
+		Client:               mgr.GetClient(),
+		Cache:                expirable.NewLRU[string, *workerpool.Result](unlimited, nil, ttl),
+	})
+	if err := mgr.Add(transferPool); err != nil {
+		setupLog.Error(err, "unable to add transfer worker pool")
+		os.Exit(1)
+	}
+
 	// TODO: migrate to mgr.GetEventRecorder() once BaseReconciler uses events.EventRecorder
 	eventsRecorder := mgr.GetEventRecorderFor("ocm-k8s-toolkit") //nolint:staticcheck,nolintlint
 
@@ -338,6 +362,21 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Deployer")
 		os.Exit(1)
 	}
+
+	if err = (&replication.Reconciler{
+		BaseReconciler: &ocm.BaseReconciler{
+			Client:        mgr.GetClient(),
+			Scheme:        mgr.GetScheme(),
+			EventRecorder: eventsRecorder,
+		},
+		Resolver:      resolver,
+		TransferPool:  transferPool,
+		PluginManager: pm,
+		DrainTimeout:  replicationDrainTimeout,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Replication")
+		os.Exit(1)
+	}
 	if err = (&v1alpha1.Component{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Component")
 		os.Exit(1)
@@ -346,6 +385,10 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Deployer")
 		os.Exit(1)
 	}
+	if err = (&v1alpha1.Replication{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Replication")
+		os.Exit(1)
+	}
 	if err = (&v1alpha1.Repository{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Repository")
 		os.Exit(1)
@@ -42,3 +42,18 @@ func ComponentInfoEnv(component *v1alpha1.ComponentInfo) (*cel.Env, error) {
 
 	return ociEnv, nil
 }
+
+// ObjectEnv constructs a CEL environment with a Kubernetes object available as the variable `self`.
+func ObjectEnv() (*cel.Env, error) {
+	env, err := sharedEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load shared cel environment: %w", err)
+	}
+
+	objectEnv, err := env.Extend(cel.Variable("self", cel.DynType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to extend shared cel environment: %w", err)
+	}
+
+	return objectEnv, nil
+}
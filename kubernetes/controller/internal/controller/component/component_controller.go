@@ -260,6 +260,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, fmt.Errorf("failed to get verifications: %w", err)
 	}
 
+	verificationPolicy, err := verification.GetPolicy(component)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, component, v1alpha1.GetComponentVersionFailedReason, err.Error())
+
+		return ctrl.Result{}, fmt.Errorf("failed to get verification policy: %w", err)
+	}
+
 	cfg, err := configuration.LoadConfigurations(ctx, r.Client, component.GetNamespace(), configs)
 	if err != nil {
 		status.MarkNotReady(r.EventRecorder, component, v1alpha1.GetComponentVersionFailedReason, err.Error())
@@ -268,10 +275,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	}
 
 	cacheBackedRepo, err := r.Resolver.NewCacheBackedRepository(ctx, &resolution.RepositoryOptions{
-		RepositorySpec:  repoSpec,
-		Configuration:   cfg,
-		SigningRegistry: r.PluginManager.SigningRegistry,
-		Verifications:   verifications,
+		RepositorySpec:     repoSpec,
+		Configuration:      cfg,
+		SigningRegistry:    r.PluginManager.SigningRegistry,
+		Verifications:      verifications,
+		VerificationPolicy: verificationPolicy,
 		RequesterFunc: func() workerpool.RequesterInfo {
 			return workerpool.RequesterInfo{
 				NamespacedName: types.NamespacedName{
@@ -294,7 +302,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, fmt.Errorf("failed to determine effective version: %w", err)
 	}
 
-	desc, err := cacheBackedRepo.GetComponentVersion(ctx, component.Spec.Component, version)
+	verified, err := cacheBackedRepo.GetVerifiedComponentVersion(ctx, component.Spec.Component, version)
 	switch {
 	case errors.Is(err, workerpool.ErrResolutionInProgress):
 		// Resolution is in progress, the controller will be re-triggered via event source when resolution completes
@@ -309,11 +317,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		event.New(r.EventRecorder, component, nil, v1alpha1.EventSeverityError, "%s", err.Error())
 	default:
 		if err != nil {
+			// A failed verification policy is reported with all its clauses in the status and the condition message.
+			component.Status.VerificationPolicy = nil
+			if policyErr, ok := errors.AsType[*verification.PolicyError](err); ok {
+				component.Status.VerificationPolicy = policyStatus(policyErr.Result)
+			}
 			status.MarkNotReady(r.EventRecorder, component, v1alpha1.GetComponentVersionFailedReason, err.Error())
 
 			return ctrl.Result{}, fmt.Errorf("failed to get component version: %w", err)
 		}
 	}
+	desc := verified.Descriptor
 
 	digestSpec, err := signing.GenerateDigest(ctx, desc, slog.New(logr.ToSlogHandler(logger)), signing.LegacyNormalisationAlgo, crypto.SHA256.String())
 	if err != nil {
@@ -333,8 +347,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 			Value:                  digestSpec.Value,
 		},
 	}
+	component.Status.VerificationPolicy = policyStatus(verified.PolicyResult)
 
-	status.MarkReady(r.EventRecorder, component, "Applied version %s", version)
+	if result := verified.PolicyResult; result != nil {
+		status.MarkReady(r.EventRecorder, component, "Applied version %s (verification policy: %d of %d required trusted signatures valid)",
+			version, result.Valid, result.Required)
+	} else {
+		status.MarkReady(r.EventRecorder, component, "Applied version %s", version)
+	}
 
 	return status.RequeueResult(component, component.GetRequeueAfter()), nil
 }
@@ -416,3 +436,26 @@ func (r *Reconciler) DetermineEffectiveVersionFromRepo(ctx context.Context, comp
 
 	return ocm.ApplyDowngradePolicy(component, latestSemver)
 }
+
+// policyStatus converts the evaluation of a verification policy into its status representation.
+func policyStatus(result *verification.PolicyResult) *v1alpha1.VerificationPolicyStatus {
+	if result == nil {
+		return nil
+	}
+
+	clauses := make([]v1alpha1.VerificationPolicyClause, 0, len(result.Clauses))
+	for _, clause := range result.Clauses {
+		clauses = append(clauses, v1alpha1.VerificationPolicyClause{
+			Kind:      clause.Kind,
+			Signature: clause.Signature,
+			Passed:    clause.Passed,
+			Reason:    clause.Reason,
+		})
+	}
+
+	return &v1alpha1.VerificationPolicyStatus{
+		Valid:    result.Valid,
+		Required: result.Required,
+		Clauses:  clauses,
+	}
+}
@@ -0,0 +1,242 @@
+package deployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+	"ocm.software/open-component-model/kubernetes/controller/internal/util"
+)
+
+// dependencyRequeueInterval is the interval a deployer is requeued at while its dependencies are not ready. The
+// deployer is also enqueued when a dependency changes, the interval only guards against missed events.
+const dependencyRequeueInterval = 30 * time.Second
+
+// checkDependencies checks that all dependencies of the deployer are ready. It reports whether the deployer can be
+// deployed. While dependencies are pending, the deployer is marked as not ready and requeued. Dependency cycles
+// between deployers can only be resolved by changing a deployer, so the deployer is not requeued in that case.
+func (r *Reconciler) checkDependencies(ctx context.Context, deployer *deliveryv1alpha1.Deployer) (ctrl.Result, bool, error) {
+	if len(deployer.Spec.DependsOn) == 0 {
+		return ctrl.Result{}, true, nil
+	}
+
+	cycle, err := r.findDependencyCycle(ctx, deployer)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.DependencyNotReadyReason, err.Error())
+
+		return ctrl.Result{}, false, err
+	}
+	if cycle != nil {
+		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.DependencyCycleReason,
+			fmt.Sprintf("dependency cycle detected: %s", strings.Join(cycle, " -> ")))
+
+		return ctrl.Result{}, false, nil
+	}
+
+	var pending []string
+	for _, dependency := range deployer.Spec.DependsOn {
+		reason, err := r.dependencyNotReadyReason(ctx, deployer, dependency)
+		if err != nil {
+			status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.DependencyNotReadyReason, err.Error())
+
+			return ctrl.Result{}, false, err
+		}
+		if reason != "" {
+			pending = append(pending, fmt.Sprintf("%s: %s", dependencyString(deployer, dependency), reason))
+		}
+	}
+
+	if len(pending) > 0 {
+		r.markWaiting(deployer, deliveryv1alpha1.DependencyNotReadyReason,
+			fmt.Sprintf("waiting for dependencies: %s", strings.Join(pending, "; ")))
+
+		return ctrl.Result{RequeueAfter: dependencyRequeueInterval}, false, nil
+	}
+
+	return ctrl.Result{}, true, nil
+}
+
+// dependencyNotReadyReason returns why the dependency is not ready, or an empty string if it is ready. Deployer
+// dependencies must additionally have observed their latest generation, so changes of a dependency are deployed
+// before its dependents.
+func (r *Reconciler) dependencyNotReadyReason(
+	ctx context.Context,
+	deployer *deliveryv1alpha1.Deployer,
+	dependency deliveryv1alpha1.DeployerDependency,
+) (string, error) {
+	key := dependencyKey(deployer, dependency)
+
+	var err error
+	switch dependencyKind(dependency) {
+	case deliveryv1alpha1.KindResource:
+		_, err = util.GetReadyObject[deliveryv1alpha1.Resource, *deliveryv1alpha1.Resource](ctx, r.Client, key)
+	default:
+		var dependencyDeployer *deliveryv1alpha1.Deployer
+		dependencyDeployer, err = util.GetReadyObject[deliveryv1alpha1.Deployer, *deliveryv1alpha1.Deployer](ctx, r.Client, key)
+		if err == nil && dependencyDeployer.Status.ObservedGeneration != dependencyDeployer.GetGeneration() {
+			return "latest generation is not deployed yet", nil
+		}
+	}
+
+	if err == nil {
+		return "", nil
+	}
+	if apierrors.IsNotFound(err) {
+		return "not found", nil
+	}
+	if _, ok := errors.AsType[util.NotReadyError](err); ok {
+		return "not ready", nil
+	}
+	if _, ok := errors.AsType[util.DeletionError](err); ok {
+		return "being deleted", nil
+	}
+
+	return "", fmt.Errorf("failed to get dependency %s: %w", dependencyString(deployer, dependency), err)
+}
+
+// findDependencyCycle follows the deployer dependencies of the deployer and returns the names of the deployers forming
+// a cycle back to the deployer, or nil if there is none. Resources cannot depend on deployers, so they cannot be part
+// of a cycle. Missing deployers end a path, they are reported as pending dependencies instead.
+func (r *Reconciler) findDependencyCycle(ctx context.Context, deployer *deliveryv1alpha1.Deployer) ([]string, error) {
+	visited := map[string]bool{}
+
+	var visit func(current *deliveryv1alpha1.Deployer, path []string) ([]string, error)
+	visit = func(current *deliveryv1alpha1.Deployer, path []string) ([]string, error) {
+		for _, dependency := range current.Spec.DependsOn {
+			if dependencyKind(dependency) != deliveryv1alpha1.KindDeployer {
+				continue
+			}
+
+			if dependency.Name == deployer.GetName() {
+				return append(slices.Clip(path), dependency.Name), nil
+			}
+			if visited[dependency.Name] {
+				continue
+			}
+			visited[dependency.Name] = true
+
+			next := &deliveryv1alpha1.Deployer{}
+			if err := r.Get(ctx, client.ObjectKey{Name: dependency.Name}, next); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+
+				return nil, fmt.Errorf("failed to get dependency Deployer %s: %w", dependency.Name, err)
+			}
+
+			if cycle, err := visit(next, append(slices.Clip(path), dependency.Name)); cycle != nil || err != nil {
+				return cycle, err
+			}
+		}
+
+		return nil, nil
+	}
+
+	return visit(deployer, []string{deployer.GetName()})
+}
+
+// dependencyKind returns the kind of the dependency, which defaults to Deployer.
+func dependencyKind(dependency deliveryv1alpha1.DeployerDependency) string {
+	if dependency.Kind == "" {
+		return deliveryv1alpha1.KindDeployer
+	}
+
+	return dependency.Kind
+}
+
+// dependencyKey returns the object key of the dependency. Deployers are cluster-scoped, Resources default to the
+// namespace of the Resource referenced by the deployer.
+func dependencyKey(deployer *deliveryv1alpha1.Deployer, dependency deliveryv1alpha1.DeployerDependency) client.ObjectKey {
+	if dependencyKind(dependency) == deliveryv1alpha1.KindDeployer {
+		return client.ObjectKey{Name: dependency.Name}
+	}
+
+	namespace := dependency.Namespace
+	if namespace == "" {
+		namespace = deployer.Spec.ResourceRef.Namespace
+	}
+	if namespace == "" {
+		namespace = deployer.GetNamespace()
+	}
+
+	return client.ObjectKey{Namespace: namespace, Name: dependency.Name}
+}
+
+// dependencyString returns the kind and the name of the dependency, prefixed by the namespace for Resources.
+func dependencyString(deployer *deliveryv1alpha1.Deployer, dependency deliveryv1alpha1.DeployerDependency) string {
+	key := dependencyKey(deployer, dependency)
+	if key.Namespace == "" {
+		return fmt.Sprintf("%s %s", dependencyKind(dependency), key.Name)
+	}
+
+	return fmt.Sprintf("%s %s", dependencyKind(dependency), key)
+}
+
+func dependsOnIndexKeys(deployer *deliveryv1alpha1.Deployer) []string {
+	keys := make([]string, 0, len(deployer.Spec.DependsOn))
+	for _, dependency := range deployer.Spec.DependsOn {
+		key := dependencyKey(deployer, dependency)
+		keys = append(keys, dependsOnIndexKey(dependencyKind(dependency), key.Namespace, key.Name))
+	}
+
+	return keys
+}
+
+func dependsOnIndexKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// deployersForDependency returns a map function that creates reconciliation requests for the deployers depending on
+// the deployer or resource.
+func (r *Reconciler) deployersForDependency(fieldName, kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := &deliveryv1alpha1.DeployerList{}
+		if err := r.List(
+			ctx,
+			list,
+			client.MatchingFields{fieldName: dependsOnIndexKey(kind, obj.GetNamespace(), obj.GetName())},
+		); err != nil {
+			return []reconcile.Request{}
+		}
+
+		requests := make([]reconcile.Request, 0, len(list.Items))
+		for _, deployer := range list.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&deployer)})
+		}
+
+		return requests
+	}
+}
+
+// deployerReadinessChangedPredicate passes updates of deployers that can change whether they are ready for their
+// dependents, i.e. changes of the Ready condition or of the observed generation.
+func deployerReadinessChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDeployer, ok := e.ObjectOld.(*deliveryv1alpha1.Deployer)
+			if !ok {
+				return false
+			}
+			newDeployer, ok := e.ObjectNew.(*deliveryv1alpha1.Deployer)
+			if !ok {
+				return false
+			}
+
+			return status.IsReady(oldDeployer) != status.IsReady(newDeployer) ||
+				oldDeployer.Status.ObservedGeneration != newDeployer.Status.ObservedGeneration
+		},
+	}
+}
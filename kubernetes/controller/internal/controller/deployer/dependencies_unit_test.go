@@ -0,0 +1,151 @@
+package deployer
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+)
+
+func newDependencyTestReconciler(t *testing.T, objs ...client.Object) *Reconciler {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	NewWithT(t).Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	return &Reconciler{
+		BaseReconciler: &ocm.BaseReconciler{
+			Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Scheme:        scheme,
+			EventRecorder: &record.FakeRecorder{Events: make(chan string, 100)},
+		},
+	}
+}
+
+func newDependencyTestDeployer(name string, ready bool, dependsOn ...v1alpha1.DeployerDependency) *v1alpha1.Deployer {
+	deployer := &v1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Generation: 1},
+		Spec: v1alpha1.DeployerSpec{
+			ResourceRef: v1alpha1.ObjectKey{Name: name, Namespace: "apps"},
+			DependsOn:   dependsOn,
+		},
+	}
+	if ready {
+		deployer.Status.ObservedGeneration = 1
+		status.SetCondition(deployer, metav1.Condition{Type: v1alpha1.ReadyCondition, Status: metav1.ConditionTrue, Reason: v1alpha1.SucceededReason})
+	}
+
+	return deployer
+}
+
+func TestCheckDependencies(t *testing.T) {
+	crds := newDependencyTestDeployer("crds", true)
+	operator := newDependencyTestDeployer("operator", false, v1alpha1.DeployerDependency{Name: "crds"})
+	resource := &v1alpha1.Resource{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "apps"}}
+
+	t.Run("ready dependencies", func(t *testing.T) {
+		g := NewWithT(t)
+		deployer := newDependencyTestDeployer("app", false, v1alpha1.DeployerDependency{Name: "crds"})
+
+		result, ready, err := newDependencyTestReconciler(t, crds).checkDependencies(t.Context(), deployer)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ready).To(BeTrue())
+		g.Expect(result.RequeueAfter).To(BeZero())
+	})
+
+	t.Run("pending dependencies", func(t *testing.T) {
+		g := NewWithT(t)
+		deployer := newDependencyTestDeployer("app", false,
+			v1alpha1.DeployerDependency{Name: "operator"},
+			v1alpha1.DeployerDependency{Kind: v1alpha1.KindResource, Name: "config"},
+			v1alpha1.DeployerDependency{Name: "missing"},
+		)
+
+		result, ready, err := newDependencyTestReconciler(t, crds, operator, resource).checkDependencies(t.Context(), deployer)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ready).To(BeFalse())
+		g.Expect(result.RequeueAfter).To(Equal(dependencyRequeueInterval))
+
+		cond := status.FindCondition(deployer, v1alpha1.ReadyCondition)
+		g.Expect(cond).NotTo(BeNil())
+		g.Expect(cond.Reason).To(Equal(v1alpha1.DependencyNotReadyReason))
+		g.Expect(cond.Message).To(Equal("waiting for dependencies: Deployer operator: not ready; Resource apps/config: not ready; Deployer missing: not found"))
+	})
+
+	t.Run("dependency with undeployed generation", func(t *testing.T) {
+		g := NewWithT(t)
+		outdated := newDependencyTestDeployer("crds", true)
+		outdated.Generation = 2
+		deployer := newDependencyTestDeployer("app", false, v1alpha1.DeployerDependency{Name: "crds"})
+
+		_, ready, err := newDependencyTestReconciler(t, outdated).checkDependencies(t.Context(), deployer)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ready).To(BeFalse())
+		g.Expect(status.FindCondition(deployer, v1alpha1.ReadyCondition).Message).To(ContainSubstring("latest generation is not deployed yet"))
+	})
+
+	t.Run("dependency cycle", func(t *testing.T) {
+		g := NewWithT(t)
+		a := newDependencyTestDeployer("a", false, v1alpha1.DeployerDependency{Name: "b"})
+		b := newDependencyTestDeployer("b", false, v1alpha1.DeployerDependency{Name: "c"}, v1alpha1.DeployerDependency{Name: "crds"})
+		c := newDependencyTestDeployer("c", false, v1alpha1.DeployerDependency{Name: "a"})
+
+		result, ready, err := newDependencyTestReconciler(t, a, b, c, crds).checkDependencies(t.Context(), a)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ready).To(BeFalse())
+		g.Expect(result.RequeueAfter).To(BeZero())
+
+		cond := status.FindCondition(a, v1alpha1.ReadyCondition)
+		g.Expect(cond).NotTo(BeNil())
+		g.Expect(cond.Reason).To(Equal(v1alpha1.DependencyCycleReason))
+		g.Expect(cond.Message).To(Equal("dependency cycle detected: a -> b -> c -> a"))
+	})
+
+	t.Run("self dependency", func(t *testing.T) {
+		g := NewWithT(t)
+		deployer := newDependencyTestDeployer("app", false, v1alpha1.DeployerDependency{Name: "app"})
+
+		_, ready, err := newDependencyTestReconciler(t, deployer).checkDependencies(t.Context(), deployer)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ready).To(BeFalse())
+		g.Expect(status.FindCondition(deployer, v1alpha1.ReadyCondition).Reason).To(Equal(v1alpha1.DependencyCycleReason))
+	})
+
+	t.Run("cycle not including the deployer", func(t *testing.T) {
+		g := NewWithT(t)
+		b := newDependencyTestDeployer("b", false, v1alpha1.DeployerDependency{Name: "c"})
+		c := newDependencyTestDeployer("c", false, v1alpha1.DeployerDependency{Name: "b"})
+		deployer := newDependencyTestDeployer("app", false, v1alpha1.DeployerDependency{Name: "b"})
+
+		_, ready, err := newDependencyTestReconciler(t, b, c).checkDependencies(t.Context(), deployer)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ready).To(BeFalse())
+		g.Expect(status.FindCondition(deployer, v1alpha1.ReadyCondition).Reason).To(Equal(v1alpha1.DependencyNotReadyReason))
+	})
+}
+
+func TestDependsOnIndexKeys(t *testing.T) {
+	g := NewWithT(t)
+
+	deployer := newDependencyTestDeployer("app", false,
+		v1alpha1.DeployerDependency{Name: "crds"},
+		v1alpha1.DeployerDependency{Kind: v1alpha1.KindDeployer, Name: "operator", Namespace: "ignored"},
+		v1alpha1.DeployerDependency{Kind: v1alpha1.KindResource, Name: "config"},
+		v1alpha1.DeployerDependency{Kind: v1alpha1.KindResource, Name: "shared", Namespace: "infra"},
+	)
+
+	g.Expect(dependsOnIndexKeys(deployer)).To(Equal([]string{
+		"Deployer//crds",
+		"Deployer//operator",
+		"Resource/apps/config",
+		"Resource/infra/shared",
+	}))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/go-logr/logr"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	apiresource "k8s.io/apimachinery/pkg/api/resource"
@@ -42,6 +43,7 @@ import (
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/applyset"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/cache"
 	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/dynamic"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/kustomize"
 	"ocm.software/open-component-model/kubernetes/controller/internal/event"
 	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
 	"ocm.software/open-component-model/kubernetes/controller/internal/resolution"
@@ -102,6 +104,7 @@ var _ ocm.Reconciler = (*Reconciler)(nil)
 // +kubebuilder:rbac:groups=delivery.ocm.software,resources=deployers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=delivery.ocm.software,resources=deployers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=delivery.ocm.software,resources=deployers/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets;configmaps,verbs=get;list;watch
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
@@ -132,6 +135,42 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) err
 		return err
 	}
 
+	// Build index for deployers that reference config maps or secrets for Helm values to get notified about changes.
+	const helmValuesFieldName = ".spec.helm.valuesFrom"
+	if err := mgr.GetFieldIndexer().IndexField(
+		ctx,
+		&deliveryv1alpha1.Deployer{},
+		helmValuesFieldName,
+		func(obj client.Object) []string {
+			deployer, ok := obj.(*deliveryv1alpha1.Deployer)
+			if !ok {
+				return nil
+			}
+
+			return helmValuesIndexKeys(deployer)
+		},
+	); err != nil {
+		return err
+	}
+
+	// Build index for deployers that depend on other deployers or resources to get notified when they become ready.
+	const dependsOnFieldName = ".spec.dependsOn"
+	if err := mgr.GetFieldIndexer().IndexField(
+		ctx,
+		&deliveryv1alpha1.Deployer{},
+		dependsOnFieldName,
+		func(obj client.Object) []string {
+			deployer, ok := obj.(*deliveryv1alpha1.Deployer)
+			if !ok {
+				return nil
+			}
+
+			return dependsOnIndexKeys(deployer)
+		},
+	); err != nil {
+		return err
+	}
+
 	eventSource := workerpool.NewEventSource(r.Resolver.WorkerPool())
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&deliveryv1alpha1.Deployer{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
@@ -169,6 +208,25 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) err
 
 				return requests
 			})).
+		// Watch for deployers and resources that other deployers depend on
+		Watches(
+			&deliveryv1alpha1.Deployer{},
+			handler.EnqueueRequestsFromMapFunc(r.deployersForDependency(dependsOnFieldName, deliveryv1alpha1.KindDeployer)),
+			builder.WithPredicates(deployerReadinessChangedPredicate()),
+		).
+		Watches(
+			&deliveryv1alpha1.Resource{},
+			handler.EnqueueRequestsFromMapFunc(r.deployersForDependency(dependsOnFieldName, deliveryv1alpha1.KindResource)),
+		).
+		// Watch for changes of config maps and secrets that are referenced for Helm values
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.deployersForHelmValues(helmValuesFieldName, "ConfigMap")),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.deployersForHelmValues(helmValuesFieldName, "Secret")),
+		).
 		WithOptions(controller.Options{
 			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
 				workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](5*time.Millisecond, 5*time.Minute),
@@ -331,9 +389,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	return r.reconcileDeployment(ctx, deployer)
 }
 
-// reconcileDeployment orchestrates the main deployment pipeline: resolve the referenced resource,
-// load configuration, download the OCM resource, apply it, and track the deployed objects.
+// reconcileDeployment orchestrates the main deployment pipeline: wait for the dependencies, resolve the referenced
+// resource, load configuration, download the OCM resource (and render or build it, if it is a Helm chart or a
+// kustomization), apply it, track the deployed objects and assess their health.
 func (r *Reconciler) reconcileDeployment(ctx context.Context, deployer *deliveryv1alpha1.Deployer) (ctrl.Result, error) {
+	if result, ready, err := r.checkDependencies(ctx, deployer); !ready {
+		return result, err
+	}
+
 	resource, err := r.resolveResource(ctx, deployer)
 	if resource == nil || err != nil {
 		return ctrl.Result{}, err
@@ -356,16 +419,36 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, deployer *delivery
 
 	key := buildResourceCacheKey(matchedResource, componentDescriptor, cfg, resource.Spec.Resource.ByReference.Resource.String())
 
-	objs, err := r.DownloadCache.Load(key, func() ([]*unstructured.Unstructured, error) {
-		return r.DownloadResourceWithOCM(ctx, cacheBackedRepo, componentDescriptor, matchedResource, cfg)
-	})
-	if err != nil {
-		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.GetOCMResourceFailedReason, err.Error())
+	var (
+		objs    []*unstructured.Unstructured
+		release *deliveryv1alpha1.HelmReleaseStatus
+	)
+	if deployer.Spec.Helm != nil {
+		objs, release, err = r.renderHelmChart(ctx, deployer, resource, cacheBackedRepo, componentDescriptor, matchedResource, cfg, key)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	} else {
+		if deployer.Spec.Kustomize != nil {
+			if key, err = kustomizationCacheKey(key, deployer.Spec.Kustomize); err != nil {
+				status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.MarshalFailedReason, err.Error())
 
-		return ctrl.Result{}, fmt.Errorf("failed to download resource from OCM or retrieve it from the cache: %w", err)
+				return ctrl.Result{}, err
+			}
+		}
+
+		objs, err = r.DownloadCache.Load(key, func() ([]*unstructured.Unstructured, error) {
+			return r.DownloadResourceWithOCM(ctx, cacheBackedRepo, componentDescriptor, matchedResource, cfg, deployer.Spec.Kustomize)
+		})
+		if err != nil {
+			status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.GetOCMResourceFailedReason, err.Error())
+
+			return ctrl.Result{}, fmt.Errorf("failed to download resource from OCM or retrieve it from the cache: %w", err)
+		}
 	}
 
-	if err = r.applyWithApplySet(ctx, resource, deployer, objs); err != nil {
+	applied, err := r.applyWithApplySet(ctx, resource, deployer, objs)
+	if err != nil {
 		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.ApplyFailed, err.Error())
 
 		return ctrl.Result{}, fmt.Errorf("failed to apply resources: %w", err)
@@ -379,6 +462,19 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, deployer *delivery
 	}
 
 	updateDeployedObjectStatusReferences(objs, deployer)
+	deployer.Status.Helm = release
+
+	if result, healthy := r.assessHealth(ctx, deployer, applied.Applied); !healthy {
+		return result, nil
+	}
+
+	if release != nil {
+		status.MarkReady(r.EventRecorder, deployer, "Applied %s:%s, resource %s as release %s/%s revision %d",
+			componentDescriptor.Component.Name, componentDescriptor.Component.Version, matchedResource.Name,
+			release.Namespace, release.ReleaseName, release.Revision)
+
+		return ctrl.Result{}, nil
+	}
 
 	status.MarkReady(r.EventRecorder, deployer, "Applied %s:%s, resource %s",
 		componentDescriptor.Component.Name, componentDescriptor.Component.Version, matchedResource.Name)
@@ -602,43 +698,63 @@ func (r *Reconciler) DownloadResourceWithOCM(
 	componentDescriptor *descriptor.Descriptor,
 	resource *descriptor.Resource,
 	cfg *configuration.Configuration,
+	kustomization *deliveryv1alpha1.KustomizeDeployment,
 ) (objs []*unstructured.Unstructured, err error) {
 	resourceBlob, err := r.downloadResourceBlob(ctx, cacheBackedRepo, componentDescriptor, resource, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download resource: %w", err)
 	}
 
-	limitedReader, err := resourceBlob.ReadCloser()
+	limitedReader, err := r.limitedResourceReader(resourceBlob)
 	if err != nil {
-		return nil, fmt.Errorf("getting reader for resource blob: %w", err)
+		return nil, err
 	}
 	defer func() {
 		err = errors.Join(err, limitedReader.Close())
 	}()
 
-	// Enforce resource size limit: opportunistic pre-check using declared size,
-	// then wrap the reader to cap reads at the limit.
-	// This only happens when a maximum resource limit is set (> 0). Otherwise, we will read the whole resource
-	// regardless of its size.
+	data, err := io.ReadAll(limitedReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource: %w", err)
+	}
+
+	// Archives of directories are built as kustomization, other resources are decoded as manifests.
+	if kustomization != nil || kustomize.IsArchive(data) {
+		return kustomize.Build(data, kustomizeOptions(kustomization))
+	}
+
+	return decodeObjectsFromManifest(bytes.NewReader(data))
+}
+
+// limitedResourceReader opens the resource blob for reading and enforces the resource size limit: opportunistic
+// pre-check using declared size, then wrap the reader to cap reads at the limit.
+// This only happens when a maximum resource limit is set (> 0). Otherwise, we will read the whole resource
+// regardless of its size.
+func (r *Reconciler) limitedResourceReader(resourceBlob blob.ReadOnlyBlob) (io.ReadCloser, error) {
 	if r.MaxResourceSizeBytes > 0 {
 		if sizeAware, ok := resourceBlob.(blob.SizeAware); ok {
 			if size := sizeAware.Size(); size != blob.SizeUnknown && size > r.MaxResourceSizeBytes {
-				err := fmt.Errorf("resource size %s exceeds maximum allowed size of %s",
+				return nil, fmt.Errorf("resource size %s exceeds maximum allowed size of %s",
 					apiresource.NewQuantity(size, apiresource.BinarySI),
 					apiresource.NewQuantity(r.MaxResourceSizeBytes, apiresource.BinarySI),
 				)
-
-				return nil, err
 			}
 		}
+	}
 
-		limitedReader = &limitedReadCloser{Closer: limitedReader, limited: &io.LimitedReader{R: limitedReader, N: r.MaxResourceSizeBytes}}
+	reader, err := resourceBlob.ReadCloser()
+	if err != nil {
+		return nil, fmt.Errorf("getting reader for resource blob: %w", err)
 	}
 
-	return decodeObjectsFromManifest(limitedReader)
+	if r.MaxResourceSizeBytes > 0 {
+		return &limitedReadCloser{Closer: reader, limited: &io.LimitedReader{R: reader, N: r.MaxResourceSizeBytes}}, nil
+	}
+
+	return reader, nil
 }
 
-func decodeObjectsFromManifest(manifest io.ReadCloser) (_ []*unstructured.Unstructured, err error) {
+func decodeObjectsFromManifest(manifest io.Reader) (_ []*unstructured.Unstructured, err error) {
 	const bufferSize = 4096
 	decoder := yaml.NewYAMLOrJSONDecoder(manifest, bufferSize)
 	var objs []*unstructured.Unstructured
@@ -782,7 +898,9 @@ func (r *Reconciler) createApplySet(deployer *deliveryv1alpha1.Deployer, logger
 // - All deployed resources are labeled with applyset.k8s.io/part-of=<applyset-id>
 // - The deployer carries annotations tracking the GroupKinds and namespaces of managed resources
 // - Pruning automatically removes resources that were previously deployed but are no longer in the manifest
-func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1alpha1.Resource, deployer *deliveryv1alpha1.Deployer, objs []*unstructured.Unstructured) error {
+//
+// The returned result contains the applied objects as observed in the cluster after the apply.
+func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1alpha1.Resource, deployer *deliveryv1alpha1.Deployer, objs []*unstructured.Unstructured) (*applyset.ApplyResult, error) {
 	logger := log.FromContext(ctx).WithValues("deployer", deployer.Name, "namespace", deployer.Namespace)
 
 	// Use the deployer as the ApplySet parent
@@ -805,12 +923,12 @@ func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1
 
 		// Set controller reference
 		if err := controllerutil.SetControllerReference(deployer, obj, r.Scheme); err != nil {
-			return fmt.Errorf("failed to set controller reference on object %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+			return nil, fmt.Errorf("failed to set controller reference on object %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
 		}
 
 		// Default namespace and apiVersion if needed
-		if err := r.defaultObj(ctx, resource, obj); err != nil {
-			return fmt.Errorf("failed to default object %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		if err := r.defaultObj(ctx, defaultNamespace(deployer), obj); err != nil {
+			return nil, fmt.Errorf("failed to default object %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
 		}
 
 		resourcesToAdd = append(resourcesToAdd, applyset.Resource{
@@ -823,21 +941,21 @@ func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1
 	logger.Info("projecting ApplySet and set deployer metadata")
 	metadata, err := set.Project(resourcesToAdd)
 	if err != nil {
-		return fmt.Errorf("failed to project ApplySet: %w", err)
+		return nil, fmt.Errorf("failed to project ApplySet: %w", err)
 	}
 
 	if err := r.setApplySetMetadata(ctx, deployer, metadata); err != nil {
-		return fmt.Errorf("failed to set ApplySet metadata on deployer: %w", err)
+		return nil, fmt.Errorf("failed to set ApplySet metadata on deployer: %w", err)
 	}
 
 	logger.Info("applying ApplySet")
 	applyResult, err := set.Apply(ctx, resourcesToAdd, applyset.ApplyMode{Concurrency: runtime.NumCPU()})
 	if err != nil {
-		return fmt.Errorf("failed to apply ApplySet: %w", err)
+		return nil, fmt.Errorf("failed to apply ApplySet: %w", err)
 	}
 
 	if applyResult.Errors() != nil {
-		return fmt.Errorf("errors occurred during ApplySet apply: %w", applyResult.Errors())
+		return nil, fmt.Errorf("errors occurred during ApplySet apply: %w", applyResult.Errors())
 	}
 
 	// Log results
@@ -849,13 +967,13 @@ func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1
 		Concurrency: runtime.NumCPU(),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to prune ApplySet: %w", err)
+		return nil, fmt.Errorf("failed to prune ApplySet: %w", err)
 	}
 
 	// Log prune results
 	logger.Info("ApplySet prune operation complete", "pruned", len(pruneResult.Pruned))
 
-	return nil
+	return applyResult, nil
 }
 
 // defaultObj ensures an unstructured object has consistent API metadata before being applied.
@@ -863,9 +981,9 @@ func (r *Reconciler) applyWithApplySet(ctx context.Context, resource *deliveryv1
 //
 // Behavior:
 //  1. Determines the GroupVersionKind (GVK) using the RESTMapper that is dynamically filled.
-//  2. If the object is namespaced but lacks a namespace, it defaults to "default" and logs the action.
+//  2. If the object is namespaced but lacks a namespace, it defaults to the given namespace and logs the action.
 //  3. If the object's apiVersion is missing but the RESTMapper provides one, it applies that version.
-func (r *Reconciler) defaultObj(ctx context.Context, resource *deliveryv1alpha1.Resource, obj *unstructured.Unstructured) error {
+func (r *Reconciler) defaultObj(ctx context.Context, namespace string, obj *unstructured.Unstructured) error {
 	logger := log.FromContext(ctx).WithValues(
 		"operation", "apply",
 		"gvk", obj.GetObjectKind().GroupVersionKind().String())
@@ -878,8 +996,8 @@ func (r *Reconciler) defaultObj(ctx context.Context, resource *deliveryv1alpha1.
 	}
 	if mapping.Scope.Name() == meta.RESTScopeNameNamespace && obj.GetNamespace() == "" {
 		// TODO(jakobmoellerdev) we can think of adding more namespacing options down the line
-		logger.Info("namespace will be defaulted", "defaultNamespace", resource.GetNamespace())
-		obj.SetNamespace(metav1.NamespaceDefault)
+		logger.Info("namespace will be defaulted", "defaultNamespace", namespace)
+		obj.SetNamespace(namespace)
 	}
 	if gvk.Version == "" && mapping.GroupVersionKind.Version != "" {
 		logger.Info("apiVersion will be defaulted to match discovered rest mapping", "defaultAPIVersion", mapping.GroupVersionKind.Version)
@@ -889,6 +1007,19 @@ func (r *Reconciler) defaultObj(ctx context.Context, resource *deliveryv1alpha1.
 	return nil
 }
 
+// defaultNamespace returns the namespace for namespaced objects deployed without namespace. This is the namespace of
+// the Helm release, if the deployer renders a chart, or the namespace set by the kustomization of the deployer.
+func defaultNamespace(deployer *deliveryv1alpha1.Deployer) string {
+	switch {
+	case deployer.Spec.Helm != nil && deployer.Spec.Helm.Namespace != "":
+		return deployer.Spec.Helm.Namespace
+	case deployer.Spec.Kustomize != nil && deployer.Spec.Kustomize.Namespace != "":
+		return deployer.Spec.Kustomize.Namespace
+	default:
+		return metav1.NamespaceDefault
+	}
+}
+
 // trackConcurrently tracks the objects for the deployer concurrently.
 //
 // See track for more details on how the objects are tracked.
@@ -970,6 +1101,11 @@ func (r *Reconciler) getEffectiveComponentDescriptor(
 		return nil, fmt.Errorf("failed to get verifications: %w", err)
 	}
 
+	verificationPolicy, err := verification.GetPolicy(component)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verification policy: %w", err)
+	}
+
 	requesterFunc := func() workerpool.RequesterInfo {
 		return workerpool.RequesterInfo{
 			NamespacedName: k8stypes.NamespacedName{
@@ -980,11 +1116,12 @@ func (r *Reconciler) getEffectiveComponentDescriptor(
 	}
 
 	verifiedOpts := resolution.RepositoryOptions{
-		RepositorySpec:  repoSpecComponent,
-		Configuration:   cfg,
-		SigningRegistry: r.PluginManager.SigningRegistry,
-		Verifications:   verifications,
-		RequesterFunc:   requesterFunc,
+		RepositorySpec:     repoSpecComponent,
+		Configuration:      cfg,
+		SigningRegistry:    r.PluginManager.SigningRegistry,
+		Verifications:      verifications,
+		VerificationPolicy: verificationPolicy,
+		RequesterFunc:      requesterFunc,
 	}
 
 	refPathOpts := resolution.RepositoryOptions{
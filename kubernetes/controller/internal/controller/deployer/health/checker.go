@@ -0,0 +1,123 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	ocmcel "ocm.software/open-component-model/kubernetes/controller/internal/cel"
+)
+
+// Checker assesses the health of objects. Objects of a group and kind with custom health check expressions are
+// assessed by these expressions, all other objects by the built-in rules of Compute.
+type Checker struct {
+	programs map[schema.GroupKind]*programs
+}
+
+// programs are the compiled health check expressions of a group and kind.
+type programs struct {
+	failed, current, inProgress cel.Program
+}
+
+// NewChecker compiles the health check expressions. It fails if an expression is invalid or a group and kind has
+// more than one set of expressions.
+func NewChecker(expressions []v1alpha1.HealthCheckExpression) (*Checker, error) {
+	checker := &Checker{programs: make(map[schema.GroupKind]*programs, len(expressions))}
+	if len(expressions) == 0 {
+		return checker, nil
+	}
+
+	env, err := ocmcel.ObjectEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, expr := range expressions {
+		gk := schema.GroupKind{Group: expr.Group, Kind: expr.Kind}
+		if _, exists := checker.programs[gk]; exists {
+			return nil, fmt.Errorf("duplicate health check expressions for %s", gk)
+		}
+
+		progs := &programs{}
+		if progs.current, err = compile(env, expr.Current); err != nil {
+			return nil, fmt.Errorf("invalid current expression for %s: %w", gk, err)
+		}
+		if progs.inProgress, err = compile(env, expr.InProgress); err != nil {
+			return nil, fmt.Errorf("invalid inProgress expression for %s: %w", gk, err)
+		}
+		if progs.failed, err = compile(env, expr.Failed); err != nil {
+			return nil, fmt.Errorf("invalid failed expression for %s: %w", gk, err)
+		}
+		checker.programs[gk] = progs
+	}
+
+	return checker, nil
+}
+
+// compile compiles a health check expression, which must evaluate to a bool. Empty expressions are skipped.
+func compile(env *cel.Env, expr string) (cel.Program, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile CEL expression %q: %w", expr, issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("CEL expression %q must evaluate to bool, got %s", expr, ast.OutputType())
+	}
+
+	prog, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CEL program %q: %w", expr, err)
+	}
+
+	return prog, nil
+}
+
+// Assess assesses the health of the object.
+//
+// The custom expressions are evaluated in the order failed, current and inProgress and the first expression that
+// evaluates to true determines the health. If no expression evaluates to true, the object is in progress. Expressions
+// failing to evaluate, e.g. because a field of the status is not set yet, are treated as in progress as well.
+func (c *Checker) Assess(ctx context.Context, obj *unstructured.Unstructured) Result {
+	progs, ok := c.programs[obj.GroupVersionKind().GroupKind()]
+	if !ok {
+		return Compute(obj)
+	}
+
+	if obj.GetDeletionTimestamp() != nil {
+		return Result{Status: TerminatingStatus, Message: "object is being deleted"}
+	}
+
+	for _, check := range []struct {
+		name   string
+		prog   cel.Program
+		status Status
+	}{
+		{"failed", progs.failed, FailedStatus},
+		{"current", progs.current, CurrentStatus},
+		{"inProgress", progs.inProgress, InProgressStatus},
+	} {
+		if check.prog == nil {
+			continue
+		}
+
+		val, _, err := check.prog.ContextEval(ctx, map[string]any{"self": obj.Object})
+		if err != nil {
+			return inProgress("failed to evaluate %s expression: %s", check.name, err)
+		}
+		if matched, ok := val.Value().(bool); !ok {
+			return inProgress("%s expression evaluated to %s instead of bool", check.name, val.Type())
+		} else if matched {
+			return Result{Status: check.status, Message: fmt.Sprintf("%s expression evaluated to true", check.name)}
+		}
+	}
+
+	return inProgress("no health check expression evaluated to true")
+}
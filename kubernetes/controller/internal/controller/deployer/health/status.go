@@ -0,0 +1,339 @@
+package health
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Status is the health status of an object. The statuses follow the statuses of kstatus.
+type Status string
+
+const (
+	// CurrentStatus means the object is reconciled and healthy.
+	CurrentStatus Status = "Current"
+	// InProgressStatus means the object is still being reconciled.
+	InProgressStatus Status = "InProgress"
+	// FailedStatus means the object failed to reconcile.
+	FailedStatus Status = "Failed"
+	// TerminatingStatus means the object is being deleted.
+	TerminatingStatus Status = "Terminating"
+)
+
+// Result is the assessed health of an object.
+type Result struct {
+	Status  Status
+	Message string
+}
+
+func current(format string, args ...any) Result {
+	return Result{Status: CurrentStatus, Message: fmt.Sprintf(format, args...)}
+}
+
+func inProgress(format string, args ...any) Result {
+	return Result{Status: InProgressStatus, Message: fmt.Sprintf(format, args...)}
+}
+
+func failed(format string, args ...any) Result {
+	return Result{Status: FailedStatus, Message: fmt.Sprintf(format, args...)}
+}
+
+// statusFunc computes the health of an object of a specific kind.
+type statusFunc func(obj *unstructured.Unstructured) Result
+
+var (
+	deploymentGK  = schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	statefulSetGK = schema.GroupKind{Group: "apps", Kind: "StatefulSet"}
+	daemonSetGK   = schema.GroupKind{Group: "apps", Kind: "DaemonSet"}
+	replicaSetGK  = schema.GroupKind{Group: "apps", Kind: "ReplicaSet"}
+	jobGK         = schema.GroupKind{Group: "batch", Kind: "Job"}
+	podGK         = schema.GroupKind{Kind: "Pod"}
+	pvcGK         = schema.GroupKind{Kind: "PersistentVolumeClaim"}
+	serviceGK     = schema.GroupKind{Kind: "Service"}
+	crdGK         = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+)
+
+var statusFuncs = map[schema.GroupKind]statusFunc{
+	deploymentGK:  deploymentStatus,
+	statefulSetGK: statefulSetStatus,
+	daemonSetGK:   daemonSetStatus,
+	replicaSetGK:  replicaSetStatus,
+	jobGK:         jobStatus,
+	podGK:         podStatus,
+	pvcGK:         pvcStatus,
+	serviceGK:     serviceStatus,
+	crdGK:         crdStatus,
+}
+
+// Compute computes the health of the object with the built-in rules, similar to kstatus:
+//   - objects being deleted are terminating,
+//   - objects whose controller did not observe the latest generation yet are in progress,
+//   - workloads and other built-in kinds are assessed by their specific status, e.g. Deployments must be rolled out
+//     and Jobs must be completed,
+//   - all other objects are assessed by their Stalled, Reconciling and Ready conditions. Objects without these
+//     conditions are current.
+func Compute(obj *unstructured.Unstructured) Result {
+	if obj.GetDeletionTimestamp() != nil {
+		return Result{Status: TerminatingStatus, Message: "object is being deleted"}
+	}
+
+	if observed, found := nestedInt(obj, "status", "observedGeneration"); found && observed < obj.GetGeneration() {
+		return inProgress("observed generation %d, expected generation %d", observed, obj.GetGeneration())
+	}
+
+	if fn, ok := statusFuncs[obj.GroupVersionKind().GroupKind()]; ok {
+		return fn(obj)
+	}
+
+	return conditionStatus(obj)
+}
+
+// conditionStatus assesses the object by the conventional conditions of custom resources.
+func conditionStatus(obj *unstructured.Unstructured) Result {
+	if cond, found := findCondition(obj, "Stalled"); found && cond.status == "True" {
+		return failed("%s", cond.messageOr("object is stalled"))
+	}
+	if cond, found := findCondition(obj, "Reconciling"); found && cond.status == "True" {
+		return inProgress("%s", cond.messageOr("object is reconciling"))
+	}
+	if cond, found := findCondition(obj, "Ready"); found {
+		if cond.status == "True" {
+			return current("%s", cond.messageOr("object is ready"))
+		}
+
+		return inProgress("%s", cond.messageOr("object is not ready"))
+	}
+
+	return current("object has no conditions to assess")
+}
+
+func deploymentStatus(obj *unstructured.Unstructured) Result {
+	if cond, found := findCondition(obj, "Progressing"); found && cond.reason == "ProgressDeadlineExceeded" {
+		return failed("%s", cond.messageOr("progress deadline exceeded"))
+	}
+
+	replicas := replicasOf(obj)
+	updated, _ := nestedInt(obj, "status", "updatedReplicas")
+	total, _ := nestedInt(obj, "status", "replicas")
+	available, _ := nestedInt(obj, "status", "availableReplicas")
+	ready, _ := nestedInt(obj, "status", "readyReplicas")
+
+	switch {
+	case updated < replicas:
+		return inProgress("updated replicas: %d/%d", updated, replicas)
+	case total > updated:
+		return inProgress("pending termination: %d", total-updated)
+	case available < replicas:
+		return inProgress("available replicas: %d/%d", available, replicas)
+	case ready < replicas:
+		return inProgress("ready replicas: %d/%d", ready, replicas)
+	}
+
+	if cond, found := findCondition(obj, "Available"); found && cond.status != "True" {
+		return inProgress("%s", cond.messageOr("deployment is not available"))
+	}
+
+	return current("deployment is available, replicas: %d", replicas)
+}
+
+func statefulSetStatus(obj *unstructured.Unstructured) Result {
+	if strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type"); strategy == "OnDelete" {
+		return current("statefulset uses the OnDelete update strategy")
+	}
+
+	replicas := replicasOf(obj)
+	partition, _ := nestedInt(obj, "spec", "updateStrategy", "rollingUpdate", "partition")
+	ready, _ := nestedInt(obj, "status", "readyReplicas")
+	currentReplicas, _ := nestedInt(obj, "status", "currentReplicas")
+	updated, _ := nestedInt(obj, "status", "updatedReplicas")
+	currentRevision, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+	updateRevision, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+
+	switch {
+	case ready < replicas:
+		return inProgress("ready replicas: %d/%d", ready, replicas)
+	case partition > 0 && updated < replicas-partition:
+		return inProgress("updated replicas: %d/%d", updated, replicas-partition)
+	case partition == 0 && currentRevision != updateRevision:
+		return inProgress("current replicas: %d/%d", currentReplicas, replicas)
+	}
+
+	return current("statefulset is ready, replicas: %d", replicas)
+}
+
+func daemonSetStatus(obj *unstructured.Unstructured) Result {
+	desired, found := nestedInt(obj, "status", "desiredNumberScheduled")
+	if !found {
+		return inProgress("missing desired number of scheduled pods")
+	}
+	scheduled, _ := nestedInt(obj, "status", "currentNumberScheduled")
+	updated, _ := nestedInt(obj, "status", "updatedNumberScheduled")
+	available, _ := nestedInt(obj, "status", "numberAvailable")
+	ready, _ := nestedInt(obj, "status", "numberReady")
+
+	switch {
+	case scheduled < desired:
+		return inProgress("scheduled pods: %d/%d", scheduled, desired)
+	case updated < desired:
+		return inProgress("updated pods: %d/%d", updated, desired)
+	case available < desired:
+		return inProgress("available pods: %d/%d", available, desired)
+	case ready < desired:
+		return inProgress("ready pods: %d/%d", ready, desired)
+	}
+
+	return current("daemonset is ready, pods: %d", desired)
+}
+
+func replicaSetStatus(obj *unstructured.Unstructured) Result {
+	if cond, found := findCondition(obj, "ReplicaFailure"); found && cond.status == "True" {
+		return inProgress("%s", cond.messageOr("replica failure"))
+	}
+
+	replicas := replicasOf(obj)
+	available, _ := nestedInt(obj, "status", "availableReplicas")
+	ready, _ := nestedInt(obj, "status", "readyReplicas")
+
+	switch {
+	case available < replicas:
+		return inProgress("available replicas: %d/%d", available, replicas)
+	case ready < replicas:
+		return inProgress("ready replicas: %d/%d", ready, replicas)
+	}
+
+	return current("replicaset is available, replicas: %d", replicas)
+}
+
+func jobStatus(obj *unstructured.Unstructured) Result {
+	if cond, found := findCondition(obj, "Failed"); found && cond.status == "True" {
+		return failed("%s", cond.messageOr("job failed"))
+	}
+	if cond, found := findCondition(obj, "Complete"); found && cond.status == "True" {
+		return current("job completed")
+	}
+
+	return inProgress("job in progress")
+}
+
+func podStatus(obj *unstructured.Unstructured) Result {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	switch phase {
+	case "Succeeded":
+		return current("pod succeeded")
+	case "Failed":
+		return failed("pod failed")
+	}
+
+	statuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "containerStatuses")
+	for _, s := range statuses {
+		reason, _, _ := unstructured.NestedString(asMap(s), "state", "waiting", "reason")
+		if reason == "CrashLoopBackOff" || reason == "ImagePullBackOff" || reason == "ErrImagePull" {
+			name, _, _ := unstructured.NestedString(asMap(s), "name")
+			return failed("container %s: %s", name, reason)
+		}
+	}
+
+	if cond, found := findCondition(obj, "Ready"); found && cond.status == "True" {
+		return current("pod is ready")
+	}
+
+	return inProgress("pod is in phase %q", phase)
+}
+
+func pvcStatus(obj *unstructured.Unstructured) Result {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	if phase == "Bound" {
+		return current("persistent volume claim is bound")
+	}
+
+	return inProgress("persistent volume claim is in phase %q", phase)
+}
+
+func serviceStatus(obj *unstructured.Unstructured) Result {
+	if serviceType, _, _ := unstructured.NestedString(obj.Object, "spec", "type"); serviceType != "LoadBalancer" {
+		return current("service is ready")
+	}
+
+	ingress, _, _ := unstructured.NestedSlice(obj.Object, "status", "loadBalancer", "ingress")
+	if len(ingress) == 0 {
+		return inProgress("load balancer is not provisioned")
+	}
+
+	return current("load balancer is provisioned")
+}
+
+func crdStatus(obj *unstructured.Unstructured) Result {
+	if cond, found := findCondition(obj, "NamesAccepted"); found && cond.status == "False" {
+		return failed("%s", cond.messageOr("names are not accepted"))
+	}
+	if cond, found := findCondition(obj, "Established"); found && cond.status == "True" {
+		return current("custom resource definition is established")
+	}
+
+	return inProgress("custom resource definition is not established")
+}
+
+// condition is the subset of a status condition needed to assess the health of an object.
+type condition struct {
+	status, reason, message string
+}
+
+func (c condition) messageOr(fallback string) string {
+	if strings.TrimSpace(c.message) != "" {
+		return c.message
+	}
+
+	return fallback
+}
+
+func findCondition(obj *unstructured.Unstructured, conditionType string) (condition, bool) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		m := asMap(c)
+		if t, _, _ := unstructured.NestedString(m, "type"); t != conditionType {
+			continue
+		}
+		status, _, _ := unstructured.NestedString(m, "status")
+		reason, _, _ := unstructured.NestedString(m, "reason")
+		message, _, _ := unstructured.NestedString(m, "message")
+
+		return condition{status: status, reason: reason, message: message}, true
+	}
+
+	return condition{}, false
+}
+
+// replicasOf returns the desired replicas of a workload, which default to 1.
+func replicasOf(obj *unstructured.Unstructured) int64 {
+	if replicas, found := nestedInt(obj, "spec", "replicas"); found {
+		return replicas
+	}
+
+	return 1
+}
+
+// nestedInt returns an integer field of the object. Integers decoded from JSON can be float64 values.
+func nestedInt(obj *unstructured.Unstructured, fields ...string) (int64, bool) {
+	val, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	if !found || err != nil {
+		return 0, false
+	}
+
+	switch v := val.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
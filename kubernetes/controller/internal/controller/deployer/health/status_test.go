@@ -0,0 +1,342 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+func newObject(t *testing.T, manifest string) *unstructured.Unstructured {
+	t.Helper()
+
+	data, err := yaml.YAMLToJSON([]byte(manifest))
+	require.NoError(t, err)
+	obj := &unstructured.Unstructured{}
+	require.NoError(t, obj.UnmarshalJSON(data))
+
+	return obj
+}
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		expected Status
+	}{
+		{
+			name: "rolled out deployment",
+			manifest: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: podinfo
+  generation: 2
+spec:
+  replicas: 2
+status:
+  observedGeneration: 2
+  replicas: 2
+  updatedReplicas: 2
+  availableReplicas: 2
+  readyReplicas: 2
+  conditions:
+    - type: Available
+      status: "True"
+`,
+			expected: CurrentStatus,
+		},
+		{
+			name: "deployment with outdated observed generation",
+			manifest: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: podinfo
+  generation: 3
+spec:
+  replicas: 1
+status:
+  observedGeneration: 2
+  replicas: 1
+  updatedReplicas: 1
+  availableReplicas: 1
+  readyReplicas: 1
+`,
+			expected: InProgressStatus,
+		},
+		{
+			name: "deployment rolling out",
+			manifest: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: podinfo
+spec:
+  replicas: 3
+status:
+  replicas: 3
+  updatedReplicas: 1
+`,
+			expected: InProgressStatus,
+		},
+		{
+			name: "deployment exceeding its progress deadline",
+			manifest: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: podinfo
+status:
+  conditions:
+    - type: Progressing
+      status: "False"
+      reason: ProgressDeadlineExceeded
+`,
+			expected: FailedStatus,
+		},
+		{
+			name: "completed job",
+			manifest: `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+status:
+  conditions:
+    - type: Complete
+      status: "True"
+`,
+			expected: CurrentStatus,
+		},
+		{
+			name: "running job",
+			manifest: `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+status:
+  active: 1
+`,
+			expected: InProgressStatus,
+		},
+		{
+			name: "failed job",
+			manifest: `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+status:
+  conditions:
+    - type: Failed
+      status: "True"
+      message: BackoffLimitExceeded
+`,
+			expected: FailedStatus,
+		},
+		{
+			name: "crash looping pod",
+			manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  name: podinfo
+status:
+  phase: Running
+  containerStatuses:
+    - name: podinfo
+      state:
+        waiting:
+          reason: CrashLoopBackOff
+`,
+			expected: FailedStatus,
+		},
+		{
+			name: "daemonset scheduling pods",
+			manifest: `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+status:
+  desiredNumberScheduled: 3
+  currentNumberScheduled: 3
+  updatedNumberScheduled: 3
+  numberAvailable: 2
+  numberReady: 2
+`,
+			expected: InProgressStatus,
+		},
+		{
+			name: "statefulset with pending update",
+			manifest: `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  replicas: 1
+status:
+  readyReplicas: 1
+  currentReplicas: 1
+  currentRevision: db-1
+  updateRevision: db-2
+`,
+			expected: InProgressStatus,
+		},
+		{
+			name: "pending load balancer",
+			manifest: `apiVersion: v1
+kind: Service
+metadata:
+  name: podinfo
+spec:
+  type: LoadBalancer
+`,
+			expected: InProgressStatus,
+		},
+		{
+			name: "established custom resource definition",
+			manifest: `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: podinfos.example.com
+status:
+  conditions:
+    - type: Established
+      status: "True"
+`,
+			expected: CurrentStatus,
+		},
+		{
+			name: "ready custom resource",
+			manifest: `apiVersion: example.com/v1
+kind: Podinfo
+metadata:
+  name: podinfo
+status:
+  conditions:
+    - type: Ready
+      status: "True"
+`,
+			expected: CurrentStatus,
+		},
+		{
+			name: "unready custom resource",
+			manifest: `apiVersion: example.com/v1
+kind: Podinfo
+metadata:
+  name: podinfo
+status:
+  conditions:
+    - type: Ready
+      status: "False"
+`,
+			expected: InProgressStatus,
+		},
+		{
+			name: "stalled custom resource",
+			manifest: `apiVersion: example.com/v1
+kind: Podinfo
+metadata:
+  name: podinfo
+status:
+  conditions:
+    - type: Stalled
+      status: "True"
+    - type: Ready
+      status: "False"
+`,
+			expected: FailedStatus,
+		},
+		{
+			name: "config map",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: podinfo
+`,
+			expected: CurrentStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Compute(newObject(t, tt.manifest))
+			assert.Equal(t, tt.expected, result.Status, result.Message)
+			assert.NotEmpty(t, result.Message)
+		})
+	}
+
+	t.Run("terminating object", func(t *testing.T) {
+		obj := newObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: podinfo\n")
+		obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+		assert.Equal(t, TerminatingStatus, Compute(obj).Status)
+	})
+}
+
+func TestChecker(t *testing.T) {
+	checker, err := NewChecker([]v1alpha1.HealthCheckExpression{{
+		Group:      "example.com",
+		Kind:       "Podinfo",
+		Current:    "self.status.phase == 'Running'",
+		InProgress: "self.status.phase == 'Pending'",
+		Failed:     "has(self.status.error)",
+	}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		status   string
+		expected Status
+	}{
+		{name: "current", status: "phase: Running", expected: CurrentStatus},
+		{name: "in progress", status: "phase: Pending", expected: InProgressStatus},
+		{name: "failed", status: "phase: Running\n  error: out of memory", expected: FailedStatus},
+		{name: "no expression matches", status: "phase: Unknown", expected: InProgressStatus},
+		{name: "missing field", status: "{}", expected: InProgressStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := newObject(t, "apiVersion: example.com/v1\nkind: Podinfo\nmetadata:\n  name: podinfo\nstatus:\n  "+tt.status+"\n")
+			result := checker.Assess(t.Context(), obj)
+			assert.Equal(t, tt.expected, result.Status, result.Message)
+		})
+	}
+
+	t.Run("other kinds use the built-in rules", func(t *testing.T) {
+		obj := newObject(t, "apiVersion: batch/v1\nkind: Job\nmetadata:\n  name: migrate\n")
+		assert.Equal(t, InProgressStatus, checker.Assess(t.Context(), obj).Status)
+	})
+}
+
+func TestNewChecker_InvalidExpressions(t *testing.T) {
+	tests := []struct {
+		name        string
+		expressions []v1alpha1.HealthCheckExpression
+		errContains string
+	}{
+		{
+			name:        "syntax error",
+			expressions: []v1alpha1.HealthCheckExpression{{Kind: "Podinfo", Current: "self.status.phase =="}},
+			errContains: "invalid current expression",
+		},
+		{
+			name:        "no bool",
+			expressions: []v1alpha1.HealthCheckExpression{{Kind: "Podinfo", Current: "true", Failed: "'failed'"}},
+			errContains: "must evaluate to bool",
+		},
+		{
+			name: "duplicate kind",
+			expressions: []v1alpha1.HealthCheckExpression{
+				{Kind: "Podinfo", Current: "true"},
+				{Kind: "Podinfo", Current: "false"},
+			},
+			errContains: "duplicate health check expressions",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewChecker(tt.expressions)
+			require.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/applyset"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/health"
+	"ocm.software/open-component-model/kubernetes/controller/internal/event"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+)
+
+const (
+	// defaultHealthTimeout is the default time the deployed objects have to become healthy.
+	defaultHealthTimeout = 5 * time.Minute
+
+	// maxReportedUnhealthyObjects limits the number of unhealthy objects listed in the Healthy condition.
+	maxReportedUnhealthyObjects = 10
+)
+
+// assessHealth assesses the health of the applied objects and reflects it in the Healthy condition of the deployer.
+// It reports whether the deployer can be marked as Ready.
+//
+// The dynamic informer manager enqueues the deployer whenever a deployed object changes, so progressing objects are
+// assessed again as soon as their status changes. Additionally, the deployer is requeued once the timeout of the
+// first progressing object expires.
+func (r *Reconciler) assessHealth(
+	ctx context.Context,
+	deployer *deliveryv1alpha1.Deployer,
+	applied []applyset.ApplyResultItem,
+) (ctrl.Result, bool) {
+	spec := deployer.Spec.Health
+	if spec != nil && spec.Disabled {
+		status.RemoveCondition(deployer, deliveryv1alpha1.HealthyCondition)
+
+		return ctrl.Result{}, true
+	}
+
+	timeout := defaultHealthTimeout
+	var expressions []deliveryv1alpha1.HealthCheckExpression
+	if spec != nil {
+		expressions = spec.Expressions
+		if spec.Timeout != nil {
+			timeout = spec.Timeout.Duration
+		}
+	}
+
+	checker, err := health.NewChecker(expressions)
+	if err != nil {
+		// the expressions can only be fixed by changing the deployer, which triggers a new reconciliation
+		msg := fmt.Sprintf("invalid health check: %s", err)
+		setHealthyCondition(deployer, metav1.ConditionFalse, deliveryv1alpha1.InvalidHealthCheckReason, msg)
+		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.InvalidHealthCheckReason, msg)
+
+		return ctrl.Result{}, false
+	}
+
+	var (
+		failed, progressing []string
+		requeueAfter        time.Duration
+		now                 = time.Now()
+	)
+	for _, item := range applied {
+		obj := item.Observed
+		if obj == nil {
+			continue
+		}
+
+		result := checker.Assess(ctx, obj)
+		if result.Status == health.CurrentStatus {
+			continue
+		}
+
+		id := fmt.Sprintf("%s %s", obj.GetKind(), client.ObjectKeyFromObject(obj))
+		if result.Status == health.FailedStatus {
+			failed = append(failed, fmt.Sprintf("%s: %s", id, result.Message))
+
+			continue
+		}
+
+		remaining := timeout - now.Sub(lastAppliedTime(obj))
+		if remaining <= 0 {
+			failed = append(failed, fmt.Sprintf("%s: not healthy after %s: %s", id, timeout, result.Message))
+
+			continue
+		}
+
+		progressing = append(progressing, fmt.Sprintf("%s: %s", id, result.Message))
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		msg := unhealthyObjectsMessage("health check failed for", failed)
+		setHealthyCondition(deployer, metav1.ConditionFalse, deliveryv1alpha1.HealthCheckFailedReason, msg)
+		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.HealthCheckFailedReason, msg)
+
+		return ctrl.Result{RequeueAfter: requeueAfter}, false
+	case len(progressing) > 0:
+		// progressing objects are expected after a change, so they are not reported as an error
+		msg := unhealthyObjectsMessage("waiting for", progressing)
+		setHealthyCondition(deployer, metav1.ConditionFalse, deliveryv1alpha1.HealthCheckInProgressReason, msg)
+		r.markWaiting(deployer, deliveryv1alpha1.HealthCheckInProgressReason, msg)
+
+		return ctrl.Result{RequeueAfter: requeueAfter}, false
+	}
+
+	setHealthyCondition(deployer, metav1.ConditionTrue, deliveryv1alpha1.SucceededReason,
+		fmt.Sprintf("%d deployed objects are healthy", len(applied)))
+
+	return ctrl.Result{}, true
+}
+
+// markWaiting sets the deployer to not ready while it waits for other objects. Unlike status.MarkNotReady, it emits an
+// info event, as waiting is expected and not an error.
+func (r *Reconciler) markWaiting(deployer *deliveryv1alpha1.Deployer, reason, msg string) {
+	status.RemoveCondition(deployer, deliveryv1alpha1.ReconcilingCondition)
+	status.SetCondition(deployer, metav1.Condition{
+		Type:    deliveryv1alpha1.ReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: msg,
+	})
+	event.New(r.EventRecorder, deployer, nil, deliveryv1alpha1.EventSeverityInfo, "%s", msg)
+}
+
+func setHealthyCondition(deployer *deliveryv1alpha1.Deployer, conditionStatus metav1.ConditionStatus, reason, msg string) {
+	status.SetCondition(deployer, metav1.Condition{
+		Type:    deliveryv1alpha1.HealthyCondition,
+		Status:  conditionStatus,
+		Reason:  reason,
+		Message: msg,
+	})
+}
+
+// unhealthyObjectsMessage lists the unhealthy objects, limited to maxReportedUnhealthyObjects.
+func unhealthyObjectsMessage(prefix string, objs []string) string {
+	listed := objs
+	if len(listed) > maxReportedUnhealthyObjects {
+		listed = listed[:maxReportedUnhealthyObjects]
+	}
+
+	msg := fmt.Sprintf("%s %d objects: %s", prefix, len(objs), strings.Join(listed, "; "))
+	if remaining := len(objs) - len(listed); remaining > 0 {
+		msg += fmt.Sprintf("; and %d more", remaining)
+	}
+
+	return msg
+}
+
+// lastAppliedTime returns the time the deployer last changed the object, which is the time of the managed fields
+// entry of the ApplySet field manager. Objects without such an entry fall back to their creation time.
+func lastAppliedTime(obj *unstructured.Unstructured) time.Time {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == applyset.FieldManager && entry.Operation == metav1.ManagedFieldsOperationApply && entry.Time != nil {
+			return entry.Time.Time
+		}
+	}
+
+	return obj.GetCreationTimestamp().Time
+}
@@ -0,0 +1,151 @@
+package deployer
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/applyset"
+	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+)
+
+func newHealthTestReconciler() *Reconciler {
+	return &Reconciler{
+		BaseReconciler: &ocm.BaseReconciler{
+			EventRecorder: &record.FakeRecorder{Events: make(chan string, 100)},
+		},
+	}
+}
+
+func newHealthTestJob(name string, appliedAt time.Time, conditionType string) applyset.ApplyResultItem {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata":   map[string]any{"name": name, "namespace": "apps"},
+	}}
+	if conditionType != "" {
+		obj.Object["status"] = map[string]any{
+			"conditions": []any{map[string]any{"type": conditionType, "status": "True"}},
+		}
+	}
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{
+		Manager:   applyset.FieldManager,
+		Operation: metav1.ManagedFieldsOperationApply,
+		Time:      &metav1.Time{Time: appliedAt},
+	}})
+
+	return applyset.ApplyResultItem{ID: name, Observed: obj}
+}
+
+func TestAssessHealth(t *testing.T) {
+	now := time.Now()
+
+	t.Run("healthy", func(t *testing.T) {
+		g := NewWithT(t)
+		deployer := &v1alpha1.Deployer{}
+
+		result, healthy := newHealthTestReconciler().assessHealth(t.Context(), deployer, []applyset.ApplyResultItem{
+			newHealthTestJob("migrate", now, "Complete"),
+		})
+		g.Expect(healthy).To(BeTrue())
+		g.Expect(result.RequeueAfter).To(BeZero())
+
+		cond := status.FindCondition(deployer, v1alpha1.HealthyCondition)
+		g.Expect(cond).NotTo(BeNil())
+		g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	t.Run("progressing", func(t *testing.T) {
+		g := NewWithT(t)
+		deployer := &v1alpha1.Deployer{Spec: v1alpha1.DeployerSpec{Health: &v1alpha1.DeployerHealth{
+			Timeout: &metav1.Duration{Duration: time.Minute},
+		}}}
+
+		result, healthy := newHealthTestReconciler().assessHealth(t.Context(), deployer, []applyset.ApplyResultItem{
+			newHealthTestJob("migrate", now, ""),
+			newHealthTestJob("seed", now.Add(-30*time.Second), ""),
+		})
+		g.Expect(healthy).To(BeFalse())
+		g.Expect(result.RequeueAfter).To(BeNumerically("~", 30*time.Second, time.Second))
+
+		ready := status.FindCondition(deployer, v1alpha1.ReadyCondition)
+		g.Expect(ready).NotTo(BeNil())
+		g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(ready.Reason).To(Equal(v1alpha1.HealthCheckInProgressReason))
+		g.Expect(ready.Message).To(ContainSubstring("waiting for 2 objects"))
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		g := NewWithT(t)
+		deployer := &v1alpha1.Deployer{}
+
+		_, healthy := newHealthTestReconciler().assessHealth(t.Context(), deployer, []applyset.ApplyResultItem{
+			newHealthTestJob("migrate", now.Add(-defaultHealthTimeout-time.Second), ""),
+		})
+		g.Expect(healthy).To(BeFalse())
+
+		cond := status.FindCondition(deployer, v1alpha1.HealthyCondition)
+		g.Expect(cond).NotTo(BeNil())
+		g.Expect(cond.Reason).To(Equal(v1alpha1.HealthCheckFailedReason))
+		g.Expect(cond.Message).To(ContainSubstring("Job apps/migrate: not healthy after 5m0s"))
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		g := NewWithT(t)
+		deployer := &v1alpha1.Deployer{}
+
+		_, healthy := newHealthTestReconciler().assessHealth(t.Context(), deployer, []applyset.ApplyResultItem{
+			newHealthTestJob("migrate", now, "Failed"),
+			newHealthTestJob("seed", now, "Complete"),
+		})
+		g.Expect(healthy).To(BeFalse())
+
+		ready := status.FindCondition(deployer, v1alpha1.ReadyCondition)
+		g.Expect(ready).NotTo(BeNil())
+		g.Expect(ready.Reason).To(Equal(v1alpha1.HealthCheckFailedReason))
+		g.Expect(ready.Message).To(Equal("health check failed for 1 objects: Job apps/migrate: job failed"))
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		g := NewWithT(t)
+		deployer := &v1alpha1.Deployer{Spec: v1alpha1.DeployerSpec{Health: &v1alpha1.DeployerHealth{
+			Expressions: []v1alpha1.HealthCheckExpression{{Group: "batch", Kind: "Job", Current: "self.status."}},
+		}}}
+
+		_, healthy := newHealthTestReconciler().assessHealth(t.Context(), deployer, []applyset.ApplyResultItem{
+			newHealthTestJob("migrate", now, "Complete"),
+		})
+		g.Expect(healthy).To(BeFalse())
+		g.Expect(status.FindCondition(deployer, v1alpha1.ReadyCondition).Reason).To(Equal(v1alpha1.InvalidHealthCheckReason))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		g := NewWithT(t)
+		deployer := &v1alpha1.Deployer{Spec: v1alpha1.DeployerSpec{Health: &v1alpha1.DeployerHealth{Disabled: true}}}
+		setHealthyCondition(deployer, metav1.ConditionFalse, v1alpha1.HealthCheckFailedReason, "failed")
+
+		_, healthy := newHealthTestReconciler().assessHealth(t.Context(), deployer, []applyset.ApplyResultItem{
+			newHealthTestJob("migrate", now, "Failed"),
+		})
+		g.Expect(healthy).To(BeTrue())
+		g.Expect(status.FindCondition(deployer, v1alpha1.HealthyCondition)).To(BeNil())
+	})
+}
+
+func TestUnhealthyObjectsMessage(t *testing.T) {
+	g := NewWithT(t)
+
+	objs := make([]string, 12)
+	for i := range objs {
+		objs[i] = "obj"
+	}
+
+	g.Expect(unhealthyObjectsMessage("waiting for", objs[:2])).To(Equal("waiting for 2 objects: obj; obj"))
+	g.Expect(unhealthyObjectsMessage("waiting for", objs)).To(HaveSuffix("obj; and 2 more"))
+}
@@ -0,0 +1,122 @@
+package helm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/go-logr/logr"
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	"helm.sh/helm/v4/pkg/chart/v2/loader"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	helmblob "ocm.software/open-component-model/bindings/go/helm/blob"
+	"ocm.software/open-component-model/bindings/go/oci/spec/layout"
+	ocitransformer "ocm.software/open-component-model/bindings/go/oci/transformer"
+)
+
+// gzipMagic are the first bytes of a gzip compressed stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// LoadChart loads a Helm chart from a downloaded OCM resource. The resource can be
+//   - a chart archive (.tgz), e.g. a local blob,
+//   - a tar archive containing the chart archive and an optional provenance file, as downloaded by the Helm
+//     resource repository from a Helm repository, or
+//   - an OCI image layout containing a Helm chart artifact, as downloaded from an OCI registry.
+func LoadChart(ctx context.Context, resourceBlob blob.ReadOnlyBlob) (*chart.Chart, error) {
+	if isOCILayout(resourceBlob) {
+		return loadChartFromOCILayout(ctx, resourceBlob)
+	}
+
+	data, err := readAll(resourceBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(data, gzipMagic) {
+		chrt, err := loader.LoadArchive(bytes.NewReader(data))
+		if err == nil {
+			return chrt, nil
+		}
+		// a gzip compressed OCI image layout without media type
+		chrt, layoutErr := loadChartFromOCILayout(ctx, inmemory.New(bytes.NewReader(data)))
+		if layoutErr != nil {
+			return nil, fmt.Errorf("failed to load chart archive: %w", err)
+		}
+
+		return chrt, nil
+	}
+
+	chrt, err := loadChartFromTar(inmemory.New(bytes.NewReader(data)))
+	if errors.Is(err, helmblob.ErrNoChartFound) {
+		// an OCI image layout without media type
+		return loadChartFromOCILayout(ctx, inmemory.New(bytes.NewReader(data)))
+	}
+
+	return chrt, err
+}
+
+// loadChartFromTar loads the chart archive contained in a tar archive.
+func loadChartFromTar(tarBlob blob.ReadOnlyBlob) (*chart.Chart, error) {
+	archive, err := helmblob.NewChartBlob(tarBlob).ChartArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readAll(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	chrt, err := loader.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart archive: %w", err)
+	}
+
+	return chrt, nil
+}
+
+// loadChartFromOCILayout extracts the chart layers of the single artifact in an OCI image layout and loads the chart.
+func loadChartFromOCILayout(ctx context.Context, layoutBlob blob.ReadOnlyBlob) (*chart.Chart, error) {
+	logger := slog.New(logr.ToSlogHandler(log.FromContext(ctx)))
+
+	extracted, err := ocitransformer.New(logger).TransformBlob(ctx, layoutBlob, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract chart from OCI image layout: %w", err)
+	}
+
+	return loadChartFromTar(extracted)
+}
+
+func isOCILayout(b blob.ReadOnlyBlob) bool {
+	mediaTypeAware, ok := b.(blob.MediaTypeAware)
+	if !ok {
+		return false
+	}
+	mediaType, known := mediaTypeAware.MediaType()
+
+	return known && strings.HasPrefix(mediaType, layout.MediaTypeOCIImageLayout)
+}
+
+func readAll(b blob.ReadOnlyBlob) (_ []byte, err error) {
+	reader, err := b.ReadCloser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reader for chart: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chart: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,134 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"helm.sh/helm/v4/pkg/chart/common"
+	commonutil "helm.sh/helm/v4/pkg/chart/common/util"
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	"helm.sh/helm/v4/pkg/chart/v2/loader"
+	chartutil "helm.sh/helm/v4/pkg/chart/v2/util"
+	"helm.sh/helm/v4/pkg/engine"
+	releaseutil "helm.sh/helm/v4/pkg/release/v1/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// notesFileSuffix is the suffix of the chart notes template, which does not render to manifests.
+const notesFileSuffix = "NOTES.txt"
+
+// Release describes the release a chart is rendered for.
+type Release struct {
+	// Name is the name of the release.
+	Name string
+	// Namespace is the namespace of the release.
+	Namespace string
+	// Revision is the revision of the release. The first revision is 1 and is rendered as install, all later
+	// revisions are rendered as upgrade.
+	Revision int
+	// SkipCRDs skips the custom resource definitions in the crds directory of the chart.
+	SkipCRDs bool
+}
+
+// Render renders the chart with the given values for the release and returns the rendered objects. The custom
+// resource definitions of the chart come first, followed by the rendered templates in the install order of Helm.
+//
+// Rendering does not require a cluster connection: the capabilities are the defaults of the Helm library and the
+// lookup template function returns empty results. Hooks are not rendered, as there is no release lifecycle to run them
+// in.
+func Render(chrt *chart.Chart, values map[string]any, release Release) ([]*unstructured.Unstructured, error) {
+	if err := chrt.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid chart: %w", err)
+	}
+	if err := chartutil.ProcessDependencies(chrt, values); err != nil {
+		return nil, fmt.Errorf("failed to process chart dependencies: %w", err)
+	}
+
+	renderValues, err := commonutil.ToRenderValues(chrt, values, common.ReleaseOptions{
+		Name:      release.Name,
+		Namespace: release.Namespace,
+		Revision:  release.Revision,
+		IsInstall: release.Revision <= 1,
+		IsUpgrade: release.Revision > 1,
+	}, common.DefaultCapabilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute render values: %w", err)
+	}
+
+	files, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chart: %w", err)
+	}
+	for name := range files {
+		if strings.HasSuffix(name, notesFileSuffix) {
+			delete(files, name)
+		}
+	}
+
+	_, manifests, err := releaseutil.SortManifests(files, nil, releaseutil.InstallOrder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sort rendered manifests: %w", err)
+	}
+
+	var objs []*unstructured.Unstructured
+	if !release.SkipCRDs {
+		for _, crd := range chrt.CRDObjects() {
+			decoded, err := decodeObjects(string(crd.File.Data))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode custom resource definition %s: %w", crd.Filename, err)
+			}
+			objs = append(objs, decoded...)
+		}
+	}
+
+	for _, manifest := range manifests {
+		decoded, err := decodeObjects(manifest.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest %s: %w", manifest.Name, err)
+		}
+		objs = append(objs, decoded...)
+	}
+
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("chart %s:%s rendered no objects", chrt.Metadata.Name, chrt.Metadata.Version)
+	}
+
+	return objs, nil
+}
+
+// MergeValues merges the values in the given order. Later values take precedence, nested maps are merged.
+func MergeValues(values ...map[string]any) map[string]any {
+	merged := map[string]any{}
+	for _, v := range values {
+		merged = loader.MergeMaps(merged, v)
+	}
+
+	return merged
+}
+
+func decodeObjects(manifest string) ([]*unstructured.Unstructured, error) {
+	const bufferSize = 4096
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), bufferSize)
+
+	var objs []*unstructured.Unstructured
+	for {
+		var obj unstructured.Unstructured
+		err := decoder.Decode(&obj.Object)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// empty documents, e.g. templates disabled by a condition
+		if len(obj.Object) == 0 {
+			continue
+		}
+		objs = append(objs, &obj)
+	}
+
+	return objs, nil
+}
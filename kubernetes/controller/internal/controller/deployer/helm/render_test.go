@@ -0,0 +1,165 @@
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v4/pkg/chart/common"
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	chartutil "helm.sh/helm/v4/pkg/chart/v2/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+)
+
+func newTestChart() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       "podinfo",
+			Version:    "1.0.0",
+			AppVersion: "6.0.0",
+		},
+		Values: map[string]any{
+			"replicaCount": 1,
+			"image":        map[string]any{"repository": "ghcr.io/stefanprodan/podinfo", "tag": "6.0.0"},
+			"hook":         false,
+		},
+		Templates: []*common.File{
+			{Name: "templates/_helpers.tpl", Data: []byte(`{{- define "podinfo.name" -}}{{ .Release.Name }}-podinfo{{- end -}}`)},
+			{Name: "templates/deployment.yaml", Data: []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "podinfo.name" . }}
+  namespace: {{ .Release.Namespace }}
+  annotations:
+    revision: "{{ .Release.Revision }}"
+    upgrade: "{{ .Release.IsUpgrade }}"
+spec:
+  replicas: {{ .Values.replicaCount }}
+  template:
+    spec:
+      containers:
+        - name: podinfo
+          image: {{ .Values.image.repository }}:{{ .Values.image.tag }}
+`)},
+			{Name: "templates/configmap.yaml", Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "podinfo.name" . }}
+data:
+  chart: {{ .Chart.Name }}
+`)},
+			{Name: "templates/hook.yaml", Data: []byte(`{{- if .Values.hook }}
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ include "podinfo.name" . }}-hook
+  annotations:
+    helm.sh/hook: pre-install
+{{- end }}
+`)},
+			{Name: "templates/NOTES.txt", Data: []byte(`Thank you for installing {{ .Chart.Name }}.`)},
+		},
+		Files: []*common.File{
+			{Name: "crds/crd.yaml", Data: []byte(`apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: podinfos.example.com
+`)},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	r := require.New(t)
+
+	objs, err := Render(newTestChart(), map[string]any{
+		"replicaCount": 3,
+		"hook":         true,
+	}, Release{Name: "test", Namespace: "apps", Revision: 2})
+	r.NoError(err)
+
+	// the custom resource definition comes first, the config map is installed before the deployment and the hook
+	// and notes are not rendered
+	r.Len(objs, 3)
+	r.Equal("CustomResourceDefinition", objs[0].GetKind())
+	r.Equal("ConfigMap", objs[1].GetKind())
+	r.Equal("test-podinfo", objs[1].GetName())
+	r.Equal("Deployment", objs[2].GetKind())
+
+	deployment := objs[2]
+	r.Equal("apps", deployment.GetNamespace())
+	r.Equal(map[string]string{"revision": "2", "upgrade": "true"}, deployment.GetAnnotations())
+	replicas, found, err := unstructured.NestedFieldNoCopy(deployment.Object, "spec", "replicas")
+	r.NoError(err)
+	r.True(found)
+	r.EqualValues(3, replicas)
+}
+
+func TestRender_SkipCRDs(t *testing.T) {
+	r := require.New(t)
+
+	objs, err := Render(newTestChart(), nil, Release{Name: "test", Namespace: "default", Revision: 1, SkipCRDs: true})
+	r.NoError(err)
+	r.Len(objs, 2)
+	for _, obj := range objs {
+		r.NotEqual("CustomResourceDefinition", obj.GetKind())
+	}
+	r.Equal(map[string]string{"revision": "1", "upgrade": "false"}, objs[1].GetAnnotations())
+}
+
+func TestRender_InvalidTemplate(t *testing.T) {
+	chrt := newTestChart()
+	chrt.Templates = append(chrt.Templates, &common.File{Name: "templates/invalid.yaml", Data: []byte(`{{ .Values.missing.field }}`)})
+
+	_, err := Render(chrt, nil, Release{Name: "test", Namespace: "default", Revision: 1})
+	require.ErrorContains(t, err, "failed to render chart")
+}
+
+func TestMergeValues(t *testing.T) {
+	base := map[string]any{"image": map[string]any{"repository": "base", "tag": "1"}, "replicaCount": 1}
+	override := map[string]any{"image": map[string]any{"tag": "2"}}
+
+	merged := MergeValues(base, override, map[string]any{"replicaCount": 2})
+	assert.Equal(t, map[string]any{"image": map[string]any{"repository": "base", "tag": "2"}, "replicaCount": 2}, merged)
+	// the inputs are not modified
+	assert.Equal(t, map[string]any{"repository": "base", "tag": "1"}, base["image"])
+}
+
+func TestLoadChart(t *testing.T) {
+	archivePath, err := chartutil.Save(newTestChart(), t.TempDir())
+	require.NoError(t, err)
+	archive, err := os.ReadFile(archivePath)
+	require.NoError(t, err)
+
+	t.Run("chart archive", func(t *testing.T) {
+		chrt, err := LoadChart(t.Context(), inmemory.New(bytes.NewReader(archive)))
+		require.NoError(t, err)
+		assert.Equal(t, "podinfo", chrt.Metadata.Name)
+		assert.Equal(t, "1.0.0", chrt.Metadata.Version)
+	})
+
+	t.Run("tar archive from a helm repository", func(t *testing.T) {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: filepath.Base(archivePath), Size: int64(len(archive)), Mode: 0o644}))
+		_, err := tw.Write(archive)
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+
+		chrt, err := LoadChart(t.Context(), inmemory.New(bytes.NewReader(buf.Bytes())))
+		require.NoError(t, err)
+		assert.Equal(t, "podinfo", chrt.Metadata.Name)
+	})
+
+	t.Run("no chart", func(t *testing.T) {
+		_, err := LoadChart(t.Context(), inmemory.New(bytes.NewReader([]byte("not a chart"))))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,333 @@
+package deployer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	"ocm.software/open-component-model/bindings/go/blob"
+	"ocm.software/open-component-model/bindings/go/blob/inmemory"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/helm"
+	"ocm.software/open-component-model/kubernetes/controller/internal/resolution"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+	"ocm.software/open-component-model/kubernetes/controller/pkg/configuration"
+)
+
+// defaultHelmValuesKey is the data key of the values in referenced config maps and secrets if none is specified.
+const defaultHelmValuesKey = "values.yaml"
+
+// renderHelmChart downloads the resource as Helm chart and renders it for the release configured in the deployer.
+// It returns the rendered objects and the release status that is recorded once the objects are applied.
+//
+// The revision of the release is incremented whenever the chart or the values change, so that templates relying on
+// .Release.Revision or .Release.IsUpgrade behave as they would with a Helm upgrade.
+func (r *Reconciler) renderHelmChart(
+	ctx context.Context,
+	deployer *deliveryv1alpha1.Deployer,
+	resource *deliveryv1alpha1.Resource,
+	repo *resolution.CacheBackedRepository,
+	componentDescriptor *descriptor.Descriptor,
+	matchedResource *descriptor.Resource,
+	cfg *configuration.Configuration,
+	resourceKey string,
+) ([]*unstructured.Unstructured, *deliveryv1alpha1.HelmReleaseStatus, error) {
+	values, err := r.getHelmValues(ctx, deployer, resource)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.GetHelmValuesFailedReason, err.Error())
+
+		return nil, nil, fmt.Errorf("failed to get helm values: %w", err)
+	}
+
+	release := helm.Release{
+		Name:      releaseName(deployer),
+		Namespace: defaultNamespace(deployer),
+		SkipCRDs:  deployer.Spec.Helm.SkipCRDs,
+	}
+
+	digest, err := helmReleaseDigest(resourceKey, values, release)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.MarshalFailedReason, err.Error())
+
+		return nil, nil, fmt.Errorf("failed to compute helm release digest: %w", err)
+	}
+
+	previous := deployer.Status.Helm
+	unchanged := previous != nil && previous.Digest == digest
+	release.Revision = 1
+	if previous != nil {
+		release.Revision = previous.Revision
+		if !unchanged {
+			release.Revision++
+		}
+	}
+
+	var metadata *chart.Metadata
+	render := func() ([]*unstructured.Unstructured, error) {
+		chrt, err := r.downloadHelmChart(ctx, repo, componentDescriptor, matchedResource, cfg)
+		if err != nil {
+			return nil, err
+		}
+		metadata = chrt.Metadata
+
+		return helm.Render(chrt, values, release)
+	}
+
+	key := fmt.Sprintf("%s/helm/%s/%d", resourceKey, digest, release.Revision)
+	objs, err := r.DownloadCache.Load(key, render)
+	if err == nil && metadata == nil {
+		// The objects were rendered before. The chart metadata is known from the status if the revision was already
+		// recorded, otherwise the chart is rendered again.
+		if unchanged {
+			metadata = &chart.Metadata{Name: previous.ChartName, Version: previous.ChartVersion, AppVersion: previous.AppVersion}
+		} else {
+			objs, err = render()
+		}
+	}
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, deployer, deliveryv1alpha1.RenderHelmChartFailedReason, err.Error())
+
+		return nil, nil, fmt.Errorf("failed to render helm chart: %w", err)
+	}
+
+	appliedTime := metav1.Now()
+	if unchanged {
+		appliedTime = previous.AppliedTime
+	}
+
+	return objs, &deliveryv1alpha1.HelmReleaseStatus{
+		ReleaseName:  release.Name,
+		Namespace:    release.Namespace,
+		ChartName:    metadata.Name,
+		ChartVersion: metadata.Version,
+		AppVersion:   metadata.AppVersion,
+		Revision:     release.Revision,
+		Digest:       digest,
+		AppliedTime:  appliedTime,
+	}, nil
+}
+
+// downloadHelmChart downloads the resource and loads it as Helm chart. The resource size limit applies to the
+// downloaded chart.
+func (r *Reconciler) downloadHelmChart(
+	ctx context.Context,
+	repo *resolution.CacheBackedRepository,
+	componentDescriptor *descriptor.Descriptor,
+	resource *descriptor.Resource,
+	cfg *configuration.Configuration,
+) (_ *chart.Chart, err error) {
+	resourceBlob, err := r.downloadResourceBlob(ctx, repo, componentDescriptor, resource, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download resource: %w", err)
+	}
+
+	limitedReader, err := r.limitedResourceReader(resourceBlob)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, limitedReader.Close())
+	}()
+
+	data, err := io.ReadAll(limitedReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource: %w", err)
+	}
+
+	// The media type tells how the chart is packaged, e.g. as OCI image layout.
+	var opts []inmemory.MemoryBlobOption
+	if mediaTypeAware, ok := resourceBlob.(blob.MediaTypeAware); ok {
+		if mediaType, known := mediaTypeAware.MediaType(); known {
+			opts = append(opts, inmemory.WithMediaType(mediaType))
+		}
+	}
+
+	return helm.LoadChart(ctx, inmemory.New(bytes.NewReader(data), opts...))
+}
+
+// getHelmValues merges the values referenced by the deployer in order, followed by the inline values.
+func (r *Reconciler) getHelmValues(
+	ctx context.Context,
+	deployer *deliveryv1alpha1.Deployer,
+	resource *deliveryv1alpha1.Resource,
+) (map[string]any, error) {
+	spec := deployer.Spec.Helm
+	values := make([]map[string]any, 0, len(spec.ValuesFrom)+1)
+
+	for _, ref := range spec.ValuesFrom {
+		data, err := r.getHelmValuesReference(ctx, ref, resource.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		var v map[string]any
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("failed to parse values of %s %s: %w", ref.Kind, ref.Name, err)
+		}
+		values = append(values, v)
+	}
+
+	if spec.Values != nil && len(spec.Values.Raw) > 0 {
+		var v map[string]any
+		if err := json.Unmarshal(spec.Values.Raw, &v); err != nil {
+			return nil, fmt.Errorf("failed to parse inline values: %w", err)
+		}
+		values = append(values, v)
+	}
+
+	return helm.MergeValues(values...), nil
+}
+
+// getHelmValuesReference returns the values data of the referenced config map or secret. It returns no data if an
+// optional referent or key does not exist.
+func (r *Reconciler) getHelmValuesReference(
+	ctx context.Context,
+	ref deliveryv1alpha1.HelmValuesReference,
+	namespace string,
+) ([]byte, error) {
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	valuesKey := ref.ValuesKey
+	if valuesKey == "" {
+		valuesKey = defaultHelmValuesKey
+	}
+	key := client.ObjectKey{Namespace: namespace, Name: ref.Name}
+
+	var (
+		data  []byte
+		found bool
+	)
+	switch ref.Kind {
+	case "ConfigMap":
+		configMap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, key, configMap); err != nil {
+			if apierrors.IsNotFound(err) && ref.Optional {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("failed to get %s %s: %w", ref.Kind, key, err)
+		}
+		var value string
+		if value, found = configMap.Data[valuesKey]; found {
+			data = []byte(value)
+		} else {
+			data, found = configMap.BinaryData[valuesKey]
+		}
+	case "Secret":
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, key, secret); err != nil {
+			if apierrors.IsNotFound(err) && ref.Optional {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("failed to get %s %s: %w", ref.Kind, key, err)
+		}
+		data, found = secret.Data[valuesKey]
+	default:
+		return nil, fmt.Errorf("unsupported values kind: %s", ref.Kind)
+	}
+
+	if !found && !ref.Optional {
+		return nil, fmt.Errorf("key %q not found in %s %s", valuesKey, ref.Kind, key)
+	}
+
+	return data, nil
+}
+
+// helmValuesIndexKeys returns the index keys of the config maps and secrets referenced for values by the deployer.
+func helmValuesIndexKeys(deployer *deliveryv1alpha1.Deployer) []string {
+	if deployer.Spec.Helm == nil {
+		return nil
+	}
+
+	resourceNamespace := deployer.Spec.ResourceRef.Namespace
+	if resourceNamespace == "" {
+		resourceNamespace = deployer.GetNamespace()
+	}
+
+	keys := make([]string, 0, len(deployer.Spec.Helm.ValuesFrom))
+	for _, ref := range deployer.Spec.Helm.ValuesFrom {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = resourceNamespace
+		}
+		keys = append(keys, helmValuesIndexKey(ref.Kind, namespace, ref.Name))
+	}
+
+	return keys
+}
+
+func helmValuesIndexKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// helmReleaseDigest identifies the chart, the values and the release options of a revision.
+func helmReleaseDigest(resourceKey string, values map[string]any, release helm.Release) (string, error) {
+	// map keys are sorted when marshalled, so equal values result in equal digests
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal values: %w", err)
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n%s\n%t\n", resourceKey, release.Name, release.Namespace, release.SkipCRDs)
+	hash.Write(data)
+
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+}
+
+// releaseName returns the name of the Helm release of the deployer.
+func releaseName(deployer *deliveryv1alpha1.Deployer) string {
+	if deployer.Spec.Helm != nil && deployer.Spec.Helm.ReleaseName != "" {
+		return deployer.Spec.Helm.ReleaseName
+	}
+
+	return deployer.GetName()
+}
+
+// deployersForHelmValues returns a map function that creates reconciliation requests for the deployers referencing
+// the config map or secret for Helm values.
+func (r *Reconciler) deployersForHelmValues(fieldName, kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := &deliveryv1alpha1.DeployerList{}
+		if err := r.List(
+			ctx,
+			list,
+			client.MatchingFields{fieldName: helmValuesIndexKey(kind, obj.GetNamespace(), obj.GetName())},
+		); err != nil {
+			return []reconcile.Request{}
+		}
+
+		requests := make([]reconcile.Request, 0, len(list.Items))
+		for _, deployer := range list.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: k8stypes.NamespacedName{
+					Namespace: deployer.GetNamespace(),
+					Name:      deployer.GetName(),
+				},
+			})
+		}
+
+		return requests
+	}
+}
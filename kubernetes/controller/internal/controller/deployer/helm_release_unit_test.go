@@ -0,0 +1,149 @@
+package deployer
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/helm"
+	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
+)
+
+func newHelmTestReconciler(t *testing.T, objs ...client.Object) *Reconciler {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	NewWithT(t).Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	return &Reconciler{
+		BaseReconciler: &ocm.BaseReconciler{
+			Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Scheme:        scheme,
+			EventRecorder: &record.FakeRecorder{Events: make(chan string, 100)},
+		},
+	}
+}
+
+func newHelmTestDeployer(helmSpec *v1alpha1.HelmDeployment) *v1alpha1.Deployer {
+	return &v1alpha1.Deployer{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo"},
+		Spec: v1alpha1.DeployerSpec{
+			ResourceRef: v1alpha1.ObjectKey{Name: "podinfo", Namespace: "apps"},
+			Helm:        helmSpec,
+		},
+	}
+}
+
+func TestGetHelmValues(t *testing.T) {
+	g := NewWithT(t)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "values", Namespace: "apps"},
+		Data: map[string]string{
+			"values.yaml": "replicaCount: 2\nimage:\n  repository: ghcr.io/stefanprodan/podinfo\n  tag: 6.0.0\n",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "secrets"},
+		Data: map[string][]byte{
+			"override.yaml": []byte("image:\n  tag: 6.1.0\nauth:\n  password: secret\n"),
+		},
+	}
+	r := newHelmTestReconciler(t, configMap, secret)
+
+	deployer := newHelmTestDeployer(&v1alpha1.HelmDeployment{
+		ValuesFrom: []v1alpha1.HelmValuesReference{
+			{Kind: "ConfigMap", Name: "values"},
+			{Kind: "Secret", Name: "credentials", Namespace: "secrets", ValuesKey: "override.yaml"},
+			{Kind: "ConfigMap", Name: "missing", Optional: true},
+			{Kind: "ConfigMap", Name: "values", ValuesKey: "missing.yaml", Optional: true},
+		},
+		Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicaCount":3}`)},
+	})
+	resource := &v1alpha1.Resource{ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "apps"}}
+
+	values, err := r.getHelmValues(t.Context(), deployer, resource)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(values).To(Equal(map[string]any{
+		"replicaCount": float64(3),
+		"image": map[string]any{
+			"repository": "ghcr.io/stefanprodan/podinfo",
+			"tag":        "6.1.0",
+		},
+		"auth": map[string]any{"password": "secret"},
+	}))
+}
+
+func TestGetHelmValues_MissingReference(t *testing.T) {
+	resource := &v1alpha1.Resource{ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "apps"}}
+
+	t.Run("missing referent", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newHelmTestReconciler(t)
+		deployer := newHelmTestDeployer(&v1alpha1.HelmDeployment{
+			ValuesFrom: []v1alpha1.HelmValuesReference{{Kind: "Secret", Name: "missing"}},
+		})
+
+		_, err := r.getHelmValues(t.Context(), deployer, resource)
+		g.Expect(err).To(MatchError(ContainSubstring("failed to get Secret apps/missing")))
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newHelmTestReconciler(t, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "values", Namespace: "apps"},
+			Data:       map[string]string{"other.yaml": "replicaCount: 2"},
+		})
+		deployer := newHelmTestDeployer(&v1alpha1.HelmDeployment{
+			ValuesFrom: []v1alpha1.HelmValuesReference{{Kind: "ConfigMap", Name: "values"}},
+		})
+
+		_, err := r.getHelmValues(t.Context(), deployer, resource)
+		g.Expect(err).To(MatchError(ContainSubstring(`key "values.yaml" not found in ConfigMap apps/values`)))
+	})
+}
+
+func TestHelmValuesIndexKeys(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(helmValuesIndexKeys(newHelmTestDeployer(nil))).To(BeEmpty())
+	g.Expect(helmValuesIndexKeys(newHelmTestDeployer(&v1alpha1.HelmDeployment{
+		ValuesFrom: []v1alpha1.HelmValuesReference{
+			{Kind: "ConfigMap", Name: "values"},
+			{Kind: "Secret", Name: "credentials", Namespace: "secrets"},
+		},
+	}))).To(Equal([]string{"ConfigMap/apps/values", "Secret/secrets/credentials"}))
+}
+
+func TestHelmReleaseDigest(t *testing.T) {
+	g := NewWithT(t)
+	release := helm.Release{Name: "podinfo", Namespace: "apps"}
+
+	digest, err := helmReleaseDigest("sha256:abc", map[string]any{"a": 1, "b": map[string]any{"c": true}}, release)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	same, err := helmReleaseDigest("sha256:abc", map[string]any{"b": map[string]any{"c": true}, "a": 1}, release)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(same).To(Equal(digest))
+
+	otherValues, err := helmReleaseDigest("sha256:abc", map[string]any{"a": 2}, release)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(otherValues).NotTo(Equal(digest))
+
+	otherChart, err := helmReleaseDigest("sha256:def", map[string]any{"a": 1, "b": map[string]any{"c": true}}, release)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(otherChart).NotTo(Equal(digest))
+
+	release.Namespace = "other"
+	otherNamespace, err := helmReleaseDigest("sha256:abc", map[string]any{"a": 1, "b": map[string]any{"c": true}}, release)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(otherNamespace).NotTo(Equal(digest))
+}
@@ -0,0 +1,68 @@
+package deployer
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+
+	deliveryv1alpha1 "ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/controller/deployer/kustomize"
+)
+
+// kustomizeOptions converts the kustomization of the deployer to build options. Without kustomization, the
+// kustomization in the root of the resource is built as is.
+func kustomizeOptions(kustomization *deliveryv1alpha1.KustomizeDeployment) kustomize.Options {
+	if kustomization == nil {
+		return kustomize.Options{}
+	}
+
+	opts := kustomize.Options{
+		Path:      kustomization.Path,
+		Namespace: kustomization.Namespace,
+	}
+
+	for _, patch := range kustomization.Patches {
+		p := types.Patch{Patch: patch.Patch}
+		if target := patch.Target; target != nil {
+			p.Target = &types.Selector{
+				ResId: resid.ResId{
+					Gvk: resid.Gvk{
+						Group:   target.Group,
+						Version: target.Version,
+						Kind:    target.Kind,
+					},
+					Name:      target.Name,
+					Namespace: target.Namespace,
+				},
+				LabelSelector:      target.LabelSelector,
+				AnnotationSelector: target.AnnotationSelector,
+			}
+		}
+		opts.Patches = append(opts.Patches, p)
+	}
+
+	for _, image := range kustomization.Images {
+		opts.Images = append(opts.Images, types.Image{
+			Name:    image.Name,
+			NewName: image.NewName,
+			NewTag:  image.NewTag,
+			Digest:  image.Digest,
+		})
+	}
+
+	return opts
+}
+
+// kustomizationCacheKey extends the resource cache key by the kustomization of the deployer, as the built objects
+// depend on it.
+func kustomizationCacheKey(resourceKey string, kustomization *deliveryv1alpha1.KustomizeDeployment) (string, error) {
+	data, err := json.Marshal(kustomization)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal kustomization: %w", err)
+	}
+
+	return fmt.Sprintf("%s/kustomize/sha256:%x", resourceKey, sha256.Sum256(data)), nil
+}
@@ -0,0 +1,186 @@
+package kustomize
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// resourceDir is the directory the resource archive is extracted to.
+	resourceDir = "/resource"
+	// overlayDir is the directory of the kustomization applying the overrides of the build options.
+	overlayDir = "/overlay"
+
+	// tarMagicOffset is the offset of the magic in the header of a tar archive.
+	tarMagicOffset = 257
+)
+
+var (
+	// gzipMagic are the first bytes of a gzip compressed stream.
+	gzipMagic = []byte{0x1f, 0x8b}
+	// tarMagic is the magic of POSIX and GNU tar archives.
+	tarMagic = []byte("ustar")
+)
+
+// Options configures a kustomize build.
+type Options struct {
+	// Path is the path of the kustomization directory in the archive. Defaults to the root of the archive.
+	Path string
+	// Namespace sets the namespace of all namespaced objects.
+	Namespace string
+	// Patches are applied to the built objects.
+	Patches []types.Patch
+	// Images override the names, tags or digests of container images.
+	Images []types.Image
+}
+
+// hasOverrides reports whether the options override the kustomization in the archive.
+func (o Options) hasOverrides() bool {
+	return o.Namespace != "" || len(o.Patches) > 0 || len(o.Images) > 0
+}
+
+// IsArchive reports whether the data is a tar archive, optionally gzip compressed, as created for a directory.
+func IsArchive(data []byte) bool {
+	if bytes.HasPrefix(data, gzipMagic) {
+		return true
+	}
+
+	return len(data) >= tarMagicOffset+len(tarMagic) &&
+		bytes.Equal(data[tarMagicOffset:tarMagicOffset+len(tarMagic)], tarMagic)
+}
+
+// Build extracts the archive and runs a kustomize build of the kustomization directory in it. The overrides of the
+// options are applied by an overlay referencing the kustomization directory.
+//
+// The build runs in memory with the default kustomize options: plugins are disabled and files can only be loaded from
+// within the kustomization directories.
+func Build(archive []byte, opts Options) ([]*unstructured.Unstructured, error) {
+	fs := filesys.MakeFsInMemory()
+	if err := extract(archive, fs); err != nil {
+		return nil, fmt.Errorf("failed to extract kustomization archive: %w", err)
+	}
+
+	dir := path.Join(resourceDir, path.Clean("/"+opts.Path))
+	if opts.hasOverrides() {
+		if err := writeOverlay(fs, dir, opts); err != nil {
+			return nil, fmt.Errorf("failed to write kustomization overlay: %w", err)
+		}
+		dir = overlayDir
+	}
+
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fs, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization: %w", err)
+	}
+
+	objs := make([]*unstructured.Unstructured, 0, resMap.Size())
+	for _, res := range resMap.Resources() {
+		// the objects are converted through JSON, as the object values must be JSON compatible to be copied
+		data, err := res.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", res.CurId(), err)
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", res.CurId(), err)
+		}
+		objs = append(objs, obj)
+	}
+
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("kustomization built no objects")
+	}
+
+	return objs, nil
+}
+
+// writeOverlay writes a kustomization to the overlay directory that applies the overrides of the options to the
+// kustomization directory.
+func writeOverlay(fs filesys.FileSystem, dir string, opts Options) error {
+	base, err := filepath.Rel(overlayDir, dir)
+	if err != nil {
+		return err
+	}
+
+	kustomization := types.Kustomization{
+		TypeMeta: types.TypeMeta{
+			APIVersion: types.KustomizationVersion,
+			Kind:       types.KustomizationKind,
+		},
+		Resources: []string{base},
+		Namespace: opts.Namespace,
+		Patches:   opts.Patches,
+		Images:    opts.Images,
+	}
+
+	data, err := yaml.Marshal(kustomization)
+	if err != nil {
+		return err
+	}
+
+	if err := fs.MkdirAll(overlayDir); err != nil {
+		return err
+	}
+
+	return fs.WriteFile(path.Join(overlayDir, "kustomization.yaml"), data)
+}
+
+// extract writes the directories and regular files of the archive to the resource directory of the file system.
+// Other entries like links are not needed to build a kustomization and are skipped.
+func extract(archive []byte, fs filesys.FileSystem) (err error) {
+	var reader io.Reader = bytes.NewReader(archive)
+	if bytes.HasPrefix(archive, gzipMagic) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, gzipReader.Close())
+		}()
+		reader = gzipReader
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// cleaning the rooted name keeps the entry within the resource directory
+		name := path.Join(resourceDir, path.Clean("/"+header.Name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := fs.MkdirAll(name); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			data, err := io.ReadAll(tarReader)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", header.Name, err)
+			}
+			if err := fs.MkdirAll(path.Dir(name)); err != nil {
+				return err
+			}
+			if err := fs.WriteFile(name, data); err != nil {
+				return err
+			}
+		}
+	}
+}
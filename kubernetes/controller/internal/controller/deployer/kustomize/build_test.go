@@ -0,0 +1,154 @@
+package kustomize
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+)
+
+var testFiles = map[string]string{
+	"base/kustomization.yaml": `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - deployment.yaml
+  - configmap.yaml
+`,
+	"base/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: podinfo
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+        - name: podinfo
+          image: ghcr.io/stefanprodan/podinfo:6.0.0
+`,
+	"base/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: podinfo
+data:
+  stage: base
+`,
+	"overlays/production/kustomization.yaml": `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+namePrefix: production-
+resources:
+  - ../../base
+`,
+}
+
+func newTestArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Size: int64(len(content)), Mode: 0o644}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	return buf.Bytes()
+}
+
+func names(objs []*unstructured.Unstructured) []string {
+	result := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		result = append(result, obj.GetKind()+"/"+obj.GetName())
+	}
+
+	return result
+}
+
+func TestBuild(t *testing.T) {
+	archive := newTestArchive(t, testFiles)
+
+	t.Run("kustomization directory", func(t *testing.T) {
+		objs, err := Build(archive, Options{Path: "base"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Deployment/podinfo", "ConfigMap/podinfo"}, names(objs))
+	})
+
+	t.Run("overlay", func(t *testing.T) {
+		objs, err := Build(archive, Options{Path: "overlays/production"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Deployment/production-podinfo", "ConfigMap/production-podinfo"}, names(objs))
+	})
+
+	t.Run("gzip compressed archive", func(t *testing.T) {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		_, err := gw.Write(archive)
+		require.NoError(t, err)
+		require.NoError(t, gw.Close())
+
+		objs, err := Build(buf.Bytes(), Options{Path: "/base/"})
+		require.NoError(t, err)
+		assert.Len(t, objs, 2)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		objs, err := Build(archive, Options{
+			Path:      "overlays/production",
+			Namespace: "apps",
+			Patches: []types.Patch{
+				{Patch: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: podinfo\ndata:\n  stage: production\n"},
+				{
+					Patch:  `[{"op": "replace", "path": "/spec/replicas", "value": 3}]`,
+					Target: &types.Selector{ResId: resid.ResId{Gvk: resid.Gvk{Kind: "Deployment"}}},
+				},
+			},
+			Images: []types.Image{{Name: "ghcr.io/stefanprodan/podinfo", NewTag: "6.1.0"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, objs, 2)
+
+		for _, obj := range objs {
+			// the objects are copied before they are applied
+			assert.NotPanics(t, func() { obj.DeepCopy() })
+			assert.Equal(t, "apps", obj.GetNamespace())
+			switch obj.GetKind() {
+			case "ConfigMap":
+				stage, _, err := unstructured.NestedString(obj.Object, "data", "stage")
+				require.NoError(t, err)
+				assert.Equal(t, "production", stage)
+			case "Deployment":
+				replicas, _, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+				require.NoError(t, err)
+				assert.EqualValues(t, 3, replicas)
+				containers, _, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+				require.NoError(t, err)
+				require.Len(t, containers, 1)
+				assert.Equal(t, "ghcr.io/stefanprodan/podinfo:6.1.0", containers[0].(map[string]any)["image"])
+			}
+		}
+	})
+
+	t.Run("no kustomization", func(t *testing.T) {
+		_, err := Build(archive, Options{})
+		require.ErrorContains(t, err, "failed to build kustomization")
+	})
+
+	t.Run("path outside of the archive", func(t *testing.T) {
+		_, err := Build(newTestArchive(t, map[string]string{"../../base/kustomization.yaml": testFiles["base/kustomization.yaml"]}), Options{Path: "../../etc"})
+		require.ErrorContains(t, err, "failed to build kustomization")
+	})
+}
+
+func TestIsArchive(t *testing.T) {
+	assert.True(t, IsArchive(newTestArchive(t, testFiles)))
+	assert.True(t, IsArchive([]byte{0x1f, 0x8b, 0x08}))
+	assert.False(t, IsArchive([]byte(testFiles["base/deployment.yaml"])))
+	assert.False(t, IsArchive(nil))
+}
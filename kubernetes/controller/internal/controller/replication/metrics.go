@@ -0,0 +1,71 @@
+package replication
+
+import (
+	kmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"ocm.software/open-component-model/kubernetes/controller/internal/metrics"
+)
+
+func init() {
+	kmetrics.Registry.MustRegister(
+		TransfersCounterTotal,
+		TransfersInProgressGauge,
+		TransferDurationHistogram,
+	)
+}
+
+const (
+	// TransfersCounterLabel tracks how many transfers finished.
+	TransfersCounterLabel = "replication_transfers"
+	// TransfersInProgressGaugeLabel tracks the number of transfers currently in progress.
+	TransfersInProgressGaugeLabel = "replication_transfers_in_progress"
+	// TransferDurationHistogramLabel tracks the duration of transfers.
+	TransferDurationHistogramLabel = "replication_transfer_duration_seconds"
+	// MetricsNamespace defines the namespace of all the replication metrics.
+	MetricsNamespace = "ocm_system"
+	// OcmComponent is the name of the component registering for these metrics.
+	OcmComponent = "ocm_k8s_toolkit"
+)
+
+const (
+	// ComponentLabel is the name of the label for the transferred component's name.
+	ComponentLabel = "component"
+	// VersionLabel is the name of the label for the transferred component's version.
+	VersionLabel = "version"
+	// ResultLabel is the name of the label for the result of a transfer, either "success" or "failure".
+	ResultLabel = "result"
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// TransfersCounterTotal counts the number of finished transfers.
+// [component, version, result].
+var TransfersCounterTotal = metrics.MustRegisterCounterVec(
+	MetricsNamespace,
+	OcmComponent,
+	TransfersCounterLabel,
+	"Number of finished component version transfers.",
+	ComponentLabel, VersionLabel, ResultLabel,
+)
+
+// TransfersInProgressGauge tracks the number of transfers currently in progress.
+var TransfersInProgressGauge = metrics.MustRegisterGauge(
+	MetricsNamespace,
+	OcmComponent,
+	TransfersInProgressGaugeLabel,
+	"Number of component version transfers currently in progress.",
+)
+
+// TransferDurationHistogram tracks the duration of transfers.
+// [component, version, result].
+var TransferDurationHistogram = metrics.MustRegisterHistogramVec(
+	MetricsNamespace,
+	OcmComponent,
+	TransferDurationHistogramLabel,
+	"Duration of component version transfers in seconds.",
+	[]float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+	ComponentLabel, VersionLabel, ResultLabel,
+)
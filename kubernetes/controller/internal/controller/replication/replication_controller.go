@@ -0,0 +1,727 @@
+package replication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	genericv1 "ocm.software/open-component-model/bindings/go/configuration/generic/v1/spec"
+	descriptor "ocm.software/open-component-model/bindings/go/descriptor/runtime"
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	ocirepository "ocm.software/open-component-model/bindings/go/oci/spec/repository"
+	"ocm.software/open-component-model/bindings/go/plugin/manager"
+	"ocm.software/open-component-model/bindings/go/repository"
+	"ocm.software/open-component-model/bindings/go/runtime"
+	"ocm.software/open-component-model/bindings/go/transfer"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	transformv1alpha1 "ocm.software/open-component-model/bindings/go/transform/spec/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/event"
+	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
+	"ocm.software/open-component-model/kubernetes/controller/internal/resolution"
+	"ocm.software/open-component-model/kubernetes/controller/internal/resolution/workerpool"
+	"ocm.software/open-component-model/kubernetes/controller/internal/setup"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+	"ocm.software/open-component-model/kubernetes/controller/internal/util"
+	"ocm.software/open-component-model/kubernetes/controller/pkg/configuration"
+)
+
+// ErrTransferInProgress is returned when a component version is being transferred in the background.
+var ErrTransferInProgress = errors.New("component version transfer in progress")
+
+// DefaultDrainTimeout is the default time the deletion of a Replication waits for its in-flight transfer to stop.
+const DefaultDrainTimeout = 30 * time.Second
+
+// Reconciler reconciles a Replication object.
+type Reconciler struct {
+	*ocm.BaseReconciler
+
+	// Resolver provides the repository resolvers of the source component versions.
+	Resolver *resolution.Resolver
+
+	// TransferPool runs the transfers in the background. It is separate from the worker pool of the Resolver, so that
+	// long-running transfers do not block component version resolutions.
+	TransferPool *workerpool.WorkerPool
+
+	// PluginManager provides the repository, resource, signing and digest processor plugins used by the transfers.
+	PluginManager *manager.PluginManager
+
+	// DrainTimeout bounds the time the deletion of a Replication waits for its canceled in-flight transfer to stop.
+	// Defaults to DefaultDrainTimeout.
+	DrainTimeout time.Duration
+
+	mu sync.Mutex
+	// inFlight tracks the running transfers by the UID of their Replication, so that they can be canceled when the
+	// Replication is deleted.
+	inFlight map[types.UID]*inFlightTransfer
+}
+
+type inFlightTransfer struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// transferResult is the result of a successful transfer.
+type transferResult struct {
+	Version string
+	Digest  string
+}
+
+var _ ocm.Reconciler = (*Reconciler)(nil)
+
+const (
+	componentIndex        = "Replication.spec.componentRef"
+	targetRepositoryIndex = "Replication.spec.targetRepositoryRef"
+)
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	// Build indexes for replications that reference a component or a target repository to make sure that we get
+	// notified when they change.
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &v1alpha1.Replication{}, componentIndex, func(obj client.Object) []string {
+		replication, ok := obj.(*v1alpha1.Replication)
+		if !ok {
+			return nil
+		}
+
+		return []string{componentKey(replication).String()}
+	}); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &v1alpha1.Replication{}, targetRepositoryIndex, func(obj client.Object) []string {
+		replication, ok := obj.(*v1alpha1.Replication)
+		if !ok {
+			return nil
+		}
+
+		return []string{targetRepositoryKey(replication).String()}
+	}); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	// event source from the transfer worker pool to get notified when transfers complete
+	eventSource := workerpool.NewEventSource(r.TransferPool)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Replication{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WatchesRawSource(eventSource).
+		Watches(&v1alpha1.Component{}, handler.EnqueueRequestsFromMapFunc(r.replicationsReferencing(componentIndex))).
+		Watches(&v1alpha1.Repository{}, handler.EnqueueRequestsFromMapFunc(r.replicationsReferencing(targetRepositoryIndex))).
+		WithOptions(controller.Options{
+			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+				workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](5*time.Millisecond, 5*time.Minute),
+				&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(10, 100)},
+			),
+		}).
+		Complete(r)
+}
+
+// replicationsReferencing returns a map function that creates a reconciliation request for every replication that
+// references the object by the given index.
+func (r *Reconciler) replicationsReferencing(index string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := &v1alpha1.ReplicationList{}
+		if err := r.List(ctx, list, client.MatchingFields{index: client.ObjectKeyFromObject(obj).String()}); err != nil {
+			return []reconcile.Request{}
+		}
+
+		requests := make([]reconcile.Request, 0, len(list.Items))
+		for _, replication := range list.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: replication.GetNamespace(),
+					Name:      replication.GetName(),
+				},
+			})
+		}
+
+		return requests
+	}
+}
+
+// +kubebuilder:rbac:groups=delivery.ocm.software,resources=replications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=delivery.ocm.software,resources=replications/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=delivery.ocm.software,resources=replications/finalizers,verbs=update
+// +kubebuilder:rbac:groups=delivery.ocm.software,resources=components;repositories,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets;configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+//nolint:cyclop,funlen // we do not want to cut the function at arbitrary points
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+	logger.Info("starting reconciliation")
+
+	replication := &v1alpha1.Replication{}
+	if err := r.Get(ctx, req.NamespacedName, replication); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	old := replication.DeepCopy()
+	defer func(ctx context.Context) {
+		status.UpdateBeforePatch(replication, r.EventRecorder, replication.GetRequeueAfter(), err)
+		if !equality.Semantic.DeepEqual(replication.Status, old.Status) {
+			err = errors.Join(err, r.GetClient().Status().Patch(ctx, replication, client.MergeFrom(old)))
+		}
+	}(ctx)
+
+	if !replication.GetDeletionTimestamp().IsZero() {
+		logger.Info("replication is marked for deletion, canceling in-flight transfer")
+		if !r.cancelTransfer(replication.GetUID()) {
+			logger.Info("timed out waiting for the in-flight transfer to stop, removing finalizer anyway",
+				"drainTimeout", r.drainTimeout())
+		}
+
+		if updated := controllerutil.RemoveFinalizer(replication, v1alpha1.ReplicationFinalizer); updated {
+			if err := r.Update(ctx, replication); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	if updated := controllerutil.AddFinalizer(replication, v1alpha1.ReplicationFinalizer); updated {
+		if err := r.Update(ctx, replication); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+		}
+
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if replication.Spec.Suspend {
+		return ctrl.Result{}, nil
+	}
+
+	// A transfer that was in progress when the controller restarted or lost its leadership is gone. In that case, the
+	// condition is stale and the transfer is submitted again below.
+	if inProgress := status.FindCondition(replication, v1alpha1.TransferInProgressCondition); inProgress != nil &&
+		inProgress.Status == metav1.ConditionTrue && !r.isTransferring(replication.GetUID()) {
+		logger.Info("clearing stale transfer in progress condition")
+		markTransferIdle(replication)
+	}
+
+	component, err := util.GetReadyObject[v1alpha1.Component, *v1alpha1.Component](ctx, r.Client, componentKey(replication))
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.ResourceIsNotAvailable, err.Error())
+
+		var notReadyErr util.NotReadyError
+		var deletionErr util.DeletionError
+		if errors.As(err, &notReadyErr) || errors.As(err, &deletionErr) {
+			logger.Info("component is not available", "error", err)
+
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, fmt.Errorf("failed to get ready component: %w", err)
+	}
+
+	componentInfo := component.Status.Component
+	if componentInfo.Digest == nil || componentInfo.RepositorySpec == nil {
+		// the replication is triggered again by the component event once the component is resolved
+		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.ResourceIsNotAvailable,
+			fmt.Sprintf("component %s has no resolved digest and repository spec yet", component.GetName()))
+
+		return ctrl.Result{}, nil
+	}
+	replication.Status.ComponentInfo = componentInfo.DeepCopy()
+
+	targetRepository, err := util.GetReadyObject[v1alpha1.Repository, *v1alpha1.Repository](ctx, r.Client, targetRepositoryKey(replication))
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.GetRepositoryFailedReason, err.Error())
+
+		var notReadyErr util.NotReadyError
+		var deletionErr util.DeletionError
+		if errors.As(err, &notReadyErr) || errors.As(err, &deletionErr) {
+			logger.Info("target repository is not available", "error", err)
+
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, fmt.Errorf("failed to get ready target repository: %w", err)
+	}
+
+	digest := formatDigest(componentInfo.Digest)
+	if replication.Status.LastTransferredDigest == digest &&
+		replication.Status.ObservedGeneration == replication.GetGeneration() && status.IsReady(replication) {
+		logger.V(1).Info("component version already transferred", "version", componentInfo.Version, "digest", digest)
+
+		return status.RequeueResult(replication, replication.GetRequeueAfter()), nil
+	}
+
+	configs, err := ocm.GetEffectiveConfig(ctx, r.GetClient(), replication, component)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.GetConfigurationFailedReason, err.Error())
+
+		return ctrl.Result{}, fmt.Errorf("failed to get effective config: %w", err)
+	}
+	replication.Status.EffectiveOCMConfig = configs
+
+	cfg, err := configuration.LoadConfigurations(ctx, r.Client, replication.GetNamespace(), configs)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.GetConfigurationFailedReason, err.Error())
+
+		return ctrl.Result{}, fmt.Errorf("failed to load configurations: %w", err)
+	}
+
+	transferCfg, err := r.getTransferConfig(ctx, replication)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.GetConfigurationFailedReason, err.Error())
+
+		return ctrl.Result{}, fmt.Errorf("failed to get transfer config: %w", err)
+	}
+
+	sourceSpec, err := decodeRepositorySpec(componentInfo.RepositorySpec)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.GetRepositoryFailedReason, err.Error())
+
+		return ctrl.Result{}, fmt.Errorf("failed to decode source repository spec: %w", err)
+	}
+
+	targetSpec, err := decodeRepositorySpec(targetRepository.Spec.RepositorySpec)
+	if err != nil {
+		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.GetRepositoryFailedReason, err.Error())
+
+		return ctrl.Result{}, fmt.Errorf("failed to decode target repository spec: %w", err)
+	}
+
+	result, err := r.submitTransfer(ctx, replication, &transferRequest{
+		component:   componentInfo.Component,
+		version:     componentInfo.Version,
+		digest:      digest,
+		sourceSpec:  sourceSpec,
+		targetSpec:  targetSpec,
+		cfg:         cfg,
+		transferCfg: transferCfg,
+	})
+	switch {
+	case errors.Is(err, ErrTransferInProgress):
+		// The transfer is in progress, the controller will be re-triggered via event source when it completes.
+		status.SetCondition(replication, metav1.Condition{
+			Type:    v1alpha1.TransferInProgressCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1alpha1.TransferringReason,
+			Message: fmt.Sprintf("Transferring component version %s", componentInfo.Version),
+		})
+		logger.Info("transfer in progress, waiting for event notification",
+			"component", componentInfo.Component, "version", componentInfo.Version)
+
+		return ctrl.Result{}, nil
+	case err != nil:
+		markTransferIdle(replication)
+		status.MarkNotReady(r.EventRecorder, replication, v1alpha1.ReplicationFailedReason, err.Error())
+
+		return ctrl.Result{}, fmt.Errorf("failed to transfer component version: %w", err)
+	}
+
+	markTransferIdle(replication)
+	replication.Status.LastTransferredVersion = result.Version
+	replication.Status.LastTransferredDigest = result.Digest
+	status.RemoveCondition(replication, v1alpha1.ReconcilingCondition)
+	status.SetCondition(replication, metav1.Condition{
+		Type:    v1alpha1.ReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.TransferCompleteReason,
+		Message: fmt.Sprintf("Successfully transferred component version %s", result.Version),
+	})
+	event.New(r.EventRecorder, replication, nil, v1alpha1.EventSeverityInfo, "Successfully transferred component version %s", result.Version)
+
+	return status.RequeueResult(replication, replication.GetRequeueAfter()), nil
+}
+
+// transferRequest contains everything a transfer of a component version requires.
+type transferRequest struct {
+	component   string
+	version     string
+	digest      string
+	sourceSpec  runtime.Typed
+	targetSpec  runtime.Typed
+	cfg         *configuration.Configuration
+	transferCfg *transferv1alpha1.Config
+}
+
+// submitTransfer submits the transfer to the transfer worker pool. The first call returns ErrTransferInProgress, a
+// call after the transfer finished returns its result. Burst reconciles for a submitted transfer return
+// ErrTransferInProgress without submitting it again.
+func (r *Reconciler) submitTransfer(ctx context.Context, replication *v1alpha1.Replication, req *transferRequest) (*transferResult, error) {
+	key, err := transferKey(replication, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transfer key: %w", err)
+	}
+
+	requester := workerpool.RequesterInfo{
+		NamespacedName: types.NamespacedName{
+			Namespace: replication.GetNamespace(),
+			Name:      replication.GetName(),
+		},
+	}
+	logger := log.FromContext(ctx)
+	uid := replication.GetUID()
+
+	result, err := workerpool.Submit(ctx, r.TransferPool, workerpool.ResolveOptions{
+		Component: req.component,
+		Version:   req.version,
+		KeyFunc:   func() (string, error) { return key, nil },
+		Requester: requester,
+	}, func(ctx context.Context) (*transferResult, error) {
+		ctx, done := r.startTransfer(ctx, uid)
+		defer done()
+
+		return r.transfer(log.IntoContext(ctx, logger), requester.NamespacedName, uid, req)
+	})
+	if errors.Is(err, workerpool.ErrResolutionInProgress) {
+		return nil, ErrTransferInProgress
+	}
+
+	return result, err
+}
+
+// transfer plans the transfer by building the transformation graph definition and executes it. It is run by the
+// transfer worker pool.
+func (r *Reconciler) transfer(ctx context.Context, key types.NamespacedName, uid types.UID, req *transferRequest) (_ *transferResult, err error) {
+	logger := log.FromContext(ctx)
+
+	// The replication might have been deleted while the transfer was queued.
+	replication := &v1alpha1.Replication{}
+	if err := r.Get(ctx, key, replication); err != nil {
+		return nil, fmt.Errorf("failed to get replication: %w", err)
+	}
+	if replication.GetUID() != uid || !replication.GetDeletionTimestamp().IsZero() {
+		return nil, fmt.Errorf("replication %s was deleted", key)
+	}
+
+	TransfersInProgressGauge.Inc()
+	start := time.Now()
+	defer func() {
+		TransfersInProgressGauge.Dec()
+		result := resultSuccess
+		if err != nil {
+			result = resultFailure
+		}
+		TransfersCounterTotal.WithLabelValues(req.component, req.version, result).Inc()
+		TransferDurationHistogram.WithLabelValues(req.component, req.version, result).Observe(time.Since(start).Seconds())
+	}()
+
+	tgd, err := r.planTransfer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("transferring component version", "component", req.component, "version", req.version,
+		"target", req.targetSpec, "transformations", len(tgd.Transformations))
+	if err := r.executeTransfer(ctx, req, tgd); err != nil {
+		return nil, err
+	}
+	logger.Info("transferred component version", "component", req.component, "version", req.version,
+		"duration", time.Since(start))
+
+	return &transferResult{Version: req.version, Digest: req.digest}, nil
+}
+
+// planTransfer builds the transformation graph definition of the transfer. It is held in memory only. To inspect it,
+// it is logged on debug level.
+func (r *Reconciler) planTransfer(ctx context.Context, req *transferRequest) (*transformv1alpha1.TransformationGraphDefinition, error) {
+	logger := log.FromContext(ctx)
+
+	resolver, err := r.Resolver.RepositoryResolver(ctx, req.sourceSpec, req.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source repository resolver: %w", err)
+	}
+
+	tgd, err := transfer.BuildGraphDefinition(ctx, req.transferCfg, transfer.Mapping{
+		Components: []transfer.ComponentID{{Component: req.component, Version: req.version}},
+		Target:     req.targetSpec,
+		Resolver:   resolver,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transformation graph definition: %w", err)
+	}
+
+	if debug := logger.V(v1alpha1.LevelDebug); debug.Enabled() {
+		if data, err := yaml.Marshal(tgd); err != nil {
+			debug.Info("failed to marshal transformation graph definition", "error", err)
+		} else {
+			debug.Info("built transformation graph definition", "component", req.component, "version", req.version,
+				"transformationGraphDefinition", string(data))
+		}
+	}
+
+	return tgd, nil
+}
+
+// executeTransfer runs the transformation graph definition of the transfer.
+func (r *Reconciler) executeTransfer(ctx context.Context, req *transferRequest, tgd *transformv1alpha1.TransformationGraphDefinition) error {
+	logger := log.FromContext(ctx)
+
+	var config *genericv1.Config
+	if req.cfg != nil {
+		config = req.cfg.Config
+	}
+	credGraph, err := setup.NewCredentialGraph(ctx, config, setup.CredentialGraphOptions{
+		PluginManager: r.PluginManager,
+		Logger:        &logger,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create credential graph: %w", err)
+	}
+
+	var concurrency int
+	if req.transferCfg != nil {
+		concurrency = req.transferCfg.Concurrency
+	}
+
+	graph, err := transfer.NewDefaultBuilder(
+		r.PluginManager.ComponentVersionRepositoryRegistry,
+		r.PluginManager.ResourcePluginRegistry,
+		credGraph,
+		transfer.WithSigningHandlers(r.PluginManager.SigningRegistry),
+		transfer.WithResourceDigestProcessors(&digestProcessorProvider{pm: r.PluginManager}),
+	).WithConcurrency(concurrency).BuildAndCheck(tgd)
+	if err != nil {
+		return fmt.Errorf("failed to build transformation graph: %w", err)
+	}
+
+	if err := graph.Process(ctx); err != nil {
+		return fmt.Errorf("failed to process transformation graph: %w", err)
+	}
+
+	return nil
+}
+
+// startTransfer registers the transfer of the replication with the given UID as in flight and returns a context that
+// is canceled when the replication is deleted. The returned function must be called once the transfer stopped.
+func (r *Reconciler) startTransfer(ctx context.Context, uid types.UID) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	transfer := &inFlightTransfer{cancel: cancel, done: make(chan struct{})}
+
+	r.mu.Lock()
+	if r.inFlight == nil {
+		r.inFlight = make(map[types.UID]*inFlightTransfer)
+	}
+	r.inFlight[uid] = transfer
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		if r.inFlight[uid] == transfer {
+			delete(r.inFlight, uid)
+		}
+		r.mu.Unlock()
+		cancel()
+		close(transfer.done)
+	}
+}
+
+// isTransferring reports whether a transfer of the replication with the given UID is in flight.
+func (r *Reconciler) isTransferring(uid types.UID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.inFlight[uid]
+
+	return ok
+}
+
+// cancelTransfer cancels the in-flight transfer of the replication with the given UID, if any, and waits for it to stop
+// for at most the drain timeout. It returns false if the transfer did not stop in time.
+func (r *Reconciler) cancelTransfer(uid types.UID) bool {
+	r.mu.Lock()
+	transfer, ok := r.inFlight[uid]
+	r.mu.Unlock()
+	if !ok {
+		return true
+	}
+
+	transfer.cancel()
+
+	timeout := time.NewTimer(r.drainTimeout())
+	defer timeout.Stop()
+	select {
+	case <-transfer.done:
+		return true
+	case <-timeout.C:
+		return false
+	}
+}
+
+func (r *Reconciler) drainTimeout() time.Duration {
+	if r.DrainTimeout <= 0 {
+		return DefaultDrainTimeout
+	}
+
+	return r.DrainTimeout
+}
+
+// getTransferConfig returns the transfer config referenced or inlined by the replication. It returns nil if the
+// replication does not configure the transfer.
+func (r *Reconciler) getTransferConfig(ctx context.Context, replication *v1alpha1.Replication) (*transferv1alpha1.Config, error) {
+	ref := replication.Spec.TransferConfig
+	switch {
+	case ref == nil:
+		return nil, nil
+	case ref.Inlined != nil:
+		return decodeTransferConfig(ref.Inlined.Raw)
+	case ref.Name != "":
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = replication.GetNamespace()
+		}
+		configMap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, configMap); err != nil {
+			return nil, fmt.Errorf("failed to get transfer config map: %w", err)
+		}
+		data, ok := configMap.Data[v1alpha1.TransferConfigKey]
+		if !ok || data == "" {
+			return nil, fmt.Errorf("no transfer config found in configmap %s/%s under key %q", namespace, ref.Name, v1alpha1.TransferConfigKey)
+		}
+
+		return decodeTransferConfig([]byte(data))
+	default:
+		return nil, errors.New("transfer config must either reference a config map or be inlined")
+	}
+}
+
+// decodeTransferConfig decodes a generic ocm config and returns its merged transfer config entries.
+func decodeTransferConfig(data []byte) (*transferv1alpha1.Config, error) {
+	var cfg genericv1.Config
+	if err := genericv1.Scheme.Decode(bytes.NewReader(data), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode transfer config: %w", err)
+	}
+
+	transferCfg, err := transferv1alpha1.LookupConfig(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return transferCfg, nil
+}
+
+// decodeRepositorySpec decodes a repository spec into its typed representation, so that the transfer can choose the
+// transformations for the repository type. Repository types unknown to the oci repository scheme are returned raw.
+func decodeRepositorySpec(spec *apiextensionsv1.JSON) (runtime.Typed, error) {
+	if spec == nil {
+		return nil, errors.New("repository spec must not be nil")
+	}
+
+	raw := &runtime.Raw{}
+	if err := runtime.NewScheme(runtime.WithAllowUnknown()).Decode(bytes.NewReader(spec.Raw), raw); err != nil {
+		return nil, err
+	}
+
+	typed, err := ocirepository.Scheme.NewObject(raw.GetType())
+	if err != nil {
+		return raw, nil //nolint:nilerr // unknown repository types are passed on to the plugins as they are
+	}
+	if err := ocirepository.Scheme.Convert(raw, typed); err != nil {
+		return nil, fmt.Errorf("failed to convert repository spec of type %s: %w", raw.GetType(), err)
+	}
+
+	return typed, nil
+}
+
+// transferKey identifies a transfer. A transfer is submitted again if the replication spec, the source digest, the
+// configuration or the repositories change.
+func transferKey(replication *v1alpha1.Replication, req *transferRequest) (string, error) {
+	transferCfg, err := json.Marshal(req.transferCfg)
+	if err != nil {
+		return "", err
+	}
+	sourceSpec, err := json.Marshal(req.sourceSpec)
+	if err != nil {
+		return "", err
+	}
+	targetSpec, err := json.Marshal(req.targetSpec)
+	if err != nil {
+		return "", err
+	}
+
+	var configHash []byte
+	if req.cfg != nil {
+		configHash = req.cfg.Hash
+	}
+
+	hasher := fnv.New64a()
+	for _, part := range [][]byte{
+		[]byte(replication.GetUID()),
+		[]byte(fmt.Sprintf("%d", replication.GetGeneration())),
+		[]byte(req.component),
+		[]byte(req.version),
+		[]byte(req.digest),
+		configHash,
+		transferCfg,
+		sourceSpec,
+		targetSpec,
+	} {
+		_, _ = hasher.Write(part)
+		// separate the parts, so that moving bytes between them changes the key
+		_, _ = hasher.Write([]byte{0})
+	}
+
+	return fmt.Sprintf("replication:%x", hasher.Sum64()), nil
+}
+
+// formatDigest formats a component version digest like "sha256:<value>".
+func formatDigest(digest *v2.Digest) string {
+	algorithm := strings.ToLower(strings.ReplaceAll(digest.HashAlgorithm, "-", ""))
+
+	return algorithm + ":" + digest.Value
+}
+
+// markTransferIdle marks that the replication is not transferring a component version.
+func markTransferIdle(replication *v1alpha1.Replication) {
+	status.SetCondition(replication, metav1.Condition{
+		Type:   v1alpha1.TransferInProgressCondition,
+		Status: metav1.ConditionFalse,
+		Reason: v1alpha1.IdleReason,
+	})
+}
+
+func componentKey(replication *v1alpha1.Replication) client.ObjectKey {
+	return objectKey(replication.Spec.ComponentRef, replication.GetNamespace())
+}
+
+func targetRepositoryKey(replication *v1alpha1.Replication) client.ObjectKey {
+	return objectKey(replication.Spec.TargetRepositoryRef, replication.GetNamespace())
+}
+
+// objectKey returns the key of the referenced object, defaulting to the given namespace.
+func objectKey(ref v1alpha1.ObjectKey, namespace string) client.ObjectKey {
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+
+	return client.ObjectKey{Namespace: namespace, Name: ref.Name}
+}
+
+// digestProcessorProvider selects the digest processor of a resource by its access.
+type digestProcessorProvider struct {
+	pm *manager.PluginManager
+}
+
+func (p *digestProcessorProvider) GetDigestProcessor(ctx context.Context, resource *descriptor.Resource) (repository.ResourceDigestProcessor, error) {
+	return p.pm.DigestProcessorRegistry.GetPlugin(ctx, resource.Access)
+}
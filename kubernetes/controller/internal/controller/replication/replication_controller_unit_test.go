@@ -0,0 +1,294 @@
+package replication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v2 "ocm.software/open-component-model/bindings/go/descriptor/v2"
+	ociv1 "ocm.software/open-component-model/bindings/go/oci/spec/repository/v1/oci"
+	ocmruntime "ocm.software/open-component-model/bindings/go/runtime"
+	transferv1alpha1 "ocm.software/open-component-model/bindings/go/transfer/v1alpha1/spec"
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+	"ocm.software/open-component-model/kubernetes/controller/internal/ocm"
+	"ocm.software/open-component-model/kubernetes/controller/internal/status"
+)
+
+const transferConfig = `
+type: generic.config.ocm.software/v1
+configurations:
+  - type: transfer.config.ocm.software/v1alpha1
+    copyMode: allResources
+    recursive: -1
+`
+
+func newReplicationReconciler(fakeClient client.Client, scheme *runtime.Scheme) *Reconciler {
+	return &Reconciler{
+		BaseReconciler: &ocm.BaseReconciler{
+			Client:        fakeClient,
+			Scheme:        scheme,
+			EventRecorder: &record.FakeRecorder{Events: make(chan string, 100)},
+		},
+		DrainTimeout: 100 * time.Millisecond,
+	}
+}
+
+func newScheme(g *WithT) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	return scheme
+}
+
+func newReplication(namespace, name string) *v1alpha1.Replication {
+	return &v1alpha1.Replication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  namespace,
+			Generation: 1,
+			UID:        "replication-uid",
+			Finalizers: []string{v1alpha1.ReplicationFinalizer},
+		},
+		Spec: v1alpha1.ReplicationSpec{
+			ComponentRef:        v1alpha1.ObjectKey{Name: "test-component"},
+			TargetRepositoryRef: v1alpha1.ObjectKey{Name: "target-repo"},
+			Interval:            metav1.Duration{Duration: time.Minute},
+		},
+	}
+}
+
+func TestGetTransferConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	NewWithT(t).Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "transfer", Namespace: "other"},
+		Data:       map[string]string{v1alpha1.TransferConfigKey: transferConfig},
+	}
+	invalid := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "default"},
+		Data:       map[string]string{"other": transferConfig},
+	}
+	r := newReplicationReconciler(fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap, invalid).Build(), scheme)
+
+	tests := []struct {
+		name      string
+		ref       *v1alpha1.TransferConfigReference
+		expectErr string
+		expectCfg *transferv1alpha1.Config
+	}{
+		{
+			name: "no transfer config",
+		},
+		{
+			name: "inlined",
+			ref:  &v1alpha1.TransferConfigReference{Inlined: &apiextensionsv1.JSON{Raw: []byte(`{"type":"generic.config.ocm.software/v1","configurations":[{"type":"transfer.config.ocm.software/v1alpha1","copyMode":"allResources","recursive":-1}]}`)}},
+			expectCfg: &transferv1alpha1.Config{
+				CopyMode:  transferv1alpha1.CopyModeAllResources,
+				Recursive: -1,
+			},
+		},
+		{
+			name: "config map",
+			ref:  &v1alpha1.TransferConfigReference{Name: "transfer", Namespace: "other"},
+			expectCfg: &transferv1alpha1.Config{
+				CopyMode:  transferv1alpha1.CopyModeAllResources,
+				Recursive: -1,
+			},
+		},
+		{
+			name:      "config map in the namespace of the replication",
+			ref:       &v1alpha1.TransferConfigReference{Name: "transfer"},
+			expectErr: "failed to get transfer config map",
+		},
+		{
+			name:      "config map without transfer config key",
+			ref:       &v1alpha1.TransferConfigReference{Name: "invalid"},
+			expectErr: "no transfer config found in configmap default/invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			replication := newReplication("default", "test-replication")
+			replication.Spec.TransferConfig = tt.ref
+
+			cfg, err := r.getTransferConfig(t.Context(), replication)
+			if tt.expectErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.expectErr)))
+
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tt.expectCfg == nil {
+				g.Expect(cfg).To(BeNil())
+
+				return
+			}
+			g.Expect(cfg).NotTo(BeNil())
+			g.Expect(cfg.CopyMode).To(Equal(tt.expectCfg.CopyMode))
+			g.Expect(cfg.Recursive).To(Equal(tt.expectCfg.Recursive))
+		})
+	}
+}
+
+func TestFormatDigest(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(formatDigest(&v2.Digest{HashAlgorithm: "SHA-256", Value: "abc"})).To(Equal("sha256:abc"))
+	g.Expect(formatDigest(&v2.Digest{HashAlgorithm: "sha512", Value: "def"})).To(Equal("sha512:def"))
+}
+
+func TestTransferKey(t *testing.T) {
+	g := NewWithT(t)
+
+	replication := newReplication("default", "test-replication")
+	newRequest := func() *transferRequest {
+		return &transferRequest{
+			component:  "ocm.software/test",
+			version:    "1.0.0",
+			digest:     "sha256:abc",
+			sourceSpec: &ociv1.Repository{Type: ocmruntime.NewUnversionedType(ociv1.Type), BaseUrl: "ghcr.io/source"},
+			targetSpec: &ociv1.Repository{Type: ocmruntime.NewUnversionedType(ociv1.Type), BaseUrl: "ghcr.io/target"},
+		}
+	}
+
+	key, err := transferKey(replication, newRequest())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	same, err := transferKey(replication, newRequest())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(same).To(Equal(key))
+
+	for name, modify := range map[string]func(*v1alpha1.Replication, *transferRequest){
+		"generation": func(r *v1alpha1.Replication, _ *transferRequest) { r.Generation = 2 },
+		"digest":     func(_ *v1alpha1.Replication, req *transferRequest) { req.digest = "sha256:def" },
+		"target": func(_ *v1alpha1.Replication, req *transferRequest) {
+			req.targetSpec = &ociv1.Repository{Type: ocmruntime.NewUnversionedType(ociv1.Type), BaseUrl: "ghcr.io/other"}
+		},
+		"transfer config": func(_ *v1alpha1.Replication, req *transferRequest) {
+			req.transferCfg = &transferv1alpha1.Config{CopyMode: transferv1alpha1.CopyModeAllResources}
+		},
+	} {
+		modifiedReplication, req := replication.DeepCopy(), newRequest()
+		modify(modifiedReplication, req)
+		modified, err := transferKey(modifiedReplication, req)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(modified).NotTo(Equal(key), "changing the %s must change the key", name)
+	}
+}
+
+func TestReconcile_AlreadyTransferred_DoesNotTransferAgain(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+	scheme := newScheme(g)
+
+	component := &v1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-component", Namespace: "default"},
+		Status: v1alpha1.ComponentStatus{
+			Component: v1alpha1.ComponentInfo{
+				RepositorySpec: &apiextensionsv1.JSON{Raw: []byte(`{"type":"OCIRepository","baseUrl":"ghcr.io/source"}`)},
+				Component:      "ocm.software/test",
+				Version:        "1.0.0",
+				Digest:         &v2.Digest{HashAlgorithm: "SHA-256", Value: "abc"},
+			},
+		},
+	}
+	status.MarkReady(&record.FakeRecorder{}, component, "ready")
+
+	target := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "target-repo", Namespace: "default"},
+		Spec: v1alpha1.RepositorySpec{
+			RepositorySpec: &apiextensionsv1.JSON{Raw: []byte(`{"type":"OCIRepository","baseUrl":"ghcr.io/target"}`)},
+		},
+	}
+	status.MarkReady(&record.FakeRecorder{}, target, "ready")
+
+	replication := newReplication("default", "test-replication")
+	replication.Status.ObservedGeneration = 1
+	replication.Status.LastTransferredVersion = "1.0.0"
+	replication.Status.LastTransferredDigest = "sha256:abc"
+	status.MarkReady(&record.FakeRecorder{}, replication, "transferred")
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(component, target, replication).
+		WithStatusSubresource(&v1alpha1.Replication{}).
+		Build()
+
+	// no transfer pool is set, so submitting a transfer would panic
+	r := newReplicationReconciler(fakeClient, scheme)
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(replication)})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+	updated := &v1alpha1.Replication{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(replication), updated)).To(Succeed())
+	g.Expect(status.IsReady(updated)).To(BeTrue())
+	g.Expect(updated.Status.LastTransferredDigest).To(Equal("sha256:abc"))
+}
+
+func TestReconcile_Deletion_CancelsInFlightTransfer(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+	scheme := newScheme(g)
+
+	replication := newReplication("default", "test-replication")
+	now := metav1.Now()
+	replication.DeletionTimestamp = &now
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(replication).
+		WithStatusSubresource(&v1alpha1.Replication{}).
+		Build()
+	r := newReplicationReconciler(fakeClient, scheme)
+
+	transferCtx, done := r.startTransfer(context.Background(), replication.GetUID())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-transferCtx.Done()
+		done()
+	}()
+	g.Expect(r.isTransferring(replication.GetUID())).To(BeTrue())
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(replication)})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Eventually(stopped).Should(BeClosed())
+	g.Expect(r.isTransferring(replication.GetUID())).To(BeFalse())
+
+	// removing the last finalizer of an object marked for deletion deletes it
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(replication), &v1alpha1.Replication{})
+	g.Expect(client.IgnoreNotFound(err)).To(Succeed())
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestReconcile_Deletion_DrainTimeout(t *testing.T) {
+	g := NewWithT(t)
+	scheme := newScheme(g)
+
+	r := newReplicationReconciler(fake.NewClientBuilder().WithScheme(scheme).Build(), scheme)
+
+	// a transfer that does not stop when it is canceled
+	_, done := r.startTransfer(context.Background(), "stuck")
+	defer done()
+
+	start := time.Now()
+	g.Expect(r.cancelTransfer("stuck")).To(BeFalse())
+	g.Expect(time.Since(start)).To(BeNumerically(">=", r.DrainTimeout))
+	g.Expect(r.cancelTransfer("unknown")).To(BeTrue())
+}
@@ -32,6 +32,8 @@ type CacheBackedRepository struct {
 	cfg      *configuration.Configuration
 	// verifications are used to verify against component version signatures and used as a cache key.
 	verifications []verification.Verification
+	// verificationPolicy relaxes the verifications to a policy and is used as part of the cache key.
+	verificationPolicy *verification.Policy
 	// digest is used to verify the integrity of a referenced component version and is used as part of the cache key.
 	digest *v2.Digest
 	// signingRegistry holds all plugins that implement capabilities to verify signatures and is used during resolution
@@ -61,6 +63,16 @@ func (c *CacheBackedRepository) AddComponentVersion(ctx context.Context, desc *d
 // This function is async. First call to this function will return a resolution.ErrResolutionInProgress error.
 // Second call, once the resolution succeeds, will return a cached result with a default TTL.
 func (c *CacheBackedRepository) GetComponentVersion(ctx context.Context, component, version string) (*descriptor.Descriptor, error) {
+	verified, err := c.GetVerifiedComponentVersion(ctx, component, version)
+	if verified == nil {
+		return nil, err
+	}
+	return verified.Descriptor, err
+}
+
+// GetVerifiedComponentVersion behaves like GetComponentVersion, but also returns the evaluation of the verification
+// policy, so that callers can report which policy clauses passed.
+func (c *CacheBackedRepository) GetVerifiedComponentVersion(ctx context.Context, component, version string) (*workerpool.VerifiedDescriptor, error) {
 	var configHash []byte
 	if c.cfg != nil {
 		configHash = c.cfg.Hash
@@ -71,9 +83,9 @@ func (c *CacheBackedRepository) GetComponentVersion(ctx context.Context, compone
 		// The actual repository is determined by the providers resolver
 		// configuration (which is represented through the config hash) and
 		// the base repository.
-		// The verifications and digests are part of the cache-key to ensure that verified or integrity-checked
-		// component versions are cached under another cache-key than non-verified ones.
-		return buildCacheKey(configHash, c.baseRepoSpec, component, version, c.verifications, c.digest)
+		// The verifications, verification policy and digests are part of the cache-key to ensure that verified or
+		// integrity-checked component versions are cached under another cache-key than non-verified ones.
+		return buildCacheKey(configHash, c.baseRepoSpec, component, version, c.verifications, c.verificationPolicy, c.digest)
 	}
 
 	repo, err := c.resolver.GetComponentVersionRepositoryForComponent(ctx, component, version)
@@ -82,26 +94,27 @@ func (c *CacheBackedRepository) GetComponentVersion(ctx context.Context, compone
 	}
 
 	wpOpts := workerpool.ResolveOptions{
-		Component:       component,
-		Version:         version,
-		Verifications:   c.verifications,
-		Digest:          c.digest,
-		SigningRegistry: c.signingRegistry,
-		Repository:      repo,
-		KeyFunc:         keyFunc,
-		Requester:       c.requesterFunc(),
+		Component:          component,
+		Version:            version,
+		Verifications:      c.verifications,
+		VerificationPolicy: c.verificationPolicy,
+		Digest:             c.digest,
+		SigningRegistry:    c.signingRegistry,
+		Repository:         repo,
+		KeyFunc:            keyFunc,
+		Requester:          c.requesterFunc(),
 	}
 
-	desc, err := c.workerPool.GetComponentVersion(ctx, wpOpts)
+	verified, err := c.workerPool.GetVerifiedComponentVersion(ctx, wpOpts)
 	if err != nil {
 		if errors.Is(err, workerpool.ErrNotSafelyDigestible) {
-			return desc, err
+			return verified, err
 		}
 
 		return nil, err
 	}
 
-	return desc, nil
+	return verified, nil
 }
 
 // ListComponentVersions lists all versions of a component.
@@ -168,12 +181,12 @@ func (c *CacheBackedRepository) CheckHealth(ctx context.Context) error {
 }
 
 // buildCacheKey generates a cache key from the configuration hash, repository spec, component, version, verifications,
-// and a digest spec.
-// The verifications and digest spec are included in the cache-key to ensure that different cache entries are created
-// for verified and unverified component versions of the same kind.
+// verification policy and a digest spec.
+// The verifications, verification policy and digest spec are included in the cache-key to ensure that different cache
+// entries are created for verified and unverified component versions of the same kind.
 // It canonicalizes the repository spec, verifications, and digest spec using JCS (RFC 8785) before hashing to ensure
 // consistent keys regardless of field ordering in the JSON representation.
-func buildCacheKey(configHash []byte, repoSpec runtime.Typed, component, version string, verifications []verification.Verification, digestSpec *v2.Digest) (string, error) {
+func buildCacheKey(configHash []byte, repoSpec runtime.Typed, component, version string, verifications []verification.Verification, policy *verification.Policy, digestSpec *v2.Digest) (string, error) {
 	repoJSON, err := json.Marshal(repoSpec)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal repository spec: %w", err)
@@ -203,6 +216,13 @@ func buildCacheKey(configHash []byte, repoSpec runtime.Typed, component, version
 		return "", fmt.Errorf("failed to canonicalize verifications: %w", err)
 	}
 
+	var policyJSON []byte
+	if policy != nil {
+		if policyJSON, err = json.Marshal(policy); err != nil {
+			return "", fmt.Errorf("failed to marshal verification policy: %w", err)
+		}
+	}
+
 	var canonicalDigestJSON []byte
 	if digestSpec != nil {
 		digestJSON, err := json.Marshal(digestSpec)
@@ -229,6 +249,8 @@ func buildCacheKey(configHash []byte, repoSpec runtime.Typed, component, version
 	_, _ = hasher.Write(sep)
 	_, _ = hasher.Write(canonicalVerificationsJSON)
 	_, _ = hasher.Write(sep)
+	_, _ = hasher.Write(policyJSON)
+	_, _ = hasher.Write(sep)
 	_, _ = hasher.Write(canonicalDigestJSON)
 
 	return fmt.Sprintf("%016x", hasher.Sum64()), nil
@@ -25,10 +25,10 @@ func TestBuildCacheKeyHashKeyGeneration(t *testing.T) {
 			BaseUrl: "localhost:5000/test",
 		}
 
-		key1, err := buildCacheKey(configHash, spec1, component, version, nil, nil)
+		key1, err := buildCacheKey(configHash, spec1, component, version, nil, nil, nil)
 		require.NoError(t, err)
 
-		key2, err := buildCacheKey(configHash, spec2, component, version, nil, nil)
+		key2, err := buildCacheKey(configHash, spec2, component, version, nil, nil, nil)
 		require.NoError(t, err)
 
 		assert.Equal(t, key1, key2, "cache keys should be identical for same spec")
@@ -47,10 +47,10 @@ func TestBuildCacheKeyHashKeyGeneration(t *testing.T) {
 			BaseUrl: "localhost:5000/test2",
 		}
 
-		key1, err := buildCacheKey(configHash, spec1, component, version, nil, nil)
+		key1, err := buildCacheKey(configHash, spec1, component, version, nil, nil, nil)
 		require.NoError(t, err)
 
-		key2, err := buildCacheKey(configHash, spec2, component, version, nil, nil)
+		key2, err := buildCacheKey(configHash, spec2, component, version, nil, nil, nil)
 		require.NoError(t, err)
 
 		assert.NotEqual(t, key1, key2, "cache keys should differ for different specs")
@@ -62,10 +62,10 @@ func TestBuildCacheKeyHashKeyGeneration(t *testing.T) {
 			BaseUrl: "localhost:5000/test",
 		}
 
-		key1, err := buildCacheKey(configHash, spec, "component1", "v1.0.0", nil, nil)
+		key1, err := buildCacheKey(configHash, spec, "component1", "v1.0.0", nil, nil, nil)
 		require.NoError(t, err)
 
-		key2, err := buildCacheKey(configHash, spec, "component2", "v1.0.0", nil, nil)
+		key2, err := buildCacheKey(configHash, spec, "component2", "v1.0.0", nil, nil, nil)
 		require.NoError(t, err)
 
 		assert.NotEqual(t, key1, key2, "cache keys should differ for different components")
@@ -78,10 +78,10 @@ func TestBuildCacheKeyHashKeyGeneration(t *testing.T) {
 		}
 		component := "test-component"
 
-		key1, err := buildCacheKey(configHash, spec, component, "v1.0.0", nil, nil)
+		key1, err := buildCacheKey(configHash, spec, component, "v1.0.0", nil, nil, nil)
 		require.NoError(t, err)
 
-		key2, err := buildCacheKey(configHash, spec, component, "v2.0.0", nil, nil)
+		key2, err := buildCacheKey(configHash, spec, component, "v2.0.0", nil, nil, nil)
 		require.NoError(t, err)
 
 		assert.NotEqual(t, key1, key2, "cache keys should differ for different versions")
@@ -94,10 +94,10 @@ func TestBuildCacheKeyHashKeyGeneration(t *testing.T) {
 		component := "test-component"
 		version := "v1.0.0"
 
-		key1, err := buildCacheKey([]byte("config1"), spec, component, version, nil, nil)
+		key1, err := buildCacheKey([]byte("config1"), spec, component, version, nil, nil, nil)
 		require.NoError(t, err)
 
-		key2, err := buildCacheKey([]byte("config2"), spec, component, version, nil, nil)
+		key2, err := buildCacheKey([]byte("config2"), spec, component, version, nil, nil, nil)
 		require.NoError(t, err)
 
 		assert.NotEqual(t, key1, key2, "cache keys should differ for different config hashes")
@@ -111,7 +111,7 @@ func TestBuildCacheKeyHashKeyGeneration(t *testing.T) {
 		component := "test-component"
 		version := "v1.0.0"
 
-		key, err := buildCacheKey(configHash, spec, component, version, nil, nil)
+		key, err := buildCacheKey(configHash, spec, component, version, nil, nil, nil)
 		require.NoError(t, err)
 
 		assert.Len(t, key, 16, "FNV-1a 64-bit hash should produce 16 hex characters")
@@ -133,10 +133,10 @@ func TestBuildCacheKeyHashKeyGeneration(t *testing.T) {
 			{Signature: "sig2"},
 		}
 
-		key1, err := buildCacheKey(configHash, spec, component, version, verifications1, nil)
+		key1, err := buildCacheKey(configHash, spec, component, version, verifications1, nil, nil)
 		require.NoError(t, err)
 
-		key2, err := buildCacheKey(configHash, spec, component, version, verifications2, nil)
+		key2, err := buildCacheKey(configHash, spec, component, version, verifications2, nil, nil)
 		require.NoError(t, err)
 
 		assert.NotEqual(t, key1, key2, "cache keys should differ for different verifications")
@@ -159,10 +159,10 @@ func TestBuildCacheKeyHashKeyGeneration(t *testing.T) {
 			{Signature: "sig1"},
 		}
 
-		key1, err := buildCacheKey(configHash, spec, component, version, verifications1, nil)
+		key1, err := buildCacheKey(configHash, spec, component, version, verifications1, nil, nil)
 		require.NoError(t, err)
 
-		key2, err := buildCacheKey(configHash, spec, component, version, verifications2, nil)
+		key2, err := buildCacheKey(configHash, spec, component, version, verifications2, nil, nil)
 		require.NoError(t, err)
 
 		assert.Equal(t, key1, key2, "cache keys should be same for different order of verifications")
@@ -187,10 +187,10 @@ func TestBuildCacheKeyHashKeyGeneration(t *testing.T) {
 			NormalisationAlgorithm: "normalisation2",
 		}
 
-		key1, err := buildCacheKey(configHash, spec, component, version, nil, digest1)
+		key1, err := buildCacheKey(configHash, spec, component, version, nil, nil, digest1)
 		require.NoError(t, err)
 
-		key2, err := buildCacheKey(configHash, spec, component, version, nil, digest2)
+		key2, err := buildCacheKey(configHash, spec, component, version, nil, nil, digest2)
 		require.NoError(t, err)
 
 		assert.NotEqual(t, key1, key2, "cache keys should differ for different digests")
@@ -215,10 +215,10 @@ func TestBuildCacheKeyHashKeyGeneration(t *testing.T) {
 			HashAlgorithm:          "sha256",
 		}
 
-		key1, err := buildCacheKey(configHash, spec, component, version, nil, digest1)
+		key1, err := buildCacheKey(configHash, spec, component, version, nil, nil, digest1)
 		require.NoError(t, err)
 
-		key2, err := buildCacheKey(configHash, spec, component, version, nil, digest2)
+		key2, err := buildCacheKey(configHash, spec, component, version, nil, nil, digest2)
 		require.NoError(t, err)
 
 		assert.Equal(t, key1, key2, "cache keys should not differ for same digests")
@@ -56,6 +56,8 @@ type RepositoryOptions struct {
 	RequesterFunc  func() workerpool.RequesterInfo
 	// Verifications are used to verify against component version signatures and used a cache key.
 	Verifications []verification.Verification
+	// VerificationPolicy relaxes the Verifications to a policy and is used as part of the cache key.
+	VerificationPolicy *verification.Policy
 	// Digest is used to verify the integrity of a referenced component version and is used as part of the cache key.
 	Digest          *v2.Digest
 	SigningRegistry *signinghandler.SigningRegistry
@@ -78,36 +80,50 @@ func (r *Resolver) NewCacheBackedRepository(ctx context.Context, opts *Repositor
 	if baseRepoSpec == nil {
 		return nil, fmt.Errorf("base repository spec is required")
 	}
+	provider, err := r.RepositoryResolver(ctx, baseRepoSpec, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CacheBackedRepository{
+		logger:             r.logger,
+		resolver:           provider,
+		cfg:                cfg,
+		workerPool:         r.workerPool,
+		requesterFunc:      requesterFunc,
+		baseRepoSpec:       baseRepoSpec,
+		verifications:      opts.Verifications,
+		verificationPolicy: opts.VerificationPolicy,
+		digest:             opts.Digest,
+		signingRegistry:    opts.SigningRegistry,
+	}, nil
+}
+
+// RepositoryResolver returns the resolver of the repository for each component based on:
+// 1. Path matcher resolvers from OCM configuration (if configured)
+// 2. The provided repository spec as a fallback
+// Resolvers are cached by the configuration and the repository spec.
+// In contrast to NewCacheBackedRepository, the repositories of the resolver are not backed by the worker pool.
+func (r *Resolver) RepositoryResolver(ctx context.Context, spec runtime.Typed, cfg *configuration.Configuration) (resolvers.ComponentVersionRepositoryResolver, error) {
 	var configHash []byte
 	if cfg != nil {
 		configHash = cfg.Hash
 	}
-	cacheKey, err := buildRepoCacheKey(configHash, baseRepoSpec)
+	cacheKey, err := buildRepoCacheKey(configHash, spec)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build repository cache key: %w", err)
 	}
-	var provider resolvers.ComponentVersionRepositoryResolver
 	if cached, ok := r.repoCache.Get(cacheKey); ok {
-		provider = cached.(resolvers.ComponentVersionRepositoryResolver)
-	} else {
-		provider, err = r.createResolver(ctx, opts.RepositorySpec, cfg)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create provider: %w", err)
-		}
-		r.repoCache.Add(cacheKey, provider)
+		return cached.(resolvers.ComponentVersionRepositoryResolver), nil
 	}
 
-	return &CacheBackedRepository{
-		logger:          r.logger,
-		resolver:        provider,
-		cfg:             cfg,
-		workerPool:      r.workerPool,
-		requesterFunc:   requesterFunc,
-		baseRepoSpec:    baseRepoSpec,
-		verifications:   opts.Verifications,
-		digest:          opts.Digest,
-		signingRegistry: opts.SigningRegistry,
-	}, nil
+	provider, err := r.createResolver(ctx, spec, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provider: %w", err)
+	}
+	r.repoCache.Add(cacheKey, provider)
+
+	return provider, nil
 }
 
 // createResolver creates a resolver based on the configuration.
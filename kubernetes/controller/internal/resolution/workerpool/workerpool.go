@@ -65,6 +65,8 @@ type ResolveOptions struct {
 	Repository repository.ComponentVersionRepository
 	// Verifications are used to verify against component version signatures and used a cache key.
 	Verifications []verification.Verification
+	// VerificationPolicy relaxes the Verifications to a policy. It is only used together with Verifications.
+	VerificationPolicy *verification.Policy
 	// Digest is used to verify the integrity of a referenced component version and is used as part of the cache key.
 	Digest          *v2.Digest
 	SigningRegistry *signinghandler.SigningRegistry
@@ -202,9 +204,36 @@ func (wp *WorkerPool) Start(ctx context.Context) error {
 	}
 }
 
+// VerifiedDescriptor is a resolved component version together with the evaluation of the verification policy, if
+// one was configured.
+type VerifiedDescriptor struct {
+	Descriptor   *descriptor.Descriptor
+	PolicyResult *verification.PolicyResult
+}
+
 // GetComponentVersion retrieves a component version using the worker pool and cache.
 func (wp *WorkerPool) GetComponentVersion(ctx context.Context, opts ResolveOptions) (*descriptor.Descriptor, error) {
-	return resolveWorkRequest[*descriptor.Descriptor](ctx, wp, opts, wp.getComponentVersion)
+	verified, err := wp.GetVerifiedComponentVersion(ctx, opts)
+	if verified == nil {
+		return nil, err
+	}
+	return verified.Descriptor, err
+}
+
+// GetVerifiedComponentVersion retrieves a component version using the worker pool and cache and returns it together
+// with the evaluation of the verification policy.
+func (wp *WorkerPool) GetVerifiedComponentVersion(ctx context.Context, opts ResolveOptions) (*VerifiedDescriptor, error) {
+	return resolveWorkRequest[*VerifiedDescriptor](ctx, wp, opts, wp.getComponentVersion)
+}
+
+// Submit runs fn on the worker pool and caches its result under the key of opts.KeyFunc. It is meant for work that
+// is not a component version resolution, e.g. a transfer. As for GetComponentVersion, the first call returns
+// ErrResolutionInProgress and the requester is notified once fn returned. Later calls return the cached result; an
+// error is returned once and then removed from the cache, so that the next call submits fn again.
+func Submit[T any](ctx context.Context, wp *WorkerPool, opts ResolveOptions, fn func(ctx context.Context) (T, error)) (T, error) {
+	return resolveWorkRequest[T](ctx, wp, opts, func(ctx context.Context, _ ResolveOptions) (any, error) {
+		return fn(ctx)
+	})
 }
 
 // resolveWorkRequest is an abstraction in front of the worker queue and resolution logic. It is meant to be called by
@@ -407,15 +436,23 @@ func (wp *WorkerPool) setResult(key string, result any, err error) []RequesterIn
 // getComponentVersion performs the actual component version resolution. If verifications or a digest from a component
 // reference from a parent component are provided, it performs the necessary integrity and signature verification.
 func (wp *WorkerPool) getComponentVersion(ctx context.Context, opts ResolveOptions) (any, error) {
+	desc, result, err := wp.resolveComponentVersion(ctx, opts)
+	if desc == nil {
+		return nil, err
+	}
+	return &VerifiedDescriptor{Descriptor: desc, PolicyResult: result}, err
+}
+
+func (wp *WorkerPool) resolveComponentVersion(ctx context.Context, opts ResolveOptions) (*descriptor.Descriptor, *verification.PolicyResult, error) {
 	logger := log.FromContext(ctx)
 
 	desc, err := opts.Repository.GetComponentVersion(ctx, opts.Component, opts.Version)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get component version %s:%s: %w", opts.Component, opts.Version, err)
+		return nil, nil, fmt.Errorf("failed to get component version %s:%s: %w", opts.Component, opts.Version, err)
 	}
 
 	if opts.Digest != nil && len(opts.Verifications) > 0 {
-		return nil, fmt.Errorf(
+		return nil, nil, fmt.Errorf(
 			"invalid resolve options for %s:%s: digest and verifications are mutually exclusive",
 			opts.Component, opts.Version,
 		)
@@ -423,73 +460,104 @@ func (wp *WorkerPool) getComponentVersion(ctx context.Context, opts ResolveOptio
 
 	switch {
 	case opts.Digest != nil:
-		return compareDigest(ctx, desc, opts.Digest)
+		digested, err := compareDigest(ctx, desc, opts.Digest)
+		return digested, nil, err
 	case len(opts.Verifications) > 0:
 		// If verifications are requested, we need to verify that the component version is safely digestible.
 		// Anything that comes after this will, in case of an error, always be skipped until cache TTL expires
 		if err := signing.IsSafelyDigestible(&desc.Component); err != nil {
-			return desc, fmt.Errorf("%w: %w", ErrNotSafelyDigestible, err)
+			return desc, nil, fmt.Errorf("%w: %w", ErrNotSafelyDigestible, err)
 		}
 
 		if opts.SigningRegistry == nil {
-			return nil, fmt.Errorf("signing registry is required when verifications are configured")
+			return nil, nil, fmt.Errorf("signing registry is required when verifications are configured")
 		}
 
-		return verifySignatures(ctx, desc, opts.Verifications, opts.SigningRegistry)
+		return verifySignatures(ctx, desc, opts.Verifications, opts.VerificationPolicy, opts.SigningRegistry)
 	default:
 		logger.Info("no digest or verifications provided, skipping integrity and signature verification",
 			"component", opts.Component, "version", opts.Version)
-		return desc, nil
+		return desc, nil, nil
 	}
 }
 
 // verifySignatures performs signature verification for the provided component version descriptor and the list of
-// verifications.
-func verifySignatures(ctx context.Context, desc *descriptor.Descriptor, verifications []verification.Verification, signingRegistry *signinghandler.SigningRegistry) (*descriptor.Descriptor, error) {
+// verifications. Without a policy, every verification must succeed. With a policy, the verifications are evaluated
+// against it and the outcome of every policy clause is logged and returned.
+func verifySignatures(ctx context.Context, desc *descriptor.Descriptor, verifications []verification.Verification, policy *verification.Policy, signingRegistry *signinghandler.SigningRegistry) (*descriptor.Descriptor, *verification.PolicyResult, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("verifying signature", "component", desc.Component.Name, "version", desc.Component.Version)
 
 	signingHandler, err := signingRegistry.GetPlugin(ctx, &signingv1alpha1.Config{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get signing handler plugin: %w", err)
+		return nil, nil, fmt.Errorf("failed to get signing handler plugin: %w", err)
 	}
 
-	for _, v := range verifications {
-		var descSig *descriptor.Signature
-		for i := range desc.Signatures {
-			if desc.Signatures[i].Name == v.Signature {
-				descSig = &desc.Signatures[i]
-				break
-			}
-		}
+	verify := func(ctx context.Context, v verification.Verification) error {
+		return verifySignature(ctx, desc, v, signingHandler)
+	}
 
-		if descSig == nil {
-			return nil, fmt.Errorf("signature %s not found in component %s", v.Signature, desc.Component.Name)
+	if policy != nil {
+		names := make([]string, 0, len(desc.Signatures))
+		for _, sig := range desc.Signatures {
+			names = append(names, sig.Name)
 		}
+		result := verification.EvaluatePolicy(ctx, policy, names, verifications, verify)
+		logger.Info("evaluated verification policy", "component", desc.Component.Name, "version", desc.Component.Version,
+			"valid", result.Valid, "required", result.Required, "clauses", result.String())
+		if err := result.Err(); err != nil {
+			return nil, nil, fmt.Errorf("signature verification failed for component %s: %w", desc.Component.Name, err)
+		}
+		return desc, result, nil
+	}
 
-		if err := signing.VerifyDigestMatchesDescriptor(ctx, desc, *descSig, slog.New(logr.ToSlogHandler(logger))); err != nil {
-			return nil, fmt.Errorf("digest verification failed for signature %q: %w", descSig.Name, err)
+	for _, v := range verifications {
+		if err := verify(ctx, v); err != nil {
+			return nil, nil, err
 		}
+	}
 
-		// TODO: We need to derive the expected credential key from the signature algorithm. This does not look that
-		//       reliable currently. This will probably change, when typed credentials are supported.
-		var credentials runtime.Typed
-		switch signingv1alpha1.SignatureAlgorithm(descSig.Signature.Algorithm) {
-		case signingv1alpha1.AlgorithmRSASSAPSS, signingv1alpha1.AlgorithmRSASSAPKCS1V15:
-			credentials = &rsacredentialsv1.RSACredentials{
-				Type:         rsacredentialsv1.VersionedType,
-				PublicKeyPEM: string(v.PublicKey),
-			}
-		default:
-			return nil, fmt.Errorf("unsupported signature algorithm: %q", descSig.Signature.Algorithm)
+	return desc, nil, nil
+}
+
+// verifySignature verifies the signature of the descriptor named by the verification with its public key.
+func verifySignature(ctx context.Context, desc *descriptor.Descriptor, v verification.Verification, signingHandler signing.Handler) error {
+	logger := log.FromContext(ctx)
+
+	var descSig *descriptor.Signature
+	for i := range desc.Signatures {
+		if desc.Signatures[i].Name == v.Signature {
+			descSig = &desc.Signatures[i]
+			break
 		}
+	}
 
-		if err := signingHandler.Verify(ctx, *descSig, &signingv1alpha1.Config{}, credentials); err != nil {
-			return nil, fmt.Errorf("signature verification failed for signature %s: %w", v.Signature, err)
+	if descSig == nil {
+		return fmt.Errorf("signature %s not found in component %s", v.Signature, desc.Component.Name)
+	}
+
+	if err := signing.VerifyDigestMatchesDescriptor(ctx, desc, *descSig, slog.New(logr.ToSlogHandler(logger))); err != nil {
+		return fmt.Errorf("digest verification failed for signature %q: %w", descSig.Name, err)
+	}
+
+	// TODO: We need to derive the expected credential key from the signature algorithm. This does not look that
+	//       reliable currently. This will probably change, when typed credentials are supported.
+	var credentials runtime.Typed
+	switch signingv1alpha1.SignatureAlgorithm(descSig.Signature.Algorithm) {
+	case signingv1alpha1.AlgorithmRSASSAPSS, signingv1alpha1.AlgorithmRSASSAPKCS1V15:
+		credentials = &rsacredentialsv1.RSACredentials{
+			Type:         rsacredentialsv1.VersionedType,
+			PublicKeyPEM: string(v.PublicKey),
 		}
+	default:
+		return fmt.Errorf("unsupported signature algorithm: %q", descSig.Signature.Algorithm)
 	}
 
-	return desc, nil
+	if err := signingHandler.Verify(ctx, *descSig, &signingv1alpha1.Config{}, credentials); err != nil {
+		return fmt.Errorf("signature verification failed for signature %s: %w", v.Signature, err)
+	}
+
+	return nil
 }
 
 // compareDigest performs integrity verification using the provided digest against a fresh calculated digest of
@@ -853,6 +853,44 @@ func TestWorkerPool_SubscriberBufferSize(t *testing.T) {
 	})
 }
 
+func TestSubmit(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := t.Context()
+		logger := logr.Discard()
+		env := setupTestEnvironment(t, nil, &logger)
+
+		var calls atomic.Int32
+		opts := workerpool.ResolveOptions{
+			Component: "submit-test",
+			Version:   "v1.0.0",
+			KeyFunc:   func() (string, error) { return "submit", nil },
+		}
+		fn := func(ctx context.Context) (string, error) {
+			if calls.Add(1) == 1 {
+				return "", errors.New("failed")
+			}
+			return "done", nil
+		}
+
+		_, err := workerpool.Submit(ctx, env.Pool, opts, fn)
+		require.ErrorIs(t, err, workerpool.ErrResolutionInProgress)
+		synctest.Wait()
+
+		// the error is returned once and then removed from the cache
+		_, err = workerpool.Submit(ctx, env.Pool, opts, fn)
+		require.EqualError(t, err, "failed")
+
+		_, err = workerpool.Submit(ctx, env.Pool, opts, fn)
+		require.ErrorIs(t, err, workerpool.ErrResolutionInProgress)
+		synctest.Wait()
+
+		result, err := workerpool.Submit(ctx, env.Pool, opts, fn)
+		require.NoError(t, err)
+		assert.Equal(t, "done", result)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+}
+
 // testEnvironment holds the test infrastructure for workerpool testing.
 type testEnvironment struct {
 	Pool *workerpool.WorkerPool
@@ -0,0 +1,195 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+// Policy is an internal representation of v1alpha1.SignatureVerificationPolicy.
+//
+// It mirrors the evaluation of ocm.software/open-component-model/bindings/go/signing/policy, which the controller
+// cannot import until a signing release containing it is pinned in go.mod. Trusted signatures are the entries of
+// Verify that do not match an Optional pattern.
+type Policy struct {
+	Threshold int      `json:"threshold,omitempty"`
+	Optional  []string `json:"optional,omitempty"`
+	Ignored   []string `json:"ignored,omitempty"`
+}
+
+// GetPolicy returns the signature verification policy of obj, or nil if it has none.
+// An invalid policy is returned as terminal error, as it requires a change of the object.
+func GetPolicy(obj v1alpha1.VerificationProvider) (*Policy, error) {
+	spec := obj.GetVerificationPolicy()
+	if spec == nil {
+		return nil, nil
+	}
+
+	policy := &Policy{
+		Threshold: spec.Threshold,
+		Optional:  spec.Optional,
+		Ignored:   spec.Ignored,
+	}
+
+	trusted := 0
+	for _, v := range obj.GetVerifications() {
+		if !matchAny(policy.Optional, v.Signature) {
+			trusted++
+		}
+	}
+	if trusted == 0 {
+		return nil, reconcile.TerminalError(errors.New("verification policy requires at least one verification that is not optional"))
+	}
+	if policy.Threshold < 0 || policy.Threshold > trusted {
+		return nil, reconcile.TerminalError(fmt.Errorf("verification policy threshold %d must be between 0 and the number of trusted signatures %d", policy.Threshold, trusted))
+	}
+	for _, pattern := range slices.Concat(policy.Optional, policy.Ignored) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, reconcile.TerminalError(fmt.Errorf("verification policy has an invalid signature pattern %q: %w", pattern, err))
+		}
+	}
+
+	return policy, nil
+}
+
+// Clause is the evaluated outcome of one part of a Policy.
+type Clause struct {
+	// Kind is one of "trusted", "optional", "ignored", "untrusted" or "threshold".
+	Kind      string
+	Signature string
+	Passed    bool
+	Reason    string
+}
+
+// PolicyResult is the evaluation of a Policy against the signatures of a component version.
+type PolicyResult struct {
+	Clauses  []Clause
+	Valid    int
+	Required int
+}
+
+// PolicyError is returned if a Policy is not satisfied. It carries the evaluation,
+// so that callers can report the outcome of every clause.
+type PolicyError struct {
+	Result *PolicyResult
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("verification policy not satisfied: %s", e.Result)
+}
+
+// Err returns a *PolicyError if the policy is not satisfied, or nil.
+func (r *PolicyResult) Err() error {
+	for _, clause := range r.Clauses {
+		if !clause.Passed && (clause.Kind == "untrusted" || clause.Kind == "threshold") {
+			return &PolicyError{Result: r}
+		}
+	}
+	return nil
+}
+
+// String renders all clauses on a single line, e.g. for condition messages.
+func (r *PolicyResult) String() string {
+	clauses := make([]string, 0, len(r.Clauses))
+	for _, clause := range r.Clauses {
+		outcome := "passed"
+		if !clause.Passed {
+			outcome = "failed"
+		}
+		s := clause.Kind
+		if clause.Signature != "" {
+			s += " " + clause.Signature
+		}
+		s += ": " + outcome
+		if clause.Reason != "" {
+			s += " (" + clause.Reason + ")"
+		}
+		clauses = append(clauses, s)
+	}
+	return strings.Join(clauses, "; ")
+}
+
+// EvaluatePolicy verifies the signatures named in verifications with verify and
+// evaluates the policy against the signature names of the component version.
+// Verifications whose signature matches an Optional pattern are optional, all
+// others are trusted. Signatures without a verification that match an Optional
+// pattern are reported as optional, but cannot be verified without a public key.
+// Signatures that match no verification and no pattern are untrusted.
+func EvaluatePolicy(ctx context.Context, policy *Policy, signatures []string, verifications []Verification, verify func(ctx context.Context, v Verification) error) *PolicyResult {
+	result := &PolicyResult{}
+
+	present := make(map[string]bool, len(signatures))
+	for _, name := range signatures {
+		present[name] = true
+	}
+
+	listed := make(map[string]bool, len(verifications))
+	trusted := 0
+	for _, v := range verifications {
+		listed[v.Signature] = true
+		kind := "trusted"
+		if matchAny(policy.Optional, v.Signature) {
+			kind = "optional"
+		} else {
+			trusted++
+		}
+
+		clause := Clause{Kind: kind, Signature: v.Signature}
+		switch {
+		case !present[v.Signature] && kind == "optional":
+			continue
+		case !present[v.Signature]:
+			clause.Reason = "signature not found"
+		default:
+			if err := verify(ctx, v); err != nil {
+				clause.Reason = err.Error()
+			} else {
+				clause.Passed = true
+				if kind == "trusted" {
+					result.Valid++
+				}
+			}
+		}
+		result.Clauses = append(result.Clauses, clause)
+	}
+
+	for _, name := range signatures {
+		switch {
+		case listed[name]:
+		case matchAny(policy.Optional, name):
+			result.Clauses = append(result.Clauses, Clause{Kind: "optional", Signature: name, Reason: "no verification configured"})
+		case matchAny(policy.Ignored, name):
+			result.Clauses = append(result.Clauses, Clause{Kind: "ignored", Signature: name, Passed: true})
+		default:
+			result.Clauses = append(result.Clauses, Clause{Kind: "untrusted", Signature: name, Reason: "signature matches no verification"})
+		}
+	}
+
+	result.Required = policy.Threshold
+	if result.Required == 0 {
+		result.Required = trusted
+	}
+	result.Clauses = append(result.Clauses, Clause{
+		Kind:   "threshold",
+		Passed: result.Valid >= result.Required,
+		Reason: fmt.Sprintf("%d of %d required trusted signatures valid", result.Valid, result.Required),
+	})
+
+	return result
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ocm.software/open-component-model/kubernetes/controller/api/v1alpha1"
+)
+
+func componentWithPolicy(policy *v1alpha1.SignatureVerificationPolicy, signatures ...string) *v1alpha1.Component {
+	component := &v1alpha1.Component{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	for _, signature := range signatures {
+		component.Spec.Verify = append(component.Spec.Verify, v1alpha1.Verification{Signature: signature, Value: "a2V5"})
+	}
+	component.Spec.VerifyPolicy = policy
+	return component
+}
+
+func TestGetPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     *v1alpha1.SignatureVerificationPolicy
+		signatures []string
+		wantErr    string
+	}{
+		{
+			name:       "no policy",
+			signatures: []string{"release-bot"},
+		},
+		{
+			name:       "threshold within trusted signatures",
+			policy:     &v1alpha1.SignatureVerificationPolicy{Threshold: 2, Optional: []string{"legacy-*"}},
+			signatures: []string{"release-bot", "security-team", "legacy-rsa"},
+		},
+		{
+			name:       "threshold counts optional verifications",
+			policy:     &v1alpha1.SignatureVerificationPolicy{Threshold: 2, Optional: []string{"legacy-*"}},
+			signatures: []string{"release-bot", "legacy-rsa"},
+			wantErr:    "threshold 2 must be between 0 and the number of trusted signatures 1",
+		},
+		{
+			name:       "negative threshold",
+			policy:     &v1alpha1.SignatureVerificationPolicy{Threshold: -1},
+			signatures: []string{"release-bot"},
+			wantErr:    "threshold -1",
+		},
+		{
+			name:       "only optional verifications",
+			policy:     &v1alpha1.SignatureVerificationPolicy{Optional: []string{"*"}},
+			signatures: []string{"release-bot"},
+			wantErr:    "at least one verification that is not optional",
+		},
+		{
+			name:       "malformed pattern",
+			policy:     &v1alpha1.SignatureVerificationPolicy{Ignored: []string{"experimental-["}},
+			signatures: []string{"release-bot"},
+			wantErr:    "invalid signature pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := GetPolicy(componentWithPolicy(tt.policy, tt.signatures...))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				if tt.policy == nil {
+					assert.Nil(t, policy)
+				} else {
+					assert.Equal(t, tt.policy.Threshold, policy.Threshold)
+				}
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestEvaluatePolicy(t *testing.T) {
+	verifications := []Verification{{Signature: "release-bot"}, {Signature: "security-team"}, {Signature: "product-owner"}, {Signature: "legacy-rsa"}}
+	policy := &Policy{Threshold: 2, Optional: []string{"legacy-*"}, Ignored: []string{"experimental-*"}}
+
+	verifyValid := func(valid ...string) func(context.Context, Verification) error {
+		return func(_ context.Context, v Verification) error {
+			for _, name := range valid {
+				if v.Signature == name {
+					return nil
+				}
+			}
+			return errors.New("invalid signature")
+		}
+	}
+
+	passed := func(result *PolicyResult) map[string]bool {
+		out := make(map[string]bool, len(result.Clauses))
+		for _, clause := range result.Clauses {
+			key := clause.Kind
+			if clause.Signature != "" {
+				key += "/" + clause.Signature
+			}
+			out[key] = clause.Passed
+		}
+		return out
+	}
+
+	tests := []struct {
+		name       string
+		signatures []string
+		valid      []string
+		wantErr    bool
+		wantValid  int
+		wantPassed map[string]bool
+	}{
+		{
+			name:       "threshold reached with a missing trusted signature and an invalid optional signature",
+			signatures: []string{"release-bot", "security-team", "legacy-rsa", "experimental-pq"},
+			valid:      []string{"release-bot", "security-team"},
+			wantValid:  2,
+			wantPassed: map[string]bool{
+				"trusted/release-bot":     true,
+				"trusted/security-team":   true,
+				"trusted/product-owner":   false,
+				"optional/legacy-rsa":     false,
+				"ignored/experimental-pq": true,
+				"threshold":               true,
+			},
+		},
+		{
+			name:       "threshold not reached",
+			signatures: []string{"release-bot", "security-team", "product-owner"},
+			valid:      []string{"release-bot"},
+			wantErr:    true,
+			wantValid:  1,
+			wantPassed: map[string]bool{
+				"trusted/release-bot":   true,
+				"trusted/security-team": false,
+				"trusted/product-owner": false,
+				"threshold":             false,
+			},
+		},
+		{
+			name:       "untrusted signature fails the policy",
+			signatures: []string{"release-bot", "security-team", "someone-else"},
+			valid:      []string{"release-bot", "security-team"},
+			wantErr:    true,
+			wantValid:  2,
+			wantPassed: map[string]bool{
+				"trusted/release-bot":    true,
+				"trusted/security-team":  true,
+				"trusted/product-owner":  false,
+				"untrusted/someone-else": false,
+				"threshold":              true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EvaluatePolicy(t.Context(), policy, tt.signatures, verifications, verifyValid(tt.valid...))
+			assert.Equal(t, tt.wantValid, result.Valid)
+			assert.Equal(t, 2, result.Required)
+			assert.Equal(t, tt.wantPassed, passed(result))
+			if tt.wantErr {
+				assert.Error(t, result.Err())
+			} else {
+				assert.NoError(t, result.Err())
+			}
+		})
+	}
+
+	t.Run("optional signature without verification is not untrusted", func(t *testing.T) {
+		result := EvaluatePolicy(t.Context(), policy, []string{"release-bot", "security-team", "legacy-ecdsa"}, verifications, verifyValid("release-bot", "security-team"))
+		require.NoError(t, result.Err())
+		assert.Equal(t, map[string]bool{
+			"trusted/release-bot":   true,
+			"trusted/security-team": true,
+			"trusted/product-owner": false,
+			"optional/legacy-ecdsa": false,
+			"threshold":             true,
+		}, passed(result))
+	})
+
+	t.Run("policy error carries the result", func(t *testing.T) {
+		result := EvaluatePolicy(t.Context(), policy, []string{"release-bot"}, verifications, verifyValid("release-bot"))
+		policyErr, ok := errors.AsType[*PolicyError](fmt.Errorf("wrapped: %w", result.Err()))
+		require.True(t, ok)
+		assert.Same(t, result, policyErr.Result)
+	})
+
+	t.Run("threshold defaults to all trusted signatures", func(t *testing.T) {
+		result := EvaluatePolicy(t.Context(), &Policy{Optional: []string{"legacy-*"}}, []string{"release-bot", "security-team"}, verifications, verifyValid("release-bot", "security-team"))
+		assert.Equal(t, 3, result.Required)
+		require.Error(t, result.Err())
+		assert.Contains(t, result.Err().Error(), "2 of 3 required trusted signatures valid")
+	})
+}